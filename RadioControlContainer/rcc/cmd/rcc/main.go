@@ -8,13 +8,16 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	"github.com/radio-control/rcc/internal/api"
 	"github.com/radio-control/rcc/internal/audit"
+	"github.com/radio-control/rcc/internal/bandplan"
 	"github.com/radio-control/rcc/internal/command"
 	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/history"
 	"github.com/radio-control/rcc/internal/radio"
 	"github.com/radio-control/rcc/internal/telemetry"
 )
@@ -51,20 +54,83 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize audit logger: %v", err)
 	}
+	auditPolicy := audit.RotationPolicy{
+		MaxSizeBytes: int64(config.GetEnvInt("RCC_AUDIT_MAX_SIZE_MB", 100)) << 20,
+		MaxAge:       config.GetEnvDuration("RCC_AUDIT_MAX_AGE", 24*time.Hour),
+		Compress:     config.GetEnvVar("RCC_AUDIT_COMPRESS", "true") == "true",
+		MaxBackups:   config.GetEnvInt("RCC_AUDIT_MAX_BACKUPS", 30),
+		MaxBackupAge: config.GetEnvDuration("RCC_AUDIT_RETENTION", 0),
+		Sync:         audit.SyncPolicy(config.GetEnvVar("RCC_AUDIT_SYNC", string(audit.SyncAlways))),
+		SyncEvery:    config.GetEnvDuration("RCC_AUDIT_SYNC_INTERVAL", time.Second),
+	}
+	if err := auditLogger.SetRotationPolicy(auditPolicy); err != nil {
+		log.Fatalf("Invalid audit rotation policy: %v", err)
+	}
+	auditLogger.SetRotationNotifier(func(rotation audit.Rotation) {
+		publishAuditRotation(telemetryHub, rotation)
+	})
+	auditCtx, stopAudit := context.WithCancel(context.Background())
+	go auditLogger.Run(auditCtx)
 	log.Println("Audit logger initialized")
 
+	// Step 3b: Initialize telemetry history store
+	historyStore, err := history.NewStore(config.GetEnvVar("RCC_HISTORY_DIR", "data/history"))
+	if err != nil {
+		log.Fatalf("Failed to initialize telemetry history: %v", err)
+	}
+	telemetryHub.SetRecorder(historyStore)
+	historyRetention := config.GetEnvDuration("RCC_HISTORY_RETENTION", 7*24*time.Hour)
+	stopPrune := make(chan struct{})
+	go pruneHistory(historyStore, historyRetention, stopPrune)
+	log.Println("Telemetry history initialized")
+
 	// Step 4: Initialize radio manager
 	// Source: Architecture §6.1 Initialization
 	radioManager := radio.NewManager()
 	if radioManager == nil {
 		log.Fatal("Failed to create radio manager")
 	}
+	radioBands, err := radio.ParseBands(config.GetEnvVar("RCC_RADIO_BANDS", ""))
+	if err != nil {
+		log.Fatalf("Invalid RCC_RADIO_BANDS: %v", err)
+	}
+	for radioID, band := range radioBands {
+		radioManager.SetBand(radioID, band)
+	}
 	log.Println("Radio manager initialized")
 
 	// Step 5: Create command orchestrator
 	// Source: Architecture §6.1 Initialization
 	orchestrator := command.NewOrchestrator(telemetryHub, cfg)
 	orchestrator.SetAuditLogger(auditLogger)
+	orchestrator.SetRadioManager(radioManager)
+
+	// Step 5a: Load versioned band plans, seeded from the startup configuration
+	bandPlans, err := bandplan.NewStore(config.GetEnvVar("RCC_BANDPLAN_DIR", "data/bandplans"))
+	if err != nil {
+		log.Fatalf("Failed to initialize band plan store: %v", err)
+	}
+	if err := bandPlans.Seed(cfg.SilvusBandPlan); err != nil {
+		log.Printf("Skipped configured band plans: %v", err)
+	}
+	orchestrator.SetSilvusBandPlan(bandPlans.Active())
+	radioManager.ApplyBandPlans(bandPlans.Active())
+	bandPlans.SetChangeNotifier(func(change bandplan.Change) {
+		orchestrator.SetSilvusBandPlan(change.Active)
+		radioManager.ApplyBandPlans(change.Active)
+		publishBandPlanChange(telemetryHub, change)
+	})
+	log.Println("Band plans loaded")
+
+	// Step 5b: Start periodic link metrics polling
+	metricsCtx, stopMetrics := context.WithCancel(context.Background())
+	go orchestrator.RunMetricsPolling(metricsCtx, config.GetEnvDuration("RCC_METRICS_INTERVAL", 5*time.Second))
+	log.Println("Link metrics polling started")
+
+	// Step 5c: Start periodic GPS location polling
+	locationCtx, stopLocation := context.WithCancel(context.Background())
+	go orchestrator.RunLocationPolling(locationCtx, config.GetEnvDuration("RCC_LOCATION_INTERVAL", 5*time.Second))
+	log.Println("Location polling started")
 
 	// Step 6: Create API server with all components
 	// Source: Architecture §6.1 Initialization
@@ -72,6 +138,9 @@ func main() {
 	if server == nil {
 		log.Fatal("Failed to create API server")
 	}
+	server.SetHistoryStore(historyStore)
+	server.SetAuditLog(auditLogger)
+	server.SetBandPlanStore(bandPlans)
 	log.Println("API server created")
 
 	// Step 7: Start HTTP server
@@ -111,11 +180,23 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Stop link metrics and location polling before the hub they publish to
+	stopMetrics()
+	stopLocation()
+
 	// Stop telemetry hub
 	telemetryHub.Stop()
 	log.Println("Telemetry hub stopped")
 
+	// Stop telemetry history
+	close(stopPrune)
+	if err := historyStore.Close(); err != nil {
+		log.Printf("Error closing telemetry history: %v", err)
+	}
+	log.Println("Telemetry history closed")
+
 	// Stop audit logger
+	stopAudit()
 	if err := auditLogger.Close(); err != nil {
 		log.Printf("Error closing audit logger: %v", err)
 	}
@@ -131,6 +212,65 @@ func main() {
 	log.Println("Radio Control Container shutdown complete")
 }
 
+// pruneHistory periodically drops telemetry history older than the retention window.
+func pruneHistory(store *history.Store, retention time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := store.Prune(time.Now().Add(-retention)); err != nil {
+				log.Printf("Error pruning telemetry history: %v", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// publishAuditRotation announces a completed audit log rotation to telemetry subscribers.
+func publishAuditRotation(hub *telemetry.Hub, rotation audit.Rotation) {
+	removed := make([]string, 0, len(rotation.Removed))
+	for _, path := range rotation.Removed {
+		removed = append(removed, filepath.Base(path))
+	}
+
+	event := telemetry.Event{
+		Type: "auditRotated",
+		Data: map[string]interface{}{
+			"ts":        rotation.Time.Format(time.RFC3339Nano),
+			"reason":    rotation.Reason,
+			"file":      filepath.Base(rotation.File),
+			"sizeBytes": rotation.SizeBytes,
+			"lastHash":  rotation.LastHash,
+			"removed":   removed,
+		},
+	}
+	if err := hub.Publish(event); err != nil {
+		log.Printf("Failed to publish audit rotation: %v", err)
+	}
+}
+
+// publishBandPlanChange announces a band plan activation to telemetry subscribers.
+func publishBandPlanChange(hub *telemetry.Hub, change bandplan.Change) {
+	event := telemetry.Event{
+		Type: "bandPlanChanged",
+		Data: map[string]interface{}{
+			"ts":              time.Now().UTC().Format(time.RFC3339Nano),
+			"model":           change.Plan.Model,
+			"band":            change.Plan.Band,
+			"version":         change.Plan.Version,
+			"previousVersion": change.Previous,
+			"rollback":        change.Rollback,
+			"channels":        len(change.Plan.Channels),
+		},
+	}
+	if err := hub.Publish(event); err != nil {
+		log.Printf("Failed to publish band plan change: %v", err)
+	}
+}
+
 // getServerAddress returns the server address from environment or default.
 func getServerAddress() string {
 	if addr := os.Getenv("RCC_ADDR"); addr != "" {
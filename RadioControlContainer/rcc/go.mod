@@ -3,3 +3,5 @@ module github.com/radio-control/rcc
 go 1.24.6
 
 require github.com/golang-jwt/jwt/v5 v5.2.1
+
+require github.com/gorilla/websocket v1.5.3
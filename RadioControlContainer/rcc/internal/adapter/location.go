@@ -0,0 +1,22 @@
+package adapter
+
+import (
+	"context"
+	"time"
+)
+
+// Location reports a radio's GPS position.
+type Location struct {
+	Timestamp time.Time `json:"ts"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	AltitudeM float64   `json:"altitudeM"`
+	AccuracyM float64   `json:"accuracyM"`
+}
+
+// LocationProvider is an optional extension of IRadioAdapter for radios
+// with a GPS receiver. Callers detect it with a type assertion.
+type LocationProvider interface {
+	// GetLocation returns the radio's current position fix.
+	GetLocation(ctx context.Context) (*Location, error)
+}
@@ -0,0 +1,31 @@
+package adapter
+
+import (
+	"context"
+	"time"
+)
+
+// LinkMetrics reports RF link quality for a radio.
+type LinkMetrics struct {
+	Timestamp      time.Time  `json:"ts"`
+	RSSIDbm        float64    `json:"rssiDbm"`
+	SNRDb          float64    `json:"snrDb"`
+	ThroughputKbps float64    `json:"throughputKbps"`
+	TemperatureC   float64    `json:"temperatureC"`
+	Neighbors      []Neighbor `json:"neighbors"`
+}
+
+// Neighbor describes one mesh peer as seen from the reporting radio.
+type Neighbor struct {
+	ID             string  `json:"id"`
+	RSSIDbm        float64 `json:"rssiDbm"`
+	SNRDb          float64 `json:"snrDb"`
+	ThroughputKbps float64 `json:"throughputKbps"`
+}
+
+// LinkMetricsProvider is an optional extension of IRadioAdapter for radios
+// that report link quality. Callers detect it with a type assertion.
+type LinkMetricsProvider interface {
+	// GetLinkMetrics returns a current link quality snapshot.
+	GetLinkMetrics(ctx context.Context) (*LinkMetrics, error)
+}
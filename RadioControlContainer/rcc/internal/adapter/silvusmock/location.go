@@ -0,0 +1,150 @@
+package silvusmock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+const (
+	// defaultLatitude/defaultLongitude/defaultAltitudeM match the silvus-mock emulator's
+	// default fix and are reported until an emulator endpoint is attached
+	defaultLatitude  = 40.7128
+	defaultLongitude = -74.0060
+	defaultAltitudeM = 10.0
+
+	// gpsAccuracyM is the reported horizontal accuracy of a 3D fix
+	gpsAccuracyM = 3.0
+
+	// gpsQueryTimeout bounds a gps_coordinates round trip to the emulator
+	gpsQueryTimeout = 2 * time.Second
+)
+
+// Compile-time assertion that SilvusMock reports its position
+var _ adapter.LocationProvider = (*SilvusMock)(nil)
+
+// GetLocation returns the radio's GPS fix. With an emulator endpoint attached
+// (SetGPSEndpoint) the fix is read from the emulator's gps_coordinates method,
+// so GPX/NMEA track playback in silvus-mock moves the radio; otherwise the
+// in-memory fix set with SetLocation is returned.
+func (s *SilvusMock) GetLocation(ctx context.Context) (*adapter.Location, error) {
+	// Check for context cancellation
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	// Check for fault injection
+	if err := s.checkFaultMode("GetLocation"); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	endpoint := s.gpsEndpoint
+	location := s.location
+	s.mu.RUnlock()
+
+	if endpoint != "" {
+		return s.queryEmulatorLocation(ctx, endpoint)
+	}
+
+	location.Timestamp = time.Now().UTC()
+	return &location, nil
+}
+
+// SetLocation moves the simulated radio.
+func (s *SilvusMock) SetLocation(latitude, longitude, altitudeM float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.location = adapter.Location{
+		Latitude:  latitude,
+		Longitude: longitude,
+		AltitudeM: altitudeM,
+		AccuracyM: gpsAccuracyM,
+	}
+}
+
+// SetGPSEndpoint attaches a silvus-mock emulator JSON-RPC endpoint
+// (e.g. "http://localhost:8080/streamscape_api") as the source of GetLocation.
+// An empty endpoint detaches it.
+func (s *SilvusMock) SetGPSEndpoint(endpoint string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gpsEndpoint = endpoint
+}
+
+// gpsRPCResponse is the emulator's JSON-RPC response to gps_coordinates.
+type gpsRPCResponse struct {
+	Result []struct {
+		Latitude  float64   `json:"latitude"`
+		Longitude float64   `json:"longitude"`
+		Altitude  float64   `json:"altitude"`
+		Accuracy  float64   `json:"accuracy"`
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// queryEmulatorLocation reads the current fix from the emulator.
+func (s *SilvusMock) queryEmulatorLocation(ctx context.Context, endpoint string) (*adapter.Location, error) {
+	ctx, cancel := context.WithTimeout(ctx, gpsQueryTimeout)
+	defer cancel()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "gps_coordinates",
+		"id":      s.RadioID,
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid GPS endpoint: %v", adapter.ErrInternal, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: GPS query timed out", adapter.ErrUnavailable)
+		}
+		return nil, fmt.Errorf("%w: GPS query failed: %v", adapter.ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%w: GPS query returned HTTP %d", adapter.ErrUnavailable, resp.StatusCode)
+	}
+
+	var rpc gpsRPCResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpc); err != nil {
+		return nil, fmt.Errorf("%w: malformed GPS response: %v", adapter.ErrInternal, err)
+	}
+	if rpc.Error != nil {
+		return nil, adapter.NormalizeVendorErrorWithVendor(errors.New(rpc.Error.Message), rpc.Error, "silvus")
+	}
+	if len(rpc.Result) == 0 {
+		return nil, fmt.Errorf("%w: empty GPS response", adapter.ErrInternal)
+	}
+
+	fix := rpc.Result[0]
+	timestamp := fix.Timestamp.UTC()
+	if timestamp.IsZero() {
+		timestamp = time.Now().UTC()
+	}
+	return &adapter.Location{
+		Timestamp: timestamp,
+		Latitude:  fix.Latitude,
+		Longitude: fix.Longitude,
+		AltitudeM: fix.Altitude,
+		AccuracyM: fix.Accuracy,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package silvusmock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+func TestGetLocation(t *testing.T) {
+	mock := NewSilvusMock("test-radio", nil)
+	ctx := context.Background()
+
+	location, err := mock.GetLocation(ctx)
+	if err != nil {
+		t.Fatalf("GetLocation failed: %v", err)
+	}
+	if location.Latitude != defaultLatitude || location.Longitude != defaultLongitude || location.AccuracyM != gpsAccuracyM {
+		t.Errorf("Expected default fix, got %+v", location)
+	}
+
+	mock.SetLocation(-33.86, 151.21, 58)
+	location, _ = mock.GetLocation(ctx)
+	if location.Latitude != -33.86 || location.Longitude != 151.21 || location.AltitudeM != 58 {
+		t.Errorf("Expected updated fix, got %+v", location)
+	}
+
+	mock.SetFaultMode("ReturnBusy")
+	if _, err := mock.GetLocation(ctx); err == nil || !strings.HasPrefix(err.Error(), "BUSY") {
+		t.Errorf("Expected BUSY fault, got %v", err)
+	}
+}
+
+func TestGetLocationFromEmulator(t *testing.T) {
+	// The emulator moves the radio north on every read, as track playback does
+	var reads int64
+	emulator := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "gps_coordinates" {
+			t.Errorf("Expected gps_coordinates request, got %q (%v)", req.Method, err)
+		}
+		n := atomic.AddInt64(&reads, 1)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      "test-radio",
+			"result": []map[string]interface{}{{
+				"latitude":  47.0 + float64(n)*0.001,
+				"longitude": 8.5,
+				"altitude":  420.0,
+				"accuracy":  2.5,
+				"timestamp": "2025-01-01T12:00:00Z",
+			}},
+		})
+	}))
+	defer emulator.Close()
+
+	mock := NewSilvusMock("test-radio", nil)
+	mock.SetGPSEndpoint(emulator.URL + "/streamscape_api")
+	ctx := context.Background()
+
+	first, err := mock.GetLocation(ctx)
+	if err != nil {
+		t.Fatalf("GetLocation failed: %v", err)
+	}
+	second, err := mock.GetLocation(ctx)
+	if err != nil {
+		t.Fatalf("GetLocation failed: %v", err)
+	}
+	if first.Latitude != 47.001 || second.Latitude != 47.002 || second.AltitudeM != 420 || second.AccuracyM != 2.5 {
+		t.Errorf("Expected the emulator's moving fix, got %+v then %+v", first, second)
+	}
+	if first.Timestamp.Format("2006-01-02T15:04:05Z") != "2025-01-01T12:00:00Z" {
+		t.Errorf("Expected the emulator's fix time, got %s", first.Timestamp)
+	}
+
+	emulator.Close()
+	if _, err := mock.GetLocation(ctx); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Errorf("Expected UNAVAILABLE when the emulator is unreachable, got %v", err)
+	}
+}
+
+func TestGetLocationEmulatorError(t *testing.T) {
+	emulator := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"x","error":{"code":-32603,"message":"RADIO_OFFLINE"}}`))
+	}))
+	defer emulator.Close()
+
+	mock := NewSilvusMock("test-radio", nil)
+	mock.SetGPSEndpoint(emulator.URL)
+	if _, err := mock.GetLocation(context.Background()); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Errorf("Expected vendor error mapped to UNAVAILABLE, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package silvusmock
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+const (
+	// antennaGainDbi is the combined TX+RX antenna gain of a simulated link
+	antennaGainDbi = 10.0
+
+	// noiseFloorDbm is thermal noise over 20 MHz plus a 6 dB receiver noise figure
+	noiseFloorDbm = -95.0
+
+	// channelBandwidthMhz matches the bandwidth advertised in frequency profiles
+	channelBandwidthMhz = 20.0
+
+	// spectralEfficiency derates Shannon capacity to a realistic MAC throughput
+	spectralEfficiency = 0.4
+
+	// maxThroughputKbps caps a single link at the radio's peak PHY rate
+	maxThroughputKbps = 100000.0
+)
+
+// Compile-time assertion that SilvusMock reports link metrics
+var _ adapter.LinkMetricsProvider = (*SilvusMock)(nil)
+
+// MockNeighbor is a simulated mesh peer at a fixed distance.
+type MockNeighbor struct {
+	ID         string
+	DistanceKm float64
+}
+
+// defaultNeighbors returns the two-peer mesh used when none is configured.
+func defaultNeighbors() []MockNeighbor {
+	return []MockNeighbor{
+		{ID: "mesh-peer-01", DistanceKm: 0.5},
+		{ID: "mesh-peer-02", DistanceKm: 1.5},
+	}
+}
+
+// GetLinkMetrics returns simulated link metrics.
+//
+// Each neighbor's RSSI follows free-space path loss for the current power and
+// frequency with ±1.5 dB of noise; SNR is measured against a fixed noise floor
+// and throughput is derated Shannon capacity. Top-level RSSI/SNR report the
+// best link and throughput is the sum over all links.
+func (s *SilvusMock) GetLinkMetrics(ctx context.Context) (*adapter.LinkMetrics, error) {
+	// Check for context cancellation
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	// Check for fault injection
+	if err := s.checkFaultMode("GetLinkMetrics"); err != nil {
+		return nil, err
+	}
+
+	// Write lock: the noise source is not safe for concurrent use
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	metrics := &adapter.LinkMetrics{
+		Timestamp:    time.Now().UTC(),
+		RSSIDbm:      noiseFloorDbm,
+		TemperatureC: round1(38 + 0.6*s.powerDbm + s.jitter(0.5)),
+		Neighbors:    make([]adapter.Neighbor, 0, len(s.neighbors)),
+	}
+
+	for i, peer := range s.neighbors {
+		rssi := s.powerDbm + antennaGainDbi - freeSpacePathLoss(peer.DistanceKm, s.frequencyMhz) + s.jitter(1.5)
+		snr := math.Max(0, rssi-noiseFloorDbm)
+		neighbor := adapter.Neighbor{
+			ID:             peer.ID,
+			RSSIDbm:        round1(rssi),
+			SNRDb:          round1(snr),
+			ThroughputKbps: math.Round(linkThroughputKbps(snr)),
+		}
+		metrics.Neighbors = append(metrics.Neighbors, neighbor)
+
+		metrics.ThroughputKbps += neighbor.ThroughputKbps
+		if i == 0 || neighbor.RSSIDbm > metrics.RSSIDbm {
+			metrics.RSSIDbm = neighbor.RSSIDbm
+			metrics.SNRDb = neighbor.SNRDb
+		}
+	}
+
+	return metrics, nil
+}
+
+// SetNeighbors replaces the simulated mesh peers.
+func (s *SilvusMock) SetNeighbors(neighbors []MockNeighbor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.neighbors = append([]MockNeighbor(nil), neighbors...)
+}
+
+// jitter returns uniform noise in [-amplitude, amplitude].
+// Caller must hold s.mu for writing.
+func (s *SilvusMock) jitter(amplitude float64) float64 {
+	return (s.rng.Float64()*2 - 1) * amplitude
+}
+
+// freeSpacePathLoss returns the free-space path loss in dB.
+func freeSpacePathLoss(distanceKm, frequencyMhz float64) float64 {
+	distanceKm = math.Max(distanceKm, 0.001)
+	frequencyMhz = math.Max(frequencyMhz, 1)
+	return 20*math.Log10(distanceKm) + 20*math.Log10(frequencyMhz) + 32.44
+}
+
+// linkThroughputKbps converts SNR to an achievable throughput.
+func linkThroughputKbps(snrDb float64) float64 {
+	capacityMbps := channelBandwidthMhz * math.Log2(1+math.Pow(10, snrDb/10))
+	return math.Min(maxThroughputKbps, capacityMbps*spectralEfficiency*1000)
+}
+
+// round1 rounds to one decimal place.
+func round1(value float64) float64 {
+	return math.Round(value*10) / 10
+}
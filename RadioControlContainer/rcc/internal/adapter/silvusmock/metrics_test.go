@@ -0,0 +1,75 @@
+package silvusmock
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGetLinkMetricsSimulatesPlausibleValues(t *testing.T) {
+	mock := NewSilvusMock("test-radio", nil)
+	ctx := context.Background()
+
+	metrics, err := mock.GetLinkMetrics(ctx)
+	if err != nil {
+		t.Fatalf("GetLinkMetrics failed: %v", err)
+	}
+
+	if len(metrics.Neighbors) != 2 {
+		t.Fatalf("Expected 2 default neighbors, got %d", len(metrics.Neighbors))
+	}
+	if metrics.RSSIDbm < -100 || metrics.RSSIDbm > -30 {
+		t.Errorf("RSSI %.1f dBm outside plausible range", metrics.RSSIDbm)
+	}
+	if metrics.SNRDb <= 0 || metrics.ThroughputKbps <= 0 {
+		t.Errorf("Expected positive SNR and throughput at default power, got %+v", metrics)
+	}
+	if metrics.TemperatureC < 30 || metrics.TemperatureC > 80 {
+		t.Errorf("Temperature %.1f C outside plausible range", metrics.TemperatureC)
+	}
+	if metrics.Neighbors[0].RSSIDbm <= metrics.Neighbors[1].RSSIDbm {
+		t.Errorf("Expected nearer neighbor to have stronger RSSI: %+v", metrics.Neighbors)
+	}
+}
+
+func TestGetLinkMetricsTracksPower(t *testing.T) {
+	mock := NewSilvusMock("test-radio", nil)
+	mock.SetNeighbors([]MockNeighbor{{ID: "peer", DistanceKm: 1}})
+	ctx := context.Background()
+
+	mock.SetCurrentState(0, 2412, 1)
+	low, _ := mock.GetLinkMetrics(ctx)
+	mock.SetCurrentState(39, 2412, 1)
+	high, _ := mock.GetLinkMetrics(ctx)
+
+	// 39 dB more power dwarfs the ±1.5 dB measurement noise
+	if high.RSSIDbm-low.RSSIDbm < 30 {
+		t.Errorf("Expected RSSI to rise with power: low %.1f, high %.1f", low.RSSIDbm, high.RSSIDbm)
+	}
+	if high.ThroughputKbps <= low.ThroughputKbps {
+		t.Errorf("Expected throughput to rise with power: low %.0f, high %.0f", low.ThroughputKbps, high.ThroughputKbps)
+	}
+}
+
+func TestGetLinkMetricsNoNeighbors(t *testing.T) {
+	mock := NewSilvusMock("test-radio", nil)
+	mock.SetNeighbors(nil)
+
+	metrics, err := mock.GetLinkMetrics(context.Background())
+	if err != nil {
+		t.Fatalf("GetLinkMetrics failed: %v", err)
+	}
+	if metrics.RSSIDbm != noiseFloorDbm || metrics.SNRDb != 0 || metrics.ThroughputKbps != 0 {
+		t.Errorf("Expected idle link metrics without neighbors, got %+v", metrics)
+	}
+}
+
+func TestGetLinkMetricsFaultMode(t *testing.T) {
+	mock := NewSilvusMock("test-radio", nil)
+	mock.SetFaultMode("ReturnBusy")
+
+	_, err := mock.GetLinkMetrics(context.Background())
+	if err == nil || !strings.HasPrefix(err.Error(), "BUSY") {
+		t.Errorf("Expected BUSY fault, got %v", err)
+	}
+}
@@ -7,6 +7,7 @@ package silvusmock
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -28,6 +29,14 @@ type SilvusMock struct {
 	// Fault injection modes
 	faultMode string // "ReturnBusy", "ReturnUnavailable", "ReturnInvalidRange", ""
 
+	// Simulated mesh peers and measurement noise for link metrics
+	neighbors []MockNeighbor
+	rng       *rand.Rand
+
+	// Simulated GPS fix, or the emulator endpoint that reports it
+	location    adapter.Location
+	gpsEndpoint string
+
 	// Configuration
 	minPower   int
 	maxPower   int
@@ -68,6 +77,14 @@ func NewSilvusMock(radioID string, bandPlan []adapter.Channel) *SilvusMock {
 		maxPower:        39,
 		validFreqs:      validFreqs,
 		faultMode:       "", // No faults by default
+		neighbors:       defaultNeighbors(),
+		rng:             rand.New(rand.NewSource(time.Now().UnixNano())),
+		location: adapter.Location{
+			Latitude:  defaultLatitude,
+			Longitude: defaultLongitude,
+			AltitudeM: defaultAltitudeM,
+			AccuracyM: gpsAccuracyM,
+		},
 	}
 }
 
@@ -1545,3 +1545,18 @@ func TestHealthAndReadiness_SubsystemHealthCheck(t *testing.T) {
 		t.Errorf("Expected auth to be true, got %v", subsystems["auth"])
 	}
 }
+
+func TestTelemetryRejectsInvalidFilter(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	for _, path := range []string{"/api/v1/telemetry?maxRate=-1", "/api/v1/telemetry/ws?types=,"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", path, w.Code, w.Body.String())
+		}
+	}
+}
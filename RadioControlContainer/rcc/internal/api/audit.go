@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/radio-control/rcc/internal/audit"
+)
+
+const (
+	defaultAuditPageSize = 100
+	maxAuditPageSize     = 1000
+)
+
+// auditCSVHeader lists the CSV export columns.
+var auditCSVHeader = []string{"ts", "user", "radioId", "action", "params", "outcome", "code", "prevHash", "hash"}
+
+// handleAudit handles GET /audit?user=&radio=&action=&from=&to=&offset=&limit=&format=
+// format is json (paged, default), csv or jsonl. Exports return every
+// matching entry unless offset or limit is given.
+func (s *Server) handleAudit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+
+	if s.auditLog == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE",
+			"Audit log not available", nil)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" && format != "jsonl" {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "format must be one of json, csv, jsonl", nil)
+		return
+	}
+
+	query, err := parseAuditQuery(r, format == "json")
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+
+	page, err := s.auditLog.Query(query)
+	if errors.Is(err, audit.ErrInvalidQuery) {
+		WriteError(w, http.StatusBadRequest, "INVALID_RANGE", err.Error(), nil)
+		return
+	}
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		return
+	}
+
+	switch format {
+	case "csv":
+		writeAuditCSV(w, page.Entries)
+	case "jsonl":
+		writeAuditJSONL(w, page.Entries)
+	default:
+		WriteSuccess(w, page)
+	}
+}
+
+// handleAuditVerify handles GET /audit/verify
+func (s *Server) handleAuditVerify(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+
+	if s.auditLog == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE",
+			"Audit log not available", nil)
+		return
+	}
+
+	result, err := s.auditLog.Verify()
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		return
+	}
+
+	WriteSuccess(w, result)
+}
+
+// parseAuditQuery parses the audit query parameters. Times are RFC3339.
+// Paged queries default to defaultAuditPageSize entries.
+func parseAuditQuery(r *http.Request, paged bool) (audit.Query, error) {
+	values := r.URL.Query()
+	query := audit.Query{
+		User:    values.Get("user"),
+		RadioID: values.Get("radio"),
+		Action:  values.Get("action"),
+	}
+
+	if from := values.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return query, errors.New("from must be an RFC3339 timestamp")
+		}
+		query.From = t
+	}
+
+	if to := values.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return query, errors.New("to must be an RFC3339 timestamp")
+		}
+		query.To = t
+	}
+
+	if offset := values.Get("offset"); offset != "" {
+		n, err := strconv.Atoi(offset)
+		if err != nil || n < 0 {
+			return query, errors.New("offset must be a non-negative integer")
+		}
+		query.Offset = n
+	}
+
+	if paged {
+		query.Limit = defaultAuditPageSize
+	}
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 || n > maxAuditPageSize {
+			return query, fmt.Errorf("limit must be between 1 and %d", maxAuditPageSize)
+		}
+		query.Limit = n
+	}
+
+	return query, nil
+}
+
+// writeAuditCSV writes entries as a CSV attachment with params as JSON.
+func writeAuditCSV(w http.ResponseWriter, entries []audit.AuditEntry) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+auditExportName("csv")+`"`)
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write(auditCSVHeader)
+	for _, entry := range entries {
+		params, _ := json.Marshal(entry.Params)
+		_ = cw.Write([]string{
+			entry.Timestamp.Format(time.RFC3339Nano),
+			entry.User,
+			entry.RadioID,
+			entry.Action,
+			string(params),
+			entry.Outcome,
+			entry.Code,
+			entry.PrevHash,
+			entry.Hash,
+		})
+	}
+	cw.Flush()
+}
+
+// writeAuditJSONL writes entries as a JSON Lines attachment.
+func writeAuditJSONL(w http.ResponseWriter, entries []audit.AuditEntry) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+auditExportName("jsonl")+`"`)
+
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		_ = enc.Encode(entry)
+	}
+}
+
+// auditExportName names an export file after the time it was taken.
+func auditExportName(ext string) string {
+	return "audit-" + time.Now().UTC().Format("20060102-150405") + "." + ext
+}
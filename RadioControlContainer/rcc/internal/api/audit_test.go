@@ -0,0 +1,241 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/audit"
+	"github.com/radio-control/rcc/internal/auth"
+)
+
+func setupAuditTest(t *testing.T, withAuth bool) *http.ServeMux {
+	t.Helper()
+
+	server, _, _, _ := setupAPITest(t)
+	if withAuth {
+		server.authMiddleware = auth.NewMiddleware()
+	}
+
+	logger, err := audit.NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create audit logger: %v", err)
+	}
+	t.Cleanup(func() { _ = logger.Close() })
+	server.SetAuditLog(logger)
+
+	ctx := context.Background()
+	logger.LogAction(ctx, "setPower", "silvus-001", "SUCCESS", 10*time.Millisecond)
+	logger.LogAction(ctx, "setChannel", "silvus-001", "BUSY", 10*time.Millisecond)
+	logger.LogAction(ctx, "setPower", "silvus-002", "SUCCESS", 10*time.Millisecond)
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	return mux
+}
+
+func TestAuditEndpoint(t *testing.T) {
+	mux := setupAuditTest(t, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit?action=setPower&limit=1", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data audit.Page `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Data.Total != 2 || len(resp.Data.Entries) != 1 || resp.Data.NextOffset != 1 {
+		t.Errorf("Unexpected audit page: %+v", resp.Data)
+	}
+	if resp.Data.Entries[0].Hash == "" {
+		t.Error("Expected entries to carry their chain hash")
+	}
+}
+
+func TestAuditExport(t *testing.T) {
+	mux := setupAuditTest(t, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit?format=csv&radio=silvus-001", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("Expected CSV export, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(records) != 3 || records[0][0] != "ts" || records[2][3] != "setChannel" {
+		t.Errorf("Unexpected CSV export: %v", records)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/audit?format=jsonl", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if w.Code != http.StatusOK || len(lines) != 3 {
+		t.Fatalf("Expected 3 JSONL lines, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Header().Get("Content-Disposition"), ".jsonl") {
+		t.Errorf("Expected JSONL attachment, got %q", w.Header().Get("Content-Disposition"))
+	}
+}
+
+func TestAuditVerifyEndpoint(t *testing.T) {
+	mux := setupAuditTest(t, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit/verify", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	var resp struct {
+		Data audit.Verification `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if w.Code != http.StatusOK || !resp.Data.Valid || resp.Data.Entries != 3 {
+		t.Errorf("Expected valid chain of 3 entries, got %d %+v", w.Code, resp.Data)
+	}
+}
+
+func TestAuditEndpointErrors(t *testing.T) {
+	mux := setupAuditTest(t, false)
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		status int
+	}{
+		{"wrong method", http.MethodPost, "/api/v1/audit", http.StatusMethodNotAllowed},
+		{"bad format", http.MethodGet, "/api/v1/audit?format=xml", http.StatusBadRequest},
+		{"bad from", http.MethodGet, "/api/v1/audit?from=yesterday", http.StatusBadRequest},
+		{"limit too large", http.MethodGet, "/api/v1/audit?limit=5000", http.StatusBadRequest},
+		{"negative offset", http.MethodGet, "/api/v1/audit?offset=-1", http.StatusBadRequest},
+		{"from after to", http.MethodGet, "/api/v1/audit?from=2025-01-02T00:00:00Z&to=2025-01-01T00:00:00Z", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Errorf("Expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuditEndpointRequiresAuditorRole(t *testing.T) {
+	mux := setupAuditTest(t, true)
+
+	tests := []struct {
+		token  string
+		status int
+	}{
+		{"", http.StatusUnauthorized},
+		{"viewer-token", http.StatusForbidden},
+		{"controller-token", http.StatusForbidden},
+		{"auditor-token", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		for _, path := range []string{"/api/v1/audit", "/api/v1/audit/verify"} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Errorf("%s with %q: expected %d, got %d", path, tt.token, tt.status, w.Code)
+			}
+		}
+	}
+}
+
+func TestAuditRecordsAuthenticatedSubject(t *testing.T) {
+	server, _, orch, _ := setupAPITest(t)
+	server.authMiddleware = auth.NewMiddleware()
+
+	logger, err := audit.NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create audit logger: %v", err)
+	}
+	t.Cleanup(func() { _ = logger.Close() })
+	orch.SetAuditLogger(logger)
+	server.SetAuditLog(logger)
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	// controller-token authenticates as admin-456
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/radios/silvus-001/power", strings.NewReader(`{"powerDbm":30}`))
+	req.Header.Set("Authorization", "Bearer controller-token")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected power change to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/audit?user=admin-456", nil)
+	req.Header.Set("Authorization", "Bearer auditor-token")
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data audit.Page `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Data.Total != 1 || resp.Data.Entries[0].User != "admin-456" || resp.Data.Entries[0].Action != "setPower" {
+		t.Errorf("Expected one setPower entry for admin-456, got %+v", resp.Data)
+	}
+}
+
+// failingAuditLog fails every read as a missing or unreadable log file would.
+type failingAuditLog struct{}
+
+func (failingAuditLog) Query(audit.Query) (audit.Page, error) {
+	return audit.Page{}, errors.New("open audit.jsonl: permission denied")
+}
+
+func (failingAuditLog) Verify() (audit.Verification, error) {
+	return audit.Verification{}, errors.New("open audit.jsonl: permission denied")
+}
+
+func TestAuditEndpointReadFailureIsInternal(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	server.SetAuditLog(failingAuditLog{})
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), "INTERNAL") {
+		t.Errorf("Expected 500 INTERNAL for a read failure, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/bandplan"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// bandPlanRequest is the body for band plan upload, validate and activate.
+type bandPlanRequest struct {
+	Channels []config.SilvusChannel `json:"channels,omitempty"`
+	Comment  string                 `json:"comment,omitempty"`
+	Activate bool                   `json:"activate,omitempty"`
+	Version  *int                   `json:"version,omitempty"`
+}
+
+// handleBandPlans handles GET /bandplans
+func (s *Server) handleBandPlans(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+
+	if s.bandPlans == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE",
+			"Band plan store not available", nil)
+		return
+	}
+
+	WriteSuccess(w, map[string]interface{}{
+		"items": s.bandPlans.List(),
+	})
+}
+
+// handleBandPlanEndpoints handles /bandplans/{model}/{band}[/validate|/activate|/rollback].
+// Reads require read scope; uploads, validation and activation require control scope.
+func (s *Server) handleBandPlanEndpoints(w http.ResponseWriter, r *http.Request) {
+	handler := s.routeBandPlan
+	if s.authMiddleware != nil {
+		scope := auth.ScopeControl
+		if r.Method == http.MethodGet {
+			scope = auth.ScopeRead
+		}
+		handler = s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(scope)(handler))
+	}
+	handler(w, r)
+}
+
+// routeBandPlan dispatches a band plan request on its path.
+func (s *Server) routeBandPlan(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/bandplans/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		WriteError(w, http.StatusNotFound, "NOT_FOUND",
+			"Expected /bandplans/{model}/{band}", nil)
+		return
+	}
+	model, band := parts[0], parts[1]
+
+	if s.bandPlans == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE",
+			"Band plan store not available", nil)
+		return
+	}
+
+	action := ""
+	if len(parts) == 3 {
+		action = parts[2]
+	}
+
+	switch action {
+	case "":
+		switch r.Method {
+		case http.MethodGet:
+			s.getBandPlanVersions(w, model, band)
+		case http.MethodPost:
+			s.uploadBandPlan(w, r, model, band)
+		default:
+			WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+				"Only GET and POST methods are allowed", nil)
+		}
+	case "validate", "activate", "rollback":
+		if r.Method != http.MethodPost {
+			WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+				"Only POST method is allowed", nil)
+			return
+		}
+		req, ok := decodeBandPlanRequest(w, r)
+		if !ok {
+			return
+		}
+		switch action {
+		case "validate":
+			validateBandPlan(w, model, band, req)
+		case "activate":
+			s.activateBandPlan(w, model, band, req)
+		default:
+			plan, err := s.bandPlans.Rollback(model, band)
+			writeBandPlanResult(w, plan, err)
+		}
+	default:
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", "Unknown band plan endpoint", nil)
+	}
+}
+
+// getBandPlanVersions handles GET /bandplans/{model}/{band}
+func (s *Server) getBandPlanVersions(w http.ResponseWriter, model, band string) {
+	summary, versions, err := s.bandPlans.Versions(model, band)
+	if err != nil {
+		writeBandPlanError(w, err)
+		return
+	}
+
+	WriteSuccess(w, map[string]interface{}{
+		"summary":  summary,
+		"versions": versions,
+	})
+}
+
+// uploadBandPlan handles POST /bandplans/{model}/{band}
+// The upload becomes the next version and is activated only if requested.
+func (s *Server) uploadBandPlan(w http.ResponseWriter, r *http.Request, model, band string) {
+	req, ok := decodeBandPlanRequest(w, r)
+	if !ok {
+		return
+	}
+
+	plan, err := s.bandPlans.Upload(model, band, req.Channels, req.Comment)
+	if err == nil && req.Activate {
+		plan, err = s.bandPlans.Activate(model, band, plan.Version)
+	}
+	writeBandPlanResult(w, plan, err)
+}
+
+// validateBandPlan handles POST /bandplans/{model}/{band}/validate
+// It reports problems in the response instead of failing the request.
+func validateBandPlan(w http.ResponseWriter, model, band string, req *bandPlanRequest) {
+	problems := []string{}
+	var validationErr *bandplan.ValidationError
+	if err := bandplan.Validate(model, band, req.Channels); errors.As(err, &validationErr) {
+		problems = validationErr.Problems
+	}
+
+	WriteSuccess(w, map[string]interface{}{
+		"valid":    len(problems) == 0,
+		"problems": problems,
+	})
+}
+
+// activateBandPlan handles POST /bandplans/{model}/{band}/activate
+func (s *Server) activateBandPlan(w http.ResponseWriter, model, band string, req *bandPlanRequest) {
+	if req.Version == nil {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "version must be provided", nil)
+		return
+	}
+
+	plan, err := s.bandPlans.Activate(model, band, *req.Version)
+	writeBandPlanResult(w, plan, err)
+}
+
+// decodeBandPlanRequest decodes the strict JSON body. An empty body is allowed.
+func decodeBandPlanRequest(w http.ResponseWriter, r *http.Request) (*bandPlanRequest, bool) {
+	var req bandPlanRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil && err != io.EOF {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "Malformed JSON or unknown fields", nil)
+		return nil, false
+	}
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "Trailing data after JSON object", nil)
+		return nil, false
+	}
+	return &req, true
+}
+
+// writeBandPlanResult writes the plan, or the store error.
+func writeBandPlanResult(w http.ResponseWriter, plan bandplan.Plan, err error) {
+	if err != nil {
+		writeBandPlanError(w, err)
+		return
+	}
+	WriteSuccess(w, plan)
+}
+
+// writeBandPlanError maps band plan store errors to API errors.
+func writeBandPlanError(w http.ResponseWriter, err error) {
+	var validationErr *bandplan.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		WriteError(w, http.StatusBadRequest, "INVALID_RANGE", "Invalid band plan",
+			map[string]interface{}{"problems": validationErr.Problems})
+	case errors.Is(err, bandplan.ErrNotFound):
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", err.Error(), nil)
+	case errors.Is(err, bandplan.ErrNoRollback):
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+	default:
+		WriteError(w, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+	}
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/adapter/silvusmock"
+	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/bandplan"
+)
+
+// setupBandPlanTest wires a band plan store into the standard API test
+// environment the same way cmd/rcc does.
+func setupBandPlanTest(t *testing.T, withAuth bool) (*http.ServeMux, *silvusmock.SilvusMock) {
+	t.Helper()
+
+	server, rm, orch, radioAdapter := setupAPITest(t)
+	if withAuth {
+		server.authMiddleware = auth.NewMiddleware()
+	}
+
+	store, err := bandplan.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create band plan store: %v", err)
+	}
+	store.SetChangeNotifier(func(change bandplan.Change) {
+		orch.SetSilvusBandPlan(change.Active)
+		rm.ApplyBandPlans(change.Active)
+	})
+	server.SetBandPlanStore(store)
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+	return mux, radioAdapter.(*silvusmock.SilvusMock)
+}
+
+func serveBandPlan(mux *http.ServeMux, method, path, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	return w
+}
+
+func TestBandPlanActivationRefreshesChannels(t *testing.T) {
+	mux, radio := setupBandPlanTest(t, false)
+	base := "/api/v1/bandplans/Unknown-Radio/default"
+
+	w := serveBandPlan(mux, http.MethodPost, base,
+		`{"channels":[{"channelIndex":1,"frequencyMhz":2412},{"channelIndex":2,"frequencyMhz":2437}],"activate":true}`, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected upload to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Channel 2 only exists in the new plan
+	w = serveBandPlan(mux, http.MethodPost, "/api/v1/radios/silvus-001/channel", `{"channelIndex":2}`, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected channel 2 to resolve, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, freq, _ := radio.GetCurrentState(); freq != 2437 {
+		t.Errorf("Expected 2437 MHz, got %v", freq)
+	}
+
+	w = serveBandPlan(mux, http.MethodGet, "/api/v1/radios/silvus-001", "", "")
+	var radioResp struct {
+		Data struct {
+			Capabilities adapter.RadioCapabilities `json:"capabilities"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &radioResp); err != nil {
+		t.Fatalf("Failed to decode radio: %v", err)
+	}
+	if channels := radioResp.Data.Capabilities.Channels; len(channels) != 2 || channels[1].Index != 2 {
+		t.Errorf("Expected capabilities to carry the active plan, got %+v", channels)
+	}
+
+	serveBandPlan(mux, http.MethodPost, base, `{"channels":[{"channelIndex":1,"frequencyMhz":2462}],"activate":true}`, "")
+	w = serveBandPlan(mux, http.MethodPost, base+"/rollback", "", "")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"version":1`) {
+		t.Fatalf("Expected rollback to version 1, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = serveBandPlan(mux, http.MethodGet, "/api/v1/bandplans", "", "")
+	var listResp struct {
+		Data struct {
+			Items []bandplan.Summary `json:"items"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &listResp); err != nil {
+		t.Fatalf("Failed to decode list: %v", err)
+	}
+	if len(listResp.Data.Items) != 1 || listResp.Data.Items[0].ActiveVersion != 1 || listResp.Data.Items[0].LatestVersion != 2 {
+		t.Errorf("Unexpected band plan list: %+v", listResp.Data.Items)
+	}
+}
+
+func TestBandPlanValidateAndErrors(t *testing.T) {
+	mux, _ := setupBandPlanTest(t, false)
+	base := "/api/v1/bandplans/Unknown-Radio/default"
+
+	w := serveBandPlan(mux, http.MethodPost, base+"/validate",
+		`{"channels":[{"channelIndex":1,"frequencyMhz":2412},{"channelIndex":1,"frequencyMhz":2437}]}`, "")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"valid":false`) {
+		t.Errorf("Expected validation problems in a 200 response, got %d: %s", w.Code, w.Body.String())
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"invalid upload", http.MethodPost, base, `{"channels":[]}`, http.StatusBadRequest},
+		{"unknown field", http.MethodPost, base, `{"plan":[]}`, http.StatusBadRequest},
+		{"unknown plan", http.MethodGet, "/api/v1/bandplans/Other/default", "", http.StatusNotFound},
+		{"activate without version", http.MethodPost, base + "/activate", `{}`, http.StatusBadRequest},
+		{"rollback without history", http.MethodPost, "/api/v1/bandplans/Other/default/rollback", "", http.StatusNotFound},
+		{"unknown action", http.MethodPost, base + "/publish", "", http.StatusNotFound},
+		{"wrong method", http.MethodDelete, base, "", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serveBandPlan(mux, tt.method, tt.path, tt.body, ""); w.Code != tt.status {
+				t.Errorf("Expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestBandPlanScopes(t *testing.T) {
+	mux, _ := setupBandPlanTest(t, true)
+	upload := `{"channels":[{"channelIndex":1,"frequencyMhz":2412}]}`
+
+	tests := []struct {
+		method string
+		path   string
+		token  string
+		status int
+	}{
+		{http.MethodGet, "/api/v1/bandplans", "", http.StatusUnauthorized},
+		{http.MethodGet, "/api/v1/bandplans", "viewer-token", http.StatusOK},
+		{http.MethodPost, "/api/v1/bandplans/Scout/default", "viewer-token", http.StatusForbidden},
+		{http.MethodPost, "/api/v1/bandplans/Scout/default", "controller-token", http.StatusOK},
+		{http.MethodGet, "/api/v1/bandplans/Scout/default", "viewer-token", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		if w := serveBandPlan(mux, tt.method, tt.path, upload, tt.token); w.Code != tt.status {
+			t.Errorf("%s %s with %q: expected %d, got %d", tt.method, tt.path, tt.token, tt.status, w.Code)
+		}
+	}
+}
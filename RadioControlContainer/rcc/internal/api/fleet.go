@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/command"
+)
+
+// fleetRequest is the common body for fleet commands.
+// Atomic defaults to true so a partial failure never leaves the mesh split across channels.
+type fleetRequest struct {
+	RadioIDs     []string `json:"radioIds"`
+	PowerDbm     *float64 `json:"powerDbm,omitempty"`
+	FrequencyMhz *float64 `json:"frequencyMhz,omitempty"`
+	ChannelIndex *int     `json:"channelIndex,omitempty"`
+	Atomic       *bool    `json:"atomic,omitempty"`
+}
+
+// handleFleetPower handles POST /fleet/power
+func (s *Server) handleFleetPower(w http.ResponseWriter, r *http.Request) {
+	fleet, req, ok := s.decodeFleetRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if req.PowerDbm == nil {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "powerDbm must be provided", nil)
+		return
+	}
+
+	result, err := fleet.SetFleetPower(r.Context(), req.RadioIDs, *req.PowerDbm, req.isAtomic())
+	writeFleetResult(w, result, err)
+}
+
+// handleFleetChannel handles POST /fleet/channel
+func (s *Server) handleFleetChannel(w http.ResponseWriter, r *http.Request) {
+	fleet, req, ok := s.decodeFleetRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if req.ChannelIndex == nil && req.FrequencyMhz == nil {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST",
+			"Either channelIndex or frequencyMhz must be provided", nil)
+		return
+	}
+
+	// Frequency wins if both provided, matching POST /radios/{id}/channel
+	var result *command.FleetResult
+	var err error
+	if req.FrequencyMhz != nil {
+		result, err = fleet.SetFleetChannel(r.Context(), req.RadioIDs, *req.FrequencyMhz, req.isAtomic())
+	} else {
+		result, err = fleet.SetFleetChannelByIndex(r.Context(), req.RadioIDs, *req.ChannelIndex, req.isAtomic())
+	}
+	writeFleetResult(w, result, err)
+}
+
+// decodeFleetRequest validates the method, decodes the strict JSON body and resolves the fleet port.
+func (s *Server) decodeFleetRequest(w http.ResponseWriter, r *http.Request) (FleetPort, *fleetRequest, bool) {
+	if r.Method != http.MethodPost {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only POST method is allowed", nil)
+		return nil, nil, false
+	}
+
+	var req fleetRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "Malformed JSON or unknown fields", nil)
+		return nil, nil, false
+	}
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "Trailing data after JSON object", nil)
+		return nil, nil, false
+	}
+
+	if len(req.RadioIDs) == 0 {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", "radioIds must contain at least one radio", nil)
+		return nil, nil, false
+	}
+
+	fleet, ok := s.orchestrator.(FleetPort)
+	if s.orchestrator == nil || !ok {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE", "Service not available", nil)
+		return nil, nil, false
+	}
+
+	return fleet, &req, true
+}
+
+// isAtomic reports whether the request asked for all-or-nothing semantics.
+func (req *fleetRequest) isAtomic() bool {
+	return req.Atomic == nil || *req.Atomic
+}
+
+// writeFleetResult writes the fleet outcome, carrying per-radio results in the
+// error details when any radio failed.
+func writeFleetResult(w http.ResponseWriter, result *command.FleetResult, err error) {
+	if err == nil {
+		WriteSuccess(w, result)
+		return
+	}
+
+	if result == nil {
+		status, body := ToAPIError(err)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		_, _ = w.Write(body)
+		return
+	}
+
+	var vendorErr *adapter.VendorError
+	code, status := mapAdapterError(err)
+	if errors.As(err, &vendorErr) {
+		code, status = mapAdapterError(vendorErr.Code)
+	}
+
+	WriteError(w, status, code, "Fleet command failed on one or more radios", result)
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/adapter/silvusmock"
+	"github.com/radio-control/rcc/internal/command"
+)
+
+// setupFleetAPITest adds a second SilvusMock radio to the standard API test environment.
+func setupFleetAPITest(t *testing.T) (*http.ServeMux, *silvusmock.SilvusMock, *silvusmock.SilvusMock) {
+	server, rm, _, first := setupAPITest(t)
+
+	second := silvusmock.NewSilvusMock("silvus-002", []adapter.Channel{
+		{Index: 1, FrequencyMhz: 2412},
+		{Index: 6, FrequencyMhz: 2437},
+		{Index: 11, FrequencyMhz: 2462},
+	})
+	if err := rm.LoadCapabilities("silvus-002", second, 5*time.Second); err != nil {
+		t.Fatalf("Failed to register second radio: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	return mux, first.(*silvusmock.SilvusMock), second
+}
+
+func TestFleetChannelEndpoint(t *testing.T) {
+	mux, first, second := setupFleetAPITest(t)
+
+	body := `{"radioIds":["silvus-001","silvus-002"],"frequencyMhz":2437}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/fleet/channel", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Result string              `json:"result"`
+		Data   command.FleetResult `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Data.Outcome != command.FleetOutcomeSuccess || len(resp.Data.Radios) != 2 {
+		t.Errorf("Unexpected fleet result: %+v", resp.Data)
+	}
+
+	for _, radio := range []*silvusmock.SilvusMock{first, second} {
+		if _, freq, _ := radio.GetCurrentState(); freq != 2437 {
+			t.Errorf("Radio %s frequency = %f, want 2437", radio.GetRadioID(), freq)
+		}
+	}
+}
+
+func TestFleetPowerEndpointReportsFailure(t *testing.T) {
+	mux, first, second := setupFleetAPITest(t)
+	second.SetFaultMode("ReturnBusy")
+
+	body := `{"radioIds":["silvus-001","silvus-002"],"powerDbm":25}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/fleet/power", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Code    string              `json:"code"`
+		Details command.FleetResult `json:"details"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Code != "BUSY" {
+		t.Errorf("Expected BUSY, got %s", resp.Code)
+	}
+	if len(resp.Details.Radios) != 2 {
+		t.Errorf("Expected per-radio results in details, got %+v", resp.Details)
+	}
+
+	// Atomic by default: the healthy radio keeps its original power
+	if power, _, _ := first.GetCurrentState(); power != 20 {
+		t.Errorf("Expected silvus-001 power to remain 20, got %f", power)
+	}
+}
+
+func TestFleetEndpointValidation(t *testing.T) {
+	mux, _, _ := setupFleetAPITest(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"wrong method", http.MethodGet, "/api/v1/fleet/power", "", http.StatusMethodNotAllowed},
+		{"empty radio list", http.MethodPost, "/api/v1/fleet/power", `{"radioIds":[],"powerDbm":10}`, http.StatusBadRequest},
+		{"missing power", http.MethodPost, "/api/v1/fleet/power", `{"radioIds":["silvus-001"]}`, http.StatusBadRequest},
+		{"missing channel", http.MethodPost, "/api/v1/fleet/channel", `{"radioIds":["silvus-001"]}`, http.StatusBadRequest},
+		{"unknown field", http.MethodPost, "/api/v1/fleet/channel", `{"radioIds":["silvus-001"],"bogus":1}`, http.StatusBadRequest},
+		{"unknown radio", http.MethodPost, "/api/v1/fleet/channel", `{"radioIds":["nope"],"frequencyMhz":2437}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Errorf("Expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/radio-control/rcc/internal/history"
+)
+
+// handleRadioHistory handles GET /radios/{id}/history?from=&to=&type=&interval=&limit=
+func (s *Server) handleRadioHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+
+	radioID := s.extractRadioID(r.URL.Path)
+	if radioID == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_RANGE",
+			"Radio ID is required", nil)
+		return
+	}
+
+	if s.historyStore == nil {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE",
+			"Telemetry history not available", nil)
+		return
+	}
+
+	if s.radioManager != nil {
+		if _, err := s.radioManager.GetRadio(radioID); err != nil {
+			WriteError(w, http.StatusNotFound, "NOT_FOUND", "Radio not found", nil)
+			return
+		}
+	}
+
+	query, err := parseHistoryQuery(r)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+		return
+	}
+
+	records, err := s.historyStore.Query(radioID, query)
+	if errors.Is(err, history.ErrInvalidRange) {
+		WriteError(w, http.StatusBadRequest, "INVALID_RANGE", err.Error(), nil)
+		return
+	}
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL", err.Error(), nil)
+		return
+	}
+
+	WriteSuccess(w, map[string]interface{}{
+		"radioId": radioID,
+		"items":   records,
+	})
+}
+
+// parseHistoryQuery parses the history query parameters.
+// Times are RFC3339, type is a comma-separated list and interval is a Go duration.
+func parseHistoryQuery(r *http.Request) (history.Query, error) {
+	values := r.URL.Query()
+	var query history.Query
+
+	if from := values.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return query, errors.New("from must be an RFC3339 timestamp")
+		}
+		query.From = t
+	}
+
+	if to := values.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return query, errors.New("to must be an RFC3339 timestamp")
+		}
+		query.To = t
+	}
+
+	if types := values.Get("type"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			switch t = strings.TrimSpace(t); t {
+			case history.TypePower, history.TypeFrequency, history.TypeState, history.TypeFault:
+				query.Types = append(query.Types, t)
+			default:
+				return query, errors.New("type must be one of power, frequency, state, fault")
+			}
+		}
+	}
+
+	if interval := values.Get("interval"); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil || d <= 0 {
+			return query, errors.New("interval must be a positive duration")
+		}
+		query.Interval = d
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return query, errors.New("limit must be a positive integer")
+		}
+		query.Limit = n
+	}
+
+	return query, nil
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/history"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+func TestRadioHistoryEndpoint(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+
+	store, err := history.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create history store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	server.SetHistoryStore(store)
+
+	store.Record(telemetry.Event{Type: "powerChanged", Radio: "silvus-001", Data: map[string]interface{}{"powerDbm": 20.0}})
+	store.Record(telemetry.Event{Type: "fault", Radio: "silvus-001", Data: map[string]interface{}{"code": "BUSY"}})
+	store.Flush()
+
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/radios/silvus-001/history?type=power", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data struct {
+			RadioID string           `json:"radioId"`
+			Items   []history.Record `json:"items"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Data.RadioID != "silvus-001" || len(resp.Data.Items) != 1 || resp.Data.Items[0].Type != history.TypePower {
+		t.Errorf("Unexpected history response: %+v", resp.Data)
+	}
+}
+
+func TestRadioHistoryEndpointErrors(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	// No store configured
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/radios/silvus-001/history", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 without history store, got %d", w.Code)
+	}
+
+	store, err := history.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create history store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	server.SetHistoryStore(store)
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		status int
+	}{
+		{"wrong method", http.MethodPost, "/api/v1/radios/silvus-001/history", http.StatusMethodNotAllowed},
+		{"unknown radio", http.MethodGet, "/api/v1/radios/nope/history", http.StatusNotFound},
+		{"bad from", http.MethodGet, "/api/v1/radios/silvus-001/history?from=yesterday", http.StatusBadRequest},
+		{"bad type", http.MethodGet, "/api/v1/radios/silvus-001/history?type=rssi", http.StatusBadRequest},
+		{"bad interval", http.MethodGet, "/api/v1/radios/silvus-001/history?interval=-1s", http.StatusBadRequest},
+		{"inverted range", http.MethodGet, "/api/v1/radios/silvus-001/history?from=2025-01-02T00:00:00Z&to=2025-01-01T00:00:00Z", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Errorf("Expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRadioHistoryEndpointReadFailure(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	dir := t.TempDir()
+	store, err := history.NewStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to create history store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	server.SetHistoryStore(store)
+
+	// A directory in place of the history file makes the read fail
+	if err := os.Mkdir(filepath.Join(dir, "silvus-001.jsonl"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/radios/silvus-001/history", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 for a history read failure, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+)
+
+// handleRadioLocation handles GET /radios/{id}/location
+func (s *Server) handleRadioLocation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+
+	radioID := s.extractRadioID(r.URL.Path)
+	if radioID == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_RANGE",
+			"Radio ID is required", nil)
+		return
+	}
+
+	locationPort, ok := s.orchestrator.(LocationPort)
+	if s.orchestrator == nil || !ok {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE",
+			"Service not available", nil)
+		return
+	}
+
+	location, err := locationPort.GetLocation(r.Context(), radioID)
+	if err != nil {
+		status, body := ToAPIError(err)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		_, _ = w.Write(body)
+		return
+	}
+
+	WriteSuccess(w, location)
+}
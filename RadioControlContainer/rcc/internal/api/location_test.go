@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+func TestRadioLocationEndpoint(t *testing.T) {
+	server, _, _, _ := setupAPITest(t)
+	mux := http.NewServeMux()
+	server.RegisterRoutes(mux)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/radios/silvus-001/location", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data adapter.Location `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Data.Latitude == 0 || resp.Data.Longitude == 0 || resp.Data.Timestamp.IsZero() {
+		t.Errorf("Unexpected location response: %+v", resp.Data)
+	}
+}
+
+func TestRadioLocationEndpointErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		fault  string
+		status int
+	}{
+		{"wrong method", http.MethodPost, "/api/v1/radios/silvus-001/location", "", http.StatusMethodNotAllowed},
+		{"unknown radio", http.MethodGet, "/api/v1/radios/nope/location", "", http.StatusNotFound},
+		{"unavailable radio", http.MethodGet, "/api/v1/radios/silvus-001/location", "ReturnUnavailable", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _, _, _ := setupAPITestWithFault(t, tt.fault)
+			mux := http.NewServeMux()
+			server.RegisterRoutes(mux)
+
+			req := httptest.NewRequest(tt.method, tt.url, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Errorf("Expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -6,7 +6,11 @@ import (
 	"net/http"
 
 	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/audit"
+	"github.com/radio-control/rcc/internal/bandplan"
 	"github.com/radio-control/rcc/internal/command"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/history"
 	"github.com/radio-control/rcc/internal/radio"
 	"github.com/radio-control/rcc/internal/telemetry"
 )
@@ -20,11 +24,53 @@ type OrchestratorPort interface {
 	SetChannelByIndex(ctx context.Context, radioID string, channelIndex int, radioManager command.RadioManager) error
 }
 
+// FleetPort defines the fleet fan-out operations the API needs from the orchestrator.
+type FleetPort interface {
+	SetFleetPower(ctx context.Context, radioIDs []string, powerDbm float64, atomic bool) (*command.FleetResult, error)
+	SetFleetChannel(ctx context.Context, radioIDs []string, frequencyMhz float64, atomic bool) (*command.FleetResult, error)
+	SetFleetChannelByIndex(ctx context.Context, radioIDs []string, channelIndex int, atomic bool) (*command.FleetResult, error)
+}
+
+// MetricsPort defines the link metrics read the API needs from the orchestrator.
+type MetricsPort interface {
+	GetLinkMetrics(ctx context.Context, radioID string) (*adapter.LinkMetrics, error)
+}
+
+// LocationPort defines the GPS position read the API needs from the orchestrator.
+type LocationPort interface {
+	GetLocation(ctx context.Context, radioID string) (*adapter.Location, error)
+}
+
+// HistoryPort defines the read interface the API needs from the telemetry history store.
+type HistoryPort interface {
+	Query(radioID string, q history.Query) ([]history.Record, error)
+}
+
+// AuditPort defines the read interface the API needs from the audit logger.
+type AuditPort interface {
+	Query(q audit.Query) (audit.Page, error)
+	Verify() (audit.Verification, error)
+}
+
+// BandPlanPort defines the band plan management the API needs from the band plan store.
+type BandPlanPort interface {
+	List() []bandplan.Summary
+	Versions(model, band string) (bandplan.Summary, []bandplan.Plan, error)
+	Upload(model, band string, channels []config.SilvusChannel, comment string) (bandplan.Plan, error)
+	Activate(model, band string, version int) (bandplan.Plan, error)
+	Rollback(model, band string) (bandplan.Plan, error)
+}
+
 // TelemetryPort defines the minimal interface the API needs from the telemetry hub.
 type TelemetryPort interface {
 	Subscribe(ctx context.Context, w http.ResponseWriter, r *http.Request) error
 }
 
+// WebSocketTelemetryPort defines the WebSocket telemetry transport the API needs from the hub.
+type WebSocketTelemetryPort interface {
+	SubscribeWebSocket(ctx context.Context, w http.ResponseWriter, r *http.Request) error
+}
+
 // RadioReadPort defines the minimal interface for radio read operations.
 type RadioReadPort interface {
 	GetRadio(radioID string) (*radio.Radio, error)
@@ -34,5 +80,12 @@ type RadioReadPort interface {
 
 // Compile-time assertions for port conformance
 var _ OrchestratorPort = (*command.Orchestrator)(nil)
+var _ FleetPort = (*command.Orchestrator)(nil)
+var _ MetricsPort = (*command.Orchestrator)(nil)
+var _ LocationPort = (*command.Orchestrator)(nil)
+var _ HistoryPort = (*history.Store)(nil)
+var _ AuditPort = (*audit.Logger)(nil)
+var _ BandPlanPort = (*bandplan.Store)(nil)
 var _ TelemetryPort = (*telemetry.Hub)(nil)
+var _ WebSocketTelemetryPort = (*telemetry.Hub)(nil)
 var _ RadioReadPort = (*radio.Manager)(nil)
@@ -4,12 +4,14 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/radio-control/rcc/internal/auth"
+	"github.com/radio-control/rcc/internal/telemetry"
 )
 
 // RegisterRoutes registers all OpenAPI v1 endpoints.
@@ -32,8 +34,21 @@ func (s *Server) RegisterRoutes(mux *http.ServeMux) {
 		// Radio-specific endpoints (power, channel, individual radio)
 		mux.HandleFunc(apiV1+"/radios/", s.handleRadioEndpoints)
 
-		// Telemetry endpoint
+		// Fleet endpoints
+		mux.HandleFunc(apiV1+"/fleet/power", s.handleFleetPower)
+		mux.HandleFunc(apiV1+"/fleet/channel", s.handleFleetChannel)
+
+		// Telemetry endpoints
 		mux.HandleFunc(apiV1+"/telemetry", s.handleTelemetry)
+		mux.HandleFunc(apiV1+"/telemetry/ws", s.handleTelemetryWebSocket)
+
+		// Audit endpoints
+		mux.HandleFunc(apiV1+"/audit", s.handleAudit)
+		mux.HandleFunc(apiV1+"/audit/verify", s.handleAuditVerify)
+
+		// Band plan endpoints
+		mux.HandleFunc(apiV1+"/bandplans", s.handleBandPlans)
+		mux.HandleFunc(apiV1+"/bandplans/", s.handleBandPlanEndpoints)
 		return
 	}
 
@@ -50,8 +65,21 @@ func (s *Server) RegisterRoutes(mux *http.ServeMux) {
 	// Radio-specific endpoints (power, channel, individual radio)
 	mux.HandleFunc(apiV1+"/radios/", s.handleRadioEndpoints)
 
+	// Fleet endpoints (controller access)
+	mux.HandleFunc(apiV1+"/fleet/power", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeControl)(s.handleFleetPower)))
+	mux.HandleFunc(apiV1+"/fleet/channel", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeControl)(s.handleFleetChannel)))
+
 	// Telemetry endpoint (viewer access)
 	mux.HandleFunc(apiV1+"/telemetry", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeTelemetry)(s.handleTelemetry)))
+	mux.HandleFunc(apiV1+"/telemetry/ws", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeTelemetry)(s.handleTelemetryWebSocket)))
+
+	// Audit endpoints (auditor role only)
+	mux.HandleFunc(apiV1+"/audit", s.authMiddleware.RequireAuth(s.authMiddleware.RequireRole(auth.RoleAuditor)(s.handleAudit)))
+	mux.HandleFunc(apiV1+"/audit/verify", s.authMiddleware.RequireAuth(s.authMiddleware.RequireRole(auth.RoleAuditor)(s.handleAuditVerify)))
+
+	// Band plan endpoints (viewer reads, controller changes)
+	mux.HandleFunc(apiV1+"/bandplans", s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.handleBandPlans)))
+	mux.HandleFunc(apiV1+"/bandplans/", s.handleBandPlanEndpoints)
 }
 
 // handleCapabilities handles GET /capabilities
@@ -64,9 +92,11 @@ func (s *Server) handleCapabilities(w http.ResponseWriter, r *http.Request) {
 
 	// Return capabilities
 	capabilities := map[string]interface{}{
-		"telemetry": []string{"sse"},
+		"telemetry": []string{"sse", "websocket"},
 		"commands":  []string{"http-json"},
-		"version":   "1.0.0",
+		// Optional adapter extensions served by /radios/{id}/metrics and /radios/{id}/location
+		"extensions": []string{"metrics", "location"},
+		"version":    "1.0.0",
 	}
 
 	WriteSuccess(w, capabilities)
@@ -169,6 +199,15 @@ func (s *Server) handleRadioEndpoints(w http.ResponseWriter, r *http.Request) {
 			} else {
 				s.handleRadioChannel(w, r)
 			}
+		} else if strings.HasSuffix(path, "/history") {
+			// History requires read scope
+			s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.handleRadioHistory))(w, r)
+		} else if strings.HasSuffix(path, "/metrics") {
+			// Link metrics require read scope
+			s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.handleRadioMetrics))(w, r)
+		} else if strings.HasSuffix(path, "/location") {
+			// GPS position requires read scope
+			s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.handleRadioLocation))(w, r)
 		} else {
 			// Individual radio endpoint requires read scope
 			s.authMiddleware.RequireAuth(s.authMiddleware.RequireScope(auth.ScopeRead)(s.handleRadioByID))(w, r)
@@ -179,6 +218,12 @@ func (s *Server) handleRadioEndpoints(w http.ResponseWriter, r *http.Request) {
 			s.handleRadioPower(w, r)
 		} else if strings.HasSuffix(path, "/channel") {
 			s.handleRadioChannel(w, r)
+		} else if strings.HasSuffix(path, "/history") {
+			s.handleRadioHistory(w, r)
+		} else if strings.HasSuffix(path, "/metrics") {
+			s.handleRadioMetrics(w, r)
+		} else if strings.HasSuffix(path, "/location") {
+			s.handleRadioLocation(w, r)
 		} else {
 			// Default to individual radio endpoint
 			s.handleRadioByID(w, r)
@@ -402,12 +447,38 @@ func (s *Server) handleTelemetry(w http.ResponseWriter, r *http.Request) {
 	// Subscribe to telemetry stream
 	ctx := r.Context()
 	if err := s.telemetryHub.Subscribe(ctx, w, r); err != nil {
+		if errors.Is(err, telemetry.ErrInvalidFilter) {
+			WriteError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+			return
+		}
 		WriteError(w, http.StatusInternalServerError, "INTERNAL",
 			"Failed to subscribe to telemetry stream", nil)
 		return
 	}
 }
 
+// handleTelemetryWebSocket handles GET /telemetry/ws (WebSocket upgrade)
+func (s *Server) handleTelemetryWebSocket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			"Only GET method is allowed", nil)
+		return
+	}
+
+	wsHub, ok := s.telemetryHub.(WebSocketTelemetryPort)
+	if s.telemetryHub == nil || !ok {
+		WriteError(w, http.StatusServiceUnavailable, "UNAVAILABLE",
+			"Telemetry service not available", nil)
+		return
+	}
+
+	// Upgrade failures are answered by the upgrader itself; filter errors
+	// are reported before the upgrade is attempted
+	if err := wsHub.SubscribeWebSocket(r.Context(), w, r); errors.Is(err, telemetry.ErrInvalidFilter) {
+		WriteError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error(), nil)
+	}
+}
+
 // handleHealth handles GET /health
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -18,6 +18,9 @@ type Server struct {
 	orchestrator   OrchestratorPort
 	radioManager   RadioReadPort
 	authMiddleware *auth.Middleware
+	historyStore   HistoryPort
+	auditLog       AuditPort
+	bandPlans      BandPlanPort
 	startTime      time.Time
 	readTimeout    time.Duration
 	writeTimeout   time.Duration
@@ -51,6 +54,21 @@ func NewServerWithAuth(telemetryHub TelemetryPort, orchestrator OrchestratorPort
 	}
 }
 
+// SetHistoryStore sets the telemetry history store backing GET /radios/{id}/history.
+func (s *Server) SetHistoryStore(store HistoryPort) {
+	s.historyStore = store
+}
+
+// SetAuditLog sets the audit log backing GET /audit.
+func (s *Server) SetAuditLog(log AuditPort) {
+	s.auditLog = log
+}
+
+// SetBandPlanStore sets the band plan store backing /bandplans.
+func (s *Server) SetBandPlanStore(store BandPlanPort) {
+	s.bandPlans = store
+}
+
 // Start starts the HTTP server.
 func (s *Server) Start(addr string) error {
 	mux := http.NewServeMux()
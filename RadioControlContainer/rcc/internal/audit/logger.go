@@ -4,6 +4,8 @@ package audit
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -11,6 +13,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/radio-control/rcc/internal/auth"
 )
 
 // AuditEntry represents a single audit log entry.
@@ -22,6 +26,12 @@ type AuditEntry struct {
 	Params    map[string]interface{} `json:"params"`
 	Outcome   string                 `json:"outcome"`
 	Code      string                 `json:"code"`
+
+	// PrevHash and Hash chain entries together so edits and deletions are
+	// detectable. Hash is the SHA-256 of the entry's JSON without the hash
+	// field and must stay last so that JSON is a prefix of the written line.
+	PrevHash string `json:"prevHash,omitempty"`
+	Hash     string `json:"hash,omitempty"`
 }
 
 // Logger implements the audit logging functionality.
@@ -29,6 +39,19 @@ type Logger struct {
 	mu       sync.Mutex
 	filePath string
 	file     *os.File
+	lastHash string
+
+	// Rotation state, guarded by mu
+	policy   RotationPolicy
+	size     int64
+	openedAt time.Time
+	dirty    bool // Written since the last fsync
+	lastSync time.Time
+	onRotate func(Rotation)
+
+	// Background compression and retention after rotation
+	maintenance sync.WaitGroup
+	maintMu     sync.Mutex
 }
 
 // NewLogger creates a new audit logger.
@@ -47,12 +70,72 @@ func NewLogger(logDir string) (*Logger, error) {
 		return nil, fmt.Errorf("failed to open audit log file: %w", err)
 	}
 
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to stat audit log file: %w", err)
+	}
+
+	// Continue the hash chain from the last entry already on disk
+	lastHash, openedAt, err := readChainState(filePath)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to read audit log chain: %w", err)
+	}
+
 	return &Logger{
 		filePath: filePath,
 		file:     file,
+		lastHash: lastHash,
+		policy:   RotationPolicy{Sync: SyncAlways},
+		size:     info.Size(),
+		openedAt: openedAt,
+		lastSync: time.Now(),
 	}, nil
 }
 
+// readChainState returns the hash of the newest entry, looking into rotated
+// logs when the current one has none, and the time the current log was
+// started (its first entry, or now when it is empty).
+func readChainState(filePath string) (string, time.Time, error) {
+	files, err := listLogFiles(filePath)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var lastHash string
+	var openedAt time.Time
+	for i := len(files) - 1; i >= 0 && lastHash == ""; i-- {
+		current := i == len(files)-1
+		err := scanFile(files[i], func(entry AuditEntry, _ []byte, parsed bool) error {
+			if !parsed {
+				return nil
+			}
+			if current && openedAt.IsZero() {
+				openedAt = entry.Timestamp
+			}
+			if entry.Hash != "" {
+				lastHash = entry.Hash
+			}
+			return nil
+		})
+		if err != nil {
+			return "", time.Time{}, err
+		}
+	}
+
+	if openedAt.IsZero() {
+		openedAt = time.Now()
+	}
+	return lastHash, openedAt, nil
+}
+
+// hashEntry returns the chain hash of an entry marshaled without its hash field.
+func hashEntry(unhashed []byte) string {
+	sum := sha256.Sum256(unhashed)
+	return hex.EncodeToString(sum[:])
+}
+
 // LogAction logs an audit record for a command action.
 func (l *Logger) LogAction(ctx context.Context, action, radioID, result string, latency time.Duration) {
 	// Extract user from context (if available)
@@ -104,7 +187,9 @@ func (l *Logger) writeEntry(entry AuditEntry) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	// Marshal entry to JSON
+	// Marshal entry to JSON, linked to the previous entry
+	entry.PrevHash = l.lastHash
+	entry.Hash = ""
 	jsonData, err := json.Marshal(entry)
 	if err != nil {
 		// Log error to stderr if JSON marshaling fails
@@ -112,30 +197,43 @@ func (l *Logger) writeEntry(entry AuditEntry) {
 		return
 	}
 
+	// Append the hash as the last field of the same JSON object
+	hash := hashEntry(jsonData)
+	line := append(jsonData[:len(jsonData)-1], []byte(`,"hash":"`+hash+`"}`+"\n")...)
+
+	// Start a new file first if this entry would overflow the current one
+	if reason := l.rotationDue(int64(len(line)), time.Now()); reason != "" {
+		if err := l.rotateLocked(reason); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to rotate audit log: %v\n", err)
+		}
+	}
+
 	// Write JSON line to file
-	if _, err := l.file.Write(append(jsonData, '\n')); err != nil {
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
 		// Log error to stderr if file write fails
 		fmt.Fprintf(os.Stderr, "Failed to write audit entry: %v\n", err)
 		return
 	}
+	l.lastHash = hash
 
-	// Flush to ensure data is written to disk
+	// Flush according to the sync policy
+	if l.policy.Sync != SyncAlways {
+		l.dirty = true
+		return
+	}
 	if err := l.file.Sync(); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to sync audit log: %v\n", err)
 	}
 }
 
-// getUserFromContext extracts user information from the request context.
+// getUserFromContext returns the subject of the claims stored by the auth
+// middleware, or "unknown" for unauthenticated calls.
 func (l *Logger) getUserFromContext(ctx context.Context) string {
-	// Try to get user from auth claims
-	// This would be populated by the auth middleware
-	if claims, ok := ctx.Value("claims").(map[string]interface{}); ok {
-		if subject, ok := claims["sub"].(string); ok {
-			return subject
-		}
+	if claims, ok := ctx.Value(auth.ClaimsKey).(*auth.Claims); ok && claims.Subject != "" {
+		return claims.Subject
 	}
-	
-	// Default to "unknown" if no user context
 	return "unknown"
 }
 
@@ -199,17 +297,23 @@ func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
 
-// Close closes the audit logger and its file.
+// Close closes the audit logger and its file, waiting for any background
+// compression of rotated files to finish.
 func (l *Logger) Close() error {
 	l.mu.Lock()
-	defer l.mu.Unlock()
-	
+	var err error
 	if l.file != nil {
-		err := l.file.Close()
+		if l.dirty {
+			_ = l.file.Sync()
+			l.dirty = false
+		}
+		err = l.file.Close()
 		l.file = nil
-		return err
 	}
-	return nil
+	l.mu.Unlock()
+
+	l.maintenance.Wait()
+	return err
 }
 
 // GetFilePath returns the path to the audit log file.
@@ -217,34 +321,10 @@ func (l *Logger) GetFilePath() string {
 	return l.filePath
 }
 
-// Rotate rotates the audit log file.
-// This is a placeholder for future log rotation functionality.
+// Rotate rotates the audit log file now, regardless of the rotation policy.
 func (l *Logger) Rotate() error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	
-	// Close current file
-	if l.file != nil {
-		if err := l.file.Close(); err != nil {
-			return fmt.Errorf("failed to close current log file: %w", err)
-		}
-	}
-	
-	// Create new file with timestamp
-	timestamp := time.Now().Format("20060102-150405")
-	newFilePath := fmt.Sprintf("%s.%s", l.filePath, timestamp)
-	
-	// Rename current file
-	if err := os.Rename(l.filePath, newFilePath); err != nil {
-		return fmt.Errorf("failed to rename log file: %w", err)
-	}
-	
-	// Open new file
-	file, err := os.OpenFile(l.filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open new log file: %w", err)
-	}
-	
-	l.file = file
-	return nil
+
+	return l.rotateLocked(RotateManual)
 }
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/radio-control/rcc/internal/auth"
 )
 
 func TestNewLogger(t *testing.T) {
@@ -330,9 +332,7 @@ func TestGetUserFromContext(t *testing.T) {
 	}
 
 	// Test with user context
-	ctxWithUser := context.WithValue(ctx, "claims", map[string]interface{}{
-		"sub": "user-123",
-	})
+	ctxWithUser := context.WithValue(ctx, auth.ClaimsKey, &auth.Claims{Subject: "user-123"})
 	user = logger.getUserFromContext(ctxWithUser)
 	if user != "user-123" {
 		t.Errorf("Expected user 'user-123', got '%s'", user)
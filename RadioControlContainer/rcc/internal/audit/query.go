@@ -0,0 +1,285 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxEntrySize bounds a single JSONL audit line when reading logs back.
+const maxEntrySize = 1024 * 1024
+
+// ErrInvalidQuery is returned by Query for malformed filters or paging, as
+// opposed to failures reading the logs.
+var ErrInvalidQuery = errors.New("invalid audit query")
+
+// Query selects audit entries. Zero-valued fields match everything.
+type Query struct {
+	User    string
+	RadioID string
+	Action  string
+	From    time.Time // Inclusive
+	To      time.Time // Exclusive
+	Offset  int
+	Limit   int // 0 returns all matches after Offset
+}
+
+// Page is one page of query results, oldest first.
+type Page struct {
+	Entries []AuditEntry `json:"items"`
+	Total   int          `json:"total"`
+	Offset  int          `json:"offset"`
+	// NextOffset is the offset of the next page, or -1 when there is none.
+	NextOffset int `json:"nextOffset"`
+}
+
+// Verification reports the result of walking the hash chain.
+type Verification struct {
+	Valid     bool `json:"valid"`
+	Entries   int  `json:"entries"`
+	Unchained int  `json:"unchained"` // Leading entries written before hash chaining
+	// Anchor is the prevHash of the oldest chained entry when older files
+	// were removed by retention; compare it with the announced rotations.
+	Anchor string `json:"anchor,omitempty"`
+	// BrokenAt is the zero-based index of the first entry that fails, or -1.
+	BrokenAt int    `json:"brokenAt"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// matches reports whether the entry satisfies the query filters.
+func (q Query) matches(entry AuditEntry) bool {
+	if q.User != "" && entry.User != q.User {
+		return false
+	}
+	if q.RadioID != "" && entry.RadioID != q.RadioID {
+		return false
+	}
+	if q.Action != "" && entry.Action != q.Action {
+		return false
+	}
+	if !q.From.IsZero() && entry.Timestamp.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !entry.Timestamp.Before(q.To) {
+		return false
+	}
+	return true
+}
+
+// Query returns entries from the current and rotated audit logs that match q.
+func (l *Logger) Query(q Query) (Page, error) {
+	if q.Offset < 0 || q.Limit < 0 {
+		return Page{}, fmt.Errorf("%w: offset and limit must not be negative", ErrInvalidQuery)
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		return Page{}, fmt.Errorf("%w: from must be before to", ErrInvalidQuery)
+	}
+
+	page := Page{Entries: []AuditEntry{}, Offset: q.Offset, NextOffset: -1}
+	err := l.scan(func(entry AuditEntry, _ []byte, parsed bool) error {
+		// Corrupt lines are skipped here and reported by Verify
+		if !parsed || !q.matches(entry) {
+			return nil
+		}
+		if page.Total >= q.Offset && (q.Limit == 0 || len(page.Entries) < q.Limit) {
+			page.Entries = append(page.Entries, entry)
+		}
+		page.Total++
+		return nil
+	})
+	if err != nil {
+		return Page{}, err
+	}
+
+	if next := q.Offset + len(page.Entries); next < page.Total {
+		page.NextOffset = next
+	}
+	return page, nil
+}
+
+// Verify walks every entry in order and checks each hash and its link to the
+// previous entry. Entries logged before chaining was introduced are counted
+// as unchained as long as they precede the first chained entry. The oldest
+// chained entry may link to a pruned file; its prevHash is reported as Anchor.
+func (l *Logger) Verify() (Verification, error) {
+	result := Verification{Valid: true, BrokenAt: -1}
+	prev := ""
+	chained := false
+
+	err := l.scan(func(entry AuditEntry, line []byte, parsed bool) error {
+		index := result.Entries
+		result.Entries++
+		if !result.Valid {
+			return nil
+		}
+
+		fail := func(reason string) {
+			result.Valid = false
+			result.BrokenAt = index
+			result.Reason = reason
+		}
+
+		if !parsed {
+			fail("entry is not valid JSON")
+			return nil
+		}
+		if entry.Hash == "" {
+			if chained {
+				fail("entry has no hash")
+			} else {
+				result.Unchained++
+			}
+			return nil
+		}
+
+		unhashed, ok := stripHash(line, entry.Hash)
+		if !ok {
+			fail("hash is not the last field")
+			return nil
+		}
+		if hashEntry(unhashed) != entry.Hash {
+			fail("entry does not match its hash")
+			return nil
+		}
+		if !chained && result.Unchained == 0 {
+			result.Anchor = entry.PrevHash
+		} else if entry.PrevHash != prev {
+			fail("entry does not link to the previous entry")
+			return nil
+		}
+		chained = true
+		prev = entry.Hash
+		return nil
+	})
+	if err != nil {
+		return Verification{}, err
+	}
+	return result, nil
+}
+
+// stripHash recovers the JSON that was hashed by removing the trailing hash field.
+func stripHash(line []byte, hash string) ([]byte, bool) {
+	suffix := []byte(`,"hash":"` + hash + `"}`)
+	if !bytes.HasSuffix(line, suffix) {
+		return nil, false
+	}
+	unhashed := append([]byte(nil), line[:len(line)-len(suffix)]...)
+	return append(unhashed, '}'), true
+}
+
+// scanFunc receives each line with its parsed entry; parsed is false for lines
+// that are not valid JSON.
+type scanFunc func(entry AuditEntry, line []byte, parsed bool) error
+
+// scan calls fn for every entry in the rotated logs, oldest first, followed
+// by the current log. Only the file list and the current log's length are
+// taken under l.mu, so writers are not blocked while files are read; entries
+// written after that point are not returned. Compression and retention are
+// held off for the duration so rotated files do not move mid-scan.
+func (l *Logger) scan(fn scanFunc) error {
+	l.maintMu.Lock()
+	defer l.maintMu.Unlock()
+
+	files, current, size, err := l.snapshotFiles()
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		defer func() { _ = current.Close() }()
+	}
+
+	for _, path := range files[:len(files)-1] {
+		if err := scanFile(path, fn); err != nil {
+			return err
+		}
+	}
+	if current == nil {
+		return nil
+	}
+	return scanLines(io.NewSectionReader(current, 0, size), fn)
+}
+
+// snapshotFiles lists the log files and opens the current log with its size.
+// The open handle keeps reading the same file if it is rotated afterwards.
+func (l *Logger) snapshotFiles() ([]string, *os.File, int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	files, err := listLogFiles(l.filePath)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	current, err := os.Open(l.filePath)
+	if os.IsNotExist(err) {
+		return files, nil, 0, nil
+	}
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	info, err := current.Stat()
+	if err != nil {
+		_ = current.Close()
+		return nil, nil, 0, fmt.Errorf("failed to stat audit log: %w", err)
+	}
+	return files, current, info.Size(), nil
+}
+
+// scanFile reads one rotated JSONL audit file, gzipped if it ends in .gz.
+func scanFile(path string, fn scanFunc) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) && !strings.HasSuffix(path, ".gz") {
+		// Compressed since it was listed
+		path += ".gz"
+		file, err = os.Open(path)
+	}
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var r io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", filepath.Base(path), err)
+		}
+		defer func() { _ = zr.Close() }()
+		r = zr
+	}
+
+	return scanLines(r, fn)
+}
+
+// scanLines parses JSONL audit entries from r and passes each to fn.
+func scanLines(r io.Reader, fn scanFunc) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxEntrySize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var entry AuditEntry
+		parsed := json.Unmarshal(line, &entry) == nil
+		if err := fn(entry, line, parsed); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return nil
+}
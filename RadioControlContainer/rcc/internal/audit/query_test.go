@@ -0,0 +1,216 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newQueryTestLogger(t *testing.T) *Logger {
+	t.Helper()
+
+	logger, err := NewLogger(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create audit logger: %v", err)
+	}
+	t.Cleanup(func() { _ = logger.Close() })
+	return logger
+}
+
+func TestQueryFiltersAndPaginates(t *testing.T) {
+	logger := newQueryTestLogger(t)
+	ctx := context.Background()
+
+	logger.LogAction(ctx, "setPower", "radio-01", "SUCCESS", 0)
+	logger.LogAction(ctx, "setChannel", "radio-01", "SUCCESS", 0)
+	logger.LogAction(ctx, "setPower", "radio-02", "BUSY", 0)
+	logger.LogAction(ctx, "setPower", "radio-01", "UNAVAILABLE", 0)
+
+	page, err := logger.Query(Query{Action: "setPower"})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if page.Total != 3 || len(page.Entries) != 3 || page.NextOffset != -1 {
+		t.Fatalf("Expected 3 setPower entries on one page, got %+v", page)
+	}
+
+	page, err = logger.Query(Query{RadioID: "radio-01", Limit: 2})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if page.Total != 3 || len(page.Entries) != 2 || page.NextOffset != 2 {
+		t.Fatalf("Expected first page of 2 with next offset 2, got %+v", page)
+	}
+
+	page, err = logger.Query(Query{RadioID: "radio-01", Offset: 2, Limit: 2})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(page.Entries) != 1 || page.Entries[0].Outcome != "UNAVAILABLE" || page.NextOffset != -1 {
+		t.Fatalf("Expected last page with the UNAVAILABLE entry, got %+v", page)
+	}
+
+	page, err = logger.Query(Query{User: "someone-else"})
+	if err != nil || page.Total != 0 || page.Entries == nil {
+		t.Errorf("Expected an empty, non-nil result for unknown user, got %+v (err %v)", page, err)
+	}
+
+	future := time.Now().Add(time.Hour)
+	if page, _ := logger.Query(Query{From: future}); page.Total != 0 {
+		t.Errorf("Expected no entries after %v, got %d", future, page.Total)
+	}
+	if _, err := logger.Query(Query{From: future, To: future.Add(-time.Minute)}); err == nil {
+		t.Error("Expected error when from is after to")
+	}
+}
+
+func TestQueryIncludesRotatedLogs(t *testing.T) {
+	logger := newQueryTestLogger(t)
+	ctx := context.Background()
+
+	logger.LogAction(ctx, "setPower", "radio-01", "SUCCESS", 0)
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	logger.LogAction(ctx, "setChannel", "radio-01", "SUCCESS", 0)
+
+	page, err := logger.Query(Query{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if page.Total != 2 || page.Entries[0].Action != "setPower" || page.Entries[1].Action != "setChannel" {
+		t.Fatalf("Expected rotated entry first, got %+v", page.Entries)
+	}
+
+	result, err := logger.Verify()
+	if err != nil || !result.Valid || result.Entries != 2 {
+		t.Errorf("Expected chain to continue across rotation, got %+v (err %v)", result, err)
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	logger := newQueryTestLogger(t)
+	ctx := context.Background()
+
+	for _, outcome := range []string{"SUCCESS", "BUSY", "SUCCESS"} {
+		logger.LogAction(ctx, "setPower", "radio-01", outcome, 0)
+	}
+
+	result, err := logger.Verify()
+	if err != nil || !result.Valid || result.Entries != 3 || result.BrokenAt != -1 {
+		t.Fatalf("Expected untouched log to verify, got %+v (err %v)", result, err)
+	}
+
+	data, err := os.ReadFile(logger.GetFilePath())
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+
+	tests := []struct {
+		name     string
+		content  string
+		brokenAt int
+	}{
+		{"edited entry", lines[0] + strings.Replace(lines[1], "BUSY", "SUCCESS", 1) + lines[2], 1},
+		{"deleted entry", lines[0] + lines[2], 1},
+		{"corrupt entry", lines[0] + "{not json\n" + lines[2], 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(logger.GetFilePath(), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write audit log: %v", err)
+			}
+
+			result, err := logger.Verify()
+			if err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+			if result.Valid || result.BrokenAt != tt.brokenAt {
+				t.Errorf("Expected chain broken at %d, got %+v", tt.brokenAt, result)
+			}
+		})
+	}
+}
+
+func TestChainContinuesAfterReopen(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	// Entries from before hash chaining have no hash fields
+	legacy := `{"ts":"2025-01-01T00:00:00Z","user":"unknown","radioId":"radio-01","action":"setPower","params":{},"outcome":"SUCCESS","code":"SUCCESS"}` + "\n"
+	if err := os.WriteFile(dir+"/audit.jsonl", []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to seed audit log: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		logger, err := NewLogger(dir)
+		if err != nil {
+			t.Fatalf("Failed to create audit logger: %v", err)
+		}
+		logger.LogAction(ctx, "setPower", "radio-01", "SUCCESS", 0)
+		_ = logger.Close()
+	}
+
+	logger, err := NewLogger(dir)
+	if err != nil {
+		t.Fatalf("Failed to reopen audit logger: %v", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	result, err := logger.Verify()
+	if err != nil || !result.Valid || result.Entries != 3 || result.Unchained != 1 {
+		t.Errorf("Expected legacy entry plus a chain spanning both loggers, got %+v (err %v)", result, err)
+	}
+}
+
+func TestScanDoesNotBlockWriters(t *testing.T) {
+	logger := newQueryTestLogger(t)
+	ctx := context.Background()
+
+	logger.LogAction(ctx, "setPower", "radio-01", "SUCCESS", 0)
+
+	// Hold a scan open mid-file while another entry is written
+	inScan := make(chan struct{})
+	release := make(chan struct{})
+	scanned := make(chan int)
+	go func() {
+		count := 0
+		_ = logger.scan(func(entry AuditEntry, line []byte, parsed bool) error {
+			if count == 0 {
+				close(inScan)
+				<-release
+			}
+			count++
+			return nil
+		})
+		scanned <- count
+	}()
+	<-inScan
+
+	written := make(chan struct{})
+	go func() {
+		logger.LogAction(ctx, "setChannel", "radio-01", "SUCCESS", 0)
+		close(written)
+	}()
+
+	select {
+	case <-written:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Write blocked behind an in-progress scan")
+	}
+	close(release)
+
+	// The scan sees the file as it was when it started
+	if count := <-scanned; count != 1 {
+		t.Errorf("Expected the scan to return the 1 entry present at its start, got %d", count)
+	}
+
+	page, err := logger.Query(Query{})
+	if err != nil || page.Total != 2 {
+		t.Errorf("Expected 2 entries after the write, got %+v (err %v)", page, err)
+	}
+}
@@ -0,0 +1,343 @@
+package audit
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SyncPolicy controls when audit writes are flushed to disk.
+type SyncPolicy string
+
+const (
+	// SyncAlways fsyncs after every entry (the default).
+	SyncAlways SyncPolicy = "always"
+	// SyncInterval fsyncs from Run at most every RotationPolicy.SyncEvery.
+	SyncInterval SyncPolicy = "interval"
+	// SyncNever leaves flushing to the operating system.
+	SyncNever SyncPolicy = "never"
+)
+
+// Rotation reasons reported in Rotation.Reason.
+const (
+	RotateSize   = "size"
+	RotateAge    = "age"
+	RotateManual = "manual"
+)
+
+// maintenanceInterval is how often Run checks age-based rotation and interval syncs.
+var maintenanceInterval = time.Second
+
+// rotatedTimeFormat names rotated files; it sorts lexically in rotation order.
+const rotatedTimeFormat = "20060102-150405.000000000"
+
+// RotationPolicy controls automatic rotation, compression and retention.
+// Zero values disable the corresponding limit.
+type RotationPolicy struct {
+	MaxSizeBytes int64         // Rotate before a write would grow the file past this
+	MaxAge       time.Duration // Rotate once the current file's first entry is this old
+	Compress     bool          // Gzip rotated files in the background
+	MaxBackups   int           // Rotated files to keep
+	MaxBackupAge time.Duration // Delete rotated files older than this
+	Sync         SyncPolicy    // Empty means SyncAlways
+	SyncEvery    time.Duration // Used with SyncInterval
+}
+
+// Rotation describes a completed rotation.
+type Rotation struct {
+	Time      time.Time
+	Reason    string
+	File      string   // Rotated file, after compression
+	SizeBytes int64    // Uncompressed size
+	LastHash  string   // Hash of the last entry in the rotated file
+	Removed   []string // Rotated files deleted by retention
+}
+
+// Validate checks the policy for invalid values.
+func (p RotationPolicy) Validate() error {
+	if p.MaxSizeBytes < 0 || p.MaxAge < 0 || p.MaxBackups < 0 || p.MaxBackupAge < 0 {
+		return fmt.Errorf("rotation limits must not be negative")
+	}
+	switch p.Sync {
+	case "", SyncAlways, SyncNever:
+	case SyncInterval:
+		if p.SyncEvery <= 0 {
+			return fmt.Errorf("sync interval must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown sync policy %q", p.Sync)
+	}
+	return nil
+}
+
+// SetRotationPolicy sets the rotation, retention and sync policy.
+func (l *Logger) SetRotationPolicy(policy RotationPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	if policy.Sync == "" {
+		policy.Sync = SyncAlways
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.policy = policy
+	return nil
+}
+
+// SetRotationNotifier sets a callback invoked after each rotation completes,
+// e.g. to announce it on the telemetry hub. It runs off the write path.
+func (l *Logger) SetRotationNotifier(notify func(Rotation)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onRotate = notify
+}
+
+// Run performs age-based rotation and interval syncs until ctx is cancelled.
+// Size-based rotation happens on the write path and does not need Run.
+func (l *Logger) Run(ctx context.Context) {
+	ticker := time.NewTicker(maintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			l.mu.Lock()
+			l.syncLocked()
+			l.mu.Unlock()
+			return
+		case now := <-ticker.C:
+			l.tick(now)
+		}
+	}
+}
+
+// tick runs one round of scheduled maintenance.
+func (l *Logger) tick(now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return
+	}
+	if l.policy.Sync == SyncInterval && now.Sub(l.lastSync) >= l.policy.SyncEvery {
+		l.syncLocked()
+	}
+	if reason := l.rotationDue(0, now); reason != "" {
+		if err := l.rotateLocked(reason); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to rotate audit log: %v\n", err)
+		}
+	}
+}
+
+// syncLocked flushes pending writes. Caller must hold l.mu.
+func (l *Logger) syncLocked() {
+	if !l.dirty || l.file == nil {
+		return
+	}
+	if err := l.file.Sync(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to sync audit log: %v\n", err)
+	}
+	l.dirty = false
+	l.lastSync = time.Now()
+}
+
+// rotationDue returns why the current file should be rotated before writing
+// pending more bytes, or "" if it should not. Empty files are never rotated.
+// Caller must hold l.mu.
+func (l *Logger) rotationDue(pending int64, now time.Time) string {
+	if l.size == 0 {
+		return ""
+	}
+	if l.policy.MaxSizeBytes > 0 && l.size+pending > l.policy.MaxSizeBytes {
+		return RotateSize
+	}
+	if l.policy.MaxAge > 0 && now.Sub(l.openedAt) >= l.policy.MaxAge {
+		return RotateAge
+	}
+	return ""
+}
+
+// rotateLocked renames the current file aside and opens a new one. The hash
+// chain carries on into the new file. Compression, retention and the
+// notifier run in the background so writers are only blocked for the rename.
+// Caller must hold l.mu.
+func (l *Logger) rotateLocked(reason string) error {
+	// Close current file
+	if l.file != nil {
+		if l.dirty {
+			_ = l.file.Sync()
+			l.dirty = false
+		}
+		if err := l.file.Close(); err != nil {
+			return fmt.Errorf("failed to close current log file: %w", err)
+		}
+		l.file = nil
+	}
+
+	now := time.Now()
+	rotation := Rotation{
+		Time:      now.UTC(),
+		Reason:    reason,
+		File:      fmt.Sprintf("%s.%s", l.filePath, now.UTC().Format(rotatedTimeFormat)),
+		SizeBytes: l.size,
+		LastHash:  l.lastHash,
+	}
+
+	// Rename current file
+	renameErr := os.Rename(l.filePath, rotation.File)
+
+	// Reopen even if the rename failed so logging can continue
+	file, err := os.OpenFile(l.filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open new log file: %w", err)
+	}
+	l.file = file
+	if renameErr != nil {
+		return fmt.Errorf("failed to rename log file: %w", renameErr)
+	}
+
+	l.size = 0
+	l.openedAt = now
+	l.lastSync = now
+
+	policy, notify := l.policy, l.onRotate
+	l.maintenance.Add(1)
+	go func() {
+		defer l.maintenance.Done()
+		l.finishRotation(rotation, policy, notify)
+	}()
+	return nil
+}
+
+// finishRotation compresses the rotated file, applies retention and reports
+// the rotation. Runs one at a time, outside l.mu.
+func (l *Logger) finishRotation(rotation Rotation, policy RotationPolicy, notify func(Rotation)) {
+	l.maintMu.Lock()
+	defer l.maintMu.Unlock()
+
+	if policy.Compress {
+		if compressed, err := compressFile(rotation.File); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to compress rotated audit log: %v\n", err)
+		} else {
+			rotation.File = compressed
+		}
+	}
+
+	removed, err := pruneRotated(l.filePath, policy, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to apply audit log retention: %v\n", err)
+	}
+	rotation.Removed = removed
+
+	if notify != nil {
+		notify(rotation)
+	}
+}
+
+// compressFile gzips path to path.gz and removes the original. The archive
+// is written under a temporary name so readers never see a partial file.
+func compressFile(path string) (string, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = src.Close() }()
+
+	gzPath := path + ".gz"
+	tmpPath := gzPath + ".tmp"
+	dst, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return "", err
+	}
+
+	zw := gzip.NewWriter(dst)
+	zw.Name = filepath.Base(path)
+	_, err = io.Copy(zw, src)
+	if err == nil {
+		err = zw.Close()
+	}
+	if err == nil {
+		err = dst.Sync()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, gzPath)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return "", err
+	}
+
+	return gzPath, os.Remove(path)
+}
+
+// pruneRotated deletes rotated files beyond MaxBackups or older than MaxBackupAge.
+func pruneRotated(filePath string, policy RotationPolicy, now time.Time) ([]string, error) {
+	if policy.MaxBackups == 0 && policy.MaxBackupAge == 0 {
+		return nil, nil
+	}
+
+	files, err := listLogFiles(filePath)
+	if err != nil {
+		return nil, err
+	}
+	rotated := files[:len(files)-1]
+
+	var removed []string
+	for i, path := range rotated {
+		expired := policy.MaxBackups > 0 && i < len(rotated)-policy.MaxBackups
+		if !expired && policy.MaxBackupAge > 0 {
+			if info, err := os.Stat(path); err == nil && now.Sub(info.ModTime()) > policy.MaxBackupAge {
+				expired = true
+			}
+		}
+		if !expired {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
+
+// listLogFiles lists rotated logs oldest first followed by the current log.
+// A rotated file whose compression has not finished is listed once, uncompressed.
+func listLogFiles(filePath string) ([]string, error) {
+	matches, err := filepath.Glob(filePath + ".*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rotated audit logs: %w", err)
+	}
+
+	present := make(map[string]bool, len(matches))
+	for _, path := range matches {
+		present[path] = true
+	}
+
+	var rotated []string
+	for _, path := range matches {
+		if strings.HasSuffix(path, ".tmp") {
+			continue
+		}
+		if plain := strings.TrimSuffix(path, ".gz"); plain != path && present[plain] {
+			continue
+		}
+		rotated = append(rotated, path)
+	}
+
+	// Rotation suffixes are timestamps, so lexical order is rotation order
+	sort.Slice(rotated, func(i, j int) bool {
+		return strings.TrimSuffix(rotated[i], ".gz") < strings.TrimSuffix(rotated[j], ".gz")
+	})
+	return append(rotated, filePath), nil
+}
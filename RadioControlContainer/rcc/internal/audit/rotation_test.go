@@ -0,0 +1,176 @@
+package audit
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// rotationRecorder collects rotations reported to the notifier.
+type rotationRecorder struct {
+	mu        sync.Mutex
+	rotations []Rotation
+}
+
+func (r *rotationRecorder) notify(rotation Rotation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rotations = append(r.rotations, rotation)
+}
+
+func (r *rotationRecorder) all() []Rotation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Rotation(nil), r.rotations...)
+}
+
+func TestSizeRotationCompressesAndStaysQueryable(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := NewLogger(dir)
+	if err != nil {
+		t.Fatalf("Failed to create audit logger: %v", err)
+	}
+	recorder := &rotationRecorder{}
+	logger.SetRotationNotifier(recorder.notify)
+	if err := logger.SetRotationPolicy(RotationPolicy{MaxSizeBytes: 300, Compress: true}); err != nil {
+		t.Fatalf("SetRotationPolicy failed: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		logger.LogAction(ctx, "setPower", "radio-01", "SUCCESS", 0)
+	}
+	// Close waits for background compression
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	rotations := recorder.all()
+	if len(rotations) != 4 {
+		t.Fatalf("Expected each entry past the first to rotate a ~250 byte file, got %d rotations", len(rotations))
+	}
+	for _, rotation := range rotations {
+		if rotation.Reason != RotateSize || !strings.HasSuffix(rotation.File, ".gz") || rotation.LastHash == "" {
+			t.Errorf("Unexpected rotation: %+v", rotation)
+		}
+	}
+	if plain, _ := filepath.Glob(filepath.Join(dir, "audit.jsonl.*[0-9]")); len(plain) != 0 {
+		t.Errorf("Expected rotated files to be compressed, found %v", plain)
+	}
+
+	logger, err = NewLogger(dir)
+	if err != nil {
+		t.Fatalf("Failed to reopen audit logger: %v", err)
+	}
+	defer func() { _ = logger.Close() }()
+	logger.LogAction(ctx, "setChannel", "radio-01", "SUCCESS", 0)
+
+	page, err := logger.Query(Query{})
+	if err != nil || page.Total != 6 || page.Entries[5].Action != "setChannel" {
+		t.Fatalf("Expected 6 entries across compressed files, got %+v (err %v)", page, err)
+	}
+	result, err := logger.Verify()
+	if err != nil || !result.Valid || result.Anchor != "" {
+		t.Errorf("Expected an intact chain across compressed files and restart, got %+v (err %v)", result, err)
+	}
+}
+
+func TestAgeRotation(t *testing.T) {
+	logger := newQueryTestLogger(t)
+	recorder := &rotationRecorder{}
+	logger.SetRotationNotifier(recorder.notify)
+	if err := logger.SetRotationPolicy(RotationPolicy{MaxAge: time.Hour}); err != nil {
+		t.Fatalf("SetRotationPolicy failed: %v", err)
+	}
+
+	// An empty file is never rotated
+	logger.tick(time.Now().Add(2 * time.Hour))
+
+	logger.LogAction(context.Background(), "setPower", "radio-01", "SUCCESS", 0)
+	logger.tick(time.Now().Add(30 * time.Minute))
+	logger.tick(time.Now().Add(2 * time.Hour))
+	logger.maintenance.Wait()
+
+	rotations := recorder.all()
+	if len(rotations) != 1 || rotations[0].Reason != RotateAge {
+		t.Fatalf("Expected one age rotation, got %+v", rotations)
+	}
+}
+
+func TestRetentionPrunesOldestAndKeepsAnchor(t *testing.T) {
+	logger := newQueryTestLogger(t)
+	recorder := &rotationRecorder{}
+	logger.SetRotationNotifier(recorder.notify)
+	if err := logger.SetRotationPolicy(RotationPolicy{MaxBackups: 2}); err != nil {
+		t.Fatalf("SetRotationPolicy failed: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		logger.LogAction(ctx, "setPower", "radio-01", "SUCCESS", 0)
+		if err := logger.Rotate(); err != nil {
+			t.Fatalf("Rotate failed: %v", err)
+		}
+		logger.maintenance.Wait()
+	}
+
+	files, err := listLogFiles(logger.GetFilePath())
+	if err != nil || len(files) != 3 {
+		t.Fatalf("Expected 2 rotated files plus the current log, got %v (err %v)", files, err)
+	}
+
+	rotations := recorder.all()
+	if len(rotations[3].Removed) != 1 {
+		t.Errorf("Expected the last rotation to report one removed file, got %+v", rotations[3])
+	}
+
+	result, err := logger.Verify()
+	if err != nil || !result.Valid || result.Entries != 2 || result.Anchor != rotations[1].LastHash {
+		t.Errorf("Expected a valid chain anchored at the last pruned entry, got %+v (err %v)", result, err)
+	}
+}
+
+func TestIntervalSync(t *testing.T) {
+	logger := newQueryTestLogger(t)
+	if err := logger.SetRotationPolicy(RotationPolicy{Sync: SyncInterval, SyncEvery: time.Minute}); err != nil {
+		t.Fatalf("SetRotationPolicy failed: %v", err)
+	}
+
+	logger.LogAction(context.Background(), "setPower", "radio-01", "SUCCESS", 0)
+	if !logger.dirty {
+		t.Fatal("Expected write to be pending a sync")
+	}
+
+	logger.tick(time.Now())
+	if !logger.dirty {
+		t.Error("Expected no sync before the interval elapsed")
+	}
+	logger.tick(time.Now().Add(2 * time.Minute))
+	if logger.dirty {
+		t.Error("Expected sync once the interval elapsed")
+	}
+}
+
+func TestRotationPolicyValidate(t *testing.T) {
+	tests := map[string]RotationPolicy{
+		"negative size":      {MaxSizeBytes: -1},
+		"negative backups":   {MaxBackups: -1},
+		"unknown sync":       {Sync: "sometimes"},
+		"interval w/o every": {Sync: SyncInterval},
+	}
+
+	for name, policy := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := policy.Validate(); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+
+	if err := (RotationPolicy{Sync: SyncNever, MaxAge: time.Hour}).Validate(); err != nil {
+		t.Errorf("Expected valid policy, got %v", err)
+	}
+}
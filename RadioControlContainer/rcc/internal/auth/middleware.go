@@ -29,6 +29,8 @@ const (
 const (
 	RoleViewer     = "viewer"
 	RoleController = "controller"
+	// RoleAuditor may read the audit log; it grants no radio access by itself
+	RoleAuditor = "auditor"
 )
 
 // Scope constants per OpenAPI v1 §1.2
@@ -133,9 +135,16 @@ func (m *Middleware) RequireRole(requiredRoles ...string) func(http.HandlerFunc)
 }
 
 // extractBearerToken extracts the bearer token from the Authorization header.
+// Browsers cannot set headers on WebSocket upgrades, so upgrade requests may
+// pass the token in the access_token query parameter instead.
 func (m *Middleware) extractBearerToken(r *http.Request) (string, error) {
 	authHeader := r.Header.Get("Authorization")
 	if authHeader == "" {
+		if isWebSocketUpgrade(r) {
+			if token := r.URL.Query().Get("access_token"); token != "" {
+				return token, nil
+			}
+		}
 		return "", fmt.Errorf("missing Authorization header")
 	}
 
@@ -152,6 +161,11 @@ func (m *Middleware) extractBearerToken(r *http.Request) (string, error) {
 	return token, nil
 }
 
+// isWebSocketUpgrade reports whether the request is a WebSocket handshake.
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
 // verifyToken verifies the token and returns claims.
 func (m *Middleware) verifyToken(token string) (*Claims, error) {
 	// Use real verifier if available
@@ -174,6 +188,12 @@ func (m *Middleware) verifyToken(token string) (*Claims, error) {
 			Roles:   []string{RoleController},
 			Scopes:  []string{ScopeRead, ScopeControl, ScopeTelemetry},
 		}, nil
+	case "auditor-token":
+		return &Claims{
+			Subject: "auditor-789",
+			Roles:   []string{RoleAuditor},
+			Scopes:  []string{ScopeRead},
+		}, nil
 	case "invalid-token":
 		return nil, fmt.Errorf("token verification failed")
 	default:
@@ -76,6 +76,24 @@ func TestExtractBearerToken(t *testing.T) {
 	}
 }
 
+func TestExtractBearerTokenWebSocketQuery(t *testing.T) {
+	middleware := NewMiddleware()
+
+	// WebSocket upgrades may carry the token in the query string
+	req := httptest.NewRequest("GET", "/api/v1/telemetry/ws?access_token=ws-token", nil)
+	req.Header.Set("Upgrade", "websocket")
+	token, err := middleware.extractBearerToken(req)
+	if err != nil || token != "ws-token" {
+		t.Errorf("Expected ws-token from query, got %q (err=%v)", token, err)
+	}
+
+	// Plain HTTP requests must not accept query tokens
+	req = httptest.NewRequest("GET", "/api/v1/radios?access_token=ws-token", nil)
+	if _, err := middleware.extractBearerToken(req); err == nil {
+		t.Error("Expected error for query token on non-WebSocket request")
+	}
+}
+
 func TestVerifyToken(t *testing.T) {
 	middleware := NewMiddleware()
 
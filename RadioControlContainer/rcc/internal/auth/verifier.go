@@ -258,6 +258,7 @@ func (v *Verifier) validateRoles(roles []string) bool {
 	validRoles := map[string]bool{
 		RoleViewer:     true,
 		RoleController: true,
+		RoleAuditor:    true,
 	}
 
 	for _, role := range roles {
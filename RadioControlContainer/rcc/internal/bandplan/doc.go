@@ -0,0 +1,11 @@
+// Package bandplan implements the versioned Silvus band plan store.
+//
+// Plans are kept per model and band. Each upload creates a new version, one
+// version per model/band is active at a time, and earlier activations can be
+// rolled back. Every version is persisted as JSON, and activations are pushed
+// to a change notifier so channel resolution picks them up without a restart.
+//
+// Architecture References:
+//   - PRE-INT-09: Silvus band plan configuration
+//   - Architecture §8.4: Configuration management patterns
+package bandplan
@@ -0,0 +1,459 @@
+package bandplan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// StateFile is the name of the persisted store inside its directory.
+const StateFile = "bandplans.json"
+
+// maxNameLength bounds model and band names, which appear in URL paths.
+const maxNameLength = 64
+
+var (
+	// ErrNotFound is returned for an unknown model/band or version.
+	ErrNotFound = errors.New("band plan not found")
+	// ErrNoRollback is returned when there is no earlier activation to restore.
+	ErrNoRollback = errors.New("no earlier band plan version to roll back to")
+)
+
+// ValidationError lists every problem found in a band plan.
+type ValidationError struct {
+	Problems []string
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	return "invalid band plan: " + strings.Join(e.Problems, "; ")
+}
+
+// Plan is one version of the channel list for a model/band.
+type Plan struct {
+	Model     string                 `json:"model"`
+	Band      string                 `json:"band"`
+	Version   int                    `json:"version"`
+	Channels  []config.SilvusChannel `json:"channels"`
+	Comment   string                 `json:"comment,omitempty"`
+	CreatedAt time.Time              `json:"createdAt"`
+}
+
+// Summary describes the versions held for a model/band.
+type Summary struct {
+	Model         string    `json:"model"`
+	Band          string    `json:"band"`
+	ActiveVersion int       `json:"activeVersion"`
+	LatestVersion int       `json:"latestVersion"`
+	Channels      int       `json:"channels"`
+	ActivatedAt   time.Time `json:"activatedAt"`
+}
+
+// Change describes an activation reported to the change notifier.
+type Change struct {
+	Plan     Plan                   // Newly active version
+	Previous int                    // Version active before, 0 if none
+	Rollback bool                   // Whether the change was a rollback
+	Active   *config.SilvusBandPlan // Every active plan after the change
+}
+
+// series holds every version of one model/band.
+type series struct {
+	Model       string    `json:"model"`
+	Band        string    `json:"band"`
+	Versions    []Plan    `json:"versions"`
+	Active      int       `json:"active"`
+	ActivatedAt time.Time `json:"activatedAt"`
+	History     []int     `json:"history,omitempty"` // Earlier active versions, oldest first
+}
+
+// Store keeps versioned band plans and persists them to a JSON file.
+type Store struct {
+	mu       sync.Mutex
+	notifyMu sync.Mutex // Keeps notifications in activation order
+	path     string
+	series   map[string]*series
+	onChange func(Change)
+	now      func() time.Time
+}
+
+// NewStore creates a band plan store persisted in dir, loading any saved
+// state. An empty dir keeps the store in memory only.
+func NewStore(dir string) (*Store, error) {
+	s := &Store{
+		series: make(map[string]*series),
+		now:    time.Now,
+	}
+	if dir == "" {
+		return s, nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create band plan directory: %w", err)
+	}
+	s.path = filepath.Join(dir, StateFile)
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read band plans: %w", err)
+	}
+
+	var saved []*series
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse band plans from %s: %w", s.path, err)
+	}
+	for _, ser := range saved {
+		if ser.Active != 0 && ser.planVersion(ser.Active) == nil {
+			return nil, fmt.Errorf("band plan %s/%s has no active version %d", ser.Model, ser.Band, ser.Active)
+		}
+		s.series[key(ser.Model, ser.Band)] = ser
+	}
+	return s, nil
+}
+
+// SetChangeNotifier sets a callback invoked after each activation or rollback.
+// It runs outside the store lock.
+func (s *Store) SetChangeNotifier(notify func(Change)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = notify
+}
+
+// Seed imports plans from a startup configuration as active version 1 of
+// each model/band the store does not know yet. Persisted versions win.
+// Invalid configured plans are skipped and reported in the returned error.
+func (s *Store) Seed(plan *config.SilvusBandPlan) error {
+	if plan == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	added := false
+	for model, bands := range plan.Models {
+		for band, channels := range bands {
+			if _, exists := s.series[key(model, band)]; exists {
+				continue
+			}
+			if err := Validate(model, band, channels); err != nil {
+				errs = append(errs, fmt.Errorf("configured band plan %s/%s: %w", model, band, err))
+				continue
+			}
+			now := s.now().UTC()
+			s.series[key(model, band)] = &series{
+				Model:       model,
+				Band:        band,
+				Versions:    []Plan{newPlan(model, band, 1, channels, "seeded from configuration", now)},
+				Active:      1,
+				ActivatedAt: now,
+			}
+			added = true
+		}
+	}
+
+	if added {
+		errs = append(errs, s.saveLocked())
+	}
+	return errors.Join(errs...)
+}
+
+// List summarizes every model/band, sorted by model then band.
+func (s *Store) List() []Summary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	summaries := make([]Summary, 0, len(s.series))
+	for _, ser := range s.series {
+		summaries = append(summaries, ser.summary())
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Model != summaries[j].Model {
+			return summaries[i].Model < summaries[j].Model
+		}
+		return summaries[i].Band < summaries[j].Band
+	})
+	return summaries
+}
+
+// Versions returns the summary and every version of a model/band, oldest first.
+func (s *Store) Versions(model, band string) (Summary, []Plan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ser, exists := s.series[key(model, band)]
+	if !exists {
+		return Summary{}, nil, ErrNotFound
+	}
+	return ser.summary(), append([]Plan(nil), ser.Versions...), nil
+}
+
+// Upload stores channels as the next version of a model/band without
+// activating it. It returns a *ValidationError if the plan is invalid.
+func (s *Store) Upload(model, band string, channels []config.SilvusChannel, comment string) (Plan, error) {
+	if err := Validate(model, band, channels); err != nil {
+		return Plan{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(model, band)
+	ser, exists := s.series[k]
+	if !exists {
+		ser = &series{Model: model, Band: band}
+		s.series[k] = ser
+	}
+
+	version := 1
+	if n := len(ser.Versions); n > 0 {
+		version = ser.Versions[n-1].Version + 1
+	}
+	plan := newPlan(model, band, version, channels, comment, s.now().UTC())
+	ser.Versions = append(ser.Versions, plan)
+
+	if err := s.saveLocked(); err != nil {
+		ser.Versions = ser.Versions[:len(ser.Versions)-1]
+		if !exists {
+			delete(s.series, k)
+		}
+		return Plan{}, err
+	}
+	return plan, nil
+}
+
+// Activate makes version the active plan for a model/band. Activating the
+// version that is already active is a no-op.
+func (s *Store) Activate(model, band string, version int) (Plan, error) {
+	return s.activate(model, band, version, false)
+}
+
+// Rollback re-activates the version that was active before the current one.
+func (s *Store) Rollback(model, band string) (Plan, error) {
+	return s.activate(model, band, 0, true)
+}
+
+// activate switches the active version and notifies after releasing the lock.
+// For a rollback the version is taken from the activation history.
+func (s *Store) activate(model, band string, version int, rollback bool) (Plan, error) {
+	s.mu.Lock()
+
+	ser, exists := s.series[key(model, band)]
+	if !exists {
+		s.mu.Unlock()
+		return Plan{}, ErrNotFound
+	}
+
+	history := ser.History
+	if rollback {
+		if len(history) == 0 {
+			s.mu.Unlock()
+			return Plan{}, ErrNoRollback
+		}
+		version = history[len(history)-1]
+		history = history[:len(history)-1]
+	}
+
+	plan := ser.planVersion(version)
+	if plan == nil {
+		s.mu.Unlock()
+		return Plan{}, fmt.Errorf("%w: version %d of %s/%s", ErrNotFound, version, model, band)
+	}
+	if version == ser.Active {
+		s.mu.Unlock()
+		return *plan, nil
+	}
+
+	if !rollback && ser.Active != 0 {
+		history = append(history[:len(history):len(history)], ser.Active)
+	}
+
+	previous := *ser
+	ser.History = history
+	ser.Active = version
+	ser.ActivatedAt = s.now().UTC()
+	if err := s.saveLocked(); err != nil {
+		*ser = previous
+		s.mu.Unlock()
+		return Plan{}, err
+	}
+
+	change := Change{
+		Plan:     *plan,
+		Previous: previous.Active,
+		Rollback: rollback,
+		Active:   s.activeLocked(),
+	}
+	notify := s.onChange
+	s.notifyMu.Lock()
+	s.mu.Unlock()
+
+	if notify != nil {
+		notify(change)
+	}
+	s.notifyMu.Unlock()
+	return change.Plan, nil
+}
+
+// Active returns every active plan in the configuration form used for
+// channel index resolution.
+func (s *Store) Active() *config.SilvusBandPlan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.activeLocked()
+}
+
+// activeLocked builds the active configuration. Caller must hold s.mu.
+func (s *Store) activeLocked() *config.SilvusBandPlan {
+	active := &config.SilvusBandPlan{Models: make(map[string]map[string][]config.SilvusChannel)}
+	for _, ser := range s.series {
+		plan := ser.planVersion(ser.Active)
+		if plan == nil {
+			continue
+		}
+		if active.Models[ser.Model] == nil {
+			active.Models[ser.Model] = make(map[string][]config.SilvusChannel)
+		}
+		active.Models[ser.Model][ser.Band] = append([]config.SilvusChannel(nil), plan.Channels...)
+	}
+	return active
+}
+
+// saveLocked writes the store to disk via a temporary file so a crash never
+// leaves a partial state file. Caller must hold s.mu.
+func (s *Store) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+
+	keys := make([]string, 0, len(s.series))
+	for k := range s.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	saved := make([]*series, 0, len(keys))
+	for _, k := range keys {
+		saved = append(saved, s.series[k])
+	}
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal band plans: %w", err)
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write band plans: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to save band plans: %w", err)
+	}
+	return nil
+}
+
+// Validate checks a model/band name pair and its channels. Channel indices
+// and frequencies must be positive and unique. It returns a *ValidationError
+// listing every problem found.
+func Validate(model, band string, channels []config.SilvusChannel) error {
+	var problems []string
+
+	for _, name := range []struct{ field, value string }{{"model", model}, {"band", band}} {
+		switch {
+		case name.value == "":
+			problems = append(problems, name.field+" is required")
+		case len(name.value) > maxNameLength:
+			problems = append(problems, fmt.Sprintf("%s must be at most %d characters", name.field, maxNameLength))
+		case strings.ContainsAny(name.value, "/?#") || strings.TrimSpace(name.value) != name.value:
+			problems = append(problems, name.field+" must not contain '/', '?', '#' or surrounding spaces")
+		}
+	}
+
+	if len(channels) == 0 {
+		problems = append(problems, "at least one channel is required")
+	}
+
+	indices := make(map[int]bool, len(channels))
+	frequencies := make(map[float64]int, len(channels))
+	for _, channel := range channels {
+		if channel.ChannelIndex <= 0 {
+			problems = append(problems, fmt.Sprintf("channel index %d must be positive", channel.ChannelIndex))
+		} else if indices[channel.ChannelIndex] {
+			problems = append(problems, fmt.Sprintf("channel index %d is duplicated", channel.ChannelIndex))
+		}
+		indices[channel.ChannelIndex] = true
+
+		if math.IsNaN(channel.FrequencyMhz) || math.IsInf(channel.FrequencyMhz, 0) || channel.FrequencyMhz <= 0 {
+			problems = append(problems, fmt.Sprintf("channel %d frequency must be a positive number of MHz", channel.ChannelIndex))
+		} else if other, dup := frequencies[channel.FrequencyMhz]; dup {
+			problems = append(problems, fmt.Sprintf("channel %d repeats the %.3f MHz frequency of channel %d",
+				channel.ChannelIndex, channel.FrequencyMhz, other))
+		} else {
+			frequencies[channel.FrequencyMhz] = channel.ChannelIndex
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// newPlan builds a plan with its channels copied and sorted by index.
+func newPlan(model, band string, version int, channels []config.SilvusChannel, comment string, now time.Time) Plan {
+	sorted := append([]config.SilvusChannel(nil), channels...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ChannelIndex < sorted[j].ChannelIndex })
+	return Plan{
+		Model:     model,
+		Band:      band,
+		Version:   version,
+		Channels:  sorted,
+		Comment:   comment,
+		CreatedAt: now,
+	}
+}
+
+// planVersion returns the given version, or nil if it does not exist.
+func (ser *series) planVersion(version int) *Plan {
+	for i := range ser.Versions {
+		if ser.Versions[i].Version == version {
+			return &ser.Versions[i]
+		}
+	}
+	return nil
+}
+
+// summary describes the series.
+func (ser *series) summary() Summary {
+	summary := Summary{
+		Model:         ser.Model,
+		Band:          ser.Band,
+		ActiveVersion: ser.Active,
+		ActivatedAt:   ser.ActivatedAt,
+	}
+	if n := len(ser.Versions); n > 0 {
+		summary.LatestVersion = ser.Versions[n-1].Version
+	}
+	if plan := ser.planVersion(ser.Active); plan != nil {
+		summary.Channels = len(plan.Channels)
+	}
+	return summary
+}
+
+// key identifies a model/band; '/' is not allowed in either name.
+func key(model, band string) string {
+	return model + "/" + band
+}
@@ -0,0 +1,116 @@
+package bandplan
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/config"
+)
+
+var testChannels = []config.SilvusChannel{
+	{ChannelIndex: 2, FrequencyMhz: 2437},
+	{ChannelIndex: 1, FrequencyMhz: 2412},
+}
+
+func TestUploadActivateRollback(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	var changes []Change
+	store.SetChangeNotifier(func(change Change) { changes = append(changes, change) })
+
+	v1, err := store.Upload("Silvus-Scout", "default", testChannels, "initial")
+	if err != nil || v1.Version != 1 || v1.Channels[0].ChannelIndex != 1 {
+		t.Fatalf("Expected version 1 sorted by index, got %+v (err %v)", v1, err)
+	}
+	if len(changes) != 0 {
+		t.Fatal("Expected upload not to activate")
+	}
+
+	v2, _ := store.Upload("Silvus-Scout", "default", []config.SilvusChannel{{ChannelIndex: 1, FrequencyMhz: 2462}}, "")
+	if _, err := store.Activate("Silvus-Scout", "default", v1.Version); err != nil {
+		t.Fatalf("Activate failed: %v", err)
+	}
+	if _, err := store.Activate("Silvus-Scout", "default", v2.Version); err != nil {
+		t.Fatalf("Activate failed: %v", err)
+	}
+
+	last := changes[len(changes)-1]
+	if len(changes) != 2 || last.Previous != 1 {
+		t.Fatalf("Expected two activations, got %+v", changes)
+	}
+	if f, _ := last.Active.GetSilvusChannelFrequency("Silvus-Scout", "default", 1); f != 2462 {
+		t.Errorf("Expected active plan to resolve channel 1 to 2462, got %v", f)
+	}
+
+	plan, err := store.Rollback("Silvus-Scout", "default")
+	if err != nil || plan.Version != 1 || !changes[2].Rollback {
+		t.Fatalf("Expected rollback to version 1, got %+v (err %v)", plan, err)
+	}
+	if _, err := store.Rollback("Silvus-Scout", "default"); !errors.Is(err, ErrNoRollback) {
+		t.Errorf("Expected ErrNoRollback once history is exhausted, got %v", err)
+	}
+	if _, err := store.Activate("Silvus-Scout", "default", 9); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unknown version, got %v", err)
+	}
+}
+
+func TestStatePersistsAndSeedKeepsSavedVersions(t *testing.T) {
+	dir := t.TempDir()
+	store, _ := NewStore(dir)
+	configured := &config.SilvusBandPlan{Models: map[string]map[string][]config.SilvusChannel{
+		"Silvus-Scout": {"default": testChannels},
+	}}
+	if err := store.Seed(configured); err != nil {
+		t.Fatalf("Seed failed: %v", err)
+	}
+	v2, _ := store.Upload("Silvus-Scout", "default", []config.SilvusChannel{{ChannelIndex: 1, FrequencyMhz: 2462}}, "")
+	if _, err := store.Activate("Silvus-Scout", "default", v2.Version); err != nil {
+		t.Fatalf("Activate failed: %v", err)
+	}
+
+	reopened, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %v", err)
+	}
+	if err := reopened.Seed(configured); err != nil {
+		t.Fatalf("Seed failed: %v", err)
+	}
+
+	summary, versions, err := reopened.Versions("Silvus-Scout", "default")
+	if err != nil || summary.ActiveVersion != 2 || len(versions) != 2 {
+		t.Fatalf("Expected persisted version 2 to stay active, got %+v (err %v)", summary, err)
+	}
+	if _, err := reopened.Rollback("Silvus-Scout", "default"); err != nil {
+		t.Errorf("Expected activation history to persist, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		model, band string
+		channels    []config.SilvusChannel
+	}{
+		"no channels":         {"Scout", "default", nil},
+		"missing model":       {"", "default", testChannels},
+		"slash in band":       {"Scout", "2.4/5", testChannels},
+		"zero index":          {"Scout", "default", []config.SilvusChannel{{ChannelIndex: 0, FrequencyMhz: 2412}}},
+		"duplicate index":     {"Scout", "default", []config.SilvusChannel{{ChannelIndex: 1, FrequencyMhz: 2412}, {ChannelIndex: 1, FrequencyMhz: 2437}}},
+		"duplicate frequency": {"Scout", "default", []config.SilvusChannel{{ChannelIndex: 1, FrequencyMhz: 2412}, {ChannelIndex: 2, FrequencyMhz: 2412}}},
+		"negative frequency":  {"Scout", "default", []config.SilvusChannel{{ChannelIndex: 1, FrequencyMhz: -5}}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var validationErr *ValidationError
+			if err := Validate(tt.model, tt.band, tt.channels); !errors.As(err, &validationErr) {
+				t.Errorf("Expected ValidationError, got %v", err)
+			}
+		})
+	}
+
+	if err := Validate("Scout", "default", testChannels); err != nil {
+		t.Errorf("Expected valid plan, got %v", err)
+	}
+}
@@ -0,0 +1,392 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+// Fleet outcome values reported per radio and for the command as a whole.
+const (
+	FleetOutcomeSuccess        = "SUCCESS"
+	FleetOutcomeFailed         = "FAILED"
+	FleetOutcomeSkipped        = "SKIPPED"
+	FleetOutcomeRolledBack     = "ROLLED_BACK"
+	FleetOutcomeRollbackFailed = "ROLLBACK_FAILED"
+	FleetOutcomePartial        = "PARTIAL"
+)
+
+// FleetRadioResult captures the outcome of a fleet command for a single radio.
+type FleetRadioResult struct {
+	RadioID      string              `json:"radioId"`
+	Outcome      string              `json:"outcome"`
+	Code         string              `json:"code,omitempty"`
+	Message      string              `json:"message,omitempty"`
+	FrequencyMhz float64             `json:"frequencyMhz,omitempty"`
+	Previous     *adapter.RadioState `json:"previous,omitempty"`
+	LatencyMs    int64               `json:"latencyMs"`
+
+	err error
+}
+
+// FleetResult aggregates the per-radio results of a fleet command.
+type FleetResult struct {
+	CorrelationID string             `json:"correlationId"`
+	Action        string             `json:"action"`
+	Atomic        bool               `json:"atomic"`
+	Outcome       string             `json:"outcome"`
+	RolledBack    bool               `json:"rolledBack"`
+	Radios        []FleetRadioResult `json:"radios"`
+}
+
+// fleetApplyFunc applies a fleet command to one radio and returns the frequency
+// it resolved to (zero for power commands).
+type fleetApplyFunc func(ctx context.Context, radioAdapter adapter.IRadioAdapter, radioID string) (float64, error)
+
+// fleetRestoreFunc restores a radio to the state captured before the fleet command.
+type fleetRestoreFunc func(ctx context.Context, radioAdapter adapter.IRadioAdapter, previous *adapter.RadioState) error
+
+// SetFleetPower sets the transmit power on every listed radio.
+// When atomic is true, a failure on any radio rolls the others back to their previous power.
+func (o *Orchestrator) SetFleetPower(ctx context.Context, radioIDs []string, dBm float64, atomic bool) (*FleetResult, error) {
+	if err := o.validatePowerRange(dBm); err != nil {
+		o.logFleetAudit(ctx, "fleetSetPower", radioIDs, map[string]interface{}{"powerDbm": dBm}, nil, err, 0)
+		return nil, err
+	}
+
+	apply := func(ctx context.Context, radioAdapter adapter.IRadioAdapter, radioID string) (float64, error) {
+		return 0, radioAdapter.SetPower(ctx, dBm)
+	}
+	restore := func(ctx context.Context, radioAdapter adapter.IRadioAdapter, previous *adapter.RadioState) error {
+		return radioAdapter.SetPower(ctx, previous.PowerDbm)
+	}
+
+	params := map[string]interface{}{"powerDbm": dBm}
+	result, err := o.runFleet(ctx, "fleetSetPower", radioIDs, params, atomic, o.config.CommandTimeoutSetPower, apply, restore)
+	if result != nil && !result.RolledBack {
+		for _, r := range result.Radios {
+			if r.Outcome == FleetOutcomeSuccess {
+				o.publishPowerChangedEvent(r.RadioID, dBm)
+			}
+		}
+	}
+	return result, err
+}
+
+// SetFleetChannel tunes every listed radio to the same frequency.
+// When atomic is true, a failure on any radio rolls the others back to their previous frequency.
+func (o *Orchestrator) SetFleetChannel(ctx context.Context, radioIDs []string, frequencyMhz float64, atomic bool) (*FleetResult, error) {
+	if err := o.validateFrequencyRange(frequencyMhz); err != nil {
+		o.logFleetAudit(ctx, "fleetSetChannel", radioIDs, map[string]interface{}{"frequencyMhz": frequencyMhz}, nil, err, 0)
+		return nil, err
+	}
+
+	apply := func(ctx context.Context, radioAdapter adapter.IRadioAdapter, radioID string) (float64, error) {
+		return frequencyMhz, radioAdapter.SetFrequency(ctx, frequencyMhz)
+	}
+
+	params := map[string]interface{}{"frequencyMhz": frequencyMhz}
+	return o.runFleetChannel(ctx, radioIDs, params, atomic, 0, apply)
+}
+
+// SetFleetChannelByIndex tunes every listed radio to the given channel index.
+// The index is resolved per radio, so radios with different band plans land on
+// their own frequency for the same logical channel.
+func (o *Orchestrator) SetFleetChannelByIndex(ctx context.Context, radioIDs []string, channelIndex int, atomic bool) (*FleetResult, error) {
+	if channelIndex < 1 {
+		o.logFleetAudit(ctx, "fleetSetChannel", radioIDs, map[string]interface{}{"channelIndex": channelIndex}, nil, adapter.ErrInvalidRange, 0)
+		return nil, adapter.ErrInvalidRange
+	}
+
+	apply := func(ctx context.Context, radioAdapter adapter.IRadioAdapter, radioID string) (float64, error) {
+		frequencyMhz, err := o.resolveChannelIndex(ctx, radioID, channelIndex, nil)
+		if err != nil {
+			return 0, err
+		}
+		if err := o.validateFrequencyRange(frequencyMhz); err != nil {
+			return 0, err
+		}
+		return frequencyMhz, radioAdapter.SetFrequency(ctx, frequencyMhz)
+	}
+
+	params := map[string]interface{}{"channelIndex": channelIndex}
+	return o.runFleetChannel(ctx, radioIDs, params, atomic, channelIndex, apply)
+}
+
+// runFleetChannel runs a channel fleet command and publishes channel events for applied radios.
+func (o *Orchestrator) runFleetChannel(ctx context.Context, radioIDs []string, params map[string]interface{}, atomic bool, channelIndex int, apply fleetApplyFunc) (*FleetResult, error) {
+	restore := func(ctx context.Context, radioAdapter adapter.IRadioAdapter, previous *adapter.RadioState) error {
+		return radioAdapter.SetFrequency(ctx, previous.FrequencyMhz)
+	}
+
+	result, err := o.runFleet(ctx, "fleetSetChannel", radioIDs, params, atomic, o.config.CommandTimeoutSetChannel, apply, restore)
+	if result != nil && !result.RolledBack {
+		for _, r := range result.Radios {
+			if r.Outcome == FleetOutcomeSuccess {
+				o.publishChannelChangedEvent(r.RadioID, r.FrequencyMhz, channelIndex)
+			}
+		}
+	}
+	return result, err
+}
+
+// runFleet fans a command out to every radio in three phases: capture the
+// previous state, apply the command concurrently, and (for atomic commands
+// with at least one failure) restore the radios that were changed.
+func (o *Orchestrator) runFleet(ctx context.Context, action string, radioIDs []string, params map[string]interface{}, atomic bool, timeout time.Duration, apply fleetApplyFunc, restore fleetRestoreFunc) (*FleetResult, error) {
+	start := time.Now()
+
+	if err := o.validateFleet(radioIDs); err != nil {
+		o.logFleetAudit(ctx, action, radioIDs, params, nil, err, time.Since(start))
+		return nil, err
+	}
+
+	result := &FleetResult{
+		CorrelationID: fmt.Sprintf("fleet-%d", time.Now().UnixNano()),
+		Action:        action,
+		Atomic:        atomic,
+		Radios:        make([]FleetRadioResult, len(radioIDs)),
+	}
+
+	// Radios without their own adapter fail individually rather than being
+	// driven through another radio's adapter.
+	adapters := make([]adapter.IRadioAdapter, len(radioIDs))
+	for i, radioID := range radioIDs {
+		result.Radios[i] = FleetRadioResult{RadioID: radioID}
+		radioAdapter, err := o.adapterFor(radioID)
+		if err != nil {
+			result.Radios[i].fail(err)
+			continue
+		}
+		adapters[i] = radioAdapter
+	}
+
+	// Phase 1: capture previous state so the command can be undone.
+	o.fanOut(radioIDs, func(i int) {
+		if adapters[i] == nil {
+			return
+		}
+		stateCtx, cancel := context.WithTimeout(ctx, o.config.CommandTimeoutGetState)
+		defer cancel()
+
+		state, err := adapters[i].GetState(stateCtx)
+		if err != nil {
+			if atomic {
+				result.Radios[i].fail(adapter.NormalizeVendorError(err, nil))
+			}
+			return
+		}
+		result.Radios[i].Previous = state
+	})
+
+	if atomic && result.anyFailed() {
+		for i := range result.Radios {
+			if result.Radios[i].Outcome == "" {
+				result.Radios[i].Outcome = FleetOutcomeSkipped
+			}
+		}
+		return o.finishFleet(ctx, result, radioIDs, params, start)
+	}
+
+	// Phase 2: apply the command to every radio concurrently.
+	o.fanOut(radioIDs, func(i int) {
+		if adapters[i] == nil {
+			return
+		}
+		applyStart := time.Now()
+		applyCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		frequencyMhz, err := apply(applyCtx, adapters[i], radioIDs[i])
+		result.Radios[i].LatencyMs = time.Since(applyStart).Milliseconds()
+		if err != nil {
+			result.Radios[i].fail(adapter.NormalizeVendorError(err, nil))
+			o.publishFaultEvent(radioIDs[i], result.Radios[i].err, "Failed to apply fleet command")
+			return
+		}
+		result.Radios[i].Outcome = FleetOutcomeSuccess
+		result.Radios[i].FrequencyMhz = frequencyMhz
+	})
+
+	// Phase 3: roll back radios that changed if the command must be all-or-nothing.
+	if atomic && result.anyFailed() {
+		result.RolledBack = true
+		o.fanOut(radioIDs, func(i int) {
+			r := &result.Radios[i]
+			if r.Outcome != FleetOutcomeSuccess || r.Previous == nil {
+				return
+			}
+
+			restoreCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			if err := restore(restoreCtx, adapters[i], r.Previous); err != nil {
+				r.Outcome = FleetOutcomeRollbackFailed
+				r.err = adapter.NormalizeVendorError(err, nil)
+				r.Code = errorCode(r.err)
+				r.Message = err.Error()
+				o.publishFaultEvent(radioIDs[i], r.err, "Failed to roll back fleet command")
+				return
+			}
+			r.Outcome = FleetOutcomeRolledBack
+		})
+	}
+
+	return o.finishFleet(ctx, result, radioIDs, params, start)
+}
+
+// finishFleet computes the overall outcome and writes the single correlated audit record.
+func (o *Orchestrator) finishFleet(ctx context.Context, result *FleetResult, radioIDs []string, params map[string]interface{}, start time.Time) (*FleetResult, error) {
+	var firstErr error
+	succeeded := 0
+	for _, r := range result.Radios {
+		if r.Outcome == FleetOutcomeSuccess {
+			succeeded++
+		}
+		if r.err != nil && firstErr == nil {
+			firstErr = r.err
+		}
+	}
+
+	switch {
+	case succeeded == len(result.Radios):
+		result.Outcome = FleetOutcomeSuccess
+	case result.RolledBack || succeeded == 0:
+		result.Outcome = FleetOutcomeFailed
+	default:
+		result.Outcome = FleetOutcomePartial
+	}
+
+	o.logFleetAudit(ctx, result.Action, radioIDs, params, result, firstErr, time.Since(start))
+
+	return result, firstErr
+}
+
+// validateFleet checks the radio list is non-empty, unique and fully known.
+func (o *Orchestrator) validateFleet(radioIDs []string) error {
+	if len(radioIDs) == 0 {
+		return ErrInvalidParameter
+	}
+	if o.radioManager == nil {
+		return adapter.ErrUnavailable
+	}
+
+	seen := make(map[string]bool, len(radioIDs))
+	for _, radioID := range radioIDs {
+		if radioID == "" || seen[radioID] {
+			return ErrInvalidParameter
+		}
+		seen[radioID] = true
+
+		if _, err := o.radioManager.GetRadio(radioID); err != nil {
+			return ErrNotFound
+		}
+	}
+
+	return nil
+}
+
+// adapterFor returns the adapter for a radio. Radios the manager has no adapter
+// for are UNAVAILABLE; only managers that do not track adapters per radio fall
+// back to the active adapter.
+func (o *Orchestrator) adapterFor(radioID string) (adapter.IRadioAdapter, error) {
+	if provider, ok := o.radioManager.(AdapterProvider); ok {
+		radioAdapter, err := provider.GetAdapter(radioID)
+		if err != nil || radioAdapter == nil {
+			return nil, fmt.Errorf("%w: no adapter for radio %s", adapter.ErrUnavailable, radioID)
+		}
+		return radioAdapter, nil
+	}
+
+	if o.activeAdapter == nil {
+		return nil, adapter.ErrUnavailable
+	}
+	return o.activeAdapter, nil
+}
+
+// fanOut runs fn for every radio index concurrently and waits for all to finish.
+func (o *Orchestrator) fanOut(radioIDs []string, fn func(i int)) {
+	var wg sync.WaitGroup
+	for i := range radioIDs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// logFleetAudit writes one audit record covering every radio in a fleet command.
+func (o *Orchestrator) logFleetAudit(ctx context.Context, action string, radioIDs []string, params map[string]interface{}, result *FleetResult, err error, latency time.Duration) {
+	if o.auditLogger == nil {
+		return
+	}
+
+	outcome := "SUCCESS"
+	if err != nil {
+		outcome = errorCode(err)
+	}
+
+	radios := strings.Join(radioIDs, ",")
+
+	if logger, ok := o.auditLogger.(ControlAuditLogger); ok {
+		details := make(map[string]interface{}, len(params)+4)
+		for k, v := range params {
+			details[k] = v
+		}
+		details["radioIds"] = radioIDs
+		details["latencyMs"] = latency.Milliseconds()
+		if result != nil {
+			outcome = result.Outcome
+			details["correlationId"] = result.CorrelationID
+			details["atomic"] = result.Atomic
+			perRadio := make(map[string]string, len(result.Radios))
+			for _, r := range result.Radios {
+				perRadio[r.RadioID] = r.Outcome
+			}
+			details["radios"] = perRadio
+		}
+		logger.LogControlAction(ctx, action, radios, details, outcome, err)
+		return
+	}
+
+	o.auditLogger.LogAction(ctx, action, radios, outcome, latency)
+}
+
+// fail records a normalized error on a per-radio result.
+func (r *FleetRadioResult) fail(err error) {
+	r.Outcome = FleetOutcomeFailed
+	r.err = err
+	r.Code = errorCode(err)
+	r.Message = err.Error()
+}
+
+// anyFailed reports whether any radio in the result has failed.
+func (f *FleetResult) anyFailed() bool {
+	for _, r := range f.Radios {
+		if r.Outcome == FleetOutcomeFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// errorCode returns the normalized code string for an error.
+func errorCode(err error) string {
+	var vendorErr *adapter.VendorError
+	if errors.As(err, &vendorErr) {
+		return vendorErr.Code.Error()
+	}
+	for _, code := range []error{adapter.ErrInvalidRange, adapter.ErrBusy, adapter.ErrUnavailable, adapter.ErrInternal, ErrNotFound, ErrInvalidParameter} {
+		if errors.Is(err, code) {
+			return code.Error()
+		}
+	}
+	return err.Error()
+}
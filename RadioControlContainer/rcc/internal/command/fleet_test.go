@@ -0,0 +1,305 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+)
+
+// fleetMockAdapter records frequency and power writes for fleet tests.
+type fleetMockAdapter struct {
+	MockAdapter
+	mu           sync.Mutex
+	powerDbm     float64
+	frequencyMhz float64
+	failSet      bool
+}
+
+func newFleetMockAdapter(power, frequency float64, failSet bool) *fleetMockAdapter {
+	a := &fleetMockAdapter{powerDbm: power, frequencyMhz: frequency, failSet: failSet}
+	a.GetStateFunc = func(ctx context.Context) (*adapter.RadioState, error) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return &adapter.RadioState{PowerDbm: a.powerDbm, FrequencyMhz: a.frequencyMhz}, nil
+	}
+	a.SetFrequencyFunc = func(ctx context.Context, frequencyMhz float64) error {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		if a.failSet {
+			a.failSet = false // fail once so rollback can succeed
+			return errors.New("BUSY: radio busy")
+		}
+		a.frequencyMhz = frequencyMhz
+		return nil
+	}
+	a.SetPowerFunc = func(ctx context.Context, dBm float64) error {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		if a.failSet {
+			a.failSet = false
+			return errors.New("UNAVAILABLE: radio offline")
+		}
+		a.powerDbm = dBm
+		return nil
+	}
+	return a
+}
+
+func (a *fleetMockAdapter) state() (float64, float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.powerDbm, a.frequencyMhz
+}
+
+// setupFleetOrchestrator registers one mock adapter per radio in a real radio manager.
+func setupFleetOrchestrator(t *testing.T, adapters map[string]*fleetMockAdapter) (*Orchestrator, *MockAuditLogger) {
+	t.Helper()
+
+	rm := radio.NewManager()
+	for id, a := range adapters {
+		if err := rm.LoadCapabilities(id, a, time.Second); err != nil {
+			t.Fatalf("LoadCapabilities(%s) failed: %v", id, err)
+		}
+	}
+
+	auditLogger := &MockAuditLogger{}
+	orchestrator := &Orchestrator{config: config.LoadCBTimingBaseline()}
+	orchestrator.SetRadioManager(rm)
+	orchestrator.SetAuditLogger(auditLogger)
+
+	return orchestrator, auditLogger
+}
+
+// unadaptedRadioManager reports extra radios that have no adapter of their own.
+type unadaptedRadioManager struct {
+	*radio.Manager
+	extra []string
+}
+
+func (m *unadaptedRadioManager) GetRadio(radioID string) (*radio.Radio, error) {
+	for _, id := range m.extra {
+		if id == radioID {
+			return &radio.Radio{ID: id, Status: "online"}, nil
+		}
+	}
+	return m.Manager.GetRadio(radioID)
+}
+
+func (m *unadaptedRadioManager) List() *radio.RadioList {
+	list := m.Manager.List()
+	for _, id := range m.extra {
+		list.Items = append(list.Items, radio.Radio{ID: id, Status: "online"})
+	}
+	return list
+}
+
+// withUnadaptedRadios adds radios without adapters to the orchestrator's radio manager.
+func withUnadaptedRadios(o *Orchestrator, radioIDs ...string) {
+	o.SetRadioManager(&unadaptedRadioManager{Manager: o.radioManager.(*radio.Manager), extra: radioIDs})
+}
+
+func TestSetFleetChannelAllSucceed(t *testing.T) {
+	adapters := map[string]*fleetMockAdapter{
+		"radio-01": newFleetMockAdapter(20, 2412, false),
+		"radio-02": newFleetMockAdapter(20, 2417, false),
+		"radio-03": newFleetMockAdapter(20, 2422, false),
+	}
+	orchestrator, auditLogger := setupFleetOrchestrator(t, adapters)
+
+	result, err := orchestrator.SetFleetChannel(context.Background(), []string{"radio-01", "radio-02", "radio-03"}, 2437, true)
+	if err != nil {
+		t.Fatalf("SetFleetChannel failed: %v", err)
+	}
+
+	if result.Outcome != FleetOutcomeSuccess {
+		t.Errorf("Expected outcome %s, got %s", FleetOutcomeSuccess, result.Outcome)
+	}
+	if result.CorrelationID == "" {
+		t.Error("Expected correlation ID to be set")
+	}
+	for id, a := range adapters {
+		if _, freq := a.state(); freq != 2437 {
+			t.Errorf("Radio %s frequency = %f, want 2437", id, freq)
+		}
+	}
+	for _, r := range result.Radios {
+		if r.Previous == nil {
+			t.Errorf("Radio %s missing previous state", r.RadioID)
+		}
+	}
+
+	if len(auditLogger.Actions) != 1 {
+		t.Fatalf("Expected exactly one audit record, got %d", len(auditLogger.Actions))
+	}
+	if auditLogger.Actions[0].Action != "fleetSetChannel" {
+		t.Errorf("Expected audit action fleetSetChannel, got %s", auditLogger.Actions[0].Action)
+	}
+}
+
+func TestSetFleetChannelAtomicRollback(t *testing.T) {
+	adapters := map[string]*fleetMockAdapter{
+		"radio-01": newFleetMockAdapter(20, 2412, false),
+		"radio-02": newFleetMockAdapter(20, 2417, true),
+		"radio-03": newFleetMockAdapter(20, 2422, false),
+	}
+	orchestrator, auditLogger := setupFleetOrchestrator(t, adapters)
+
+	result, err := orchestrator.SetFleetChannel(context.Background(), []string{"radio-01", "radio-02", "radio-03"}, 2437, true)
+	if !errors.Is(err, adapter.ErrBusy) {
+		t.Fatalf("Expected BUSY error, got %v", err)
+	}
+
+	if !result.RolledBack || result.Outcome != FleetOutcomeFailed {
+		t.Errorf("Expected rolled back failure, got outcome=%s rolledBack=%v", result.Outcome, result.RolledBack)
+	}
+
+	want := map[string]float64{"radio-01": 2412, "radio-02": 2417, "radio-03": 2422}
+	for id, a := range adapters {
+		if _, freq := a.state(); freq != want[id] {
+			t.Errorf("Radio %s frequency = %f, want %f after rollback", id, freq, want[id])
+		}
+	}
+
+	outcomes := map[string]string{}
+	for _, r := range result.Radios {
+		outcomes[r.RadioID] = r.Outcome
+	}
+	if outcomes["radio-02"] != FleetOutcomeFailed {
+		t.Errorf("Expected radio-02 FAILED, got %s", outcomes["radio-02"])
+	}
+	if outcomes["radio-01"] != FleetOutcomeRolledBack || outcomes["radio-03"] != FleetOutcomeRolledBack {
+		t.Errorf("Expected radio-01 and radio-03 ROLLED_BACK, got %v", outcomes)
+	}
+
+	if len(auditLogger.Actions) != 1 {
+		t.Fatalf("Expected exactly one audit record, got %d", len(auditLogger.Actions))
+	}
+}
+
+func TestSetFleetPowerNonAtomicPartial(t *testing.T) {
+	adapters := map[string]*fleetMockAdapter{
+		"radio-01": newFleetMockAdapter(20, 2412, false),
+		"radio-02": newFleetMockAdapter(20, 2412, true),
+	}
+	orchestrator, _ := setupFleetOrchestrator(t, adapters)
+
+	result, err := orchestrator.SetFleetPower(context.Background(), []string{"radio-01", "radio-02"}, 30, false)
+	if !errors.Is(err, adapter.ErrUnavailable) {
+		t.Fatalf("Expected UNAVAILABLE error, got %v", err)
+	}
+
+	if result.Outcome != FleetOutcomePartial || result.RolledBack {
+		t.Errorf("Expected partial outcome without rollback, got outcome=%s rolledBack=%v", result.Outcome, result.RolledBack)
+	}
+	if power, _ := adapters["radio-01"].state(); power != 30 {
+		t.Errorf("Expected radio-01 power 30, got %f", power)
+	}
+	if power, _ := adapters["radio-02"].state(); power != 20 {
+		t.Errorf("Expected radio-02 power unchanged at 20, got %f", power)
+	}
+}
+
+func TestSetFleetChannelByIndexResolvesPerRadio(t *testing.T) {
+	adapters := map[string]*fleetMockAdapter{
+		"radio-01": newFleetMockAdapter(20, 2412, false),
+	}
+	orchestrator, _ := setupFleetOrchestrator(t, adapters)
+
+	// MockAdapter exposes no band plan, so the channel index cannot be resolved
+	result, err := orchestrator.SetFleetChannelByIndex(context.Background(), []string{"radio-01"}, 6, true)
+	if err == nil {
+		t.Fatal("Expected error for unresolvable channel index")
+	}
+	if result == nil || result.Radios[0].Outcome != FleetOutcomeFailed {
+		t.Errorf("Expected radio-01 FAILED, got %+v", result)
+	}
+}
+
+func TestSetFleetValidation(t *testing.T) {
+	adapters := map[string]*fleetMockAdapter{
+		"radio-01": newFleetMockAdapter(20, 2412, false),
+	}
+	orchestrator, _ := setupFleetOrchestrator(t, adapters)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		radioIDs []string
+		power    float64
+		wantErr  error
+	}{
+		{"empty fleet", nil, 20, ErrInvalidParameter},
+		{"duplicate radio", []string{"radio-01", "radio-01"}, 20, ErrInvalidParameter},
+		{"unknown radio", []string{"radio-01", "radio-99"}, 20, ErrNotFound},
+		{"power out of range", []string{"radio-01"}, 50, adapter.ErrInvalidRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := orchestrator.SetFleetPower(ctx, tt.radioIDs, tt.power, true)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if result != nil {
+				t.Errorf("Expected no result for validation failure, got %+v", result)
+			}
+		})
+	}
+}
+
+func TestSetFleetPowerRadioWithoutAdapter(t *testing.T) {
+	adapters := map[string]*fleetMockAdapter{
+		"radio-01": newFleetMockAdapter(20, 2412, false),
+	}
+	orchestrator, _ := setupFleetOrchestrator(t, adapters)
+
+	// radio-02 is known to the manager but has no adapter of its own; the
+	// active adapter must not be driven on its behalf.
+	withUnadaptedRadios(orchestrator, "radio-02")
+	active := newFleetMockAdapter(20, 2412, false)
+	orchestrator.SetActiveAdapter(active)
+
+	result, err := orchestrator.SetFleetPower(context.Background(), []string{"radio-01", "radio-02"}, 30, false)
+	if !errors.Is(err, adapter.ErrUnavailable) {
+		t.Fatalf("Expected UNAVAILABLE error, got %v", err)
+	}
+
+	outcomes := map[string]FleetRadioResult{}
+	for _, r := range result.Radios {
+		outcomes[r.RadioID] = r
+	}
+	if outcomes["radio-02"].Outcome != FleetOutcomeFailed || outcomes["radio-02"].Code != "UNAVAILABLE" {
+		t.Errorf("Expected radio-02 FAILED with UNAVAILABLE, got %+v", outcomes["radio-02"])
+	}
+	if outcomes["radio-01"].Outcome != FleetOutcomeSuccess {
+		t.Errorf("Expected radio-01 SUCCESS, got %s", outcomes["radio-01"].Outcome)
+	}
+	if power, _ := active.state(); power != 20 {
+		t.Errorf("Expected active adapter untouched at 20, got %f", power)
+	}
+}
+
+func TestSetFleetChannelAtomicRadioWithoutAdapter(t *testing.T) {
+	adapters := map[string]*fleetMockAdapter{
+		"radio-01": newFleetMockAdapter(20, 2412, false),
+	}
+	orchestrator, _ := setupFleetOrchestrator(t, adapters)
+	withUnadaptedRadios(orchestrator, "radio-02")
+
+	result, err := orchestrator.SetFleetChannel(context.Background(), []string{"radio-01", "radio-02"}, 2437, true)
+	if !errors.Is(err, adapter.ErrUnavailable) {
+		t.Fatalf("Expected UNAVAILABLE error, got %v", err)
+	}
+	if result.Radios[0].Outcome != FleetOutcomeSkipped {
+		t.Errorf("Expected radio-01 SKIPPED, got %s", result.Radios[0].Outcome)
+	}
+	if _, freq := adapters["radio-01"].state(); freq != 2412 {
+		t.Errorf("Expected radio-01 frequency unchanged at 2412, got %f", freq)
+	}
+}
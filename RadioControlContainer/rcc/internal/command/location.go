@@ -0,0 +1,114 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// GetLocation reads a radio's GPS position from its adapter.
+// Radios whose adapter does not implement adapter.LocationProvider report
+// UNAVAILABLE. Location reads are polled frequently, so they are not audited.
+func (o *Orchestrator) GetLocation(ctx context.Context, radioID string) (*adapter.Location, error) {
+	if o.radioManager == nil {
+		return nil, adapter.ErrUnavailable
+	}
+	if _, err := o.radioManager.GetRadio(radioID); err != nil {
+		return nil, ErrNotFound
+	}
+
+	provider, err := o.locationProviderFor(radioID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.config.CommandTimeoutGetState)
+	defer cancel()
+
+	location, err := provider.GetLocation(ctx)
+	if err != nil {
+		normalizedErr := adapter.NormalizeVendorError(err, nil)
+		o.publishFaultEvent(radioID, normalizedErr, "Failed to read location")
+		return nil, normalizedErr
+	}
+
+	return location, nil
+}
+
+// RunLocationPolling publishes a location event for every radio that reports
+// its position once per interval, until ctx is cancelled.
+func (o *Orchestrator) RunLocationPolling(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			o.PollLocations(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// PollLocations reads the position of every radio that supports it and
+// publishes one location event per radio. Read failures are reported as
+// fault events by GetLocation.
+func (o *Orchestrator) PollLocations(ctx context.Context) {
+	lister, ok := o.radioManager.(RadioLister)
+	if !ok {
+		return
+	}
+
+	for _, item := range lister.List().Items {
+		if _, err := o.locationProviderFor(item.ID); err != nil {
+			continue
+		}
+		location, err := o.GetLocation(ctx, item.ID)
+		if err != nil {
+			continue
+		}
+		o.publishLocationEvent(item.ID, location)
+	}
+}
+
+// locationProviderFor returns the radio's adapter if it reports its position.
+func (o *Orchestrator) locationProviderFor(radioID string) (adapter.LocationProvider, error) {
+	radioAdapter, err := o.adapterFor(radioID)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, ok := radioAdapter.(adapter.LocationProvider)
+	if !ok {
+		return nil, fmt.Errorf("%w: radio %s does not report location", adapter.ErrUnavailable, radioID)
+	}
+	return provider, nil
+}
+
+// publishLocationEvent publishes a location event.
+func (o *Orchestrator) publishLocationEvent(radioID string, location *adapter.Location) {
+	if o.telemetryHub == nil {
+		return // Skip if no telemetry hub
+	}
+
+	event := telemetry.Event{
+		Type: "location",
+		Data: map[string]interface{}{
+			"radioId":   radioID,
+			"latitude":  location.Latitude,
+			"longitude": location.Longitude,
+			"altitudeM": location.AltitudeM,
+			"accuracyM": location.AccuracyM,
+			"ts":        location.Timestamp.Format(time.RFC3339),
+		},
+	}
+
+	if err := o.telemetryHub.PublishRadio(radioID, event); err != nil {
+		// Publish fault event for telemetry failure
+		o.publishFaultEvent(radioID, err, "Failed to publish location event")
+	}
+}
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/radio-control/rcc/internal/adapter"
+)
+
+func TestGetLocation(t *testing.T) {
+	orchestrator, mock := setupMetricsOrchestrator(t)
+	ctx := context.Background()
+
+	mock.SetLocation(51.5, -0.12, 35)
+	location, err := orchestrator.GetLocation(ctx, "silvus-001")
+	if err != nil {
+		t.Fatalf("GetLocation failed: %v", err)
+	}
+	if location.Latitude != 51.5 || location.Longitude != -0.12 || location.AltitudeM != 35 || location.Timestamp.IsZero() {
+		t.Errorf("Unexpected location: %+v", location)
+	}
+
+	if _, err := orchestrator.GetLocation(ctx, "plain-001"); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Errorf("Expected UNAVAILABLE for adapter without GPS, got %v", err)
+	}
+	if _, err := orchestrator.GetLocation(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected NOT_FOUND for unknown radio, got %v", err)
+	}
+
+	mock.SetFaultMode("ReturnUnavailable")
+	if _, err := orchestrator.GetLocation(ctx, "silvus-001"); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Errorf("Expected normalized UNAVAILABLE error, got %v", err)
+	}
+}
+
+func TestPollLocationsPublishesEvents(t *testing.T) {
+	orchestrator, mock := setupMetricsOrchestrator(t)
+	mock.SetLocation(40.0, -74.0, 10)
+
+	recorder := &metricsRecorder{}
+	orchestrator.telemetryHub.SetRecorder(recorder)
+
+	orchestrator.PollLocations(context.Background())
+
+	if len(recorder.events) != 1 {
+		t.Fatalf("Expected one location event for the GPS-capable radio, got %d", len(recorder.events))
+	}
+	event := recorder.events[0]
+	if event.Type != "location" || event.Radio != "silvus-001" {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+	if event.Data["latitude"] != 40.0 || event.Data["longitude"] != -74.0 {
+		t.Errorf("Unexpected location event data: %+v", event.Data)
+	}
+	for _, key := range []string{"altitudeM", "accuracyM", "ts"} {
+		if _, ok := event.Data[key]; !ok {
+			t.Errorf("Location event missing %s", key)
+		}
+	}
+}
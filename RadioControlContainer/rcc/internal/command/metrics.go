@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/radio"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// RadioLister enumerates managed radios for periodic metric polling.
+type RadioLister interface {
+	List() *radio.RadioList
+}
+
+// Compile-time assertion that radio.Manager implements RadioLister
+var _ RadioLister = (*radio.Manager)(nil)
+
+// GetLinkMetrics reads link quality metrics from a radio's adapter.
+// Radios without an adapter of their own, or whose adapter does not implement
+// adapter.LinkMetricsProvider, report UNAVAILABLE. Metric reads are polled frequently, so they are not audited.
+func (o *Orchestrator) GetLinkMetrics(ctx context.Context, radioID string) (*adapter.LinkMetrics, error) {
+	if o.radioManager == nil {
+		return nil, adapter.ErrUnavailable
+	}
+	if _, err := o.radioManager.GetRadio(radioID); err != nil {
+		return nil, ErrNotFound
+	}
+
+	provider, err := o.metricsProviderFor(radioID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.config.CommandTimeoutGetState)
+	defer cancel()
+
+	metrics, err := provider.GetLinkMetrics(ctx)
+	if err != nil {
+		normalizedErr := adapter.NormalizeVendorError(err, nil)
+		o.publishFaultEvent(radioID, normalizedErr, "Failed to read link metrics")
+		return nil, normalizedErr
+	}
+
+	return metrics, nil
+}
+
+// RunMetricsPolling publishes a metrics event for every radio that reports
+// link metrics once per interval, until ctx is cancelled.
+func (o *Orchestrator) RunMetricsPolling(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			o.PollLinkMetrics(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// PollLinkMetrics reads metrics from every radio that supports them and
+// publishes one metrics event per radio. Radios without an adapter of their
+// own are skipped rather than read through the active adapter. Read failures are reported as fault
+// events by GetLinkMetrics.
+func (o *Orchestrator) PollLinkMetrics(ctx context.Context) {
+	lister, ok := o.radioManager.(RadioLister)
+	if !ok {
+		return
+	}
+
+	for _, item := range lister.List().Items {
+		if _, err := o.metricsProviderFor(item.ID); err != nil {
+			continue
+		}
+		metrics, err := o.GetLinkMetrics(ctx, item.ID)
+		if err != nil {
+			continue
+		}
+		o.publishMetricsEvent(item.ID, metrics)
+	}
+}
+
+// metricsProviderFor returns the radio's adapter if it reports link metrics.
+func (o *Orchestrator) metricsProviderFor(radioID string) (adapter.LinkMetricsProvider, error) {
+	radioAdapter, err := o.adapterFor(radioID)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, ok := radioAdapter.(adapter.LinkMetricsProvider)
+	if !ok {
+		return nil, fmt.Errorf("%w: radio %s does not report link metrics", adapter.ErrUnavailable, radioID)
+	}
+	return provider, nil
+}
+
+// publishMetricsEvent publishes a link metrics event.
+func (o *Orchestrator) publishMetricsEvent(radioID string, metrics *adapter.LinkMetrics) {
+	if o.telemetryHub == nil {
+		return // Skip if no telemetry hub
+	}
+
+	event := telemetry.Event{
+		Type: "metrics",
+		Data: map[string]interface{}{
+			"radioId":        radioID,
+			"rssiDbm":        metrics.RSSIDbm,
+			"snrDb":          metrics.SNRDb,
+			"throughputKbps": metrics.ThroughputKbps,
+			"temperatureC":   metrics.TemperatureC,
+			"neighbors":      metrics.Neighbors,
+			"ts":             metrics.Timestamp.Format(time.RFC3339),
+		},
+	}
+
+	if err := o.telemetryHub.PublishRadio(radioID, event); err != nil {
+		// Publish fault event for telemetry failure
+		o.publishFaultEvent(radioID, err, "Failed to publish metrics event")
+	}
+}
@@ -0,0 +1,118 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/adapter/silvusmock"
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/radio"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// setupMetricsOrchestrator registers a metrics-capable SilvusMock and a plain mock adapter.
+func setupMetricsOrchestrator(t *testing.T) (*Orchestrator, *silvusmock.SilvusMock) {
+	t.Helper()
+
+	cfg := config.LoadCBTimingBaseline()
+	hub := telemetry.NewHub(cfg)
+	t.Cleanup(func() { hub.Stop() })
+
+	mock := silvusmock.NewSilvusMock("silvus-001", nil)
+	plain := newFleetMockAdapter(20, 2412, false)
+
+	rm := radio.NewManager()
+	if err := rm.LoadCapabilities("silvus-001", mock, time.Second); err != nil {
+		t.Fatalf("LoadCapabilities failed: %v", err)
+	}
+	if err := rm.LoadCapabilities("plain-001", plain, time.Second); err != nil {
+		t.Fatalf("LoadCapabilities failed: %v", err)
+	}
+
+	orchestrator := NewOrchestratorWithRadioManager(hub, cfg, rm)
+	return orchestrator, mock
+}
+
+func TestGetLinkMetrics(t *testing.T) {
+	orchestrator, mock := setupMetricsOrchestrator(t)
+	ctx := context.Background()
+
+	metrics, err := orchestrator.GetLinkMetrics(ctx, "silvus-001")
+	if err != nil {
+		t.Fatalf("GetLinkMetrics failed: %v", err)
+	}
+	if len(metrics.Neighbors) == 0 {
+		t.Error("Expected simulated neighbors")
+	}
+
+	if _, err := orchestrator.GetLinkMetrics(ctx, "plain-001"); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Errorf("Expected UNAVAILABLE for adapter without metrics, got %v", err)
+	}
+	if _, err := orchestrator.GetLinkMetrics(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected NOT_FOUND for unknown radio, got %v", err)
+	}
+
+	mock.SetFaultMode("ReturnBusy")
+	if _, err := orchestrator.GetLinkMetrics(ctx, "silvus-001"); !errors.Is(err, adapter.ErrBusy) {
+		t.Errorf("Expected normalized BUSY error, got %v", err)
+	}
+}
+
+func TestPollLinkMetricsPublishesEvents(t *testing.T) {
+	orchestrator, _ := setupMetricsOrchestrator(t)
+
+	recorder := &metricsRecorder{}
+	orchestrator.telemetryHub.SetRecorder(recorder)
+
+	orchestrator.PollLinkMetrics(context.Background())
+
+	if len(recorder.events) != 1 {
+		t.Fatalf("Expected one metrics event for the metrics-capable radio, got %d", len(recorder.events))
+	}
+	event := recorder.events[0]
+	if event.Type != "metrics" || event.Radio != "silvus-001" {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+	for _, key := range []string{"rssiDbm", "snrDb", "throughputKbps", "temperatureC", "neighbors"} {
+		if _, ok := event.Data[key]; !ok {
+			t.Errorf("Metrics event missing %s", key)
+		}
+	}
+}
+
+// metricsRecorder captures published radio events.
+func TestLinkMetricsRadioWithoutAdapter(t *testing.T) {
+	orchestrator, mock := setupMetricsOrchestrator(t)
+	withUnadaptedRadios(orchestrator, "radio-02")
+
+	// The active adapter reports metrics; they must not be attributed to radio-02
+	orchestrator.SetActiveAdapter(mock)
+
+	if _, err := orchestrator.GetLinkMetrics(context.Background(), "radio-02"); !errors.Is(err, adapter.ErrUnavailable) {
+		t.Errorf("Expected UNAVAILABLE for radio without adapter, got %v", err)
+	}
+
+	recorder := &metricsRecorder{}
+	orchestrator.telemetryHub.SetRecorder(recorder)
+	orchestrator.PollLinkMetrics(context.Background())
+
+	for _, event := range recorder.events {
+		if event.Radio == "radio-02" {
+			t.Errorf("Expected no metrics event for radio without adapter, got %+v", event)
+		}
+	}
+	if len(recorder.events) != 1 {
+		t.Errorf("Expected one metrics event for silvus-001, got %d", len(recorder.events))
+	}
+}
+
+type metricsRecorder struct {
+	events []telemetry.Event
+}
+
+func (r *metricsRecorder) Record(event telemetry.Event) {
+	r.events = append(r.events, event)
+}
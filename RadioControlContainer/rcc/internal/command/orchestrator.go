@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/radio-control/rcc/internal/adapter"
@@ -27,11 +28,18 @@ type Orchestrator struct {
 
 	// Radio manager for channel index resolution
 	radioManager RadioManager
+
+	// Band plan replacing config.SilvusBandPlan once set at runtime
+	bandPlanMu sync.RWMutex
+	bandPlan   *config.SilvusBandPlan
 }
 
 // Compile-time assertion that radio.Manager implements RadioManager
 var _ RadioManager = (*radio.Manager)(nil)
 
+// Compile-time assertion that radio.Manager implements AdapterProvider
+var _ AdapterProvider = (*radio.Manager)(nil)
+
 // Compile-time assertion that Orchestrator implements OrchestratorPort
 var _ OrchestratorPort = (*Orchestrator)(nil)
 
@@ -470,14 +478,36 @@ func (o *Orchestrator) SetRadioManager(radioManager RadioManager) {
 	o.radioManager = radioManager
 }
 
+// SetSilvusBandPlan replaces the Silvus band plan used for channel index
+// resolution, e.g. when a new plan is activated. It is safe to call while
+// commands are in flight.
+func (o *Orchestrator) SetSilvusBandPlan(plan *config.SilvusBandPlan) {
+	o.bandPlanMu.Lock()
+	defer o.bandPlanMu.Unlock()
+	o.bandPlan = plan
+}
+
+// silvusBandPlan returns the runtime band plan, falling back to configuration.
+func (o *Orchestrator) silvusBandPlan() *config.SilvusBandPlan {
+	o.bandPlanMu.RLock()
+	defer o.bandPlanMu.RUnlock()
+	if o.bandPlan != nil {
+		return o.bandPlan
+	}
+	if o.config != nil {
+		return o.config.SilvusBandPlan
+	}
+	return nil
+}
+
 // resolveChannelIndex resolves a channel index to frequency via radio manager or Silvus band plan.
 func (o *Orchestrator) resolveChannelIndex(ctx context.Context, radioID string, channelIndex int, radioManager RadioManager) (float64, error) {
 	// First, try to resolve using Silvus band plan if available
-	if o.config != nil && o.config.SilvusBandPlan != nil {
+	if bandPlan := o.silvusBandPlan(); bandPlan != nil {
 		// Try to get model and band from radio manager
 		model, band, err := o.getRadioModelAndBand(ctx, radioID, radioManager)
 		if err == nil {
-			frequency, err := o.config.SilvusBandPlan.GetSilvusChannelFrequency(model, band, channelIndex)
+			frequency, err := bandPlan.GetSilvusChannelFrequency(model, band, channelIndex)
 			if err == nil {
 				return frequency, nil
 			}
@@ -504,16 +534,19 @@ func (o *Orchestrator) getRadioModelAndBand(ctx context.Context, radioID string,
 	}
 
 	// Get radio from manager
-	radio, err := manager.GetRadio(radioID)
+	radioInfo, err := manager.GetRadio(radioID)
 	if err != nil {
 		return "", "", fmt.Errorf("radio %s not found: %w", radioID, err)
 	}
 
 	// Extract model and band from radio data
-	model := radio.Model
+	model := radioInfo.Model
 
 	// Default band if not specified in radio
-	band := "default"
+	band := radioInfo.Band
+	if band == "" {
+		band = radio.DefaultBand
+	}
 
 	return model, band, nil
 }
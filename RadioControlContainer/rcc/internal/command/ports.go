@@ -24,6 +24,17 @@ type RadioManager interface {
 	SetActive(radioID string) error
 }
 
+// AdapterProvider resolves the adapter for a specific radio.
+// Fleet commands use it to reach radios other than the active one.
+type AdapterProvider interface {
+	GetAdapter(radioID string) (adapter.IRadioAdapter, error)
+}
+
+// ControlAuditLogger is implemented by audit loggers that record structured parameters.
+type ControlAuditLogger interface {
+	LogControlAction(ctx context.Context, action, radioID string, params map[string]interface{}, outcome string, err error)
+}
+
 // ErrNotFound indicates a requested radio was not found.
 var ErrNotFound = errors.New("NOT_FOUND")
 
@@ -0,0 +1,11 @@
+// Package history implements the persistent per-radio telemetry history store.
+//
+// The store appends power, frequency, state and fault events published on the
+// telemetry hub to one JSONL file per radio, and answers time-range queries with
+// optional downsampling for post-mission link analysis. Events are queued and
+// written by a background writer so publishing never waits on the disk.
+//
+// Architecture References:
+//   - Telemetry SSE §2: Event types recorded by the store
+//   - CB-TIMING §6: In-memory buffering that this store extends to disk
+package history
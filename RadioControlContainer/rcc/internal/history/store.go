@@ -0,0 +1,541 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// Record types stored in the history.
+const (
+	TypePower     = "power"
+	TypeFrequency = "frequency"
+	TypeState     = "state"
+	TypeFault     = "fault"
+)
+
+// DefaultQueryLimit caps the number of records returned by a single query.
+const DefaultQueryLimit = 1000
+
+// DefaultQueueSize is the number of records buffered between the telemetry hub
+// and the history writer. Records are dropped when the queue is full.
+const DefaultQueueSize = 4096
+
+// timestampSkew bounds how far out of order records may be in a history file.
+// Records are appended in queue order but stamped with the publisher's event
+// time, so neighbouring lines can disagree by up to this much.
+const timestampSkew = time.Minute
+
+// seekWindow is the span below which Query stops bisecting the file and scans.
+const seekWindow = 64 * 1024
+
+// ErrInvalidRange is returned by Query when the time range is inverted.
+var ErrInvalidRange = errors.New("invalid time range")
+
+// eventTypes maps telemetry event types to history record types.
+var eventTypes = map[string]string{
+	"powerChanged":   TypePower,
+	"channelChanged": TypeFrequency,
+	"state":          TypeState,
+	"fault":          TypeFault,
+}
+
+// Record represents a single persisted telemetry sample.
+type Record struct {
+	Timestamp time.Time              `json:"ts"`
+	RadioID   string                 `json:"radioId"`
+	Type      string                 `json:"type"`
+	Value     *float64               `json:"value,omitempty"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Count     int                    `json:"count,omitempty"`
+}
+
+// Query selects records from a radio's history.
+type Query struct {
+	From     time.Time
+	To       time.Time
+	Types    []string
+	Interval time.Duration // Downsampling bucket width; zero returns raw records
+	Limit    int
+}
+
+// Store persists radio events as per-radio JSONL files.
+//
+// Record only queues the event; a single writer goroutine appends to the files,
+// so publishing telemetry never waits on disk I/O. Queries read the files
+// without taking the append lock.
+type Store struct {
+	mu    sync.Mutex // Guards files and serializes appends and prunes
+	dir   string
+	files map[string]*os.File
+	now   func() time.Time
+
+	queue   chan pendingRecord
+	stop    chan struct{}
+	done    chan struct{}
+	closed  sync.Once
+	dropped int64
+}
+
+// pendingRecord is a queued record, or a flush marker when flushed is set.
+type pendingRecord struct {
+	record  Record
+	flushed chan struct{}
+}
+
+// Compile-time assertion that Store implements telemetry.EventRecorder
+var _ telemetry.EventRecorder = (*Store)(nil)
+
+// NewStore creates a history store rooted at dir.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	s := &Store{
+		dir:   dir,
+		files: make(map[string]*os.File),
+		now:   time.Now,
+		queue: make(chan pendingRecord, DefaultQueueSize),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go s.writeLoop()
+
+	return s, nil
+}
+
+// Record queues a telemetry event for persistence if it is a radio event of a
+// tracked type. It never blocks; the event is dropped when the queue is full.
+func (s *Store) Record(event telemetry.Event) {
+	recordType, tracked := eventTypes[event.Type]
+	if !tracked || event.Radio == "" {
+		return
+	}
+
+	record := Record{
+		Timestamp: s.eventTime(event),
+		RadioID:   event.Radio,
+		Type:      recordType,
+		Value:     valueFromEvent(recordType, event.Data),
+		Data:      event.Data,
+	}
+
+	select {
+	case <-s.stop:
+		return
+	default:
+	}
+
+	select {
+	case s.queue <- pendingRecord{record: record}:
+	default:
+		if atomic.AddInt64(&s.dropped, 1) == 1 {
+			log.Printf("Telemetry history queue full, dropping records")
+		}
+	}
+}
+
+// Flush waits until every record queued before the call has been written.
+func (s *Store) Flush() {
+	flushed := make(chan struct{})
+	select {
+	case s.queue <- pendingRecord{flushed: flushed}:
+	case <-s.done:
+		return
+	}
+	select {
+	case <-flushed:
+	case <-s.done:
+	}
+}
+
+// Dropped returns the number of records dropped because the queue was full.
+func (s *Store) Dropped() int64 {
+	return atomic.LoadInt64(&s.dropped)
+}
+
+// writeLoop appends queued records until the store is closed, then drains the queue.
+func (s *Store) writeLoop() {
+	defer close(s.done)
+
+	for {
+		select {
+		case pending := <-s.queue:
+			s.write(pending)
+		case <-s.stop:
+			for {
+				select {
+				case pending := <-s.queue:
+					s.write(pending)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *Store) write(pending pendingRecord) {
+	if pending.flushed != nil {
+		close(pending.flushed)
+		return
+	}
+	if err := s.Append(pending.record); err != nil {
+		log.Printf("Failed to record telemetry history: %v", err)
+	}
+}
+
+// eventTime returns the time the event was produced: its "ts" field when the
+// publisher set one, otherwise the time it was handed to the store.
+func (s *Store) eventTime(event telemetry.Event) time.Time {
+	switch ts := event.Data["ts"].(type) {
+	case time.Time:
+		return ts.UTC()
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			return t.UTC()
+		}
+	}
+	return s.now().UTC()
+}
+
+// Append writes a record to its radio's history file.
+func (s *Store) Append(record Record) error {
+	jsonData, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := s.fileFor(record.RadioID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(jsonData, '\n')); err != nil {
+		return fmt.Errorf("failed to write history record: %w", err)
+	}
+
+	return nil
+}
+
+// Query returns a radio's records within the time range, filtered by type and
+// optionally downsampled to one record per type per interval.
+//
+// History files are append-only and grow until pruned, so Query bisects the
+// file to the start of the window and stops reading once records pass its end
+// rather than parsing the whole file.
+func (s *Store) Query(radioID string, q Query) ([]Record, error) {
+	if !q.To.IsZero() && !q.From.IsZero() && q.To.Before(q.From) {
+		return nil, fmt.Errorf("%w: to %s is before from %s", ErrInvalidRange, q.To, q.From)
+	}
+
+	limit := q.Limit
+	if limit <= 0 || limit > DefaultQueryLimit {
+		limit = DefaultQueryLimit
+	}
+
+	types := make(map[string]bool, len(q.Types))
+	for _, t := range q.Types {
+		types[t] = true
+	}
+
+	// Read without the append lock: appends only add whole lines at the end
+	// (a torn trailing line is skipped) and prunes replace the file by rename.
+	file, err := os.Open(s.pathFor(radioID))
+	if os.IsNotExist(err) {
+		return []Record{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history for radio %s: %w", radioID, err)
+	}
+	defer file.Close()
+
+	var offset int64
+	if !q.From.IsZero() {
+		if offset, err = seekBefore(file, q.From.Add(-timestampSkew)); err != nil {
+			return nil, fmt.Errorf("failed to read history for radio %s: %w", radioID, err)
+		}
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to read history for radio %s: %w", radioID, err)
+	}
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	if offset > 0 {
+		scanner.Scan() // Drop the partial line the seek landed in
+	}
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue // Skip a torn trailing line from an unclean shutdown
+		}
+		if !q.To.IsZero() && record.Timestamp.After(q.To.Add(timestampSkew)) {
+			break
+		}
+		if !q.From.IsZero() && record.Timestamp.Before(q.From) {
+			continue
+		}
+		if !q.To.IsZero() && record.Timestamp.After(q.To) {
+			continue
+		}
+		if len(types) > 0 && !types[record.Type] {
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history for radio %s: %w", radioID, err)
+	}
+
+	if q.Interval > 0 {
+		records = downsample(records, q.Interval)
+	}
+
+	// Keep the most recent records when the result exceeds the limit
+	if len(records) > limit {
+		records = records[len(records)-limit:]
+	}
+	if records == nil {
+		records = []Record{}
+	}
+
+	return records, nil
+}
+
+// seekBefore returns an offset in a history file at or before the first line
+// stamped at or after target. The offset may fall inside a line.
+func seekBefore(file *os.File, target time.Time) (int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	lo, hi := int64(0), info.Size()
+	for hi-lo > seekWindow {
+		mid := lo + (hi-lo)/2
+		ts, ok, err := lineTimestampAfter(file, mid, hi)
+		if err != nil {
+			return 0, err
+		}
+		if ok && ts.Before(target) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// lineTimestampAfter parses the timestamp of the first complete line that
+// starts after offset. ok is false when no such line exists before end or the
+// line cannot be parsed.
+func lineTimestampAfter(file *os.File, offset, end int64) (time.Time, bool, error) {
+	reader := bufio.NewReader(io.NewSectionReader(file, offset, end-offset))
+	if _, err := reader.ReadSlice('\n'); err != nil {
+		if err == io.EOF || err == bufio.ErrBufferFull {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, false, err
+	}
+	line, err := reader.ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return time.Time{}, false, err
+	}
+
+	var stamp struct {
+		Timestamp time.Time `json:"ts"`
+	}
+	if err := json.Unmarshal(line, &stamp); err != nil {
+		return time.Time{}, false, nil
+	}
+	return stamp.Timestamp, true, nil
+}
+
+// Prune removes records older than cutoff from every radio's history.
+func (s *Store) Prune(cutoff time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.jsonl"))
+	if err != nil {
+		return fmt.Errorf("failed to list history files: %w", err)
+	}
+
+	for _, path := range paths {
+		if err := s.pruneFile(path, cutoff); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close writes the queued records and closes all open history files.
+func (s *Store) Close() error {
+	s.closed.Do(func() { close(s.stop) })
+	<-s.done
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var firstErr error
+	for radioID, file := range s.files {
+		if err := file.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(s.files, radioID)
+	}
+	return firstErr
+}
+
+// pruneFile rewrites a history file without records older than cutoff.
+// Caller must hold s.mu.
+func (s *Store) pruneFile(path string, cutoff time.Time) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read history file %s: %w", path, err)
+	}
+
+	tmpPath := path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create pruned history file: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	writer := bufio.NewWriter(tmp)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.Timestamp.Before(cutoff) {
+			continue
+		}
+		writer.Write(scanner.Bytes())
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write pruned history file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close pruned history file: %w", err)
+	}
+
+	// Drop the cached handle so the next append reopens the replaced file
+	for radioID, file := range s.files {
+		if file.Name() == path {
+			file.Close()
+			delete(s.files, radioID)
+		}
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace history file: %w", err)
+	}
+
+	return nil
+}
+
+// fileFor returns the append handle for a radio, opening it on first use.
+// Caller must hold s.mu.
+func (s *Store) fileFor(radioID string) (*os.File, error) {
+	if file, exists := s.files[radioID]; exists {
+		return file, nil
+	}
+
+	file, err := os.OpenFile(s.pathFor(radioID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history for radio %s: %w", radioID, err)
+	}
+
+	s.files[radioID] = file
+	return file, nil
+}
+
+// pathFor returns the history file path for a radio.
+func (s *Store) pathFor(radioID string) string {
+	return filepath.Join(s.dir, fileNameFor(radioID)+".jsonl")
+}
+
+// fileNameFor encodes a radio ID as a file name. Characters outside
+// [A-Za-z0-9_.-] are percent-encoded, so distinct IDs never share a file.
+func fileNameFor(radioID string) string {
+	var name strings.Builder
+	for i := 0; i < len(radioID); i++ {
+		c := radioID[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '_', c == '.', c == '-':
+			name.WriteByte(c)
+		default:
+			fmt.Fprintf(&name, "%%%02X", c)
+		}
+	}
+	return name.String()
+}
+
+// downsample keeps the latest record per type in each interval bucket and
+// counts how many raw records it stands for.
+func downsample(records []Record, interval time.Duration) []Record {
+	type bucketKey struct {
+		recordType string
+		bucket     int64
+	}
+
+	latest := make(map[bucketKey]int)
+	var result []Record
+	for _, record := range records {
+		key := bucketKey{record.Type, record.Timestamp.UnixNano() / int64(interval)}
+		if idx, exists := latest[key]; exists {
+			count := result[idx].Count + 1
+			result[idx] = record
+			result[idx].Count = count
+			continue
+		}
+		record.Count = 1
+		latest[key] = len(result)
+		result = append(result, record)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(result[j].Timestamp)
+	})
+
+	return result
+}
+
+// valueFromEvent extracts the numeric value tracked for a record type.
+func valueFromEvent(recordType string, data map[string]interface{}) *float64 {
+	var key string
+	switch recordType {
+	case TypePower:
+		key = "powerDbm"
+	case TypeFrequency:
+		key = "frequencyMhz"
+	default:
+		return nil
+	}
+
+	if value, ok := data[key].(float64); ok {
+		return &value
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/radio-control/rcc/internal/config"
+	"github.com/radio-control/rcc/internal/telemetry"
+)
+
+// newTestStore creates a store whose clock is driven by the returned pointer.
+func newTestStore(t *testing.T) (*Store, *time.Time) {
+	t.Helper()
+
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func TestRecordMapsEventTypes(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	store.Record(telemetry.Event{Type: "powerChanged", Radio: "radio-01", Data: map[string]interface{}{"powerDbm": 25.0}})
+	store.Record(telemetry.Event{Type: "channelChanged", Radio: "radio-01", Data: map[string]interface{}{"frequencyMhz": 2437.0}})
+	store.Record(telemetry.Event{Type: "fault", Radio: "radio-01", Data: map[string]interface{}{"code": "BUSY"}})
+	store.Record(telemetry.Event{Type: "heartbeat", Radio: "radio-01"})
+	store.Record(telemetry.Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 10.0}})
+
+	store.Flush()
+	records, err := store.Query("radio-01", Query{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+
+	if records[0].Type != TypePower || records[0].Value == nil || *records[0].Value != 25 {
+		t.Errorf("Unexpected power record: %+v", records[0])
+	}
+	if records[1].Type != TypeFrequency || records[1].Value == nil || *records[1].Value != 2437 {
+		t.Errorf("Unexpected frequency record: %+v", records[1])
+	}
+	if records[2].Type != TypeFault || records[2].Value != nil {
+		t.Errorf("Unexpected fault record: %+v", records[2])
+	}
+}
+
+func TestQueryFiltersByTimeAndType(t *testing.T) {
+	store, now := newTestStore(t)
+	base := *now
+
+	for i := 0; i < 10; i++ {
+		*now = base.Add(time.Duration(i) * time.Minute)
+		eventType := "powerChanged"
+		if i%2 == 1 {
+			eventType = "fault"
+		}
+		store.Record(telemetry.Event{Type: eventType, Radio: "radio-01", Data: map[string]interface{}{"powerDbm": float64(i)}})
+	}
+
+	store.Flush()
+	records, err := store.Query("radio-01", Query{
+		From:  base.Add(2 * time.Minute),
+		To:    base.Add(6 * time.Minute),
+		Types: []string{TypePower},
+	})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 power records between minute 2 and 6, got %d", len(records))
+	}
+	for _, record := range records {
+		if record.Type != TypePower {
+			t.Errorf("Expected only power records, got %s", record.Type)
+		}
+	}
+
+	if _, err := store.Query("radio-01", Query{From: base, To: base.Add(-time.Minute)}); err == nil {
+		t.Error("Expected error for inverted time range")
+	}
+}
+
+func TestQueryDownsamplesAndLimits(t *testing.T) {
+	store, now := newTestStore(t)
+	base := *now
+
+	for i := 0; i < 60; i++ {
+		*now = base.Add(time.Duration(i) * 10 * time.Second)
+		store.Record(telemetry.Event{Type: "powerChanged", Radio: "radio-01", Data: map[string]interface{}{"powerDbm": float64(i)}})
+	}
+
+	store.Flush()
+	records, err := store.Query("radio-01", Query{Interval: time.Minute})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(records) != 10 {
+		t.Fatalf("Expected 10 one-minute buckets, got %d", len(records))
+	}
+	if records[0].Count != 6 || *records[0].Value != 5 {
+		t.Errorf("Expected first bucket to keep the last of 6 samples, got count=%d value=%v", records[0].Count, *records[0].Value)
+	}
+
+	limited, err := store.Query("radio-01", Query{Limit: 5})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(limited) != 5 || *limited[4].Value != 59 {
+		t.Errorf("Expected the 5 most recent records, got %d ending at %v", len(limited), *limited[len(limited)-1].Value)
+	}
+}
+
+func TestQueryWindowInLargeHistory(t *testing.T) {
+	store, _ := newTestStore(t)
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Enough records that Query bisects the file instead of scanning it
+	for i := 0; i < 20000; i++ {
+		value := float64(i)
+		if err := store.Append(Record{Timestamp: base.Add(time.Duration(i) * time.Second), RadioID: "radio-01", Type: TypePower, Value: &value}); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	from := base.Add(12345 * time.Second)
+	records, err := store.Query("radio-01", Query{From: from, To: from.Add(9 * time.Second)})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(records) != 10 {
+		t.Fatalf("Expected 10 records, got %d", len(records))
+	}
+	for i, record := range records {
+		if *record.Value != float64(12345+i) {
+			t.Errorf("Record %d value = %v, want %d", i, *record.Value, 12345+i)
+		}
+	}
+
+	first, err := store.Query("radio-01", Query{From: base, To: base.Add(2 * time.Second)})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(first) != 3 || *first[0].Value != 0 {
+		t.Errorf("Expected the first 3 records, got %d", len(first))
+	}
+}
+
+func TestStorePersistsAcrossReopen(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	store.Record(telemetry.Event{Type: "state", Radio: "radio/01", Data: map[string]interface{}{"status": "online"}})
+	store.Close()
+
+	if _, err := os.Stat(filepath.Join(dir, "radio%2F01.jsonl")); err != nil {
+		t.Errorf("Expected encoded history file name: %v", err)
+	}
+
+	reopened, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer reopened.Close()
+
+	records, err := reopened.Query("radio/01", Query{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Type != TypeState {
+		t.Errorf("Expected persisted state record, got %+v", records)
+	}
+}
+
+func TestPruneDropsOldRecords(t *testing.T) {
+	store, now := newTestStore(t)
+	base := *now
+
+	for i := 0; i < 4; i++ {
+		*now = base.Add(time.Duration(i) * time.Hour)
+		store.Record(telemetry.Event{Type: "powerChanged", Radio: "radio-01", Data: map[string]interface{}{"powerDbm": float64(i)}})
+	}
+
+	store.Flush()
+	if err := store.Prune(base.Add(2 * time.Hour)); err != nil {
+		t.Fatalf("Prune failed: %v", err)
+	}
+
+	// Appends after prune must land in the rewritten file
+	*now = base.Add(5 * time.Hour)
+	store.Record(telemetry.Event{Type: "powerChanged", Radio: "radio-01", Data: map[string]interface{}{"powerDbm": 5.0}})
+
+	store.Flush()
+	records, err := store.Query("radio-01", Query{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records after prune, got %d", len(records))
+	}
+	if *records[0].Value != 2 || *records[2].Value != 5 {
+		t.Errorf("Unexpected records after prune: %v, %v", *records[0].Value, *records[2].Value)
+	}
+}
+
+func TestHubRecordsPublishedEvents(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	hub := telemetry.NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+	hub.SetRecorder(store)
+
+	if err := hub.PublishRadio("radio-01", telemetry.Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 12.0}}); err != nil {
+		t.Fatalf("PublishRadio failed: %v", err)
+	}
+
+	store.Flush()
+	records, err := store.Query("radio-01", Query{Types: []string{TypePower}})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Errorf("Expected hub event to be recorded, got %d records", len(records))
+	}
+}
+
+func TestRadioIDsDoNotShareFiles(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	store.Record(telemetry.Event{Type: "powerChanged", Radio: "a/b", Data: map[string]interface{}{"powerDbm": 1.0}})
+	store.Record(telemetry.Event{Type: "powerChanged", Radio: "a_b", Data: map[string]interface{}{"powerDbm": 2.0}})
+	store.Flush()
+
+	for radioID, want := range map[string]float64{"a/b": 1, "a_b": 2} {
+		records, err := store.Query(radioID, Query{})
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		if len(records) != 1 || *records[0].Value != want {
+			t.Errorf("Expected one record with value %v for %q, got %+v", want, radioID, records)
+		}
+	}
+}
+
+func TestRecordUsesEventTimestamp(t *testing.T) {
+	store, now := newTestStore(t)
+
+	eventTime := now.Add(-time.Minute)
+	store.Record(telemetry.Event{Type: "state", Radio: "radio-01", Data: map[string]interface{}{
+		"status": "online",
+		"ts":     eventTime.Format(time.RFC3339),
+	}})
+	store.Flush()
+
+	records, err := store.Query("radio-01", Query{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(records) != 1 || !records[0].Timestamp.Equal(eventTime) {
+		t.Errorf("Expected record stamped with the event time %s, got %+v", eventTime, records)
+	}
+}
+
+func TestRecordDoesNotWaitForWriter(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	// Hold the append lock as a slow disk or a prune would
+	store.mu.Lock()
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < DefaultQueueSize+10; i++ {
+			store.Record(telemetry.Event{Type: "powerChanged", Radio: "radio-01", Data: map[string]interface{}{"powerDbm": float64(i)}})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Record blocked on the history writer")
+	}
+
+	// Queries do not take the append lock either
+	if _, err := store.Query("radio-01", Query{}); err != nil {
+		t.Errorf("Query failed while appends were blocked: %v", err)
+	}
+	store.mu.Unlock()
+
+	if store.Dropped() == 0 {
+		t.Error("Expected records beyond the queue size to be dropped")
+	}
+}
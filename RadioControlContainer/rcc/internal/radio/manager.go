@@ -5,16 +5,22 @@ package radio
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
 )
 
+// DefaultBand is the band plan used for radios without an assigned band.
+const DefaultBand = "default"
+
 // Radio represents a single radio with its capabilities and current state.
 type Radio struct {
 	ID           string                    `json:"id"`
 	Model        string                    `json:"model"`
+	Band         string                    `json:"band,omitempty"`
 	Status       string                    `json:"status"`
 	Capabilities *adapter.RadioCapabilities `json:"capabilities"`
 	State        *adapter.RadioState       `json:"state"`
@@ -33,13 +39,19 @@ type Manager struct {
 	radios        map[string]*Radio
 	activeRadioID string
 	adapters      map[string]adapter.IRadioAdapter
+	bandPlans     map[string]map[string][]adapter.Channel // Active band plan channels by model and band
+	bands         map[string]string                       // Assigned band by radio ID
+	planned       map[string]bool                         // Radios whose channels come from a band plan
 }
 
 // NewManager creates a new radio manager.
 func NewManager() *Manager {
 	return &Manager{
-		radios:   make(map[string]*Radio),
-		adapters: make(map[string]adapter.IRadioAdapter),
+		radios:    make(map[string]*Radio),
+		adapters:  make(map[string]adapter.IRadioAdapter),
+		bandPlans: make(map[string]map[string][]adapter.Channel),
+		bands:     make(map[string]string),
+		planned:   make(map[string]bool),
 	}
 }
 
@@ -71,18 +83,20 @@ func (m *Manager) LoadCapabilities(radioID string, radioAdapter adapter.IRadioAd
 	}
 
 	// Create radio entry
+	model := m.getModelFromCapabilities(capabilities)
 	radio := &Radio{
 		ID:     radioID,
-		Model:  m.getModelFromCapabilities(capabilities),
+		Model:  model,
+		Band:   m.bands[radioID],
 		Status: m.determineStatus(err),
 		Capabilities: &adapter.RadioCapabilities{
 			MinPowerDbm: m.getMinPowerFromCapabilities(capabilities),
 			MaxPowerDbm: m.getMaxPowerFromCapabilities(capabilities),
-			Channels:    m.getChannelsFromCapabilities(capabilities, radioAdapter),
 		},
 		State:    state,
 		LastSeen: time.Now(),
 	}
+	radio.Capabilities.Channels = m.channelsFor(radio, capabilities, radioAdapter)
 
 	m.radios[radioID] = radio
 
@@ -144,6 +158,19 @@ func (m *Manager) GetActiveAdapter() (adapter.IRadioAdapter, string, error) {
 	return adapter, m.activeRadioID, nil
 }
 
+// GetAdapter returns the adapter registered for a specific radio.
+func (m *Manager) GetAdapter(radioID string) (adapter.IRadioAdapter, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	radioAdapter, exists := m.adapters[radioID]
+	if !exists {
+		return nil, fmt.Errorf("no adapter for radio %s", radioID)
+	}
+
+	return radioAdapter, nil
+}
+
 // List returns the radio list matching OpenAPI schema.
 func (m *Manager) List() *RadioList {
 	m.mu.RLock()
@@ -229,6 +256,7 @@ func (m *Manager) RemoveRadio(radioID string) error {
 
 	delete(m.radios, radioID)
 	delete(m.adapters, radioID)
+	delete(m.planned, radioID)
 
 	// If this was the active radio, clear active selection
 	if m.activeRadioID == radioID {
@@ -263,12 +291,122 @@ func (m *Manager) RefreshCapabilities(radioID string, timeout time.Duration) err
 	}
 
 	// Update capabilities
-	radio.Capabilities.Channels = m.getChannelsFromCapabilities(capabilities, radioAdapter)
+	radio.Capabilities.Channels = m.channelsFor(radio, capabilities, radioAdapter)
 	radio.LastSeen = time.Now()
 
 	return nil
 }
 
+// ApplyBandPlans makes plan the set of active band plans. Each radio takes the
+// channels of its model and assigned band (DefaultBand when unassigned), which
+// replace those reported by its adapter and survive capability refreshes.
+// Radios whose plan was removed revert to their adapter's channels. A nil plan
+// removes every band plan. It returns the IDs of the radios whose channels changed.
+func (m *Manager) ApplyBandPlans(plan *config.SilvusBandPlan) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.bandPlans = make(map[string]map[string][]adapter.Channel)
+	if plan != nil {
+		for model, bands := range plan.Models {
+			for band, silvusChannels := range bands {
+				if len(silvusChannels) == 0 {
+					continue
+				}
+				channels := make([]adapter.Channel, 0, len(silvusChannels))
+				for _, channel := range silvusChannels {
+					channels = append(channels, adapter.Channel{Index: channel.ChannelIndex, FrequencyMhz: channel.FrequencyMhz})
+				}
+				if m.bandPlans[model] == nil {
+					m.bandPlans[model] = make(map[string][]adapter.Channel)
+				}
+				m.bandPlans[model][band] = channels
+			}
+		}
+	}
+
+	updated := make([]string, 0)
+	for id, radio := range m.radios {
+		if m.reapplyBandPlan(radio) {
+			updated = append(updated, id)
+		}
+	}
+	return updated
+}
+
+// SetBand assigns the band plan a radio uses. The assignment is kept for radios
+// that are loaded later; an empty band reverts to DefaultBand.
+func (m *Manager) SetBand(radioID, band string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if band == "" {
+		delete(m.bands, radioID)
+	} else {
+		m.bands[radioID] = band
+	}
+
+	if radio, exists := m.radios[radioID]; exists {
+		radio.Band = band
+		m.reapplyBandPlan(radio)
+	}
+}
+
+// ParseBands parses band assignments of the form "radio-01=wide,radio-02=narrow".
+func ParseBands(value string) (map[string]string, error) {
+	bands := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		radioID, band, ok := strings.Cut(pair, "=")
+		radioID, band = strings.TrimSpace(radioID), strings.TrimSpace(band)
+		if !ok || radioID == "" || band == "" {
+			return nil, fmt.Errorf("invalid band assignment %q, expected radio=band", pair)
+		}
+		bands[radioID] = band
+	}
+	return bands, nil
+}
+
+// reapplyBandPlan updates a radio's channels from the active band plans and
+// reports whether they changed. Caller must hold m.mu.
+func (m *Manager) reapplyBandPlan(radio *Radio) bool {
+	channels, ok := m.planChannels(radio)
+	if !ok {
+		if !m.planned[radio.ID] {
+			return false
+		}
+		delete(m.planned, radio.ID)
+		// Fall back to the adapter's own band plan; profile-derived channels
+		// return on the next capability refresh.
+		channels = m.getChannelsFromCapabilities(nil, m.adapters[radio.ID])
+	} else {
+		m.planned[radio.ID] = true
+	}
+
+	if radio.Capabilities == nil {
+		radio.Capabilities = &adapter.RadioCapabilities{}
+	}
+	radio.Capabilities.Channels = channels
+	return true
+}
+
+// planChannels returns a copy of the active band plan channels for a radio's
+// model and band. Caller must hold m.mu.
+func (m *Manager) planChannels(radio *Radio) ([]adapter.Channel, bool) {
+	band := radio.Band
+	if band == "" {
+		band = DefaultBand
+	}
+	channels, ok := m.bandPlans[radio.Model][band]
+	if !ok {
+		return nil, false
+	}
+	return append([]adapter.Channel(nil), channels...), true
+}
+
 // Helper methods for capability processing
 
 func (m *Manager) getModelFromCapabilities(capabilities []adapter.FrequencyProfile) string {
@@ -286,6 +424,17 @@ func (m *Manager) getMaxPowerFromCapabilities(capabilities []adapter.FrequencyPr
 	return 39
 }
 
+// channelsFor prefers an applied band plan over the adapter's channels.
+// Caller must hold m.mu.
+func (m *Manager) channelsFor(radio *Radio, capabilities []adapter.FrequencyProfile, radioAdapter adapter.IRadioAdapter) []adapter.Channel {
+	if channels, ok := m.planChannels(radio); ok {
+		m.planned[radio.ID] = true
+		return channels
+	}
+	delete(m.planned, radio.ID)
+	return m.getChannelsFromCapabilities(capabilities, radioAdapter)
+}
+
 func (m *Manager) getChannelsFromCapabilities(capabilities []adapter.FrequencyProfile, radioAdapter adapter.IRadioAdapter) []adapter.Channel {
 	// Try to get channels directly from adapter if it supports it (e.g., SilvusMock)
 	if bandPlanAdapter, ok := radioAdapter.(interface{ GetBandPlan() []adapter.Channel }); ok {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/radio-control/rcc/internal/adapter"
+	"github.com/radio-control/rcc/internal/config"
 )
 
 // MockAdapter is a mock implementation of IRadioAdapter for testing.
@@ -484,6 +485,119 @@ func TestRefreshCapabilities(t *testing.T) {
 	}
 }
 
+// bandPlanFor builds a single model's band plan from band to channel indexes at 2400+index MHz.
+func bandPlanFor(model string, bands map[string][]int) *config.SilvusBandPlan {
+	plan := &config.SilvusBandPlan{Models: map[string]map[string][]config.SilvusChannel{model: {}}}
+	for band, indexes := range bands {
+		for _, index := range indexes {
+			plan.Models[model][band] = append(plan.Models[model][band], config.SilvusChannel{ChannelIndex: index, FrequencyMhz: 2400 + float64(index)})
+		}
+	}
+	return plan
+}
+
+func TestApplyBandPlans(t *testing.T) {
+	manager := NewManager()
+	if err := manager.LoadCapabilities("radio-01", &MockAdapter{}, 5*time.Second); err != nil {
+		t.Fatalf("LoadCapabilities() failed: %v", err)
+	}
+
+	if updated := manager.ApplyBandPlans(bandPlanFor("Other-Model", map[string][]int{DefaultBand: {7}})); len(updated) != 0 {
+		t.Errorf("Expected no radios of another model to be updated, got %v", updated)
+	}
+	if updated := manager.ApplyBandPlans(bandPlanFor("Unknown-Radio", map[string][]int{DefaultBand: {7}})); len(updated) != 1 || updated[0] != "radio-01" {
+		t.Fatalf("Expected radio-01 to be updated, got %v", updated)
+	}
+
+	// The plan must survive a capability refresh from the adapter
+	if err := manager.RefreshCapabilities("radio-01", 5*time.Second); err != nil {
+		t.Fatalf("RefreshCapabilities() failed: %v", err)
+	}
+	radio, _ := manager.GetRadio("radio-01")
+	if len(radio.Capabilities.Channels) != 1 || radio.Capabilities.Channels[0].Index != 7 {
+		t.Errorf("Expected band plan channels after refresh, got %+v", radio.Capabilities.Channels)
+	}
+}
+
+func TestApplyBandPlansResolvesBandPerRadio(t *testing.T) {
+	manager := NewManager()
+	manager.SetBand("radio-02", "wide")
+	for _, id := range []string{"radio-01", "radio-02"} {
+		if err := manager.LoadCapabilities(id, &MockAdapter{}, 5*time.Second); err != nil {
+			t.Fatalf("LoadCapabilities() failed: %v", err)
+		}
+	}
+
+	manager.ApplyBandPlans(bandPlanFor("Unknown-Radio", map[string][]int{DefaultBand: {1, 2}, "wide": {10, 11, 12}}))
+
+	defaultRadio, _ := manager.GetRadio("radio-01")
+	if len(defaultRadio.Capabilities.Channels) != 2 {
+		t.Errorf("Expected radio-01 on the default band with 2 channels, got %+v", defaultRadio.Capabilities.Channels)
+	}
+	wideRadio, _ := manager.GetRadio("radio-02")
+	if wideRadio.Band != "wide" || len(wideRadio.Capabilities.Channels) != 3 || wideRadio.Capabilities.Channels[0].Index != 10 {
+		t.Errorf("Expected radio-02 on the wide band with 3 channels, got band=%q %+v", wideRadio.Band, wideRadio.Capabilities.Channels)
+	}
+
+	// Reassigning the band takes effect immediately
+	manager.SetBand("radio-02", "")
+	wideRadio, _ = manager.GetRadio("radio-02")
+	if len(wideRadio.Capabilities.Channels) != 2 {
+		t.Errorf("Expected radio-02 back on the default band, got %+v", wideRadio.Capabilities.Channels)
+	}
+}
+
+func TestApplyBandPlansClearsRemovedPlan(t *testing.T) {
+	manager := NewManager()
+	if err := manager.LoadCapabilities("radio-01", &MockAdapter{}, 5*time.Second); err != nil {
+		t.Fatalf("LoadCapabilities() failed: %v", err)
+	}
+	radio, _ := manager.GetRadio("radio-01")
+	adapterChannels := len(radio.Capabilities.Channels)
+
+	manager.ApplyBandPlans(bandPlanFor("Unknown-Radio", map[string][]int{DefaultBand: {7}}))
+
+	// Removing the plan reverts to the adapter's channels
+	if updated := manager.ApplyBandPlans(&config.SilvusBandPlan{}); len(updated) != 1 {
+		t.Fatalf("Expected radio-01 to be updated when its plan is removed, got %v", updated)
+	}
+	radio, _ = manager.GetRadio("radio-01")
+	for _, channel := range radio.Capabilities.Channels {
+		if channel.Index == 7 {
+			t.Errorf("Expected stale band plan channel to be cleared, got %+v", radio.Capabilities.Channels)
+		}
+	}
+
+	// An empty band is treated as removed, and radios never planned are left alone
+	manager.ApplyBandPlans(bandPlanFor("Unknown-Radio", map[string][]int{DefaultBand: {}}))
+	if err := manager.RefreshCapabilities("radio-01", 5*time.Second); err != nil {
+		t.Fatalf("RefreshCapabilities() failed: %v", err)
+	}
+	radio, _ = manager.GetRadio("radio-01")
+	if len(radio.Capabilities.Channels) != adapterChannels {
+		t.Errorf("Expected %d adapter channels after refresh, got %+v", adapterChannels, radio.Capabilities.Channels)
+	}
+}
+
+func TestParseBands(t *testing.T) {
+	bands, err := ParseBands("radio-01=wide, radio-02 = narrow,")
+	if err != nil {
+		t.Fatalf("ParseBands failed: %v", err)
+	}
+	if len(bands) != 2 || bands["radio-01"] != "wide" || bands["radio-02"] != "narrow" {
+		t.Errorf("Unexpected bands: %v", bands)
+	}
+
+	if bands, err := ParseBands(""); err != nil || len(bands) != 0 {
+		t.Errorf("Expected no assignments for empty value, got %v (err %v)", bands, err)
+	}
+	for _, value := range []string{"radio-01", "=wide", "radio-01="} {
+		if _, err := ParseBands(value); err == nil {
+			t.Errorf("Expected error for %q", value)
+		}
+	}
+}
+
 func TestMultipleRadios(t *testing.T) {
 	manager := NewManager()
 
@@ -0,0 +1,183 @@
+package telemetry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidFilter indicates a malformed subscription filter query parameter.
+var ErrInvalidFilter = errors.New("invalid telemetry filter")
+
+// alwaysDelivered lists event types that bypass type, threshold and rate filters
+// so clients can still detect liveness and resume.
+var alwaysDelivered = map[string]bool{
+	"ready":     true,
+	"heartbeat": true,
+}
+
+// ClientFilter limits which events a subscriber receives.
+//
+// Query parameters:
+//   - types: comma-separated event type allowlist (e.g. powerChanged,fault)
+//   - minPowerDeltaDb: drop powerChanged events that move less than this from the last delivered value
+//   - minFrequencyDeltaMhz: same for channelChanged events
+//   - maxRate: maximum events per second delivered to this client
+type ClientFilter struct {
+	Types                map[string]bool
+	MinPowerDeltaDb      float64
+	MinFrequencyDeltaMhz float64
+	MaxRate              float64
+
+	mu            sync.Mutex
+	lastPower     map[string]float64
+	lastFrequency map[string]float64
+	tokens        float64
+	lastRefill    time.Time
+}
+
+// ParseClientFilter builds a filter from subscription query parameters.
+// It returns nil when no filter parameters are present.
+func ParseClientFilter(values url.Values) (*ClientFilter, error) {
+	filter := &ClientFilter{
+		lastPower:     make(map[string]float64),
+		lastFrequency: make(map[string]float64),
+	}
+	configured := false
+
+	if types := values.Get("types"); types != "" {
+		filter.Types = make(map[string]bool)
+		for _, eventType := range strings.Split(types, ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				filter.Types[eventType] = true
+			}
+		}
+		if len(filter.Types) == 0 {
+			return nil, fmt.Errorf("%w: types must list at least one event type", ErrInvalidFilter)
+		}
+		configured = true
+	}
+
+	var err error
+	if filter.MinPowerDeltaDb, err = parseNonNegative(values, "minPowerDeltaDb"); err != nil {
+		return nil, err
+	}
+	if filter.MinFrequencyDeltaMhz, err = parseNonNegative(values, "minFrequencyDeltaMhz"); err != nil {
+		return nil, err
+	}
+	if filter.MaxRate, err = parseNonNegative(values, "maxRate"); err != nil {
+		return nil, err
+	}
+	if filter.MinPowerDeltaDb > 0 || filter.MinFrequencyDeltaMhz > 0 || filter.MaxRate > 0 {
+		configured = true
+	}
+
+	if !configured {
+		return nil, nil
+	}
+
+	filter.tokens = math.Max(1, filter.MaxRate)
+	filter.lastRefill = time.Now()
+	return filter, nil
+}
+
+// Allow reports whether an event should be delivered, updating threshold
+// baselines and the rate budget when it is.
+func (f *ClientFilter) Allow(event Event, now time.Time) bool {
+	if f == nil || alwaysDelivered[event.Type] {
+		return true
+	}
+	if !f.AllowsType(event.Type) {
+		return false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	baseline, key, threshold := f.thresholdFor(event)
+	value, hasValue := numericField(event.Data, key)
+	if baseline != nil && hasValue && threshold > 0 {
+		if last, seen := baseline[event.Radio]; seen && math.Abs(value-last) < threshold {
+			return false
+		}
+	}
+
+	if f.MaxRate > 0 {
+		f.refill(now)
+		if f.tokens < 1 {
+			return false
+		}
+		f.tokens--
+	}
+
+	if baseline != nil && hasValue {
+		baseline[event.Radio] = value
+	}
+	return true
+}
+
+// AllowsType reports whether the event type passes the allowlist.
+func (f *ClientFilter) AllowsType(eventType string) bool {
+	if f == nil || len(f.Types) == 0 || alwaysDelivered[eventType] {
+		return true
+	}
+	return f.Types[eventType]
+}
+
+// thresholdFor returns the baseline map, data key and threshold for an event type.
+// Caller must hold f.mu.
+func (f *ClientFilter) thresholdFor(event Event) (map[string]float64, string, float64) {
+	switch event.Type {
+	case "powerChanged":
+		return f.lastPower, "powerDbm", f.MinPowerDeltaDb
+	case "channelChanged":
+		return f.lastFrequency, "frequencyMhz", f.MinFrequencyDeltaMhz
+	default:
+		return nil, "", 0
+	}
+}
+
+// refill adds rate tokens for the time elapsed since the last refill.
+// Caller must hold f.mu.
+func (f *ClientFilter) refill(now time.Time) {
+	elapsed := now.Sub(f.lastRefill).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	f.tokens = math.Min(math.Max(1, f.MaxRate), f.tokens+elapsed*f.MaxRate)
+	f.lastRefill = now
+}
+
+// parseNonNegative parses an optional non-negative float query parameter.
+func parseNonNegative(values url.Values, key string) (float64, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("%w: %s must be a non-negative number", ErrInvalidFilter, key)
+	}
+	return value, nil
+}
+
+// numericField extracts a numeric event data field regardless of its Go number type.
+func numericField(data map[string]interface{}, key string) (float64, bool) {
+	switch v := data[key].(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,135 @@
+package telemetry
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParseClientFilter(t *testing.T) {
+	filter, err := ParseClientFilter(url.Values{})
+	if err != nil || filter != nil {
+		t.Fatalf("Expected nil filter without parameters, got %+v, %v", filter, err)
+	}
+
+	filter, err = ParseClientFilter(url.Values{
+		"types":           {"powerChanged, fault"},
+		"minPowerDeltaDb": {"1.5"},
+		"maxRate":         {"2"},
+	})
+	if err != nil {
+		t.Fatalf("ParseClientFilter failed: %v", err)
+	}
+	if !filter.Types["powerChanged"] || !filter.Types["fault"] || len(filter.Types) != 2 {
+		t.Errorf("Unexpected types: %v", filter.Types)
+	}
+	if filter.MinPowerDeltaDb != 1.5 || filter.MaxRate != 2 {
+		t.Errorf("Unexpected thresholds: %+v", filter)
+	}
+
+	invalid := []url.Values{
+		{"types": {" , "}},
+		{"minPowerDeltaDb": {"-1"}},
+		{"minFrequencyDeltaMhz": {"abc"}},
+		{"maxRate": {"NaN"}},
+	}
+	for _, values := range invalid {
+		if _, err := ParseClientFilter(values); !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("Expected ErrInvalidFilter for %v, got %v", values, err)
+		}
+	}
+}
+
+func TestClientFilterTypes(t *testing.T) {
+	filter, _ := ParseClientFilter(url.Values{"types": {"fault"}})
+	now := time.Now()
+
+	if filter.Allow(Event{Type: "powerChanged", Radio: "radio-01"}, now) {
+		t.Error("Expected powerChanged to be filtered out")
+	}
+	if !filter.Allow(Event{Type: "fault", Radio: "radio-01"}, now) {
+		t.Error("Expected fault to be delivered")
+	}
+	if !filter.Allow(Event{Type: "heartbeat"}, now) {
+		t.Error("Expected heartbeat to bypass the type filter")
+	}
+}
+
+func TestClientFilterThresholds(t *testing.T) {
+	filter, _ := ParseClientFilter(url.Values{"minPowerDeltaDb": {"1"}})
+	now := time.Now()
+
+	power := func(radioID string, dBm float64) Event {
+		return Event{Type: "powerChanged", Radio: radioID, Data: map[string]interface{}{"powerDbm": dBm}}
+	}
+
+	steps := []struct {
+		event Event
+		want  bool
+	}{
+		{power("radio-01", 20), true},    // first value establishes the baseline
+		{power("radio-01", 20.5), false}, // below threshold
+		{power("radio-01", 20.9), false}, // still measured against 20
+		{power("radio-01", 21), true},    // reaches threshold
+		{power("radio-02", 21.2), true},  // baselines are per radio
+		{power("radio-01", 19.5), true},  // decreases count too
+	}
+	for i, step := range steps {
+		if got := filter.Allow(step.event, now); got != step.want {
+			t.Errorf("Step %d: expected %v, got %v", i, step.want, got)
+		}
+	}
+
+	if !filter.Allow(Event{Type: "channelChanged", Radio: "radio-01", Data: map[string]interface{}{"frequencyMhz": 2412.0}}, now) {
+		t.Error("Expected channelChanged to be unaffected by the power threshold")
+	}
+}
+
+func TestClientFilterRateCap(t *testing.T) {
+	filter, _ := ParseClientFilter(url.Values{"maxRate": {"2"}})
+	start := filter.lastRefill
+	event := Event{Type: "fault", Radio: "radio-01"}
+
+	delivered := 0
+	for i := 0; i < 5; i++ {
+		if filter.Allow(event, start) {
+			delivered++
+		}
+	}
+	if delivered != 2 {
+		t.Errorf("Expected burst capped at 2 events, got %d", delivered)
+	}
+
+	if !filter.Allow(Event{Type: "heartbeat"}, start) {
+		t.Error("Expected heartbeat to bypass the rate cap")
+	}
+
+	if !filter.Allow(event, start.Add(500*time.Millisecond)) {
+		t.Error("Expected one token to refill after 500ms at 2/s")
+	}
+	if filter.Allow(event, start.Add(500*time.Millisecond)) {
+		t.Error("Expected rate cap to apply after refill is spent")
+	}
+}
+
+func TestPublishAppliesClientFilter(t *testing.T) {
+	hub, server := startWebSocketHub(t)
+	conn := dialWebSocket(t, server, "types=channelChanged&minFrequencyDeltaMhz=10")
+	readEvent(t, conn) // ready
+	waitForClients(t, hub, 1)
+
+	channel := func(freq float64) Event {
+		return Event{Type: "channelChanged", Data: map[string]interface{}{"frequencyMhz": freq}}
+	}
+	hub.PublishRadio("radio-01", channel(2412))
+	hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 10.0}})
+	hub.PublishRadio("radio-01", channel(2415))
+	hub.PublishRadio("radio-01", channel(2437))
+
+	first := readEvent(t, conn)
+	second := readEvent(t, conn)
+	if first.Data["frequencyMhz"] != 2412.0 || second.Data["frequencyMhz"] != 2437.0 {
+		t.Errorf("Expected 2412 then 2437, got %+v and %+v", first, second)
+	}
+}
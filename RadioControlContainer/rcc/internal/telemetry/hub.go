@@ -12,6 +12,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/radio-control/rcc/internal/config"
 )
 
@@ -23,7 +24,7 @@ type Event struct {
 	Radio string                 `json:"radio,omitempty"`
 }
 
-// Client represents an SSE client connection.
+// Client represents an SSE or WebSocket client connection.
 type Client struct {
 	ID      string
 	Writer  http.ResponseWriter
@@ -34,7 +35,25 @@ type Client struct {
 	Radio   string
 	Events  chan Event
 	once    sync.Once
-	mu      sync.Mutex // Protect Writer access
+	mu      sync.Mutex // Protect Writer/conn access
+
+	// WebSocket transport (nil for SSE clients)
+	conn *websocket.Conn
+
+	// Radios multiplexed over a WebSocket connection. A nil set subscribes to
+	// every radio except those in excluded; a non-nil set, even an empty one,
+	// delivers only the radios it contains.
+	filterMu sync.RWMutex
+	radios   map[string]bool
+	excluded map[string]bool
+
+	// Type, change-threshold and rate filters (nil delivers everything)
+	filter *ClientFilter
+}
+
+// EventRecorder persists radio events beyond the in-memory replay buffer.
+type EventRecorder interface {
+	Record(event Event)
 }
 
 // Hub manages SSE telemetry distribution with per-radio buffering.
@@ -58,6 +77,9 @@ type Hub struct {
 	// Configuration
 	config *config.TimingConfig
 
+	// Optional persistent recorder for radio events
+	recorder EventRecorder
+
 	// Heartbeat ticker
 	heartbeatTicker *time.Ticker
 	stopHeartbeat   chan bool
@@ -90,7 +112,14 @@ func NewHub(timingConfig *config.TimingConfig) *Hub {
 }
 
 // Subscribe handles SSE client subscription with Last-Event-ID resume support.
+// Filter query parameters are validated before any response is written and
+// reported as ErrInvalidFilter; see ClientFilter.
 func (h *Hub) Subscribe(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	filter, err := ParseClientFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
 	// Set SSE headers
 	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
 	w.Header().Set("Cache-Control", "no-cache")
@@ -125,6 +154,7 @@ func (h *Hub) Subscribe(ctx context.Context, w http.ResponseWriter, r *http.Requ
 		LastID:  lastEventID,
 		Radio:   radioID,
 		Events:  make(chan Event, 100), // Buffer for client events
+		filter:  filter,
 	}
 
 	// Register client
@@ -169,6 +199,7 @@ func (h *Hub) Publish(event Event) error {
 	// Buffer the event (needs write lock)
 	if event.Radio != "" {
 		h.bufferEvent(event)
+		h.recordEvent(event)
 	}
 
 	// Send to all clients (needs read lock)
@@ -181,6 +212,9 @@ func (h *Hub) Publish(event Event) error {
 
 	// Send to all clients without holding the lock
 	for _, client := range clients {
+		if !client.accepts(event) {
+			continue
+		}
 		select {
 		case <-client.Context.Done():
 			// Client context cancelled, skip this client - PRIORITY
@@ -197,6 +231,24 @@ func (h *Hub) Publish(event Event) error {
 	return nil
 }
 
+// SetRecorder sets the persistent recorder that receives every radio event.
+func (h *Hub) SetRecorder(recorder EventRecorder) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.recorder = recorder
+}
+
+// recordEvent hands a radio event to the persistent recorder, if any.
+func (h *Hub) recordEvent(event Event) {
+	h.mu.RLock()
+	recorder := h.recorder
+	h.mu.RUnlock()
+
+	if recorder != nil {
+		recorder.Record(event)
+	}
+}
+
 // PublishRadio publishes an event for a specific radio.
 func (h *Hub) PublishRadio(radioID string, event Event) error {
 	event.Radio = radioID
@@ -234,6 +286,9 @@ func (h *Hub) replayEvents(client *Client, lastEventID int64) error {
 
 	// Send replayed events
 	for _, event := range events {
+		if !client.filter.AllowsType(event.Type) {
+			continue
+		}
 		if err := h.sendEventToClient(client, event); err != nil {
 			return err
 		}
@@ -242,12 +297,16 @@ func (h *Hub) replayEvents(client *Client, lastEventID int64) error {
 	return nil
 }
 
-// sendEventToClient sends a single event to a client via SSE.
+// sendEventToClient sends a single event to a client over its transport.
 func (h *Hub) sendEventToClient(client *Client, event Event) error {
 	// Protect Writer access with mutex to prevent race conditions
 	client.mu.Lock()
 	defer client.mu.Unlock()
 
+	if client.conn != nil {
+		return h.writeWebSocketEvent(client, event)
+	}
+
 	// Format as SSE
 	if event.ID > 0 {
 		if _, err := fmt.Fprintf(client.Writer, "id: %d\n", event.ID); err != nil {
@@ -276,6 +335,29 @@ func (h *Hub) sendEventToClient(client *Client, event Event) error {
 	return nil
 }
 
+// accepts reports whether an event passes the client's radio and subscription filters.
+// Events without a radio (heartbeats, global notices) skip the radio filter.
+func (c *Client) accepts(event Event) bool {
+	if event.Radio != "" && !c.acceptsRadio(event.Radio) {
+		return false
+	}
+	return c.filter.Allow(event, time.Now())
+}
+
+// acceptsRadio reports whether the client is subscribed to the radio.
+func (c *Client) acceptsRadio(radioID string) bool {
+	c.filterMu.RLock()
+	defer c.filterMu.RUnlock()
+
+	if c.radios != nil {
+		return c.radios[radioID]
+	}
+	if c.excluded[radioID] {
+		return false
+	}
+	return c.Radio == "" || c.Radio == radioID
+}
+
 // handleClient manages a client connection and event delivery.
 func (h *Hub) handleClient(client *Client) {
 	defer func() {
@@ -0,0 +1,397 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket control actions sent by clients.
+const (
+	WSActionSubscribe   = "subscribe"
+	WSActionUnsubscribe = "unsubscribe"
+	WSActionPing        = "ping"
+)
+
+const (
+	// wsWriteTimeout bounds a single frame write to a slow client
+	wsWriteTimeout = 10 * time.Second
+
+	// wsMaxMessageSize bounds client control messages
+	wsMaxMessageSize = 4096
+)
+
+// upgrader mirrors the SSE endpoint's permissive CORS policy.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// WSControlMessage is a client-to-server control message on the WebSocket transport.
+//
+// Event IDs are counted per radio, so Resume maps each radio to the last event
+// ID the client saw from it. LastEventID is shorthand for a single radio and
+// only applies when Radios names exactly one.
+type WSControlMessage struct {
+	Action      string           `json:"action"`
+	Radios      []string         `json:"radios,omitempty"`
+	Resume      map[string]int64 `json:"resume,omitempty"`
+	LastEventID int64            `json:"lastEventId,omitempty"`
+}
+
+// SubscribeWebSocket upgrades the request and streams telemetry over a WebSocket.
+//
+// Radios are selected with ?radio=a,b and may be changed at runtime with
+// subscribe/unsubscribe control messages, so one socket can multiplex many
+// radios; with no radios selected every radio's events are delivered.
+// Subscribing from the all-radio state narrows to the listed radios, while
+// unsubscribing from it excludes the listed radios and keeps the rest.
+//
+// Resume replays buffered events per radio: pass ?resume=radio-01:12,radio-02:7
+// with the last event ID seen from each radio. Radios in the map are replayed
+// whenever the subscription covers them, including all-radio subscriptions.
+// Last-Event-ID (header or ?lastEventId=) is accepted when exactly one radio
+// is selected, as on the SSE endpoint.
+//
+// Subscription filters (types, minPowerDeltaDb, minFrequencyDeltaMhz, maxRate)
+// are validated before the upgrade; invalid values return ErrInvalidFilter
+// without writing a response.
+func (h *Hub) SubscribeWebSocket(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	filter, err := ParseClientFilter(r.URL.Query())
+	if err != nil {
+		return err
+	}
+	resume, err := parseResumeList(r.URL.Query().Get("resume"))
+	if err != nil {
+		return err
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already written the HTTP error response
+		return fmt.Errorf("failed to upgrade WebSocket: %w", err)
+	}
+	defer conn.Close()
+
+	clientCtx, cancel := context.WithCancel(ctx)
+
+	lastEventID := parseLastEventID(r)
+	radios := parseRadioList(r.URL.Query().Get("radio"))
+
+	client := &Client{
+		ID:      fmt.Sprintf("ws_%d", time.Now().UnixNano()),
+		Request: r,
+		Context: clientCtx,
+		Cancel:  cancel,
+		LastID:  lastEventID,
+		Events:  make(chan Event, 100),
+		conn:    conn,
+		filter:  filter,
+	}
+	if len(radios) > 0 {
+		client.radios = make(map[string]bool, len(radios))
+		for _, radioID := range radios {
+			client.radios[radioID] = true
+		}
+	}
+	resume = withLastEventID(resume, radios, lastEventID)
+
+	// Register client
+	h.mu.Lock()
+	h.clients[client.ID] = client
+	h.mu.Unlock()
+
+	if err := h.sendReadyEvent(client); err != nil {
+		h.unregisterClient(client.ID)
+		return fmt.Errorf("failed to send ready event: %w", err)
+	}
+
+	if err := h.replayResume(client, resume); err != nil {
+		h.unregisterClient(client.ID)
+		return fmt.Errorf("failed to replay events: %w", err)
+	}
+
+	// Start heartbeat if this is the first client
+	h.mu.Lock()
+	if len(h.clients) == 1 && h.heartbeatTicker == nil {
+		h.startHeartbeat()
+	}
+	h.mu.Unlock()
+
+	go h.readWebSocket(client)
+	go h.pingWebSocket(client)
+
+	// Handle client events (blocks until client disconnects)
+	h.handleClient(client)
+
+	return nil
+}
+
+// readWebSocket processes control messages until the client disconnects.
+func (h *Hub) readWebSocket(client *Client) {
+	defer client.Cancel()
+
+	conn := client.conn
+	conn.SetReadLimit(wsMaxMessageSize)
+	h.extendReadDeadline(conn)
+	conn.SetPongHandler(func(string) error {
+		h.extendReadDeadline(conn)
+		return nil
+	})
+
+	for {
+		_, payload, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		h.extendReadDeadline(conn)
+
+		var msg WSControlMessage
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			// Malformed control message: report and keep the stream open
+			h.sendControlReply(client, "error", map[string]interface{}{
+				"code":    "BAD_REQUEST",
+				"message": "Malformed control message",
+			})
+			continue
+		}
+		h.handleControlMessage(client, msg)
+	}
+}
+
+// handleControlMessage applies a client control message.
+func (h *Hub) handleControlMessage(client *Client, msg WSControlMessage) {
+	switch msg.Action {
+	case WSActionSubscribe:
+		client.filterMu.Lock()
+		if len(msg.Radios) > 0 && client.radios == nil {
+			client.radios = make(map[string]bool, len(msg.Radios))
+			client.excluded = nil
+		}
+		for _, radioID := range msg.Radios {
+			client.radios[radioID] = true
+		}
+		client.filterMu.Unlock()
+
+		if err := h.replayResume(client, withLastEventID(msg.Resume, msg.Radios, msg.LastEventID)); err != nil {
+			client.Cancel()
+			return
+		}
+		h.sendSubscriptionAck(client, msg.Action)
+
+	case WSActionUnsubscribe:
+		client.filterMu.Lock()
+		for _, radioID := range msg.Radios {
+			if client.radios != nil {
+				delete(client.radios, radioID)
+				continue
+			}
+			if client.excluded == nil {
+				client.excluded = make(map[string]bool)
+			}
+			client.excluded[radioID] = true
+		}
+		client.filterMu.Unlock()
+		h.sendSubscriptionAck(client, msg.Action)
+
+	case WSActionPing:
+		h.sendControlReply(client, "pong", map[string]interface{}{"ts": time.Now().UTC().Format(time.RFC3339)})
+
+	default:
+		h.sendControlReply(client, "error", map[string]interface{}{
+			"code":    "BAD_REQUEST",
+			"message": fmt.Sprintf("Unknown action %q", msg.Action),
+		})
+	}
+}
+
+// pingWebSocket sends protocol pings at the heartbeat interval so dead peers are detected.
+func (h *Hub) pingWebSocket(client *Client) {
+	ticker := time.NewTicker(h.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			deadline := time.Now().Add(wsWriteTimeout)
+			if err := client.conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				client.Cancel()
+				return
+			}
+		case <-client.Context.Done():
+			return
+		case <-h.done:
+			return
+		}
+	}
+}
+
+// writeWebSocketEvent writes one event as a JSON text frame.
+// Caller must hold client.mu.
+func (h *Hub) writeWebSocketEvent(client *Client, event Event) error {
+	if err := client.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return fmt.Errorf("failed to set write deadline: %w", err)
+	}
+	if err := client.conn.WriteJSON(event); err != nil {
+		return fmt.Errorf("failed to write WebSocket event: %w", err)
+	}
+	return nil
+}
+
+// sendControlReply sends an unnumbered reply to a control message.
+func (h *Hub) sendControlReply(client *Client, eventType string, data map[string]interface{}) {
+	if err := h.sendEventToClient(client, Event{Type: eventType, Data: data}); err != nil {
+		client.Cancel()
+	}
+}
+
+// sendSubscriptionAck acknowledges a subscription change with the resulting radio set.
+func (h *Hub) sendSubscriptionAck(client *Client, action string) {
+	radios, all, excluded := client.subscription()
+	data := map[string]interface{}{"action": action, "radios": radios, "allRadios": all}
+	if all {
+		data["excluded"] = excluded
+	}
+	h.sendControlReply(client, "ack", data)
+}
+
+// replayResume replays buffered events for every radio in the resume map that
+// the client is subscribed to.
+func (h *Hub) replayResume(client *Client, resume map[string]int64) error {
+	radioIDs := make([]string, 0, len(resume))
+	for radioID := range resume {
+		radioIDs = append(radioIDs, radioID)
+	}
+	sort.Strings(radioIDs)
+
+	for _, radioID := range radioIDs {
+		if !client.acceptsRadio(radioID) {
+			continue
+		}
+		if err := h.replayRadioEvents(client, radioID, resume[radioID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replayRadioEvents replays one radio's buffered events after lastEventID.
+func (h *Hub) replayRadioEvents(client *Client, radioID string, lastEventID int64) error {
+	h.mu.RLock()
+	buffer, exists := h.buffers[radioID]
+	h.mu.RUnlock()
+
+	if !exists {
+		return nil
+	}
+
+	for _, event := range buffer.GetEventsAfter(lastEventID) {
+		if !client.filter.AllowsType(event.Type) {
+			continue
+		}
+		if err := h.sendEventToClient(client, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extendReadDeadline allows the peer one heartbeat timeout between frames.
+func (h *Hub) extendReadDeadline(conn *websocket.Conn) {
+	_ = conn.SetReadDeadline(time.Now().Add(h.config.HeartbeatTimeout))
+}
+
+// subscription returns the radios currently multiplexed on the client, whether
+// it follows every radio, and the radios excluded from that.
+func (c *Client) subscription() (radios []string, all bool, excluded []string) {
+	c.filterMu.RLock()
+	defer c.filterMu.RUnlock()
+
+	radios = sortedKeys(c.radios)
+	excluded = sortedKeys(c.excluded)
+	return radios, c.radios == nil, excluded
+}
+
+// sortedKeys returns a set's members in order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// withLastEventID adds a single-radio Last-Event-ID to the resume map. The ID
+// is ignored unless exactly one radio is named, since IDs are per radio.
+func withLastEventID(resume map[string]int64, radios []string, lastEventID int64) map[string]int64 {
+	if lastEventID <= 0 || len(radios) != 1 {
+		return resume
+	}
+	if _, exists := resume[radios[0]]; exists {
+		return resume
+	}
+
+	merged := make(map[string]int64, len(resume)+1)
+	for radioID, id := range resume {
+		merged[radioID] = id
+	}
+	merged[radios[0]] = lastEventID
+	return merged
+}
+
+// parseResumeList parses a per-radio resume list of radio:lastEventId pairs.
+func parseResumeList(value string) (map[string]int64, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	resume := make(map[string]int64)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		sep := strings.LastIndex(pair, ":")
+		if sep <= 0 {
+			return nil, fmt.Errorf("%w: resume entry %q must be radio:lastEventId", ErrInvalidFilter, pair)
+		}
+		id, err := strconv.ParseInt(pair[sep+1:], 10, 64)
+		if err != nil || id < 0 {
+			return nil, fmt.Errorf("%w: resume entry %q has an invalid event ID", ErrInvalidFilter, pair)
+		}
+		resume[pair[:sep]] = id
+	}
+	return resume, nil
+}
+
+// parseLastEventID reads the resume point from the header or query string.
+func parseLastEventID(r *http.Request) int64 {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("lastEventId")
+	}
+	if id, err := strconv.ParseInt(value, 10, 64); err == nil && id > 0 {
+		return id
+	}
+	return 0
+}
+
+// parseRadioList splits a comma-separated radio filter.
+func parseRadioList(value string) []string {
+	var radios []string
+	for _, radioID := range strings.Split(value, ",") {
+		if radioID = strings.TrimSpace(radioID); radioID != "" {
+			radios = append(radios, radioID)
+		}
+	}
+	return radios
+}
@@ -0,0 +1,285 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/radio-control/rcc/internal/config"
+)
+
+// startWebSocketHub serves hub.SubscribeWebSocket on a test server.
+func startWebSocketHub(t *testing.T) (*Hub, *httptest.Server) {
+	t.Helper()
+
+	hub := NewHub(config.LoadCBTimingBaseline())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = hub.SubscribeWebSocket(r.Context(), w, r)
+	}))
+	t.Cleanup(func() {
+		hub.Stop()
+		server.Close()
+	})
+
+	return hub, server
+}
+
+// dialWebSocket connects to the test server with the given query string.
+func dialWebSocket(t *testing.T, server *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/?" + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readEvent reads the next event frame with a timeout.
+func readEvent(t *testing.T, conn *websocket.Conn) Event {
+	t.Helper()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var event Event
+	if err := conn.ReadJSON(&event); err != nil {
+		t.Fatalf("ReadJSON failed: %v", err)
+	}
+	return event
+}
+
+// waitForClients waits until the hub has registered n clients.
+func waitForClients(t *testing.T, hub *Hub, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		hub.mu.RLock()
+		count := len(hub.clients)
+		hub.mu.RUnlock()
+		if count >= n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for %d clients", n)
+}
+
+func TestWebSocketReceivesFilteredEvents(t *testing.T) {
+	hub, server := startWebSocketHub(t)
+	conn := dialWebSocket(t, server, "radio=radio-01")
+
+	if event := readEvent(t, conn); event.Type != "ready" {
+		t.Fatalf("Expected ready event, got %s", event.Type)
+	}
+	waitForClients(t, hub, 1)
+
+	hub.PublishRadio("radio-02", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 10.0}})
+	hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": 20.0}})
+
+	event := readEvent(t, conn)
+	if event.Radio != "radio-01" || event.Data["powerDbm"] != 20.0 {
+		t.Errorf("Expected radio-01 event only, got %+v", event)
+	}
+}
+
+func TestWebSocketSubscribeMultiplexesRadios(t *testing.T) {
+	hub, server := startWebSocketHub(t)
+	conn := dialWebSocket(t, server, "radio=radio-01")
+	readEvent(t, conn) // ready
+	waitForClients(t, hub, 1)
+
+	if err := conn.WriteJSON(WSControlMessage{Action: WSActionSubscribe, Radios: []string{"radio-02"}}); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	if ack := readEvent(t, conn); ack.Type != "ack" {
+		t.Fatalf("Expected ack, got %+v", ack)
+	}
+
+	hub.PublishRadio("radio-02", Event{Type: "channelChanged", Data: map[string]interface{}{"frequencyMhz": 2437.0}})
+	if event := readEvent(t, conn); event.Radio != "radio-02" {
+		t.Errorf("Expected radio-02 event after subscribe, got %+v", event)
+	}
+
+	if err := conn.WriteJSON(WSControlMessage{Action: WSActionUnsubscribe, Radios: []string{"radio-02"}}); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	readEvent(t, conn) // ack
+
+	hub.PublishRadio("radio-02", Event{Type: "channelChanged", Data: map[string]interface{}{"frequencyMhz": 2412.0}})
+	hub.PublishRadio("radio-01", Event{Type: "fault", Data: map[string]interface{}{"code": "BUSY"}})
+	if event := readEvent(t, conn); event.Radio != "radio-01" {
+		t.Errorf("Expected radio-02 events to stop after unsubscribe, got %+v", event)
+	}
+}
+
+func TestWebSocketResumeReplaysBufferedEvents(t *testing.T) {
+	hub, server := startWebSocketHub(t)
+
+	for i := 1; i <= 5; i++ {
+		hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": float64(i)}})
+	}
+
+	conn := dialWebSocket(t, server, "radio=radio-01&lastEventId=3")
+	readEvent(t, conn) // ready
+
+	first := readEvent(t, conn)
+	second := readEvent(t, conn)
+	if first.ID != 4 || second.ID != 5 {
+		t.Errorf("Expected replay of events 4 and 5, got %d and %d", first.ID, second.ID)
+	}
+}
+
+func TestWebSocketUnsubscribeAllRadiosStopsRadioEvents(t *testing.T) {
+	hub, server := startWebSocketHub(t)
+	conn := dialWebSocket(t, server, "radio=radio-01")
+	readEvent(t, conn) // ready
+	waitForClients(t, hub, 1)
+
+	if err := conn.WriteJSON(WSControlMessage{Action: WSActionUnsubscribe, Radios: []string{"radio-01"}}); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	ack := readEvent(t, conn)
+	if ack.Type != "ack" || ack.Data["allRadios"] != false {
+		t.Fatalf("Expected ack for an empty explicit subscription, got %+v", ack)
+	}
+
+	hub.PublishRadio("radio-01", Event{Type: "fault", Data: map[string]interface{}{"code": "BUSY"}})
+	hub.PublishRadio("radio-02", Event{Type: "fault", Data: map[string]interface{}{"code": "BUSY"}})
+	if err := conn.WriteJSON(WSControlMessage{Action: WSActionPing}); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	if event := readEvent(t, conn); event.Type != "pong" {
+		t.Errorf("Expected no radio events after unsubscribing every radio, got %+v", event)
+	}
+}
+
+func TestWebSocketUnsubscribeFromAllRadiosExcludes(t *testing.T) {
+	hub, server := startWebSocketHub(t)
+	conn := dialWebSocket(t, server, "")
+	readEvent(t, conn) // ready
+	waitForClients(t, hub, 1)
+
+	if err := conn.WriteJSON(WSControlMessage{Action: WSActionUnsubscribe, Radios: []string{"radio-02"}}); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	readEvent(t, conn) // ack
+
+	hub.PublishRadio("radio-02", Event{Type: "fault", Data: map[string]interface{}{"code": "BUSY"}})
+	hub.PublishRadio("radio-03", Event{Type: "fault", Data: map[string]interface{}{"code": "BUSY"}})
+	if event := readEvent(t, conn); event.Radio != "radio-03" {
+		t.Errorf("Expected only radio-03 after excluding radio-02, got %+v", event)
+	}
+}
+
+func TestWebSocketResumePerRadio(t *testing.T) {
+	hub, server := startWebSocketHub(t)
+
+	for i := 1; i <= 3; i++ {
+		hub.PublishRadio("radio-01", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": float64(i)}})
+	}
+	for i := 1; i <= 5; i++ {
+		hub.PublishRadio("radio-02", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": float64(i)}})
+	}
+
+	// All-radio subscription resuming each radio from its own last event
+	conn := dialWebSocket(t, server, "resume=radio-01:2,radio-02:4")
+	readEvent(t, conn) // ready
+
+	got := map[string][]int64{}
+	for i := 0; i < 2; i++ {
+		event := readEvent(t, conn)
+		got[event.Radio] = append(got[event.Radio], event.ID)
+	}
+	if len(got["radio-01"]) != 1 || got["radio-01"][0] != 3 {
+		t.Errorf("Expected radio-01 replay of event 3, got %v", got["radio-01"])
+	}
+	if len(got["radio-02"]) != 1 || got["radio-02"][0] != 5 {
+		t.Errorf("Expected radio-02 replay of event 5, got %v", got["radio-02"])
+	}
+}
+
+func TestWebSocketSubscribeResumesPerRadio(t *testing.T) {
+	hub, server := startWebSocketHub(t)
+	conn := dialWebSocket(t, server, "radio=radio-01")
+	readEvent(t, conn) // ready
+	waitForClients(t, hub, 1)
+
+	for i := 1; i <= 4; i++ {
+		hub.PublishRadio("radio-02", Event{Type: "powerChanged", Data: map[string]interface{}{"powerDbm": float64(i)}})
+	}
+
+	msg := WSControlMessage{Action: WSActionSubscribe, Radios: []string{"radio-02"}, Resume: map[string]int64{"radio-02": 3}}
+	if err := conn.WriteJSON(msg); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	if event := readEvent(t, conn); event.Radio != "radio-02" || event.ID != 4 {
+		t.Errorf("Expected replay of radio-02 event 4, got %+v", event)
+	}
+	if ack := readEvent(t, conn); ack.Type != "ack" {
+		t.Errorf("Expected ack after replay, got %+v", ack)
+	}
+}
+
+func TestWebSocketRejectsMalformedResume(t *testing.T) {
+	hub := NewHub(config.LoadCBTimingBaseline())
+	defer hub.Stop()
+
+	req := httptest.NewRequest(http.MethodGet, "/?resume=radio-01", nil)
+	err := hub.SubscribeWebSocket(context.Background(), httptest.NewRecorder(), req)
+	if !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter, got %v", err)
+	}
+}
+
+func TestWebSocketControlErrors(t *testing.T) {
+	hub, server := startWebSocketHub(t)
+	conn := dialWebSocket(t, server, "")
+	readEvent(t, conn) // ready
+	waitForClients(t, hub, 1)
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte("not json")); err != nil {
+		t.Fatalf("WriteMessage failed: %v", err)
+	}
+	if event := readEvent(t, conn); event.Type != "error" {
+		t.Errorf("Expected error reply for malformed message, got %+v", event)
+	}
+
+	if err := conn.WriteJSON(WSControlMessage{Action: WSActionPing}); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	if event := readEvent(t, conn); event.Type != "pong" {
+		t.Errorf("Expected pong reply, got %+v", event)
+	}
+}
+
+func TestWebSocketDisconnectUnregistersClient(t *testing.T) {
+	hub, server := startWebSocketHub(t)
+	conn := dialWebSocket(t, server, "")
+	readEvent(t, conn) // ready
+	waitForClients(t, hub, 1)
+
+	conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for {
+		hub.mu.RLock()
+		count := len(hub.clients)
+		hub.mu.RUnlock()
+		if count == 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal("Client was not unregistered after disconnect")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -63,7 +63,7 @@ func TestManifestAware_E2EExecution(t *testing.T) {
 			w.Write([]byte(`{"result":"ok","data":{"status":"healthy","timestamp":"2025-10-03T11:45:00Z"}}`))
 		case "/api/v1/capabilities":
 			w.WriteHeader(200)
-			w.Write([]byte(`{"result":"ok","data":{"telemetry":["sse"],"commands":["http-json"],"version":"1.0.0"}}`))
+			w.Write([]byte(`{"result":"ok","data":{"telemetry":["sse","websocket"],"commands":["http-json"],"version":"1.0.0"}}`))
 		case "/api/v1/radios":
 			w.WriteHeader(200)
 			w.Write([]byte(`{"result":"ok","data":[{"id":"silvus-001","name":"Silvus Radio 1"}]}`))
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditEntry represents a structured audit log entry
+type AuditEntry struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Actor         string    `json:"actor"`
+	RadioID       string    `json:"radioId"`
+	Action        string    `json:"action"`
+	Result        string    `json:"result"`
+	LatencyMS     int64     `json:"latencyMs"`
+	CorrelationID string    `json:"correlationId"`
+}
+
+// rotatingFile is an append-only file that is rotated to numbered backups
+// (audit.log.1, audit.log.2, ...) once it grows past maxBytes.
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxBytes   int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+var auditLog *rotatingFile
+
+func openRotatingFile(path string, maxBytes int64, maxBackups int) (*rotatingFile, error) {
+	rf := &rotatingFile{path: path, maxBytes: maxBytes, maxBackups: maxBackups}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+func (rf *rotatingFile) open() error {
+	file, err := os.OpenFile(rf.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", rf.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat %s: %w", rf.path, err)
+	}
+	rf.file = file
+	rf.size = info.Size()
+	return nil
+}
+
+// Write appends p, rotating first if it would take the file past maxBytes
+func (rf *rotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.maxBytes > 0 && rf.size > 0 && rf.size+int64(len(p)) > rf.maxBytes {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// rotate shifts existing backups up by one, dropping the oldest, and starts a new file
+func (rf *rotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", rf.path, err)
+	}
+
+	if rf.maxBackups > 0 {
+		os.Remove(fmt.Sprintf("%s.%d", rf.path, rf.maxBackups))
+		for i := rf.maxBackups - 1; i >= 1; i-- {
+			os.Rename(fmt.Sprintf("%s.%d", rf.path, i), fmt.Sprintf("%s.%d", rf.path, i+1))
+		}
+		if err := os.Rename(rf.path, rf.path+".1"); err != nil {
+			return fmt.Errorf("failed to rotate %s: %w", rf.path, err)
+		}
+	} else if err := os.Remove(rf.path); err != nil {
+		return fmt.Errorf("failed to truncate %s: %w", rf.path, err)
+	}
+
+	return rf.open()
+}
+
+func (rf *rotatingFile) Close() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	return rf.file.Close()
+}
+
+func logAudit(entry AuditEntry) {
+	// Log to console
+	log.Printf("AUDIT: %+v", entry)
+
+	if auditLog == nil {
+		return
+	}
+
+	jsonData, _ := json.Marshal(entry)
+	if _, err := auditLog.Write(append(jsonData, '\n')); err != nil {
+		log.Printf("Failed to write audit log: %v", err)
+	}
+}
+
+// handleAudit persists audit entries posted by the browser. The actor is
+// always taken from the session so the client cannot log as someone else.
+func handleAudit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sess := sessions.FromRequest(r)
+	if sess == nil {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	var entry AuditEntry
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16<<10)).Decode(&entry); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	entry.Actor = sess.Username
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now().UTC()
+	}
+	logAudit(entry)
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func TestRotatingFileKeepsBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	rf, err := openRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("openRotatingFile: %v", err)
+	}
+	defer rf.Close()
+
+	for _, line := range []string{"one\n", "two\n", "three\n", "four\n", "five\n"} {
+		if _, err := rf.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	// A line that would take the file past 10 bytes starts a new one
+	want := map[string]string{
+		path:        "four\nfive",
+		path + ".1": "three",
+		path + ".2": "one\ntwo",
+	}
+	for file, content := range want {
+		if got := strings.Join(readLines(t, file), "\n"); got != content {
+			t.Errorf("%s = %q, want %q", filepath.Base(file), got, content)
+		}
+	}
+
+	if _, err := rf.Write([]byte("six\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := rf.Write([]byte("seventh\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	// Two more rotations push "one two" and then "three" out of the backups
+	if got := strings.Join(readLines(t, path+".2"), "\n"); got != "four\nfive" {
+		t.Errorf("oldest backup = %q after two more rotations, want %q", got, "four\nfive")
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("rotation kept more than maxBackups files")
+	}
+}
+
+func TestRotatingFileWithoutBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	rf, err := openRotatingFile(path, 8, 0)
+	if err != nil {
+		t.Fatalf("openRotatingFile: %v", err)
+	}
+	defer rf.Close()
+
+	for _, line := range []string{"first\n", "second\n"} {
+		if _, err := rf.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if got := readLines(t, path); len(got) != 1 || got[0] != "second" {
+		t.Errorf("audit.log = %q, want only the latest line", got)
+	}
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Errorf("maxBackups 0 created a backup")
+	}
+}
+
+func TestRotatingFileResumesSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	if err := os.WriteFile(path, []byte("existing\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	rf, err := openRotatingFile(path, 12, 1)
+	if err != nil {
+		t.Fatalf("openRotatingFile: %v", err)
+	}
+	defer rf.Close()
+
+	if _, err := rf.Write([]byte("new\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := readLines(t, path+".1"); len(got) != 1 || got[0] != "existing" {
+		t.Errorf("existing content was not rotated out: %q", got)
+	}
+}
+
+func TestHandleAuditUsesSessionActor(t *testing.T) {
+	setupAuth(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	rf, err := openRotatingFile(path, 1<<20, 1)
+	if err != nil {
+		t.Fatalf("openRotatingFile: %v", err)
+	}
+	defer rf.Close()
+	auditLog = rf
+
+	unauth := httptest.NewRecorder()
+	handleAudit(unauth, httptest.NewRequest("POST", "/audit", strings.NewReader(`{}`)))
+	if unauth.Code != http.StatusUnauthorized {
+		t.Errorf("audit without session status = %d, want 401", unauth.Code)
+	}
+
+	login := httptest.NewRecorder()
+	if err := sessions.Create(login, &Session{Username: "alice", Role: "controller"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/audit", strings.NewReader(`{"actor":"mallory","action":"setPower","radioId":"r1","result":"ok"}`))
+	for _, c := range login.Result().Cookies() {
+		req.AddCookie(c)
+	}
+	rec := httptest.NewRecorder()
+	handleAudit(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("audit status = %d", rec.Code)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		t.Fatal("audit log is empty")
+	}
+	var entry AuditEntry
+	if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+		t.Fatalf("unmarshal entry: %v", err)
+	}
+	if entry.Actor != "alice" || entry.Action != "setPower" || entry.RadioID != "r1" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if time.Since(entry.Timestamp) > time.Minute {
+		t.Errorf("entry timestamp %v was not filled in", entry.Timestamp)
+	}
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Minted tokens are replaced this long before they expire
+	tokenRefreshMargin = 30 * time.Second
+
+	passwordHashScheme     = "pbkdf2-sha256"
+	passwordHashIterations = 600000
+)
+
+// roleScopes maps UI roles to the RCC scopes minted into their tokens
+// (OpenAPI v1 §1.2: controller is a superset of viewer)
+var roleScopes = map[string][]string{
+	"viewer":     {"read", "telemetry"},
+	"controller": {"read", "control", "telemetry"},
+}
+
+var tokenSecret []byte
+
+// loadTokenSecret reads the HS256 secret shared with RCC. It is only
+// required when local users are configured.
+func loadTokenSecret() error {
+	if len(config.Auth.Users) == 0 {
+		return nil
+	}
+
+	secret := envOrEmpty(config.Auth.TokenSecretEnv)
+	if len(secret) < 32 {
+		return fmt.Errorf("%s must hold at least 32 bytes to mint RCC tokens for local users", config.Auth.TokenSecretEnv)
+	}
+	tokenSecret = []byte(secret)
+
+	for _, user := range config.Auth.Users {
+		if _, ok := roleScopes[user.Role]; !ok {
+			return fmt.Errorf("user %q has unknown role %q", user.Username, user.Role)
+		}
+	}
+	return nil
+}
+
+// mintToken issues an HS256 RCC token carrying the sub/roles/scopes claims
+// that RCC's auth.Verifier expects.
+func mintToken(username, role string) (string, time.Time, error) {
+	scopes, ok := roleScopes[role]
+	if !ok {
+		return "", time.Time{}, fmt.Errorf("unknown role %q", role)
+	}
+
+	now := time.Now()
+	expiry := now.Add(time.Duration(config.Auth.TokenTTLSec) * time.Second)
+	header, _ := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	claims, err := json.Marshal(map[string]interface{}{
+		"sub":    username,
+		"roles":  []string{role},
+		"scopes": scopes,
+		"iat":    now.Unix(),
+		"exp":    expiry.Unix(),
+		"iss":    "rcc-webui",
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	mac := hmac.New(sha256.New, tokenSecret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), expiry, nil
+}
+
+// hashPassword returns a "pbkdf2-sha256$iterations$salt$hash" string for config.json
+func hashPassword(password string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := pbkdf2.Key(sha256.New, password, salt, passwordHashIterations, 32)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s$%d$%s$%s", passwordHashScheme, passwordHashIterations,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func checkPassword(encoded, password string) bool {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 4 || parts[0] != passwordHashScheme {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+	salt, err1 := base64.RawStdEncoding.DecodeString(parts[2])
+	want, err2 := base64.RawStdEncoding.DecodeString(parts[3])
+	if err1 != nil || err2 != nil || len(want) == 0 {
+		return false
+	}
+
+	got, err := pbkdf2.Key(sha256.New, password, salt, iterations, len(want))
+	return err == nil && subtle.ConstantTimeCompare(got, want) == 1
+}
+
+// authenticateUser checks a local username/password and mints the session's first token
+func authenticateUser(username, password string) (*Session, error) {
+	for _, user := range config.Auth.Users {
+		if user.Username != username {
+			continue
+		}
+		if !checkPassword(user.PasswordHash, password) {
+			break
+		}
+
+		token, expiry, err := mintToken(user.Username, user.Role)
+		if err != nil {
+			return nil, err
+		}
+		return &Session{Username: user.Username, Role: user.Role, token: token, tokenExpiry: expiry, minted: true}, nil
+	}
+	return nil, nil
+}
+
+// authenticateToken accepts an RCC token issued elsewhere (e.g. by the
+// identity provider RCC trusts) and checks it against RCC before storing it.
+// Claims are only read for display; RCC remains the verifier.
+func authenticateToken(ctx context.Context, token string) (*Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(config.Timing.CmdTimeoutsSec.GetState)*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", config.RCCBaseURL+"/radios", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := rccClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach RCC: %w", err)
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, nil
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		// Only a successful RCC call proves the token; anything else is not a verdict
+		return nil, fmt.Errorf("RCC token check returned HTTP %d", resp.StatusCode)
+	}
+
+	sess := &Session{Username: "token", Role: "viewer", token: token}
+	if parts := strings.Split(token, "."); len(parts) == 3 {
+		var claims struct {
+			Sub   string   `json:"sub"`
+			Roles []string `json:"roles"`
+		}
+		if payload, err := base64.RawURLEncoding.DecodeString(parts[1]); err == nil && json.Unmarshal(payload, &claims) == nil {
+			if claims.Sub != "" {
+				sess.Username = claims.Sub
+			}
+			for _, role := range claims.Roles {
+				if role == "controller" {
+					sess.Role = role
+				}
+			}
+		}
+	}
+	return sess, nil
+}
+
+func handleLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Token    string `json:"token"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16<<10)).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Invalid JSON")
+		return
+	}
+
+	var sess *Session
+	var err error
+	actor := body.Username
+	switch {
+	case body.Token != "" && config.Auth.AllowTokenLogin:
+		actor = "token"
+		sess, err = authenticateToken(r.Context(), body.Token)
+	case body.Username != "" && body.Password != "":
+		sess, err = authenticateUser(body.Username, body.Password)
+	default:
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Username and password required")
+		return
+	}
+
+	if err != nil {
+		log.Printf("Login failed: %v", err)
+		writeError(w, http.StatusBadGateway, "UNAVAILABLE", "Login is unavailable")
+		return
+	}
+	if sess == nil {
+		logAudit(AuditEntry{Timestamp: time.Now().UTC(), Actor: actor, Action: "login", Result: "denied"})
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid credentials")
+		return
+	}
+
+	if err := sessions.Create(w, sess); err != nil {
+		writeError(w, http.StatusInternalServerError, "INTERNAL", "Failed to create session")
+		return
+	}
+	logAudit(AuditEntry{Timestamp: time.Now().UTC(), Actor: sess.Username, Action: "login", Result: "success"})
+	writeSession(w, sess)
+}
+
+func handleLogout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if sess := sessions.Destroy(w, r); sess != nil {
+		logAudit(AuditEntry{Timestamp: time.Now().UTC(), Actor: sess.Username, Action: "logout", Result: "success"})
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleSession(w http.ResponseWriter, r *http.Request) {
+	sess := sessions.FromRequest(r)
+	if sess == nil {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+	writeSession(w, sess)
+}
+
+func writeSession(w http.ResponseWriter, sess *Session) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"result": "ok",
+		"data": map[string]interface{}{
+			"username":  sess.Username,
+			"role":      sess.Role,
+			"expiresAt": sess.ExpiresAt.UTC(),
+		},
+	})
+}
+
+// writeError writes an error in the RCC response envelope so app.js handles both alike
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"result":  "error",
+		"code":    code,
+		"message": message,
+	})
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setupAuth installs a test config, token secret and session store, restoring
+// the package globals when the test ends.
+func setupAuth(t *testing.T, users ...UserConfig) {
+	t.Helper()
+	prevConfig, prevSecret, prevSessions, prevAudit := config, tokenSecret, sessions, auditLog
+	t.Cleanup(func() {
+		config, tokenSecret, sessions, auditLog = prevConfig, prevSecret, prevSessions, prevAudit
+	})
+
+	config = Config{}
+	config.Timing.CmdTimeoutsSec.GetState = 2
+	config.Auth = AuthConfig{TokenTTLSec: 600, SessionTTLSec: 3600, CookieName: "rcc_session", Users: users}
+	tokenSecret = []byte(strings.Repeat("s", 32))
+	sessions = NewSessionStore(time.Hour, "rcc_session", false)
+	auditLog = nil
+}
+
+func postLogin(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/auth/login", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handleLogin(rec, req)
+	return rec
+}
+
+func TestPasswordHashRoundTrip(t *testing.T) {
+	encoded, err := hashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("hashPassword: %v", err)
+	}
+	if !strings.HasPrefix(encoded, passwordHashScheme+"$") {
+		t.Errorf("hash %q does not use %s", encoded, passwordHashScheme)
+	}
+	if !checkPassword(encoded, "correct horse") {
+		t.Error("checkPassword rejected the right password")
+	}
+	if checkPassword(encoded, "battery staple") {
+		t.Error("checkPassword accepted a wrong password")
+	}
+	for _, bad := range []string{"", "plain", "md5$1$abc$def", passwordHashScheme + "$0$AAAA$AAAA"} {
+		if checkPassword(bad, "correct horse") {
+			t.Errorf("checkPassword accepted malformed hash %q", bad)
+		}
+	}
+}
+
+func TestMintToken(t *testing.T) {
+	setupAuth(t)
+
+	token, expiry, err := mintToken("alice", "controller")
+	if err != nil {
+		t.Fatalf("mintToken: %v", err)
+	}
+	if d := time.Until(expiry); d < 590*time.Second || d > 600*time.Second {
+		t.Errorf("expiry %v from now, want about 600s", d)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token has %d parts", len(parts))
+	}
+	mac := hmac.New(sha256.New, tokenSecret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if parts[2] != base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) {
+		t.Error("token signature does not verify with the shared secret")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("decode claims: %v", err)
+	}
+	var claims struct {
+		Sub    string   `json:"sub"`
+		Roles  []string `json:"roles"`
+		Scopes []string `json:"scopes"`
+		Exp    int64    `json:"exp"`
+		Iss    string   `json:"iss"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("unmarshal claims: %v", err)
+	}
+	if claims.Sub != "alice" || claims.Iss != "rcc-webui" || claims.Exp != expiry.Unix() {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+	if len(claims.Roles) != 1 || claims.Roles[0] != "controller" {
+		t.Errorf("roles = %v, want [controller]", claims.Roles)
+	}
+	if strings.Join(claims.Scopes, ",") != "read,control,telemetry" {
+		t.Errorf("scopes = %v, want controller scopes", claims.Scopes)
+	}
+
+	if _, _, err := mintToken("alice", "admin"); err == nil {
+		t.Error("mintToken accepted an unknown role")
+	}
+}
+
+func TestLoginLocalUser(t *testing.T) {
+	hash, err := hashPassword("secret")
+	if err != nil {
+		t.Fatalf("hashPassword: %v", err)
+	}
+	setupAuth(t, UserConfig{Username: "alice", PasswordHash: hash, Role: "viewer"})
+
+	rec := postLogin(t, `{"username":"alice","password":"secret"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("login status = %d, body %s", rec.Code, rec.Body.String())
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != "rcc_session" || !cookies[0].HttpOnly {
+		t.Fatalf("unexpected cookies %+v", cookies)
+	}
+
+	req := httptest.NewRequest("GET", "/auth/session", nil)
+	req.AddCookie(cookies[0])
+	sess := sessions.FromRequest(req)
+	if sess == nil || sess.Username != "alice" || sess.Role != "viewer" || !sess.minted {
+		t.Fatalf("unexpected session %+v", sess)
+	}
+
+	for _, body := range []string{
+		`{"username":"alice","password":"wrong"}`,
+		`{"username":"bob","password":"secret"}`,
+	} {
+		if rec := postLogin(t, body); rec.Code != http.StatusUnauthorized {
+			t.Errorf("login %s status = %d, want 401", body, rec.Code)
+		}
+	}
+	if rec := postLogin(t, `{"username":"alice"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("login without password status = %d, want 400", rec.Code)
+	}
+}
+
+func TestLoginToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		rccStatus  int
+		rccBody    string
+		wantStatus int
+	}{
+		{"accepted", http.StatusOK, `{"result":"ok"}`, http.StatusOK},
+		{"unauthorized", http.StatusUnauthorized, `{"result":"error"}`, http.StatusUnauthorized},
+		{"forbidden", http.StatusForbidden, `{"result":"error"}`, http.StatusUnauthorized},
+		{"server error", http.StatusInternalServerError, `{"result":"error"}`, http.StatusBadGateway},
+		{"not found", http.StatusNotFound, `404 page not found`, http.StatusBadGateway},
+		{"proxy error page", http.StatusBadGateway, `<html><body>Bad Gateway</body></html>`, http.StatusBadGateway},
+		{"unavailable", http.StatusServiceUnavailable, ``, http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotAuth string
+			rcc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAuth = r.Header.Get("Authorization")
+				w.WriteHeader(tt.rccStatus)
+				w.Write([]byte(tt.rccBody))
+			}))
+			defer rcc.Close()
+
+			setupAuth(t)
+			config.RCCBaseURL = rcc.URL
+			config.Auth.AllowTokenLogin = true
+
+			claims := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"ops","roles":["controller"]}`))
+			token := "e30." + claims + ".sig"
+			rec := postLogin(t, `{"token":"`+token+`"}`)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("login status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if gotAuth != "Bearer "+token {
+				t.Errorf("RCC saw Authorization %q", gotAuth)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if cookies := rec.Result().Cookies(); len(cookies) != 0 {
+					t.Errorf("failed login set cookies %+v", cookies)
+				}
+				return
+			}
+
+			var resp struct {
+				Data struct {
+					Username string `json:"username"`
+					Role     string `json:"role"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Data.Username != "ops" || resp.Data.Role != "controller" {
+				t.Errorf("session = %+v, want ops/controller", resp.Data)
+			}
+		})
+	}
+}
+
+func TestLoginTokenDisabled(t *testing.T) {
+	setupAuth(t)
+	if rec := postLogin(t, `{"token":"abc"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("token login with AllowTokenLogin off status = %d, want 400", rec.Code)
+	}
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -13,42 +16,71 @@ import (
 
 // Config represents the application configuration
 type Config struct {
-	RCCBaseURL string `json:"rccBaseUrl"`
-	Timing     struct {
-		HeartbeatIntervalSec  int `json:"heartbeatIntervalSec"`
-		HeartbeatTimeoutSec   int `json:"heartbeatTimeoutSec"`
-		ProbeNormalSec        int `json:"probeNormalSec"`
-		ProbeRecoveringMinSec int `json:"probeRecoveringMinSec"`
-		ProbeRecoveringMaxSec int `json:"probeRecoveringMaxSec"`
-		ProbeOfflineMinSec    int `json:"probeOfflineMinSec"`
-		ProbeOfflineMaxSec    int `json:"probeOfflineMaxSec"`
-		CmdTimeoutsSec        struct {
-			SetPower    int `json:"setPower"`
-			SetChannel  int `json:"setChannel"`
-			SelectRadio int `json:"selectRadio"`
-			GetState    int `json:"getState"`
-		} `json:"cmdTimeoutsSec"`
-		Retry struct {
-			BusyBaseMs        int `json:"busyBaseMs"`
-			UnavailableBaseMs int `json:"unavailableBaseMs"`
-			JitterMs          int `json:"jitterMs"`
-		} `json:"retry"`
-	} `json:"timing"`
+	RCCBaseURL string       `json:"rccBaseUrl"`
+	Timing     TimingConfig `json:"timing"`
+	Auth       AuthConfig   `json:"auth"`
+	Audit      AuditConfig  `json:"audit"`
 }
 
-// AuditEntry represents a structured audit log entry
-type AuditEntry struct {
-	Timestamp     time.Time `json:"timestamp"`
-	Actor         string    `json:"actor"`
-	RadioID       string    `json:"radioId"`
-	Action        string    `json:"action"`
-	Result        string    `json:"result"`
-	LatencyMS     int64     `json:"latencyMs"`
-	CorrelationID string    `json:"correlationId"`
+// TimingConfig holds the CB-TIMING v0.3 values shared with the browser
+type TimingConfig struct {
+	HeartbeatIntervalSec  int `json:"heartbeatIntervalSec"`
+	HeartbeatTimeoutSec   int `json:"heartbeatTimeoutSec"`
+	ProbeNormalSec        int `json:"probeNormalSec"`
+	ProbeRecoveringMinSec int `json:"probeRecoveringMinSec"`
+	ProbeRecoveringMaxSec int `json:"probeRecoveringMaxSec"`
+	ProbeOfflineMinSec    int `json:"probeOfflineMinSec"`
+	ProbeOfflineMaxSec    int `json:"probeOfflineMaxSec"`
+	CmdTimeoutsSec        struct {
+		SetPower    int `json:"setPower"`
+		SetChannel  int `json:"setChannel"`
+		SelectRadio int `json:"selectRadio"`
+		GetState    int `json:"getState"`
+	} `json:"cmdTimeoutsSec"`
+	Retry struct {
+		BusyBaseMs        int `json:"busyBaseMs"`
+		UnavailableBaseMs int `json:"unavailableBaseMs"`
+		JitterMs          int `json:"jitterMs"`
+	} `json:"retry"`
+}
+
+// AuthConfig controls UI login and how RCC tokens are obtained. Local users
+// get HS256 tokens minted with the secret in TokenSecretEnv; with
+// AllowTokenLogin, a token issued elsewhere can be forwarded instead.
+type AuthConfig struct {
+	TokenSecretEnv  string       `json:"tokenSecretEnv"`
+	TokenTTLSec     int          `json:"tokenTtlSec"`
+	SessionTTLSec   int          `json:"sessionTtlSec"`
+	CookieName      string       `json:"cookieName"`
+	SecureCookie    bool         `json:"secureCookie"`
+	AllowTokenLogin bool         `json:"allowTokenLogin"`
+	Users           []UserConfig `json:"users"`
+}
+
+// UserConfig is a local UI account; generate PasswordHash with -hash-password
+type UserConfig struct {
+	Username     string `json:"username"`
+	PasswordHash string `json:"passwordHash"`
+	Role         string `json:"role"` // viewer or controller
+}
+
+// AuditConfig controls the rotating UI audit log
+type AuditConfig struct {
+	File       string `json:"file"`
+	MaxSizeMB  int    `json:"maxSizeMb"`
+	MaxBackups int    `json:"maxBackups"`
 }
 
 var config Config
 
+// rccClient has no overall timeout so telemetry streams stay open; commands
+// are bounded per request by the CB-TIMING command timeouts instead.
+var rccClient = &http.Client{}
+
+// forwardedHeaders are the only browser headers passed on to RCC. Cookies and
+// any browser-supplied Authorization are dropped; RCC sees the session token.
+var forwardedHeaders = []string{"Accept", "Content-Type", "Last-Event-ID", "X-Correlation-ID"}
+
 func loadConfig() error {
 	data, err := os.ReadFile("config.json")
 	if err != nil {
@@ -59,44 +91,99 @@ func loadConfig() error {
 		return fmt.Errorf("failed to parse config.json: %w", err)
 	}
 
+	if config.Auth.TokenSecretEnv == "" {
+		config.Auth.TokenSecretEnv = "RCC_WEBUI_TOKEN_SECRET"
+	}
+	if config.Auth.TokenTTLSec <= 0 {
+		config.Auth.TokenTTLSec = 900
+	}
+	if config.Auth.SessionTTLSec <= 0 {
+		config.Auth.SessionTTLSec = 8 * 3600
+	}
+	if config.Auth.CookieName == "" {
+		config.Auth.CookieName = "rcc_session"
+	}
+	if config.Audit.File == "" {
+		config.Audit.File = "audit.log"
+	}
+	if config.Audit.MaxSizeMB <= 0 {
+		config.Audit.MaxSizeMB = 10
+	}
+	if config.Timing.CmdTimeoutsSec.GetState <= 0 {
+		config.Timing.CmdTimeoutsSec.GetState = 5
+	}
+
 	return nil
 }
 
-func logAudit(entry AuditEntry) {
-	// Log to console
-	log.Printf("AUDIT: %+v", entry)
+func envOrEmpty(name string) string {
+	return strings.TrimSpace(os.Getenv(name))
+}
 
-	// Log to file via /audit endpoint
-	jsonData, _ := json.Marshal(entry)
-	resp, err := http.Post("http://localhost:8080/audit", "application/json", strings.NewReader(string(jsonData)))
-	if err == nil {
-		resp.Body.Close()
+// commandTimeout picks the CB-TIMING timeout for a proxied request
+func commandTimeout(r *http.Request) time.Duration {
+	timeouts := config.Timing.CmdTimeoutsSec
+	seconds := timeouts.GetState
+	if r.Method == "POST" {
+		switch {
+		case r.URL.Path == "/radios/select":
+			seconds = timeouts.SelectRadio
+		case strings.HasSuffix(r.URL.Path, "/power"):
+			seconds = timeouts.SetPower
+		case strings.HasSuffix(r.URL.Path, "/channel"):
+			seconds = timeouts.SetChannel
+		}
+	}
+	if seconds <= 0 {
+		seconds = timeouts.GetState
 	}
+	return time.Duration(seconds) * time.Second
 }
 
 func reverseProxy(w http.ResponseWriter, r *http.Request) {
+	sess := sessions.FromRequest(r)
+	if sess == nil {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+	token, err := sess.Token()
+	if err != nil {
+		log.Printf("Failed to mint RCC token for %s: %v", sess.Username, err)
+		writeError(w, http.StatusInternalServerError, "INTERNAL", "Failed to obtain RCC token")
+		return
+	}
+
 	// Build target URL
 	targetURL := config.RCCBaseURL + r.URL.Path
 	if r.URL.RawQuery != "" {
 		targetURL += "?" + r.URL.RawQuery
 	}
 
+	// Telemetry lives as long as the browser connection; commands are bounded
+	telemetry := strings.HasPrefix(r.URL.Path, "/telemetry")
+	ctx := r.Context()
+	if !telemetry {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, commandTimeout(r))
+		defer cancel()
+	}
+
 	// Create request to RCC
-	req, err := http.NewRequest(r.Method, targetURL, r.Body)
+	req, err := http.NewRequestWithContext(ctx, r.Method, targetURL, r.Body)
 	if err != nil {
 		http.Error(w, "Failed to create request", http.StatusInternalServerError)
 		return
 	}
 
-	// Copy headers
-	for key, values := range r.Header {
-		for _, value := range values {
-			req.Header.Add(key, value)
+	for _, key := range forwardedHeaders {
+		if value := r.Header.Get(key); value != "" {
+			req.Header.Set(key, value)
 		}
 	}
+	req.Header.Set("Authorization", "Bearer "+token)
 
 	// Handle SSE with Last-Event-ID injection
-	if strings.HasPrefix(r.URL.Path, "/telemetry") {
+	if telemetry {
 		lastEventID := r.URL.Query().Get("lastEventId")
 		if lastEventID != "" {
 			req.Header.Set("Last-Event-ID", lastEventID)
@@ -104,9 +191,12 @@ func reverseProxy(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Make request
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := rccClient.Do(req)
 	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			writeError(w, http.StatusGatewayTimeout, "UNAVAILABLE", "RCC did not respond in time")
+			return
+		}
 		http.Error(w, "Failed to connect to RCC", http.StatusBadGateway)
 		return
 	}
@@ -122,50 +212,92 @@ func reverseProxy(w http.ResponseWriter, r *http.Request) {
 	// Set status
 	w.WriteHeader(resp.StatusCode)
 
-	// Copy body
-	io.Copy(w, resp.Body)
-}
-
-func handleAudit(w http.ResponseWriter, r *http.Request) {
-	if r.Method != "POST" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	if !telemetry {
+		io.Copy(w, resp.Body)
 		return
 	}
 
-	var entry AuditEntry
-	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
-		return
+	// Stream events to the browser as they arrive
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
 	}
-
-	// Append to audit log file
-	file, err := os.OpenFile("audit.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		http.Error(w, "Failed to open audit log", http.StatusInternalServerError)
-		return
+	buf := make([]byte, 4096)
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err != nil {
+			return
+		}
 	}
-	defer file.Close()
-
-	jsonData, _ := json.Marshal(entry)
-	file.Write(append(jsonData, '\n'))
+}
 
-	w.WriteHeader(http.StatusOK)
+// handleConfig serves the browser its view of config.json; auth and audit
+// settings stay on the server.
+func handleConfig(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		RCCBaseURL string       `json:"rccBaseUrl"`
+		Timing     TimingConfig `json:"timing"`
+	}{config.RCCBaseURL, config.Timing})
 }
 
 func main() {
+	hashPasswordFlag := flag.Bool("hash-password", false, "read a password from stdin and print its passwordHash for config.json")
+	flag.Parse()
+
+	if *hashPasswordFlag {
+		password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && password == "" {
+			log.Fatalf("Failed to read password: %v", err)
+		}
+		hash, err := hashPassword(strings.TrimRight(password, "\r\n"))
+		if err != nil {
+			log.Fatalf("Failed to hash password: %v", err)
+		}
+		fmt.Println(hash)
+		return
+	}
+
 	// Load configuration
 	if err := loadConfig(); err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := loadTokenSecret(); err != nil {
+		log.Fatalf("Failed to configure auth: %v", err)
+	}
+	if len(config.Auth.Users) == 0 && !config.Auth.AllowTokenLogin {
+		log.Printf("No users configured and token login disabled; nobody can log in")
+	}
+
+	sessions = NewSessionStore(time.Duration(config.Auth.SessionTTLSec)*time.Second, config.Auth.CookieName, config.Auth.SecureCookie)
+	go sessions.Sweep(time.Minute)
+
+	var err error
+	auditLog, err = openRotatingFile(config.Audit.File, int64(config.Audit.MaxSizeMB)<<20, config.Audit.MaxBackups)
+	if err != nil {
+		log.Fatalf("Failed to open audit log: %v", err)
+	}
+	defer auditLog.Close()
 
 	// Serve static files
 	fs := http.FileServer(http.Dir("./static"))
 	http.Handle("/", fs)
 
-	// Serve config.json
-	http.HandleFunc("/config.json", func(w http.ResponseWriter, r *http.Request) {
-		http.ServeFile(w, r, "config.json")
-	})
+	// Serve the browser-safe part of config.json
+	http.HandleFunc("/config.json", handleConfig)
+
+	// Login and session endpoints
+	http.HandleFunc("/login", handleLogin)
+	http.HandleFunc("/logout", handleLogout)
+	http.HandleFunc("/session", handleSession)
 
 	// API reverse proxy routes
 	http.HandleFunc("/radios", reverseProxy)
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Session is a logged-in browser. The RCC token never leaves the server;
+// the browser only holds the opaque session ID cookie.
+type Session struct {
+	ID        string
+	Username  string
+	Role      string
+	ExpiresAt time.Time
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time // Zero for forwarded tokens
+	minted      bool
+}
+
+// SessionStore holds sessions in memory; restarting the server logs everyone out
+type SessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+	ttl      time.Duration
+	cookie   string
+	secure   bool
+}
+
+var sessions *SessionStore
+
+func NewSessionStore(ttl time.Duration, cookie string, secure bool) *SessionStore {
+	return &SessionStore{
+		sessions: make(map[string]*Session),
+		ttl:      ttl,
+		cookie:   cookie,
+		secure:   secure,
+	}
+}
+
+// Create starts a session and sets its cookie on the response
+func (s *SessionStore) Create(w http.ResponseWriter, sess *Session) error {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return err
+	}
+	sess.ID = base64.RawURLEncoding.EncodeToString(buf)
+	sess.ExpiresAt = time.Now().Add(s.ttl)
+
+	s.mu.Lock()
+	s.sessions[sess.ID] = sess
+	s.mu.Unlock()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.cookie,
+		Value:    sess.ID,
+		Path:     "/",
+		Expires:  sess.ExpiresAt,
+		HttpOnly: true,
+		Secure:   s.secure,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return nil
+}
+
+// FromRequest returns the live session for the request's cookie, or nil
+func (s *SessionStore) FromRequest(r *http.Request) *Session {
+	cookie, err := r.Cookie(s.cookie)
+	if err != nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[cookie.Value]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(sess.ExpiresAt) {
+		delete(s.sessions, sess.ID)
+		return nil
+	}
+	return sess
+}
+
+// Destroy ends the request's session and clears its cookie
+func (s *SessionStore) Destroy(w http.ResponseWriter, r *http.Request) *Session {
+	sess := s.FromRequest(r)
+	if sess != nil {
+		s.mu.Lock()
+		delete(s.sessions, sess.ID)
+		s.mu.Unlock()
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.cookie,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   s.secure,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return sess
+}
+
+// Sweep drops expired sessions so abandoned logins do not accumulate
+func (s *SessionStore) Sweep(interval time.Duration) {
+	for range time.Tick(interval) {
+		s.sweepExpired(time.Now())
+	}
+}
+
+// sweepExpired drops sessions that expired before now
+func (s *SessionStore) sweepExpired(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, sess := range s.sessions {
+		if now.After(sess.ExpiresAt) {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+// Token returns the RCC bearer token for the session, minting a fresh one
+// when a minted token is close to expiry.
+func (sess *Session) Token() (string, error) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	if sess.minted && time.Until(sess.tokenExpiry) < tokenRefreshMargin {
+		token, expiry, err := mintToken(sess.Username, sess.Role)
+		if err != nil {
+			return "", err
+		}
+		sess.token, sess.tokenExpiry = token, expiry
+	}
+	return sess.token, nil
+}
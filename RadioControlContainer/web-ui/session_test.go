@@ -0,0 +1,130 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requestWithCookie(rec *httptest.ResponseRecorder) *http.Request {
+	req := httptest.NewRequest("GET", "/", nil)
+	for _, c := range rec.Result().Cookies() {
+		req.AddCookie(c)
+	}
+	return req
+}
+
+func TestSessionLifecycle(t *testing.T) {
+	store := NewSessionStore(time.Hour, "rcc_session", true)
+
+	rec := httptest.NewRecorder()
+	sess := &Session{Username: "alice", Role: "viewer"}
+	if err := store.Create(rec, sess); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Value != sess.ID || !cookies[0].Secure || cookies[0].SameSite != http.SameSiteStrictMode {
+		t.Fatalf("unexpected cookie %+v", cookies)
+	}
+
+	if got := store.FromRequest(requestWithCookie(rec)); got != sess {
+		t.Fatalf("FromRequest = %v, want the created session", got)
+	}
+	if got := store.FromRequest(httptest.NewRequest("GET", "/", nil)); got != nil {
+		t.Errorf("FromRequest without cookie = %v, want nil", got)
+	}
+
+	out := httptest.NewRecorder()
+	if got := store.Destroy(out, requestWithCookie(rec)); got != sess {
+		t.Errorf("Destroy returned %v, want the session", got)
+	}
+	if cleared := out.Result().Cookies(); len(cleared) != 1 || cleared[0].MaxAge >= 0 {
+		t.Errorf("Destroy did not clear the cookie: %+v", cleared)
+	}
+	if got := store.FromRequest(requestWithCookie(rec)); got != nil {
+		t.Errorf("session still live after Destroy")
+	}
+}
+
+func TestSessionExpiry(t *testing.T) {
+	store := NewSessionStore(time.Hour, "rcc_session", false)
+
+	rec := httptest.NewRecorder()
+	sess := &Session{Username: "alice", Role: "viewer"}
+	if err := store.Create(rec, sess); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	sess.ExpiresAt = time.Now().Add(-time.Second)
+
+	if got := store.FromRequest(requestWithCookie(rec)); got != nil {
+		t.Fatalf("FromRequest returned an expired session")
+	}
+	if len(store.sessions) != 0 {
+		t.Errorf("expired session was not removed on lookup")
+	}
+}
+
+func TestSessionSweep(t *testing.T) {
+	store := NewSessionStore(time.Hour, "rcc_session", false)
+
+	live := &Session{Username: "live"}
+	stale := &Session{Username: "stale"}
+	for _, sess := range []*Session{live, stale} {
+		if err := store.Create(httptest.NewRecorder(), sess); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	store.sweepExpired(time.Now())
+	if len(store.sessions) != 2 {
+		t.Fatalf("sweep dropped live sessions, %d left", len(store.sessions))
+	}
+
+	stale.ExpiresAt = time.Now().Add(-time.Minute)
+	store.sweepExpired(time.Now())
+	if _, ok := store.sessions[stale.ID]; ok {
+		t.Error("sweep kept the expired session")
+	}
+	if _, ok := store.sessions[live.ID]; !ok {
+		t.Error("sweep dropped the live session")
+	}
+
+	store.sweepExpired(time.Now().Add(2 * time.Hour))
+	if len(store.sessions) != 0 {
+		t.Errorf("sweep after TTL left %d sessions", len(store.sessions))
+	}
+}
+
+func TestSessionTokenRefresh(t *testing.T) {
+	setupAuth(t)
+
+	token, expiry, err := mintToken("alice", "viewer")
+	if err != nil {
+		t.Fatalf("mintToken: %v", err)
+	}
+	sess := &Session{Username: "alice", Role: "viewer", token: token, tokenExpiry: expiry, minted: true}
+
+	if got, err := sess.Token(); err != nil || got != token {
+		t.Fatalf("Token() = %q, %v; want the current token", got, err)
+	}
+
+	sess.token = "stale"
+	sess.tokenExpiry = time.Now().Add(tokenRefreshMargin / 2)
+	got, err := sess.Token()
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	if got == "stale" || strings.Count(got, ".") != 2 {
+		t.Errorf("Token() = %q, want a freshly minted token", got)
+	}
+	if time.Until(sess.tokenExpiry) < tokenRefreshMargin {
+		t.Errorf("token expiry %v was not extended", sess.tokenExpiry)
+	}
+
+	forwarded := &Session{token: "external"}
+	if got, _ := forwarded.Token(); got != "external" {
+		t.Errorf("forwarded token was replaced with %q", got)
+	}
+}
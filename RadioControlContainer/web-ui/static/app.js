@@ -10,6 +10,7 @@ class RCCClient {
         this.eventSource = null;
         this.lastEventId = localStorage.getItem('lastEventId') || '';
         this.activeRadioId = null;
+        this.username = null;
         this.retryTimeouts = new Map();
         this.pendingOperations = new Map();
         
@@ -19,15 +20,70 @@ class RCCClient {
     async init() {
         try {
             await this.loadConfig();
-            await this.loadRadios();
-            this.connectTelemetry();
             this.setupEventHandlers();
+            
+            // The server holds the RCC token; the browser only needs a session cookie
+            const response = await fetch('/session');
+            if (response.ok) {
+                const session = await response.json();
+                await this.startSession(session.data);
+            } else {
+                this.showLogin();
+            }
         } catch (error) {
             this.showToast('Failed to initialize: ' + error.message, 'error');
             console.error('Initialization error:', error);
         }
     }
     
+    async startSession(session) {
+        this.username = session.username;
+        document.getElementById('sessionUser').textContent = `${session.username} (${session.role})`;
+        document.getElementById('logoutBtn').hidden = false;
+        document.getElementById('loginForm').hidden = true;
+        document.getElementById('mainContent').hidden = false;
+        
+        await this.loadRadios();
+        this.connectTelemetry();
+    }
+    
+    showLogin() {
+        if (this.eventSource) {
+            this.eventSource.close();
+            this.eventSource = null;
+        }
+        this.username = null;
+        document.getElementById('sessionUser').textContent = '';
+        document.getElementById('logoutBtn').hidden = true;
+        document.getElementById('mainContent').hidden = true;
+        document.getElementById('loginForm').hidden = false;
+    }
+    
+    async login(username, password) {
+        try {
+            const response = await fetch('/login', {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ username, password })
+            });
+            const data = await response.json();
+            
+            if (!response.ok) {
+                throw new Error(data.message || 'Login failed');
+            }
+            
+            document.getElementById('loginPassword').value = '';
+            await this.startSession(data.data);
+        } catch (error) {
+            this.showToast('Login failed: ' + error.message, 'error');
+        }
+    }
+    
+    async logout() {
+        await fetch('/logout', { method: 'POST' }).catch(() => {});
+        this.showLogin();
+    }
+    
     async loadConfig() {
         try {
             const response = await fetch('/config.json');
@@ -63,12 +119,16 @@ class RCCClient {
         
         try {
             const response = await fetch(endpoint, mergedOptions);
+            if (response.status === 401) {
+                this.showLogin();
+                throw new Error('Session expired, please log in again');
+            }
             const data = await response.json();
             
             const latency = Date.now() - startTime;
             this.logAudit({
                 timestamp: new Date().toISOString(),
-                actor: 'ui',
+                actor: this.username || 'ui',
                 radioId: this.activeRadioId || '',
                 action: options.method || 'GET',
                 result: response.ok ? 'success' : 'error',
@@ -85,7 +145,7 @@ class RCCClient {
             const latency = Date.now() - startTime;
             this.logAudit({
                 timestamp: new Date().toISOString(),
-                actor: 'ui',
+                actor: this.username || 'ui',
                 radioId: this.activeRadioId || '',
                 action: options.method || 'GET',
                 result: 'error',
@@ -290,8 +350,20 @@ class RCCClient {
         
         this.eventSource.onerror = (error) => {
             console.error('SSE error:', error);
+            if (!this.username) {
+                return; // Logged out
+            }
+            this.eventSource.close();
             this.addTelemetryLog('Telemetry connection error, reconnecting...', 'fault');
-            setTimeout(() => this.connectTelemetry(), 5000);
+            setTimeout(async () => {
+                // A stream rejected for an expired session would otherwise retry forever
+                const response = await fetch('/session').catch(() => null);
+                if (response && response.status === 401) {
+                    this.showLogin();
+                } else if (this.username) {
+                    this.connectTelemetry();
+                }
+            }, 5000);
         };
         
         // Handle specific event types per SSE v1 spec
@@ -407,7 +479,7 @@ class RCCClient {
     logAudit(entry) {
         console.log('AUDIT:', entry);
         
-        // Send to server audit endpoint
+        // Send to server audit endpoint; the server records the session user as actor
         fetch('/audit', {
             method: 'POST',
             headers: { 'Content-Type': 'application/json' },
@@ -420,6 +492,19 @@ class RCCClient {
     }
     
     setupEventHandlers() {
+        // Login and logout
+        document.getElementById('loginForm').addEventListener('submit', (e) => {
+            e.preventDefault();
+            this.login(
+                document.getElementById('loginUsername').value,
+                document.getElementById('loginPassword').value
+            );
+        });
+        
+        document.getElementById('logoutBtn').addEventListener('click', () => {
+            this.logout();
+        });
+        
         // Radio selection
         document.getElementById('radioSelect').addEventListener('change', (e) => {
             this.selectRadio(e.target.value);
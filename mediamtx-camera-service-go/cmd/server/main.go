@@ -40,7 +40,9 @@ import (
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/health"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/mediamtx"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/metrics"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/security"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/tracing"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/websocket"
 )
 
@@ -87,6 +89,20 @@ func main() {
 	ctx := context.Background()
 	pathValidator.StartPeriodicValidation(ctx)
 
+	// Request tracing - spans from JSON-RPC requests down to FFmpeg and MediaMTX calls
+	var tracer *tracing.Tracer
+	if cfg.Tracing.Enabled {
+		newTracer, err := tracing.NewTracer(&cfg.Tracing, logging.GetLogger("tracing"))
+		if err != nil {
+			logger.WithError(err).Warn("Failed to create tracer - request tracing disabled")
+		} else if err := newTracer.Start(ctx); err != nil {
+			logger.WithError(err).Warn("Failed to start tracer - request tracing disabled")
+		} else {
+			tracer = newTracer
+			tracing.SetTracer(tracer)
+		}
+	}
+
 	// Layer 2: Core Services - Initialize hardware abstraction layer
 	// Use real implementations for production hardware access
 	deviceChecker := &camera.RealDeviceChecker{}
@@ -147,6 +163,12 @@ func main() {
 		if err != nil {
 			logger.WithError(err).Fatal("Failed to create HTTP health server")
 		}
+
+		// Expose controller and WebSocket server metrics on the metrics endpoint
+		if collector, ok := mediaMTXController.(metrics.Collector); ok {
+			httpHealthServer.RegisterMetricsCollector(collector)
+		}
+		httpHealthServer.RegisterMetricsCollector(wsServer)
 		logger.Info("HTTP Health Server initialized")
 	}
 
@@ -173,6 +195,15 @@ func main() {
 		logger.Info("Connected SystemEventNotifier to controller for unified health notifications")
 	}
 
+	// Connect UAV telemetry from external STANAG 4609 streams to the event system
+	telemetryEventNotifier := websocket.NewTelemetryEventNotifier(wsServer.GetEventManager(), logger)
+	if controllerWithTelemetry, ok := mediaMTXController.(interface {
+		SetTelemetryEventNotifier(notifier mediamtx.TelemetryEventNotifier)
+	}); ok {
+		controllerWithTelemetry.SetTelemetryEventNotifier(telemetryEventNotifier)
+		logger.Info("Connected TelemetryEventNotifier to controller for UAV telemetry events")
+	}
+
 	// Service Startup - Follow architectural compliance with controller orchestration
 	logger.Info("Starting MediaMTX Controller orchestration...")
 
@@ -312,6 +343,14 @@ func main() {
 		os.Exit(1) // Force exit on timeout to prevent hanging
 	}
 
+	// Flush remaining spans after all instrumented services have stopped
+	if tracer != nil {
+		tracing.SetTracer(nil)
+		if err := tracer.Stop(shutdownCtx); err != nil {
+			logger.WithError(err).Warn("Error flushing trace spans")
+		}
+	}
+
 	// Collect and report shutdown errors for monitoring
 	close(errorChan)
 	var errors []error
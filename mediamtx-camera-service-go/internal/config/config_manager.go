@@ -643,6 +643,25 @@ func (cm *ConfigManager) setConfigValues(v *viper.Viper, config *Config) {
 	v.Set("retention_policy.max_age_days", config.RetentionPolicy.MaxAgeDays)
 	v.Set("retention_policy.max_size_gb", config.RetentionPolicy.MaxSizeGB)
 	v.Set("retention_policy.auto_cleanup", config.RetentionPolicy.AutoCleanup)
+	v.Set("retention_policy.cleanup_interval_minutes", config.RetentionPolicy.CleanupIntervalMinutes)
+	v.Set("retention_policy.cameras", retentionRulesToMap(config.RetentionPolicy.Cameras))
+	v.Set("retention_policy.groups", retentionRulesToMap(config.RetentionPolicy.Groups))
+}
+
+// retentionRulesToMap converts retention rules to mapstructure-keyed maps for saving.
+func retentionRulesToMap(rules map[string]RetentionRuleConfig) map[string]interface{} {
+	result := make(map[string]interface{}, len(rules))
+	for name, rule := range rules {
+		entry := map[string]interface{}{
+			"max_age_days": rule.MaxAgeDays,
+			"max_size_gb":  rule.MaxSizeGB,
+		}
+		if len(rule.Cameras) > 0 {
+			entry["cameras"] = rule.Cameras
+		}
+		result[name] = entry
+	}
+	return result
 }
 
 // AddUpdateCallback adds a callback function to be called when configuration is updated.
@@ -777,6 +796,11 @@ func (cm *ConfigManager) setDefaults(v *viper.Viper) {
 	v.SetDefault("mediamtx.external_discovery.generic_uav.stream_paths", []string{"/stream", "/live", "/video"})
 	v.SetDefault("mediamtx.external_discovery.generic_uav.known_ips", []string{})
 
+	// UAV telemetry extraction defaults
+	v.SetDefault("external_discovery.telemetry.enabled", false)
+	v.SetDefault("external_discovery.telemetry.sample_interval_ms", 1000)
+	v.SetDefault("external_discovery.telemetry.reconnect_interval_seconds", 5)
+
 	// MediaMTX stream readiness defaults
 	v.SetDefault("mediamtx.stream_readiness.timeout", 15.0)
 	v.SetDefault("mediamtx.stream_readiness.retry_attempts", 3)
@@ -868,6 +892,7 @@ func (cm *ConfigManager) setDefaults(v *viper.Viper) {
 	v.SetDefault("retention_policy.max_age_days", 7)
 	v.SetDefault("retention_policy.max_size_gb", 1)
 	v.SetDefault("retention_policy.auto_cleanup", true)
+	v.SetDefault("retention_policy.cleanup_interval_minutes", 60)
 
 	// Logging defaults - aligned with canonical configuration
 	v.SetDefault("logging.level", "error") // Only critical errors by default
@@ -888,6 +913,11 @@ func (cm *ConfigManager) setDefaults(v *viper.Viper) {
 	v.SetDefault("recording.cleanup_interval", 86400)
 	v.SetDefault("recording.max_age", 604800)
 	v.SetDefault("recording.max_size", 10737418240)
+	v.SetDefault("recording.klv.enabled", false)
+	v.SetDefault("recording.klv.mission_id", "")
+	v.SetDefault("recording.klv.platform_designation", "")
+	v.SetDefault("recording.klv.interval_ms", 1000)
+	v.SetDefault("recording.klv.position_file", "")
 
 	// Snapshots defaults
 	v.SetDefault("snapshots.enabled", true)
@@ -905,6 +935,46 @@ func (cm *ConfigManager) setDefaults(v *viper.Viper) {
 	v.SetDefault("storage.block_percent", 90)
 	v.SetDefault("storage.default_path", "/opt/camera-service/recordings")
 	v.SetDefault("storage.fallback_path", "/tmp/recordings")
+	v.SetDefault("storage.tiering.enabled", false)
+	v.SetDefault("storage.tiering.archive_path", "")
+	v.SetDefault("storage.tiering.move_after_hours", 24)
+	v.SetDefault("storage.tiering.check_interval_minutes", 15)
+	v.SetDefault("storage.tiering.max_concurrent_moves", 2)
+	v.SetDefault("storage.tiering.io_limit_mbps", 0)
+	v.SetDefault("storage.encryption.enabled", false)
+	v.SetDefault("storage.encryption.key_source", "keyfile")
+	v.SetDefault("storage.encryption.keyfile_path", "/opt/camera-service/keys/storage.key")
+	v.SetDefault("storage.encryption.key_store_path", "/opt/camera-service/keys/storage-keys.json")
+	v.SetDefault("storage.encryption.scan_interval_seconds", 30)
+	v.SetDefault("storage.encryption.chunk_size_kb", 64)
+
+	// Replication defaults
+	v.SetDefault("replication.enabled", false)
+	v.SetDefault("replication.region", "us-east-1")
+	v.SetDefault("replication.part_size_mb", 8)
+	v.SetDefault("replication.bandwidth_limit_mbps", 0)
+	v.SetDefault("replication.queue_path", "/opt/camera-service/replication-queue.json")
+	v.SetDefault("replication.scan_interval_seconds", 60)
+	v.SetDefault("replication.max_attempts", 10)
+	v.SetDefault("replication.include_snapshots", true)
+	v.SetDefault("replication.delete_local_after", false)
+
+	// Chain-of-custody defaults
+	v.SetDefault("custody.enabled", false)
+	v.SetDefault("custody.manifest_path", "/opt/camera-service/custody/manifest.jsonl")
+	v.SetDefault("custody.signing_key_path", "/opt/camera-service/custody/signing_key.pem")
+	v.SetDefault("custody.export_path", "/opt/camera-service/custody/exports")
+
+	// Tracing defaults
+	v.SetDefault("tracing.enabled", false)
+	v.SetDefault("tracing.exporter", "otlp")
+	v.SetDefault("tracing.endpoint", "http://localhost:4318")
+	v.SetDefault("tracing.file_path", "/opt/camera-service/logs/traces.jsonl")
+	v.SetDefault("tracing.service_name", "camera-service")
+	v.SetDefault("tracing.sample_ratio", 1.0)
+	v.SetDefault("tracing.batch_size", 128)
+	v.SetDefault("tracing.flush_interval_ms", 5000)
+	v.SetDefault("tracing.queue_size", 2048)
 }
 
 // notifyConfigUpdated notifies all registered callbacks of configuration updates.
@@ -1066,6 +1136,7 @@ func getDefaultConfig() *Config {
 			DetailedEndpoint:  "/health/detailed",
 			ReadyEndpoint:     "/health/ready",
 			LiveEndpoint:      "/health/live",
+			MetricsEndpoint:   "/metrics",
 			ResponseFormat:    "json",
 			IncludeVersion:    true,
 			IncludeUptime:     true,
@@ -62,6 +62,34 @@ type StorageConfig struct {
 	BlockPercent int    `mapstructure:"block_percent"` // Default: 90% usage block
 	DefaultPath  string `mapstructure:"default_path"`  // Default: "/opt/camera-service/recordings"
 	FallbackPath string `mapstructure:"fallback_path"` // Default: "/tmp/recordings"
+
+	Tiering    TieringConfig    `mapstructure:"tiering"`    // Move aged recordings to an archive volume
+	Encryption EncryptionConfig `mapstructure:"encryption"` // Encrypt completed recordings and snapshots at rest
+}
+
+// TieringConfig represents tiered storage configuration.
+// Recordings older than MoveAfterHours are moved from the recordings path to
+// ArchivePath; the source is deleted only after the copy's checksum matches.
+type TieringConfig struct {
+	Enabled              bool    `mapstructure:"enabled"`                // Default: false
+	ArchivePath          string  `mapstructure:"archive_path"`           // Archive volume for aged recordings
+	MoveAfterHours       int     `mapstructure:"move_after_hours"`       // Default: 24
+	CheckIntervalMinutes int     `mapstructure:"check_interval_minutes"` // Default: 15
+	MaxConcurrentMoves   int     `mapstructure:"max_concurrent_moves"`   // Default: 2
+	IOLimitMBps          float64 `mapstructure:"io_limit_mbps"`          // Combined copy bandwidth, 0 = unlimited
+}
+
+// EncryptionConfig represents encryption at rest for completed recordings and snapshots.
+// Each file is encrypted with its own AES-256-GCM data key, wrapped by a storage key
+// that is itself wrapped by a key-encryption key (KEK) from a keyfile or passphrase.
+type EncryptionConfig struct {
+	Enabled             bool   `mapstructure:"enabled"`               // Default: false
+	KeySource           string `mapstructure:"key_source"`            // "keyfile" or "passphrase"
+	KeyfilePath         string `mapstructure:"keyfile_path"`          // 32-byte KEK (raw, hex or base64), generated if missing
+	Passphrase          string `mapstructure:"passphrase"`            // Passphrase the KEK is derived from (PBKDF2-SHA256)
+	KeyStorePath        string `mapstructure:"key_store_path"`        // Wrapped storage keys and KDF salt (JSON)
+	ScanIntervalSeconds int    `mapstructure:"scan_interval_seconds"` // Default: 30
+	ChunkSizeKB         int    `mapstructure:"chunk_size_kb"`         // Default: 64, encryption chunk size
 }
 
 // MediaMTXConfig represents MediaMTX integration configuration.
@@ -147,6 +175,16 @@ type ExternalDiscoveryConfig struct {
 
 	// Generic UAV configuration (for other models)
 	GenericUAV GenericUAVConfig `mapstructure:"generic_uav"`
+
+	// KLV telemetry extraction from STANAG 4609 streams
+	Telemetry UAVTelemetryConfig `mapstructure:"telemetry"`
+}
+
+// UAVTelemetryConfig represents KLV telemetry extraction from discovered STANAG 4609 streams.
+type UAVTelemetryConfig struct {
+	Enabled                  bool `mapstructure:"enabled"`                    // Default: false
+	SampleIntervalMs         int  `mapstructure:"sample_interval_ms"`         // Minimum interval between stored/published samples per stream (default: 1000)
+	ReconnectIntervalSeconds int  `mapstructure:"reconnect_interval_seconds"` // Delay before reconnecting a dropped stream (default: 5)
 }
 
 // SkydioDiscoveryConfig represents Skydio UAV discovery configuration
@@ -348,6 +386,33 @@ type RecordingConfig struct {
 	DefaultPageSize int           `mapstructure:"default_page_size"` // Default: 50
 	MaxPageSize     int           `mapstructure:"max_page_size"`     // Default: 100
 	ProcessTimeout  time.Duration `mapstructure:"process_timeout"`   // default 5s
+
+	// STANAG 4609 KLV metadata muxed into finished recordings
+	KLV KLVConfig `mapstructure:"klv"`
+}
+
+// KLVConfig represents MISB ST 0601 KLV metadata injection into recordings.
+// Injection muxes a KLV data track into MPEG-TS recordings once they are closed.
+type KLVConfig struct {
+	Enabled             bool                         `mapstructure:"enabled"`
+	MissionID           string                       `mapstructure:"mission_id"`           // ST 0601 tag 3
+	PlatformDesignation string                       `mapstructure:"platform_designation"` // ST 0601 tag 10
+	IntervalMs          int                          `mapstructure:"interval_ms"`          // Sampling interval (default: 1000)
+	PositionFile        string                       `mapstructure:"position_file"`        // Optional JSON file of live positions per camera
+	Cameras             map[string]KLVPositionConfig `mapstructure:"cameras"`              // Camera identifier -> static position
+}
+
+// KLVPositionConfig represents the static platform and sensor position of a camera.
+type KLVPositionConfig struct {
+	Latitude              float64 `mapstructure:"latitude" json:"latitude"`                               // Degrees (-90..90)
+	Longitude             float64 `mapstructure:"longitude" json:"longitude"`                             // Degrees (-180..180)
+	Altitude              float64 `mapstructure:"altitude" json:"altitude"`                               // Meters MSL (-900..19000)
+	Heading               float64 `mapstructure:"heading" json:"heading"`                                 // Degrees (0..360)
+	Pitch                 float64 `mapstructure:"pitch" json:"pitch"`                                     // Degrees (-20..20)
+	Roll                  float64 `mapstructure:"roll" json:"roll"`                                       // Degrees (-50..50)
+	HorizontalFOV         float64 `mapstructure:"horizontal_fov" json:"horizontal_fov"`                   // Degrees (0..180)
+	VerticalFOV           float64 `mapstructure:"vertical_fov" json:"vertical_fov"`                       // Degrees (0..180)
+	SensorRelativeAzimuth float64 `mapstructure:"sensor_relative_azimuth" json:"sensor_relative_azimuth"` // Degrees (0..360)
 }
 
 // SnapshotConfig represents snapshot configuration.
@@ -374,6 +439,19 @@ type RetentionPolicyConfig struct {
 	MaxAgeDays  int    `mapstructure:"max_age_days"` // For age-based policy (default: 7)
 	MaxSizeGB   int    `mapstructure:"max_size_gb"`  // For size-based policy (default: 1)
 	AutoCleanup bool   `mapstructure:"auto_cleanup"` // Whether to automatically clean up files
+
+	// Per-camera and per-group rules, enforced by the background retention janitor
+	CleanupIntervalMinutes int                            `mapstructure:"cleanup_interval_minutes"` // Janitor interval (default: 60)
+	Cameras                map[string]RetentionRuleConfig `mapstructure:"cameras"`                  // Camera identifier -> rule
+	Groups                 map[string]RetentionRuleConfig `mapstructure:"groups"`                   // Group name -> rule (with member cameras)
+}
+
+// RetentionRuleConfig represents a per-camera or per-group retention rule.
+// A camera rule overrides its groups' rules, and group rules override the global policy.
+type RetentionRuleConfig struct {
+	Cameras    []string `mapstructure:"cameras"`      // Member camera identifiers (groups only)
+	MaxAgeDays int      `mapstructure:"max_age_days"` // Delete files older than this (0 = no age limit)
+	MaxSizeGB  float64  `mapstructure:"max_size_gb"`  // Storage quota, oldest files deleted first (0 = no quota)
 }
 
 // Config represents the complete service configuration.
@@ -390,6 +468,12 @@ type Config struct {
 	Security        SecurityConfig        `mapstructure:"security"`
 	Storage         StorageConfig         `mapstructure:"storage"`
 	RetentionPolicy RetentionPolicyConfig `mapstructure:"retention_policy"`
+	// Off-box replication of recordings and snapshots to S3-compatible storage
+	Replication ReplicationConfig `mapstructure:"replication"`
+	// Chain-of-custody hashing and signed manifests for captured media
+	Custody CustodyConfig `mapstructure:"custody"`
+	// Distributed tracing of API requests through the media pipeline
+	Tracing TracingConfig `mapstructure:"tracing"`
 	// API Key Management configuration (architectural compliance)
 	APIKeyManagement APIKeyManagementConfig `mapstructure:"api_key_management"`
 	// HTTP Health Endpoint configuration (architectural compliance)
@@ -400,6 +484,51 @@ type Config struct {
 	ServerDefaults ServerDefaults `mapstructure:"server_defaults"`
 }
 
+// ReplicationConfig represents off-box replication to an S3-compatible object store.
+// Completed recordings and snapshots are queued, uploaded with resumable multipart
+// uploads and optionally deleted locally once the upload is confirmed.
+type ReplicationConfig struct {
+	Enabled             bool    `mapstructure:"enabled"`               // Default: false
+	Endpoint            string  `mapstructure:"endpoint"`              // e.g. "https://s3.example.com" or "http://minio:9000"
+	Region              string  `mapstructure:"region"`                // Default: "us-east-1"
+	Bucket              string  `mapstructure:"bucket"`                // Destination bucket
+	Prefix              string  `mapstructure:"prefix"`                // Object key prefix, e.g. "edge-01/"
+	AccessKey           string  `mapstructure:"access_key"`            // Access key ID
+	SecretKey           string  `mapstructure:"secret_key"`            // Secret access key
+	PartSizeMB          int     `mapstructure:"part_size_mb"`          // Default: 8 (S3 minimum is 5)
+	BandwidthLimitMBps  float64 `mapstructure:"bandwidth_limit_mbps"`  // Upload bandwidth in MB/s, 0 = unlimited
+	QueuePath           string  `mapstructure:"queue_path"`            // Persisted upload queue (JSON)
+	ScanIntervalSeconds int     `mapstructure:"scan_interval_seconds"` // Default: 60
+	MaxAttempts         int     `mapstructure:"max_attempts"`          // Default: 10, then the file is marked failed
+	IncludeSnapshots    bool    `mapstructure:"include_snapshots"`     // Default: true
+	DeleteLocalAfter    bool    `mapstructure:"delete_local_after"`    // Delete the local copy after a confirmed upload
+}
+
+// CustodyConfig represents chain-of-custody tracking for recordings and snapshots.
+// Each completed file is hashed with SHA-256 and appended to a hash-chained
+// manifest whose entries are signed with the service's Ed25519 key.
+type CustodyConfig struct {
+	Enabled        bool   `mapstructure:"enabled"`          // Default: false
+	ManifestPath   string `mapstructure:"manifest_path"`    // Hash-chained manifest (JSON lines)
+	SigningKeyPath string `mapstructure:"signing_key_path"` // Ed25519 private key (PEM), generated if missing
+	ExportPath     string `mapstructure:"export_path"`      // Directory for exported evidence bundles
+}
+
+// TracingConfig represents OpenTelemetry-compatible request tracing.
+// Spans are batched and exported as OTLP/JSON, either to an OTLP/HTTP collector
+// or appended to a local file (one export request per line) for offline analysis.
+type TracingConfig struct {
+	Enabled         bool    `mapstructure:"enabled"`           // Default: false
+	Exporter        string  `mapstructure:"exporter"`          // "otlp" or "file", Default: "otlp"
+	Endpoint        string  `mapstructure:"endpoint"`          // OTLP/HTTP collector, Default: "http://localhost:4318"
+	FilePath        string  `mapstructure:"file_path"`         // Span file for the "file" exporter
+	ServiceName     string  `mapstructure:"service_name"`      // Default: "camera-service"
+	SampleRatio     float64 `mapstructure:"sample_ratio"`      // Fraction of traces recorded, Default: 1.0
+	BatchSize       int     `mapstructure:"batch_size"`        // Default: 128 spans per export
+	FlushIntervalMs int     `mapstructure:"flush_interval_ms"` // Default: 5000
+	QueueSize       int     `mapstructure:"queue_size"`        // Default: 2048, spans dropped when full
+}
+
 // ServerDefaults represents server operation default values
 type ServerDefaults struct {
 	ShutdownTimeout     float64 `mapstructure:"shutdown_timeout"`      // Default: 30.0 seconds
@@ -465,6 +594,7 @@ type HTTPHealthConfig struct {
 	DetailedEndpoint string `mapstructure:"detailed_endpoint"` // Detailed health endpoint path
 	ReadyEndpoint    string `mapstructure:"ready_endpoint"`    // Readiness probe endpoint path
 	LiveEndpoint     string `mapstructure:"live_endpoint"`     // Liveness probe endpoint path
+	MetricsEndpoint  string `mapstructure:"metrics_endpoint"`  // Prometheus metrics endpoint path
 
 	// Response configuration
 	ResponseFormat    string `mapstructure:"response_format"`    // Response format (json, text)
@@ -474,7 +604,7 @@ type HTTPHealthConfig struct {
 
 	// Performance configuration
 	MaxResponseTime string `mapstructure:"max_response_time"` // Maximum response time
-	EnableMetrics   bool   `mapstructure:"enable_metrics"`    // Enable metrics collection
+	EnableMetrics   bool   `mapstructure:"enable_metrics"`    // Enable the Prometheus metrics endpoint
 
 	// Security configuration
 	InternalOnly bool     `mapstructure:"internal_only"` // Restrict to internal access only
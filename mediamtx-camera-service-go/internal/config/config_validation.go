@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -78,6 +79,22 @@ func ValidateConfig(config *Config) error {
 		errors = append(errors, err)
 	}
 
+	if err := validateReplicationConfig(&config.Replication); err != nil {
+		errors = append(errors, err)
+	}
+
+	if err := validateCustodyConfig(&config.Custody); err != nil {
+		errors = append(errors, err)
+	}
+
+	if err := validateTracingConfig(&config.Tracing); err != nil {
+		errors = append(errors, err)
+	}
+
+	if err := validateUAVTelemetryConfig(&config.ExternalDiscovery.Telemetry); err != nil {
+		errors = append(errors, err)
+	}
+
 	// CRITICAL: Add comprehensive path validation
 	if err := ValidatePathConfiguration(config); err != nil {
 		errors = append(errors, err)
@@ -480,6 +497,73 @@ func validateRecordingConfig(config *RecordingConfig) error {
 		return &ValidationError{Field: "recording.default_rotation_size", Message: fmt.Sprintf("recording default rotation size cannot be negative, got %d", config.DefaultRotationSize)}
 	}
 
+	return validateKLVConfig(config)
+}
+
+// validateUAVTelemetryConfig validates KLV telemetry extraction configuration.
+func validateUAVTelemetryConfig(config *UAVTelemetryConfig) error {
+	if config.SampleIntervalMs < 0 {
+		return &ValidationError{Field: "external_discovery.telemetry.sample_interval_ms", Message: fmt.Sprintf("sample interval cannot be negative, got %d", config.SampleIntervalMs)}
+	}
+
+	if config.ReconnectIntervalSeconds < 0 {
+		return &ValidationError{Field: "external_discovery.telemetry.reconnect_interval_seconds", Message: fmt.Sprintf("reconnect interval cannot be negative, got %d", config.ReconnectIntervalSeconds)}
+	}
+
+	return nil
+}
+
+// validateKLVConfig validates KLV metadata injection configuration.
+func validateKLVConfig(config *RecordingConfig) error {
+	klv := &config.KLV
+	if klv.IntervalMs < 0 {
+		return &ValidationError{Field: "recording.klv.interval_ms", Message: fmt.Sprintf("KLV interval cannot be negative, got %d", klv.IntervalMs)}
+	}
+
+	if len(klv.MissionID) > 127 {
+		return &ValidationError{Field: "recording.klv.mission_id", Message: fmt.Sprintf("mission ID cannot exceed 127 bytes, got %d", len(klv.MissionID))}
+	}
+
+	if len(klv.PlatformDesignation) > 127 {
+		return &ValidationError{Field: "recording.klv.platform_designation", Message: fmt.Sprintf("platform designation cannot exceed 127 bytes, got %d", len(klv.PlatformDesignation))}
+	}
+
+	for camera, position := range klv.Cameras {
+		if err := validateKLVPosition(fmt.Sprintf("recording.klv.cameras.%s", camera), &position); err != nil {
+			return err
+		}
+	}
+
+	// STANAG 4609 carries KLV as a data stream in an MPEG-TS container
+	if klv.Enabled && config.RecordFormat != "" && config.RecordFormat != "mpegts" && config.RecordFormat != "ts" {
+		return &ValidationError{Field: "recording.klv.enabled", Message: fmt.Sprintf("KLV injection requires record_format mpegts, got %s", config.RecordFormat)}
+	}
+
+	return nil
+}
+
+// validateKLVPosition validates a static camera position against the ST 0601 ranges.
+func validateKLVPosition(field string, position *KLVPositionConfig) error {
+	ranges := []struct {
+		name     string
+		value    float64
+		min, max float64
+	}{
+		{"latitude", position.Latitude, -90, 90},
+		{"longitude", position.Longitude, -180, 180},
+		{"altitude", position.Altitude, -900, 19000},
+		{"heading", position.Heading, 0, 360},
+		{"pitch", position.Pitch, -20, 20},
+		{"roll", position.Roll, -50, 50},
+		{"horizontal_fov", position.HorizontalFOV, 0, 180},
+		{"vertical_fov", position.VerticalFOV, 0, 180},
+		{"sensor_relative_azimuth", position.SensorRelativeAzimuth, 0, 360},
+	}
+	for _, r := range ranges {
+		if r.value < r.min || r.value > r.max {
+			return &ValidationError{Field: field + "." + r.name, Message: fmt.Sprintf("%s must be between %.0f and %.0f, got %.6f", r.name, r.min, r.max, r.value)}
+		}
+	}
 	return nil
 }
 
@@ -805,6 +889,40 @@ func validateRetentionPolicyConfig(config *RetentionPolicyConfig) error {
 		}
 	}
 
+	if config.CleanupIntervalMinutes < 0 {
+		return &ValidationError{Field: "retention_policy.cleanup_interval_minutes", Message: fmt.Sprintf("cleanup interval minutes cannot be negative, got %d", config.CleanupIntervalMinutes)}
+	}
+
+	for camera, rule := range config.Cameras {
+		if err := validateRetentionRuleConfig("retention_policy.cameras."+camera, &rule); err != nil {
+			return err
+		}
+	}
+
+	for group, rule := range config.Groups {
+		field := "retention_policy.groups." + group
+		if len(rule.Cameras) == 0 {
+			return &ValidationError{Field: field + ".cameras", Message: "retention group must list at least one camera"}
+		}
+		if err := validateRetentionRuleConfig(field, &rule); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateRetentionRuleConfig validates a per-camera or per-group retention rule.
+func validateRetentionRuleConfig(field string, rule *RetentionRuleConfig) error {
+	if rule.MaxAgeDays < 0 || rule.MaxAgeDays > 365 {
+		return &ValidationError{Field: field + ".max_age_days", Message: fmt.Sprintf("max age days must be between 0 and 365, got %d", rule.MaxAgeDays)}
+	}
+	if rule.MaxSizeGB < 0 || rule.MaxSizeGB > 1000 {
+		return &ValidationError{Field: field + ".max_size_gb", Message: fmt.Sprintf("max size GB must be between 0 and 1000, got %v", rule.MaxSizeGB)}
+	}
+	if rule.MaxAgeDays == 0 && rule.MaxSizeGB == 0 {
+		return &ValidationError{Field: field, Message: "retention rule must set max_age_days or max_size_gb"}
+	}
 	return nil
 }
 
@@ -877,6 +995,164 @@ func validateStorageConfig(config *StorageConfig) error {
 		}
 	}
 
+	if err := validateTieringConfig(&config.Tiering); err != nil {
+		return err
+	}
+
+	return validateEncryptionConfig(&config.Encryption)
+}
+
+// validateTieringConfig validates tiered storage configuration.
+func validateTieringConfig(config *TieringConfig) error {
+	if config.MoveAfterHours < 0 {
+		return &ValidationError{Field: "storage.tiering.move_after_hours", Message: fmt.Sprintf("move after hours cannot be negative, got %d", config.MoveAfterHours)}
+	}
+
+	if config.CheckIntervalMinutes < 0 {
+		return &ValidationError{Field: "storage.tiering.check_interval_minutes", Message: fmt.Sprintf("check interval cannot be negative, got %d", config.CheckIntervalMinutes)}
+	}
+
+	if config.MaxConcurrentMoves < 0 {
+		return &ValidationError{Field: "storage.tiering.max_concurrent_moves", Message: fmt.Sprintf("max concurrent moves cannot be negative, got %d", config.MaxConcurrentMoves)}
+	}
+
+	if config.IOLimitMBps < 0 {
+		return &ValidationError{Field: "storage.tiering.io_limit_mbps", Message: fmt.Sprintf("I/O limit cannot be negative, got %.2f", config.IOLimitMBps)}
+	}
+
+	if config.Enabled {
+		if err := validateStoragePath("storage.tiering.archive_path", config.ArchivePath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateEncryptionConfig validates encryption at rest configuration.
+func validateEncryptionConfig(config *EncryptionConfig) error {
+	if config.ScanIntervalSeconds < 0 {
+		return &ValidationError{Field: "storage.encryption.scan_interval_seconds", Message: fmt.Sprintf("scan interval cannot be negative, got %d", config.ScanIntervalSeconds)}
+	}
+
+	if config.ChunkSizeKB < 0 || config.ChunkSizeKB > 16*1024 {
+		return &ValidationError{Field: "storage.encryption.chunk_size_kb", Message: fmt.Sprintf("chunk size must be between 0 and 16384 KB, got %d", config.ChunkSizeKB)}
+	}
+
+	if !config.Enabled {
+		return nil
+	}
+
+	switch config.KeySource {
+	case "keyfile":
+		if strings.TrimSpace(config.KeyfilePath) == "" {
+			return &ValidationError{Field: "storage.encryption.keyfile_path", Message: "keyfile path cannot be empty when key source is keyfile"}
+		}
+	case "passphrase":
+		if len(config.Passphrase) < 12 {
+			return &ValidationError{Field: "storage.encryption.passphrase", Message: "passphrase must be at least 12 characters"}
+		}
+	default:
+		return &ValidationError{Field: "storage.encryption.key_source", Message: fmt.Sprintf("key source must be 'keyfile' or 'passphrase', got %q", config.KeySource)}
+	}
+
+	if strings.TrimSpace(config.KeyStorePath) == "" {
+		return &ValidationError{Field: "storage.encryption.key_store_path", Message: "key store path cannot be empty"}
+	}
+
+	return nil
+}
+
+// validateReplicationConfig validates off-box replication configuration.
+func validateReplicationConfig(config *ReplicationConfig) error {
+	if config.BandwidthLimitMBps < 0 {
+		return &ValidationError{Field: "replication.bandwidth_limit_mbps", Message: fmt.Sprintf("bandwidth limit cannot be negative, got %.2f", config.BandwidthLimitMBps)}
+	}
+
+	if config.MaxAttempts < 0 {
+		return &ValidationError{Field: "replication.max_attempts", Message: fmt.Sprintf("max attempts cannot be negative, got %d", config.MaxAttempts)}
+	}
+
+	if !config.Enabled {
+		return nil
+	}
+
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return &ValidationError{Field: "replication.endpoint", Message: fmt.Sprintf("endpoint must be an http or https URL, got %q", config.Endpoint)}
+	}
+
+	if strings.TrimSpace(config.Bucket) == "" {
+		return &ValidationError{Field: "replication.bucket", Message: "bucket cannot be empty"}
+	}
+
+	if config.AccessKey == "" || config.SecretKey == "" {
+		return &ValidationError{Field: "replication.access_key", Message: "access key and secret key are required"}
+	}
+
+	if config.PartSizeMB < 5 {
+		return &ValidationError{Field: "replication.part_size_mb", Message: fmt.Sprintf("part size must be at least 5 MB, got %d", config.PartSizeMB)}
+	}
+
+	if strings.TrimSpace(config.QueuePath) == "" {
+		return &ValidationError{Field: "replication.queue_path", Message: "queue path cannot be empty"}
+	}
+
+	if config.ScanIntervalSeconds <= 0 {
+		return &ValidationError{Field: "replication.scan_interval_seconds", Message: fmt.Sprintf("scan interval must be positive, got %d", config.ScanIntervalSeconds)}
+	}
+
+	return nil
+}
+
+// validateCustodyConfig validates chain-of-custody configuration.
+func validateCustodyConfig(config *CustodyConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+
+	if strings.TrimSpace(config.ManifestPath) == "" {
+		return &ValidationError{Field: "custody.manifest_path", Message: "manifest path cannot be empty"}
+	}
+
+	if strings.TrimSpace(config.SigningKeyPath) == "" {
+		return &ValidationError{Field: "custody.signing_key_path", Message: "signing key path cannot be empty"}
+	}
+
+	if strings.TrimSpace(config.ExportPath) == "" {
+		return &ValidationError{Field: "custody.export_path", Message: "export path cannot be empty"}
+	}
+
+	return nil
+}
+
+// validateTracingConfig validates request tracing configuration.
+func validateTracingConfig(config *TracingConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+
+	switch config.Exporter {
+	case "otlp":
+		if strings.TrimSpace(config.Endpoint) == "" {
+			return &ValidationError{Field: "tracing.endpoint", Message: "endpoint cannot be empty for the otlp exporter"}
+		}
+	case "file":
+		if strings.TrimSpace(config.FilePath) == "" {
+			return &ValidationError{Field: "tracing.file_path", Message: "file path cannot be empty for the file exporter"}
+		}
+	default:
+		return &ValidationError{Field: "tracing.exporter", Message: fmt.Sprintf("exporter must be one of: [otlp file], got %s", config.Exporter)}
+	}
+
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return &ValidationError{Field: "tracing.sample_ratio", Message: fmt.Sprintf("sample ratio must be between 0 and 1, got %g", config.SampleRatio)}
+	}
+
+	if config.BatchSize < 0 || config.FlushIntervalMs < 0 || config.QueueSize < 0 {
+		return &ValidationError{Field: "tracing", Message: "batch size, flush interval and queue size cannot be negative"}
+	}
+
 	return nil
 }
 
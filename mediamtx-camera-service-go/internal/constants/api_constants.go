@@ -24,6 +24,7 @@ const (
 	API_RATE_LIMIT_EXCEEDED     = -32040 // Rate Limited (too many requests)
 	API_DEPENDENCY_FAILED       = -32050 // Dependency Failed (MediaMTX/FFmpeg error)
 	API_NOT_FOUND               = -32010 // Not Found (recording/file/camera not found)
+	API_INSUFFICIENT_STORAGE    = -32007 // Insufficient Storage (storage over block threshold)
 
 	// Legacy constants (deprecated)
 	API_INSUFFICIENT_PERMISSIONS = API_PERMISSION_DENIED
@@ -164,6 +165,7 @@ var APIErrorMessages = map[int]string{
 	API_RATE_LIMIT_EXCEEDED:     "Rate limited",
 	API_DEPENDENCY_FAILED:       "Dependency failed",
 	API_NOT_FOUND:               "Not found",
+	API_INSUFFICIENT_STORAGE:    "Insufficient storage",
 
 	// Enhanced Recording Management Error Codes
 	ERROR_CAMERA_NOT_FOUND:         "Camera not found",
@@ -23,6 +23,7 @@
 //   - /health/detailed: Comprehensive health with components and metrics
 //   - /ready: Readiness probe for Kubernetes
 //   - /alive: Liveness probe for Kubernetes
+//   - /metrics: Prometheus text format metrics from registered collectors (when enabled)
 //
 // Health Status Semantics:
 //   - healthy: All components operational, system ready for requests
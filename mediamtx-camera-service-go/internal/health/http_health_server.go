@@ -9,13 +9,18 @@ import (
 
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/metrics"
 )
 
+// defaultMetricsEndpoint is the metrics path used when none is configured
+const defaultMetricsEndpoint = "/metrics"
+
 // HTTPHealthServer implements HTTP health endpoints with thin delegation pattern.
 type HTTPHealthServer struct {
 	config    *config.HTTPHealthConfig
 	logger    *logging.Logger
 	healthAPI HealthAPI
+	metrics   *metrics.Registry
 	server    *http.Server
 	startTime time.Time
 }
@@ -36,6 +41,7 @@ func NewHTTPHealthServer(config *config.HTTPHealthConfig, healthAPI HealthAPI, l
 		config:    config,
 		logger:    logger,
 		healthAPI: healthAPI,
+		metrics:   metrics.NewRegistry(),
 		startTime: time.Now(),
 	}
 
@@ -47,6 +53,9 @@ func NewHTTPHealthServer(config *config.HTTPHealthConfig, healthAPI HealthAPI, l
 	mux.HandleFunc(config.DetailedEndpoint, server.handleDetailedHealth)
 	mux.HandleFunc(config.ReadyEndpoint, server.handleReadiness)
 	mux.HandleFunc(config.LiveEndpoint, server.handleLiveness)
+	if config.EnableMetrics {
+		mux.HandleFunc(server.metricsEndpoint(), server.handleMetrics)
+	}
 
 	// Parse timeouts
 	readTimeout, err := time.ParseDuration(config.ReadTimeout)
@@ -89,13 +98,8 @@ func (hs *HTTPHealthServer) Start(ctx context.Context) error {
 	}
 
 	hs.logger.WithFields(logging.Fields{
-		"address": hs.server.Addr,
-		"endpoints": []string{
-			hs.config.BasicEndpoint,
-			hs.config.DetailedEndpoint,
-			hs.config.ReadyEndpoint,
-			hs.config.LiveEndpoint,
-		},
+		"address":   hs.server.Addr,
+		"endpoints": hs.endpoints(),
 	}).Info("Starting HTTP Health Server")
 
 	// Start server in goroutine
@@ -233,6 +237,35 @@ func (hs *HTTPHealthServer) handleLiveness(w http.ResponseWriter, r *http.Reques
 	hs.logRequest(r, "liveness", time.Since(start), statusCode)
 }
 
+// RegisterMetricsCollector adds a component whose metrics are exposed on the metrics endpoint
+func (hs *HTTPHealthServer) RegisterMetricsCollector(collector metrics.Collector) {
+	hs.metrics.Register(collector)
+}
+
+// metricsEndpoint returns the configured metrics endpoint path
+func (hs *HTTPHealthServer) metricsEndpoint() string {
+	if hs.config.MetricsEndpoint != "" {
+		return hs.config.MetricsEndpoint
+	}
+	return defaultMetricsEndpoint
+}
+
+// handleMetrics handles the Prometheus metrics endpoint
+func (hs *HTTPHealthServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	// Delegate to registered collectors - NO business logic in HTTP server
+	w.Header().Set("Content-Type", metrics.ContentType)
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.WriteHeader(http.StatusOK)
+	if err := hs.metrics.WriteText(r.Context(), w); err != nil {
+		hs.logger.WithError(err).Error("Failed to write metrics response")
+	}
+
+	// Log request
+	hs.logRequest(r, "metrics", time.Since(start), http.StatusOK)
+}
+
 // setResponseHeaders sets common response headers
 func (hs *HTTPHealthServer) setResponseHeaders(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json")
@@ -289,11 +322,20 @@ func (hs *HTTPHealthServer) GetServerInfo() map[string]interface{} {
 		"port":       hs.config.Port,
 		"start_time": hs.startTime,
 		"uptime":     time.Since(hs.startTime).String(),
-		"endpoints": []string{
-			hs.config.BasicEndpoint,
-			hs.config.DetailedEndpoint,
-			hs.config.ReadyEndpoint,
-			hs.config.LiveEndpoint,
-		},
+		"endpoints":  hs.endpoints(),
+	}
+}
+
+// endpoints returns the paths served by the HTTP health server
+func (hs *HTTPHealthServer) endpoints() []string {
+	endpoints := []string{
+		hs.config.BasicEndpoint,
+		hs.config.DetailedEndpoint,
+		hs.config.ReadyEndpoint,
+		hs.config.LiveEndpoint,
+	}
+	if hs.config.EnableMetrics {
+		endpoints = append(endpoints, hs.metricsEndpoint())
 	}
+	return endpoints
 }
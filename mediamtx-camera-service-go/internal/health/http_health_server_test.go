@@ -24,6 +24,7 @@ import (
 
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/metrics"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -635,3 +636,48 @@ func TestHTTPHealthServer_Stop_Disabled(t *testing.T) {
 	err = server.Stop()
 	assert.NoError(t, err)
 }
+
+func TestHTTPHealthServer_handleMetrics(t *testing.T) {
+	// Setup
+	config := &config.HTTPHealthConfig{
+		Enabled:          true,
+		Host:             "localhost",
+		Port:             8003,
+		ReadTimeout:      "5s",
+		WriteTimeout:     "5s",
+		IdleTimeout:      "30s",
+		BasicEndpoint:    "/health",
+		DetailedEndpoint: "/health/detailed",
+		ReadyEndpoint:    "/health/ready",
+		LiveEndpoint:     "/health/live",
+		EnableMetrics:    true,
+	}
+
+	server, err := NewHTTPHealthServer(config, &mockHealthAPI{}, logging.GetLogger("test"))
+	require.NoError(t, err)
+	server.RegisterMetricsCollector(metrics.CollectorFunc(func(ctx context.Context) []metrics.Family {
+		return []metrics.Family{
+			metrics.Gauge("camera_service_camera_recording", "Whether a camera is recording.",
+				metrics.Sample{Labels: metrics.Labels{"camera": "camera0"}, Value: 1}),
+		}
+	}))
+	assert.Contains(t, server.GetServerInfo()["endpoints"], "/metrics")
+
+	// Serve through the registered routes
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	server.server.Handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, metrics.ContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "# TYPE camera_service_camera_recording gauge\n")
+	assert.Contains(t, w.Body.String(), `camera_service_camera_recording{camera="camera0"} 1`)
+
+	// The endpoint is only served when metrics are enabled
+	config.EnableMetrics = false
+	server, err = NewHTTPHealthServer(config, &mockHealthAPI{}, logging.GetLogger("test"))
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	server.server.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,107 @@
+package klv
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+const (
+	// readChunkSize is the amount read from the underlying stream at a time
+	readChunkSize = 4096
+
+	// maxPacketSize bounds the Local Set size accepted from a stream
+	maxPacketSize = 64 * 1024
+)
+
+// errNeedMoreData reports that a packet extends past the buffered data
+var errNeedMoreData = errors.New("need more data")
+
+// Decoder reads UAS Local Sets incrementally from a live KLV data stream, such
+// as the data track FFmpeg demuxes from a STANAG 4609 transport stream
+type Decoder struct {
+	r        io.Reader
+	buf      []byte
+	rejected int
+}
+
+// NewDecoder creates a decoder reading from r
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Rejected returns the number of packets skipped because they failed parsing or the checksum
+func (d *Decoder) Rejected() int {
+	return d.rejected
+}
+
+// Next returns the next valid packet, skipping corrupt or foreign data.
+// It returns io.EOF once the stream ends; a trailing partial packet is dropped.
+func (d *Decoder) Next() (*Packet, error) {
+	for {
+		if index := bytes.Index(d.buf, UASLocalSetKey); index >= 0 {
+			d.buf = d.buf[index:]
+			size, err := packetSize(d.buf)
+			switch {
+			case err == nil:
+				packet, _, err := Decode(d.buf[:size])
+				if err != nil {
+					d.rejected++
+					d.buf = d.buf[1:]
+					continue
+				}
+				d.buf = d.buf[size:]
+				return packet, nil
+			case !errors.Is(err, errNeedMoreData):
+				d.rejected++
+				d.buf = d.buf[1:]
+				continue
+			}
+		} else if keep := len(UASLocalSetKey) - 1; len(d.buf) > keep {
+			// Keep only what could be the start of a key split across reads
+			d.buf = d.buf[len(d.buf)-keep:]
+		}
+
+		if err := d.fill(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// fill appends the next chunk of the stream to the buffer
+func (d *Decoder) fill() error {
+	chunk := make([]byte, readChunkSize)
+	n, err := d.r.Read(chunk)
+	d.buf = append(d.buf, chunk[:n]...)
+	if err != nil && n == 0 {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return io.EOF
+		}
+		return err
+	}
+	return nil
+}
+
+// packetSize returns the total size of the Local Set at the start of data
+func packetSize(data []byte) (int, error) {
+	header := len(UASLocalSetKey)
+	if len(data) <= header {
+		return 0, errNeedMoreData
+	}
+	// A long-form length may itself be split across reads
+	if data[header] >= 0x80 && len(data) < header+1+int(data[header]&0x7F) {
+		return 0, errNeedMoreData
+	}
+	length, lengthSize, err := readLength(data[header:])
+	if err != nil {
+		return 0, err
+	}
+	size := header + lengthSize + length
+	if size > maxPacketSize {
+		return 0, ErrInvalidPacket
+	}
+	if size > len(data) {
+		return 0, errNeedMoreData
+	}
+	return size, nil
+}
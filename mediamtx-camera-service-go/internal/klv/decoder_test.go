@@ -0,0 +1,57 @@
+/*
+KLV Stream Decoder Unit Tests
+
+Tests incremental decoding of live KLV data streams split across reads.
+
+Test Categories: Unit
+*/
+
+package klv
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_ReadsPacketsSplitAcrossReads(t *testing.T) {
+	var stream bytes.Buffer
+	stream.WriteString("noise")
+	for i := 0; i < 3; i++ {
+		packet := testPacket()
+		packet.Timestamp = packet.Timestamp.Add(time.Duration(i) * time.Second)
+		data, err := Encode(packet)
+		require.NoError(t, err)
+		if i == 1 {
+			corrupt := append([]byte(nil), data...)
+			corrupt[len(corrupt)-1] ^= 0xFF
+			stream.Write(corrupt)
+		}
+		stream.Write(data)
+	}
+	// A trailing partial packet is dropped at the end of the stream
+	data, err := Encode(testPacket())
+	require.NoError(t, err)
+	stream.Write(data[:len(data)/2])
+
+	decoder := NewDecoder(iotest.OneByteReader(&stream))
+	var packets []*Packet
+	for {
+		packet, err := decoder.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		packets = append(packets, packet)
+	}
+
+	require.Len(t, packets, 3)
+	assert.Equal(t, 1, decoder.Rejected())
+	assert.True(t, testPacket().Timestamp.Add(2*time.Second).Equal(packets[2].Timestamp))
+	require.NotNil(t, packets[0].Position)
+}
@@ -0,0 +1,25 @@
+// Package klv encodes and decodes MISB ST 0601 UAS Datalink Local Sets.
+//
+// STANAG 4609 motion imagery carries platform and sensor metadata as SMPTE
+// 336M KLV packets in a data stream alongside the video. This package builds
+// the ST 0601 core tags the service can populate - precision timestamp,
+// mission and platform identification, platform attitude, sensor position
+// and field of view - and parses them back out of a recorded data stream.
+//
+// Architecture Compliance:
+//   - Pure Encoding: No file, FFmpeg or configuration dependencies
+//   - Integrity: Every packet carries the ST 0601 checksum, verified on decode
+//   - Resilience: Stream decoding resynchronizes on the Local Set key
+//
+// Key Components:
+//   - Packet: One Local Set with timestamp, identification and position
+//   - Encode/Decode: Single packet encoding and checksum-verified decoding
+//   - DecodeStream: Extraction of all packets from a recorded KLV data stream
+//   - Decoder: Incremental decoding of a live KLV data stream
+//
+// Requirements Coverage:
+//   - REQ-MTX-002: STANAG 4609 compliance for UAV streams
+//
+// Test Categories: Unit
+// API Documentation Reference: docs/api/json_rpc_methods.md
+package klv
@@ -0,0 +1,376 @@
+package klv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// UASLocalSetKey is the SMPTE 336M universal key of the ST 0601 UAS Datalink Local Set
+var UASLocalSetKey = []byte{
+	0x06, 0x0E, 0x2B, 0x34, 0x02, 0x0B, 0x01, 0x01,
+	0x0E, 0x01, 0x03, 0x01, 0x01, 0x00, 0x00, 0x00,
+}
+
+// ST 0601 tags produced and parsed by this package
+const (
+	TagChecksum              = 1
+	TagPrecisionTimeStamp    = 2
+	TagMissionID             = 3
+	TagPlatformHeading       = 5
+	TagPlatformPitch         = 6
+	TagPlatformRoll          = 7
+	TagPlatformDesignation   = 10
+	TagImageSourceSensor     = 11
+	TagSensorLatitude        = 13
+	TagSensorLongitude       = 14
+	TagSensorTrueAltitude    = 15
+	TagSensorHorizontalFOV   = 16
+	TagSensorVerticalFOV     = 17
+	TagSensorRelativeAzimuth = 18
+	TagUASLSVersion          = 65
+)
+
+// LSVersion is the ST 0601 version number written into tag 65
+const LSVersion = 19
+
+// maxStringLength bounds the ST 0601 string tags
+const maxStringLength = 127
+
+var (
+	// ErrInvalidPacket is returned for data that is not a well-formed UAS Local Set
+	ErrInvalidPacket = errors.New("invalid KLV packet")
+
+	// ErrChecksumMismatch is returned when a packet's checksum does not match its contents
+	ErrChecksumMismatch = errors.New("KLV checksum mismatch")
+)
+
+// Position holds the platform attitude and sensor position of one packet
+type Position struct {
+	Latitude              float64 // Sensor latitude, degrees (-90..90)
+	Longitude             float64 // Sensor longitude, degrees (-180..180)
+	Altitude              float64 // Sensor true altitude, meters MSL (-900..19000)
+	Heading               float64 // Platform heading, degrees (0..360)
+	Pitch                 float64 // Platform pitch, degrees (-20..20)
+	Roll                  float64 // Platform roll, degrees (-50..50)
+	HorizontalFOV         float64 // Sensor horizontal field of view, degrees (0..180)
+	VerticalFOV           float64 // Sensor vertical field of view, degrees (0..180)
+	SensorRelativeAzimuth float64 // Sensor azimuth relative to the platform, degrees (0..360)
+}
+
+// Packet is one ST 0601 UAS Datalink Local Set
+type Packet struct {
+	Timestamp           time.Time
+	MissionID           string
+	PlatformDesignation string
+	ImageSourceSensor   string
+	Position            *Position // nil when no position is known
+	Version             uint8     // Set on decode; Encode always writes LSVersion
+}
+
+// Encode serializes a packet as a UAS Local Set with a trailing checksum
+func Encode(p *Packet) ([]byte, error) {
+	if p == nil {
+		return nil, fmt.Errorf("%w: nil packet", ErrInvalidPacket)
+	}
+	if p.Timestamp.IsZero() {
+		return nil, fmt.Errorf("%w: timestamp is required", ErrInvalidPacket)
+	}
+
+	var value bytes.Buffer
+	putUint(&value, TagPrecisionTimeStamp, uint64(p.Timestamp.UnixMicro()), 8)
+	for _, item := range []struct {
+		tag   byte
+		value string
+	}{
+		{TagMissionID, p.MissionID},
+		{TagPlatformDesignation, p.PlatformDesignation},
+		{TagImageSourceSensor, p.ImageSourceSensor},
+	} {
+		if item.value == "" {
+			continue
+		}
+		if len(item.value) > maxStringLength {
+			return nil, fmt.Errorf("%w: tag %d exceeds %d bytes", ErrInvalidPacket, item.tag, maxStringLength)
+		}
+		putItem(&value, item.tag, []byte(item.value))
+	}
+
+	if pos := p.Position; pos != nil {
+		if err := encodePosition(&value, pos); err != nil {
+			return nil, err
+		}
+	}
+	putUint(&value, TagUASLSVersion, LSVersion, 1)
+
+	// The checksum covers everything up to and including its own tag and length
+	value.Write([]byte{TagChecksum, 2})
+	var packet bytes.Buffer
+	packet.Write(UASLocalSetKey)
+	putLength(&packet, value.Len()+2)
+	packet.Write(value.Bytes())
+	sum := checksum(packet.Bytes())
+	packet.Write([]byte{byte(sum >> 8), byte(sum)})
+	return packet.Bytes(), nil
+}
+
+// encodePosition writes the attitude, position and field of view tags
+func encodePosition(buf *bytes.Buffer, pos *Position) error {
+	checks := []struct {
+		name     string
+		value    float64
+		min, max float64
+	}{
+		{"latitude", pos.Latitude, -90, 90},
+		{"longitude", pos.Longitude, -180, 180},
+		{"altitude", pos.Altitude, -900, 19000},
+		{"heading", pos.Heading, 0, 360},
+		{"pitch", pos.Pitch, -20, 20},
+		{"roll", pos.Roll, -50, 50},
+		{"horizontal FOV", pos.HorizontalFOV, 0, 180},
+		{"vertical FOV", pos.VerticalFOV, 0, 180},
+		{"sensor relative azimuth", pos.SensorRelativeAzimuth, 0, 360},
+	}
+	for _, check := range checks {
+		if math.IsNaN(check.value) || check.value < check.min || check.value > check.max {
+			return fmt.Errorf("%w: %s %.6f outside %.0f..%.0f", ErrInvalidPacket, check.name, check.value, check.min, check.max)
+		}
+	}
+
+	putUint(buf, TagPlatformHeading, mapUnsigned(pos.Heading, 0, 360, math.MaxUint16), 2)
+	putUint(buf, TagPlatformPitch, uint64(uint16(mapSigned(pos.Pitch, 20, math.MaxInt16))), 2)
+	putUint(buf, TagPlatformRoll, uint64(uint16(mapSigned(pos.Roll, 50, math.MaxInt16))), 2)
+	putUint(buf, TagSensorLatitude, uint64(uint32(mapSigned(pos.Latitude, 90, math.MaxInt32))), 4)
+	putUint(buf, TagSensorLongitude, uint64(uint32(mapSigned(pos.Longitude, 180, math.MaxInt32))), 4)
+	putUint(buf, TagSensorTrueAltitude, mapUnsigned(pos.Altitude, -900, 19000, math.MaxUint16), 2)
+	putUint(buf, TagSensorHorizontalFOV, mapUnsigned(pos.HorizontalFOV, 0, 180, math.MaxUint16), 2)
+	putUint(buf, TagSensorVerticalFOV, mapUnsigned(pos.VerticalFOV, 0, 180, math.MaxUint16), 2)
+	putUint(buf, TagSensorRelativeAzimuth, mapUnsigned(pos.SensorRelativeAzimuth, 0, 360, math.MaxUint32), 4)
+	return nil
+}
+
+// Decode parses one UAS Local Set and returns it with the number of bytes consumed.
+// The checksum is verified; tags this package does not know are skipped.
+func Decode(data []byte) (*Packet, int, error) {
+	if len(data) < len(UASLocalSetKey) || !bytes.Equal(data[:len(UASLocalSetKey)], UASLocalSetKey) {
+		return nil, 0, fmt.Errorf("%w: missing UAS Local Set key", ErrInvalidPacket)
+	}
+	length, lengthSize, err := readLength(data[len(UASLocalSetKey):])
+	if err != nil {
+		return nil, 0, err
+	}
+	start := len(UASLocalSetKey) + lengthSize
+	end := start + length
+	if length < 4 || end > len(data) {
+		return nil, 0, fmt.Errorf("%w: truncated packet", ErrInvalidPacket)
+	}
+
+	// The checksum item must be last
+	if data[end-4] != TagChecksum || data[end-3] != 2 {
+		return nil, 0, fmt.Errorf("%w: checksum item missing", ErrInvalidPacket)
+	}
+	if want := binary.BigEndian.Uint16(data[end-2 : end]); checksum(data[:end-2]) != want {
+		return nil, 0, ErrChecksumMismatch
+	}
+
+	packet := &Packet{}
+	var pos Position
+	var hasLatitude, hasLongitude bool
+	value := data[start : end-4]
+	for len(value) > 0 {
+		tag, tagSize, err := readTag(value)
+		if err != nil {
+			return nil, 0, err
+		}
+		itemLength, itemLengthSize, err := readLength(value[tagSize:])
+		if err != nil {
+			return nil, 0, err
+		}
+		itemStart := tagSize + itemLengthSize
+		if itemStart+itemLength > len(value) {
+			return nil, 0, fmt.Errorf("%w: tag %d overruns the packet", ErrInvalidPacket, tag)
+		}
+		item := value[itemStart : itemStart+itemLength]
+		value = value[itemStart+itemLength:]
+
+		switch tag {
+		case TagPrecisionTimeStamp:
+			if len(item) == 8 {
+				packet.Timestamp = time.UnixMicro(int64(binary.BigEndian.Uint64(item))).UTC()
+			}
+		case TagMissionID:
+			packet.MissionID = string(item)
+		case TagPlatformDesignation:
+			packet.PlatformDesignation = string(item)
+		case TagImageSourceSensor:
+			packet.ImageSourceSensor = string(item)
+		case TagUASLSVersion:
+			if len(item) == 1 {
+				packet.Version = item[0]
+			}
+		case TagPlatformHeading:
+			if len(item) == 2 {
+				pos.Heading = unmapUnsigned(uint64(binary.BigEndian.Uint16(item)), 0, 360, math.MaxUint16)
+			}
+		case TagPlatformPitch:
+			if len(item) == 2 {
+				pos.Pitch = unmapSigned(int64(int16(binary.BigEndian.Uint16(item))), 20, math.MaxInt16)
+			}
+		case TagPlatformRoll:
+			if len(item) == 2 {
+				pos.Roll = unmapSigned(int64(int16(binary.BigEndian.Uint16(item))), 50, math.MaxInt16)
+			}
+		case TagSensorLatitude:
+			// 0x80000000 is the ST 0601 "out of range" indicator
+			if len(item) == 4 && binary.BigEndian.Uint32(item) != 0x80000000 {
+				pos.Latitude = unmapSigned(int64(int32(binary.BigEndian.Uint32(item))), 90, math.MaxInt32)
+				hasLatitude = true
+			}
+		case TagSensorLongitude:
+			if len(item) == 4 && binary.BigEndian.Uint32(item) != 0x80000000 {
+				pos.Longitude = unmapSigned(int64(int32(binary.BigEndian.Uint32(item))), 180, math.MaxInt32)
+				hasLongitude = true
+			}
+		case TagSensorTrueAltitude:
+			if len(item) == 2 {
+				pos.Altitude = unmapUnsigned(uint64(binary.BigEndian.Uint16(item)), -900, 19000, math.MaxUint16)
+			}
+		case TagSensorHorizontalFOV:
+			if len(item) == 2 {
+				pos.HorizontalFOV = unmapUnsigned(uint64(binary.BigEndian.Uint16(item)), 0, 180, math.MaxUint16)
+			}
+		case TagSensorVerticalFOV:
+			if len(item) == 2 {
+				pos.VerticalFOV = unmapUnsigned(uint64(binary.BigEndian.Uint16(item)), 0, 180, math.MaxUint16)
+			}
+		case TagSensorRelativeAzimuth:
+			if len(item) == 4 {
+				pos.SensorRelativeAzimuth = unmapUnsigned(uint64(binary.BigEndian.Uint32(item)), 0, 360, math.MaxUint32)
+			}
+		}
+	}
+
+	if hasLatitude && hasLongitude {
+		packet.Position = &pos
+	}
+	return packet, end, nil
+}
+
+// DecodeStream extracts every valid packet from a raw KLV data stream.
+// Corrupt or foreign data is skipped by searching for the next Local Set key;
+// the number of rejected packets is returned alongside the decoded ones.
+func DecodeStream(data []byte) ([]*Packet, int) {
+	var packets []*Packet
+	rejected := 0
+	for {
+		index := bytes.Index(data, UASLocalSetKey)
+		if index < 0 {
+			return packets, rejected
+		}
+		data = data[index:]
+
+		packet, size, err := Decode(data)
+		if err != nil {
+			rejected++
+			data = data[1:]
+			continue
+		}
+		packets = append(packets, packet)
+		data = data[size:]
+	}
+}
+
+// checksum computes the ST 0601 16-bit running sum
+func checksum(data []byte) uint16 {
+	var sum uint16
+	for i, b := range data {
+		sum += uint16(b) << (8 * ((i + 1) % 2))
+	}
+	return sum
+}
+
+// putItem writes a tag, BER length and value
+func putItem(buf *bytes.Buffer, tag byte, value []byte) {
+	buf.WriteByte(tag)
+	putLength(buf, len(value))
+	buf.Write(value)
+}
+
+// putUint writes an unsigned big-endian value of the given size
+func putUint(buf *bytes.Buffer, tag byte, value uint64, size int) {
+	item := make([]byte, 8)
+	binary.BigEndian.PutUint64(item, value)
+	putItem(buf, tag, item[8-size:])
+}
+
+// putLength writes a BER short- or long-form length
+func putLength(buf *bytes.Buffer, length int) {
+	if length < 0x80 {
+		buf.WriteByte(byte(length))
+		return
+	}
+	var encoded []byte
+	for length > 0 {
+		encoded = append([]byte{byte(length)}, encoded...)
+		length >>= 8
+	}
+	buf.WriteByte(0x80 | byte(len(encoded)))
+	buf.Write(encoded)
+}
+
+// readLength parses a BER length and returns it with the bytes it occupies
+func readLength(data []byte) (int, int, error) {
+	if len(data) == 0 {
+		return 0, 0, fmt.Errorf("%w: missing length", ErrInvalidPacket)
+	}
+	if data[0] < 0x80 {
+		return int(data[0]), 1, nil
+	}
+	size := int(data[0] & 0x7F)
+	if size == 0 || size > 4 || len(data) < 1+size {
+		return 0, 0, fmt.Errorf("%w: bad BER length", ErrInvalidPacket)
+	}
+	length := 0
+	for _, b := range data[1 : 1+size] {
+		length = length<<8 | int(b)
+	}
+	return length, 1 + size, nil
+}
+
+// readTag parses a BER-OID encoded tag
+func readTag(data []byte) (int, int, error) {
+	tag := 0
+	for i, b := range data {
+		if i >= 4 {
+			break
+		}
+		tag = tag<<7 | int(b&0x7F)
+		if b&0x80 == 0 {
+			return tag, i + 1, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("%w: bad tag", ErrInvalidPacket)
+}
+
+// mapUnsigned maps value in min..max onto 0..scale
+func mapUnsigned(value, min, max float64, scale uint64) uint64 {
+	return uint64(math.Round((value - min) / (max - min) * float64(scale)))
+}
+
+// unmapUnsigned is the inverse of mapUnsigned
+func unmapUnsigned(raw uint64, min, max float64, scale uint64) float64 {
+	return float64(raw)/float64(scale)*(max-min) + min
+}
+
+// mapSigned maps value in -limit..limit onto -scale..scale
+func mapSigned(value, limit float64, scale int64) int64 {
+	return int64(math.Round(value / limit * float64(scale)))
+}
+
+// unmapSigned is the inverse of mapSigned
+func unmapSigned(raw int64, limit float64, scale int64) float64 {
+	return float64(raw) / float64(scale) * limit
+}
@@ -0,0 +1,127 @@
+/*
+ST 0601 KLV Encoding Unit Tests
+
+Tests Local Set encoding, checksum-verified decoding and stream resynchronization.
+
+Test Categories: Unit
+*/
+
+package klv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPacket() *Packet {
+	return &Packet{
+		Timestamp:           time.Date(2025, 1, 1, 10, 0, 0, 123456000, time.UTC),
+		MissionID:           "MISSION-7",
+		PlatformDesignation: "Camera Service",
+		ImageSourceSensor:   "camera0",
+		Position: &Position{
+			Latitude:              48.8584,
+			Longitude:             -2.2945,
+			Altitude:              310.5,
+			Heading:               271.25,
+			Pitch:                 -3.5,
+			Roll:                  12.75,
+			HorizontalFOV:         62.2,
+			VerticalFOV:           48.8,
+			SensorRelativeAzimuth: 90,
+		},
+	}
+}
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	original := testPacket()
+	data, err := Encode(original)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, UASLocalSetKey))
+
+	decoded, size, err := Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), size)
+	assert.True(t, original.Timestamp.Equal(decoded.Timestamp))
+	assert.Equal(t, original.MissionID, decoded.MissionID)
+	assert.Equal(t, original.PlatformDesignation, decoded.PlatformDesignation)
+	assert.Equal(t, original.ImageSourceSensor, decoded.ImageSourceSensor)
+	assert.Equal(t, uint8(LSVersion), decoded.Version)
+
+	require.NotNil(t, decoded.Position)
+	assert.InDelta(t, original.Position.Latitude, decoded.Position.Latitude, 1e-6)
+	assert.InDelta(t, original.Position.Longitude, decoded.Position.Longitude, 1e-6)
+	assert.InDelta(t, original.Position.Altitude, decoded.Position.Altitude, 0.5)
+	assert.InDelta(t, original.Position.Heading, decoded.Position.Heading, 0.01)
+	assert.InDelta(t, original.Position.Pitch, decoded.Position.Pitch, 0.001)
+	assert.InDelta(t, original.Position.Roll, decoded.Position.Roll, 0.002)
+	assert.InDelta(t, original.Position.HorizontalFOV, decoded.Position.HorizontalFOV, 0.01)
+	assert.InDelta(t, original.Position.VerticalFOV, decoded.Position.VerticalFOV, 0.01)
+	assert.InDelta(t, original.Position.SensorRelativeAzimuth, decoded.Position.SensorRelativeAzimuth, 1e-6)
+}
+
+func TestEncode_RejectsInvalidPackets(t *testing.T) {
+	_, err := Encode(&Packet{})
+	assert.ErrorIs(t, err, ErrInvalidPacket, "Timestamp is required")
+
+	packet := testPacket()
+	packet.Position.Latitude = 91
+	_, err = Encode(packet)
+	assert.ErrorIs(t, err, ErrInvalidPacket)
+
+	packet = testPacket()
+	packet.MissionID = strings.Repeat("M", maxStringLength+1)
+	_, err = Encode(packet)
+	assert.ErrorIs(t, err, ErrInvalidPacket)
+}
+
+func TestDecode_VerifiesChecksum(t *testing.T) {
+	data, err := Encode(testPacket())
+	require.NoError(t, err)
+
+	data[len(UASLocalSetKey)+5] ^= 0x01
+	_, _, err = Decode(data)
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestDecode_LongFormLength(t *testing.T) {
+	// A mission ID near the string limit pushes the set past 127 bytes
+	packet := testPacket()
+	packet.MissionID = strings.Repeat("M", maxStringLength)
+	data, err := Encode(packet)
+	require.NoError(t, err)
+	assert.Equal(t, byte(0x81), data[len(UASLocalSetKey)], "BER long form length")
+
+	decoded, _, err := Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, packet.MissionID, decoded.MissionID)
+}
+
+func TestDecodeStream_ResynchronizesOnKey(t *testing.T) {
+	first, err := Encode(testPacket())
+	require.NoError(t, err)
+	secondPacket := testPacket()
+	secondPacket.Timestamp = secondPacket.Timestamp.Add(time.Second)
+	second, err := Encode(secondPacket)
+	require.NoError(t, err)
+
+	corrupt := append([]byte(nil), first...)
+	corrupt[len(corrupt)-1] ^= 0xFF
+
+	var stream []byte
+	stream = append(stream, []byte("garbage")...)
+	stream = append(stream, first...)
+	stream = append(stream, corrupt...)
+	stream = append(stream, 0x00, 0x01)
+	stream = append(stream, second...)
+
+	packets, rejected := DecodeStream(stream)
+	require.Len(t, packets, 2)
+	assert.Equal(t, 1, rejected)
+	assert.True(t, secondPacket.Timestamp.Equal(packets[1].Timestamp))
+}
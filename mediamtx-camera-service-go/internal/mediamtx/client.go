@@ -40,6 +40,7 @@ import (
 
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/tracing"
 )
 
 // client implements the MediaMTX HTTP client with connection pooling and fault tolerance.
@@ -165,7 +166,14 @@ func (c *client) Close() error {
 }
 
 // doRequest performs the actual HTTP request with proper error handling
-func (c *client) doRequest(ctx context.Context, method, path string, data []byte) ([]byte, error) {
+func (c *client) doRequest(ctx context.Context, method, path string, data []byte) (respBody []byte, err error) {
+	ctx, span := tracing.Start(ctx, "mediamtx "+method, tracing.SpanKindClient,
+		tracing.String("http.request.method", method),
+		tracing.String("url.path", path))
+	defer func() {
+		span.EndWithError(err)
+	}()
+
 	// Create request with context
 	url := c.baseURL + path
 	var body io.Reader
@@ -181,6 +189,9 @@ func (c *client) doRequest(ctx context.Context, method, path string, data []byte
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	if traceParent := span.TraceParent(); traceParent != "" {
+		req.Header.Set("traceparent", traceParent)
+	}
 
 	// Log request with detailed information
 	c.logger.WithFields(logging.Fields{
@@ -224,6 +235,7 @@ func (c *client) doRequest(ctx context.Context, method, path string, data []byte
 			"Content-Length": resp.Header.Get("Content-Length"),
 		},
 	}).Info("Received MediaMTX response")
+	span.SetAttributes(tracing.Int("http.response.status_code", resp.StatusCode))
 
 	// Check for HTTP errors
 	if resp.StatusCode >= 400 {
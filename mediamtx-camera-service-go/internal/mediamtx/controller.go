@@ -38,8 +38,15 @@ type controller struct {
 	rtspManager     RTSPConnectionManager // RTSP connection pooling and keepalive
 
 	// Layer 4: Business Logic - High-level operation orchestration
-	recordingManager *RecordingManager // Stateless recording via MediaMTX API
-	snapshotManager  *SnapshotManager  // Multi-tier snapshot capture (V4L2→FFmpeg→RTSP)
+	recordingManager   *RecordingManager   // Stateless recording via MediaMTX API
+	snapshotManager    *SnapshotManager    // Multi-tier snapshot capture (V4L2→FFmpeg→RTSP)
+	storageGuard       *StorageGuard       // Storage block threshold and fallback path enforcement
+	retentionJanitor   *RetentionJanitor   // Per-camera and per-group retention enforcement
+	tierManager        *StorageTierManager // Moves aged recordings to the archive tier
+	replicationManager *ReplicationManager // Off-box replication to S3-compatible storage
+	custodyManager     *CustodyManager     // Chain-of-custody hashing and signed manifest
+	storageEncryption  *StorageEncryption  // Encryption at rest for completed files
+	klvInjector        *KLVInjector        // STANAG 4609 KLV metadata tracks in recordings
 
 	// Configuration and Integration
 	config            *config.MediaMTXConfig // MediaMTX-specific configuration
@@ -51,7 +58,8 @@ type controller struct {
 	eventNotifier             MediaMTXEventNotifier      // Real-time client notifications
 
 	// Optional Components (may be nil based on configuration)
-	externalDiscovery *ExternalStreamDiscovery // Optional: UAV/UGV stream discovery
+	externalDiscovery  *ExternalStreamDiscovery // Optional: UAV/UGV stream discovery
+	telemetryExtractor *UAVTelemetryExtractor   // Optional: KLV telemetry of discovered UAV streams
 
 	// Concurrency and State Management
 	mu        sync.RWMutex    // Protects shared state during configuration changes
@@ -274,11 +282,48 @@ func ControllerWithConfigManager(configManager *config.ConfigManager, cameraMoni
 	// Create system metrics manager
 	systemMetricsManager := NewSystemMetricsManager(fullConfig, recordingConfig, configIntegration, logger)
 
+	// Create storage guard and enforce the block threshold on new captures
+	storageGuard := NewStorageGuard(fullConfig, logger)
+	recordingManager.SetStorageGuard(storageGuard)
+	snapshotManager.SetStorageGuard(storageGuard)
+
+	// Create retention janitor for per-camera and per-group retention rules
+	retentionJanitor := NewRetentionJanitor(fullConfig, logger)
+
+	// Create storage tier manager for moving aged recordings to the archive volume
+	tierManager := NewStorageTierManager(fullConfig, logger)
+	recordingManager.SetTierManager(tierManager)
+
+	// Create replication manager for off-box copies of recordings and snapshots
+	replicationManager := NewReplicationManager(fullConfig, logger)
+	recordingManager.SetReplicationManager(replicationManager)
+	storageGuard.SetReplicationManager(replicationManager)
+
+	// Create storage encryption for completed recordings and snapshots at rest
+	storageEncryption := NewStorageEncryption(fullConfig, logger)
+	recordingManager.SetStorageEncryption(storageEncryption)
+
+	// Create custody manager for hash-chained, signed records of captured media
+	custodyMetadata := NewMetadataManager(configIntegration, ffmpegManager, logger)
+	custodyMetadata.SetStorageEncryption(storageEncryption)
+	custodyManager := NewCustodyManager(fullConfig, custodyMetadata, logger)
+	recordingManager.SetCustodyManager(custodyManager)
+	snapshotManager.SetCustodyManager(custodyManager)
+
+	// Create KLV injector for MISB ST 0601 metadata tracks in finished recordings
+	klvInjector := NewKLVInjector(fullConfig, ffmpegManager, logger)
+	recordingManager.SetKLVInjector(klvInjector)
+
 	// Create external stream discovery (optional component based on configuration)
 	var externalDiscovery *ExternalStreamDiscovery
+	var telemetryExtractor *UAVTelemetryExtractor
 	if externalDiscoveryConfig, err := configIntegration.GetExternalDiscoveryConfig(); err == nil && externalDiscoveryConfig != nil && externalDiscoveryConfig.Enabled {
 		externalDiscovery = NewExternalStreamDiscovery(configIntegration, logger)
 		logger.Info("External stream discovery configured and enabled")
+
+		// Create UAV telemetry extractor for the KLV tracks of discovered STANAG 4609 streams
+		telemetryExtractor = NewUAVTelemetryExtractor(fullConfig, ffmpegManager, logger)
+		externalDiscovery.SetTelemetryExtractor(telemetryExtractor)
 	} else {
 		logger.Info("External stream discovery disabled or not configured")
 	}
@@ -293,6 +338,13 @@ func ControllerWithConfigManager(configManager *config.ConfigManager, cameraMoni
 		ffmpegManager:             ffmpegManager,
 		recordingManager:          recordingManager,
 		snapshotManager:           snapshotManager,
+		storageGuard:              storageGuard,
+		retentionJanitor:          retentionJanitor,
+		tierManager:               tierManager,
+		replicationManager:        replicationManager,
+		custodyManager:            custodyManager,
+		storageEncryption:         storageEncryption,
+		klvInjector:               klvInjector,
 		rtspManager:               rtspManager,
 		cameraMonitor:             cameraMonitor,
 		config:                    mediaMTXConfig,
@@ -301,6 +353,7 @@ func ControllerWithConfigManager(configManager *config.ConfigManager, cameraMoni
 		healthNotificationManager: healthNotificationManager,
 		systemMetricsManager:      systemMetricsManager,
 		externalDiscovery:         externalDiscovery, // Optional component based on configuration
+		telemetryExtractor:        telemetryExtractor,
 		// No local recording state - query MediaMTX directly
 	}
 
@@ -338,6 +391,46 @@ func (c *controller) Start(ctx context.Context) error {
 		c.systemMetricsManager.SetDependencies(c.recordingManager, c.cameraMonitor, c.streamManager)
 	}
 
+	// Start storage guard (migrates active recordings and cleans up when storage is blocked)
+	if c.storageGuard != nil {
+		c.storageGuard.SetDependencies(c.recordingManager, c.healthNotificationManager)
+		c.storageGuard.Start(c.ctx)
+	}
+
+	if c.retentionJanitor != nil {
+		c.retentionJanitor.Start(c.ctx)
+	}
+
+	if c.tierManager != nil {
+		if err := c.tierManager.Start(c.ctx); err != nil {
+			c.logger.WithError(err).Warn("Failed to start storage tiering")
+		}
+	}
+
+	if c.replicationManager != nil {
+		if err := c.replicationManager.Start(c.ctx); err != nil {
+			c.logger.WithError(err).Warn("Failed to start replication")
+		}
+	}
+
+	if c.storageEncryption != nil {
+		if err := c.storageEncryption.Start(c.ctx); err != nil {
+			c.logger.WithError(err).Error("Failed to start storage encryption")
+		}
+	}
+
+	if c.custodyManager != nil {
+		if err := c.custodyManager.Start(c.ctx); err != nil {
+			c.logger.WithError(err).Error("Failed to start chain-of-custody tracking")
+		}
+	}
+
+	if c.klvInjector != nil {
+		if err := c.klvInjector.Start(c.ctx); err != nil {
+			c.logger.WithError(err).Warn("Failed to start KLV metadata injection")
+		}
+	}
+
 	// Start camera monitor with startup coordination
 	if c.cameraMonitor != nil {
 		// Check camera monitor running state to avoid duplicate starts
@@ -361,6 +454,14 @@ func (c *controller) Start(ctx context.Context) error {
 		c.logger.Info("Path integration started successfully")
 	}
 
+	// Start UAV telemetry extraction before discovery so startup scan results are tracked
+	if c.telemetryExtractor != nil {
+		if err := c.telemetryExtractor.Start(c.ctx); err != nil {
+			c.logger.WithError(err).Error("Failed to start UAV telemetry extraction")
+			return fmt.Errorf("failed to start UAV telemetry extraction: %w", err)
+		}
+	}
+
 	// Start external stream discovery (optional component)
 	if c.externalDiscovery != nil {
 		if err := c.externalDiscovery.Start(ctx); err != nil {
@@ -460,6 +561,38 @@ func (c *controller) Stop(ctx context.Context) error {
 		}
 	}
 
+	// Stop storage guard
+	if c.storageGuard != nil {
+		c.storageGuard.Stop()
+	}
+
+	if c.retentionJanitor != nil {
+		c.retentionJanitor.Stop()
+	}
+
+	if c.tierManager != nil {
+		if err := c.tierManager.Stop(ctx); err != nil {
+			c.logger.WithError(err).Warn("Error stopping storage tiering")
+		}
+	}
+
+	if c.replicationManager != nil {
+		c.replicationManager.Stop()
+	}
+
+	// Pending KLV muxing hands finished recordings to custody hashing, so stop it first
+	if c.klvInjector != nil {
+		c.klvInjector.Stop()
+	}
+
+	if c.custodyManager != nil {
+		c.custodyManager.Stop()
+	}
+
+	if c.storageEncryption != nil {
+		c.storageEncryption.Stop()
+	}
+
 	// Stop health monitor
 	if err := c.healthMonitor.Stop(ctx); err != nil {
 		c.logger.WithError(err).Error("Failed to stop health monitor")
@@ -474,6 +607,10 @@ func (c *controller) Stop(ctx context.Context) error {
 		}
 	}
 
+	if c.telemetryExtractor != nil {
+		c.telemetryExtractor.Stop()
+	}
+
 	// Close HTTP client
 	if err := c.client.Close(); err != nil {
 		c.logger.WithError(err).Error("Failed to close HTTP client")
@@ -548,16 +685,13 @@ func (c *controller) GetServerInfo(ctx context.Context) (*GetServerInfoResponse,
 // Implements file lifecycle management for recording and snapshot storage with support for
 // age-based, count-based, and size-based cleanup strategies.
 //
-// Pure delegation pattern: Controller orchestrates cleanup by delegating to manager methods
-// and aggregating results for API response. All business logic resides in managers.
+// Pure delegation pattern: Controller delegates planning and deletion to the RetentionJanitor,
+// which applies per-camera rules, then per-group rules, then the global policy
+// (MaxAgeDays, MaxCount, MaxSizeGB) to cameras without their own rule.
+// Locked files (".lock" sidecar or still being written) are never deleted.
 //
-// Supports both retention policy types:
-// - Age-based: Removes files older than MaxAgeDays and excess files beyond MaxCount
-// - Size-based: Removes oldest files until total storage is under MaxSizeGB limit
-//
-// Returns accurate counts of removed recordings, snapshots, and space freed using
-// centralized configuration from configIntegration.GetCleanupLimits().
-func (c *controller) CleanupOldFiles(ctx context.Context) (*CleanupOldFilesResponse, error) {
+// When dryRun is set nothing is deleted; the response lists the files that would be removed.
+func (c *controller) CleanupOldFiles(ctx context.Context, dryRun bool) (*CleanupOldFilesResponse, error) {
 	if !c.checkRunningState() {
 		return nil, fmt.Errorf("controller is not running")
 	}
@@ -573,45 +707,39 @@ func (c *controller) CleanupOldFiles(ctx context.Context) (*CleanupOldFilesRespo
 		return nil, fmt.Errorf("retention policy is not enabled")
 	}
 
-	// Perform cleanup based on retention policy
-	var deletedCount int
-	var totalSize int64
-
-	// Get cleanup limits from centralized configuration
-	maxAge, maxCount, maxSize, err := c.configIntegration.GetCleanupLimits()
+	// Pure delegation to RetentionJanitor - returns API-ready response
+	response, err := c.retentionJanitor.Run(ctx, true, dryRun)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get cleanup limits: %v", err)
+		return nil, fmt.Errorf("failed to cleanup old files: %w", err)
 	}
+	return response, nil
+}
 
-	// Pure delegation to manager methods - pass size parameter for both age and size-based cleanup
-	var recordingsRemoved, snapshotsRemoved int
-	var recordingsSpaceFreed, snapshotsSpaceFreed int64
-
-	// Clean up recordings using extended manager method
-	recordingsRemoved, recordingsSpaceFreed, err = c.recordingManager.CleanupOldRecordings(ctx, maxAge, maxCount, maxSize)
-	if err != nil {
-		return nil, fmt.Errorf("failed to cleanup old recordings: %v", err)
+// VerifyFileIntegrity checks a recording or snapshot against its signed custody record
+func (c *controller) VerifyFileIntegrity(ctx context.Context, filename, fileType string) (*VerifyFileIntegrityResponse, error) {
+	if !c.checkRunningState() {
+		return nil, fmt.Errorf("controller is not running")
 	}
 
-	// Clean up snapshots using extended manager method
-	snapshotsRemoved, snapshotsSpaceFreed, err = c.snapshotManager.CleanupOldSnapshots(ctx, maxAge, maxCount, maxSize)
-	if err != nil {
-		return nil, fmt.Errorf("failed to cleanup old snapshots: %v", err)
+	return c.custodyManager.VerifyFile(ctx, filename, fileType)
+}
+
+// ExportEvidenceBundle bundles a file with the custody manifest, its signature and the public key
+func (c *controller) ExportEvidenceBundle(ctx context.Context, filename, fileType string) (*ExportEvidenceBundleResponse, error) {
+	if !c.checkRunningState() {
+		return nil, fmt.Errorf("controller is not running")
 	}
 
-	// Aggregate results from manager methods
-	deletedCount = recordingsRemoved + snapshotsRemoved
-	totalSize = recordingsSpaceFreed + snapshotsSpaceFreed
+	return c.custodyManager.ExportBundle(ctx, filename, fileType)
+}
 
-	// Build API-ready response using CleanupOldFilesResponse from rpc_types.go
-	response := &CleanupOldFilesResponse{
-		RecordingsRemoved: recordingsRemoved,
-		SnapshotsRemoved:  snapshotsRemoved,
-		SpaceFreed:        totalSize,
-		Status:            "SUCCESS",
-		Message:           fmt.Sprintf("Cleaned up %d files (%d recordings, %d snapshots), freed %d bytes", deletedCount, recordingsRemoved, snapshotsRemoved, totalSize),
+// RotateStorageKey rotates the storage key used to wrap per-file encryption keys
+func (c *controller) RotateStorageKey(ctx context.Context) (*RotateStorageKeyResponse, error) {
+	if !c.checkRunningState() {
+		return nil, fmt.Errorf("controller is not running")
 	}
-	return response, nil
+
+	return c.storageEncryption.RotateKey(ctx)
 }
 
 // SetRetentionPolicy updates the retention policy configuration
@@ -626,6 +754,22 @@ func (c *controller) SetRetentionPolicy(ctx context.Context, enabled bool, polic
 		return nil, fmt.Errorf("failed to get configuration: %w", err)
 	}
 
+	// Camera and group rules are stored alongside the global policy
+	if device, ok := params["device"].(string); ok && device != "" {
+		return c.setRetentionRule(cfg, "camera:"+device, cfg.RetentionPolicy.Cameras, device, nil, enabled, policyType, params)
+	}
+	if group, ok := params["group"].(string); ok && group != "" {
+		var cameras []string
+		if list, ok := params["cameras"].([]interface{}); ok {
+			for _, camera := range list {
+				if id, ok := camera.(string); ok {
+					cameras = append(cameras, id)
+				}
+			}
+		}
+		return c.setRetentionRule(cfg, "group:"+group, cfg.RetentionPolicy.Groups, group, cameras, enabled, policyType, params)
+	}
+
 	// Update retention policy configuration
 	cfg.RetentionPolicy.Enabled = enabled
 	cfg.RetentionPolicy.Type = policyType
@@ -666,6 +810,72 @@ func (c *controller) SetRetentionPolicy(ctx context.Context, enabled bool, polic
 	return response, nil
 }
 
+// setRetentionRule creates, updates or (when disabled) removes a camera or group retention rule.
+// The policy type selects which limit is updated; the other limit of an existing rule is kept.
+func (c *controller) setRetentionRule(cfg *config.Config, scope string, rules map[string]config.RetentionRuleConfig, name string, cameras []string,
+	enabled bool, policyType string, params map[string]interface{}) (*SetRetentionPolicyResponse, error) {
+	isGroup := strings.HasPrefix(scope, "group:")
+
+	if !enabled {
+		delete(rules, name)
+		return &SetRetentionPolicyResponse{
+			Success:    true,
+			PolicyType: policyType,
+			Scope:      scope,
+			Message:    fmt.Sprintf("Retention rule %s removed", scope),
+		}, nil
+	}
+
+	rule := rules[name]
+	if len(cameras) > 0 {
+		rule.Cameras = cameras
+	}
+	if isGroup && len(rule.Cameras) == 0 {
+		return nil, fmt.Errorf("retention group %s must list cameras", name)
+	}
+
+	switch policyType {
+	case "age":
+		if maxAgeDays, ok := params["max_age_days"].(float64); ok {
+			rule.MaxAgeDays = int(maxAgeDays)
+		} else if maxAgeDays, ok := params["max_age_days"].(int); ok {
+			rule.MaxAgeDays = maxAgeDays
+		}
+	case "size":
+		if maxSizeGB, ok := params["max_size_gb"].(float64); ok {
+			rule.MaxSizeGB = maxSizeGB
+		} else if maxSizeGB, ok := params["max_size_gb"].(int); ok {
+			rule.MaxSizeGB = float64(maxSizeGB)
+		}
+	}
+
+	if cfg.RetentionPolicy.Cameras == nil {
+		cfg.RetentionPolicy.Cameras = make(map[string]config.RetentionRuleConfig)
+	}
+	if cfg.RetentionPolicy.Groups == nil {
+		cfg.RetentionPolicy.Groups = make(map[string]config.RetentionRuleConfig)
+	}
+	if isGroup {
+		cfg.RetentionPolicy.Groups[name] = rule
+	} else {
+		cfg.RetentionPolicy.Cameras[name] = rule
+	}
+
+	response := &SetRetentionPolicyResponse{
+		Success:    true,
+		PolicyType: policyType,
+		Scope:      scope,
+		Message:    fmt.Sprintf("Retention rule %s updated successfully", scope),
+	}
+	if rule.MaxAgeDays > 0 {
+		response.MaxAge = fmt.Sprintf("%d days", rule.MaxAgeDays)
+	}
+	if rule.MaxSizeGB > 0 {
+		response.MaxSize = fmt.Sprintf("%g GB", rule.MaxSizeGB)
+	}
+	return response, nil
+}
+
 // SetSystemEventNotifier sets the system event notifier for health notifications
 func (c *controller) SetSystemEventNotifier(notifier SystemEventNotifier) {
 	if c.healthNotificationManager != nil {
@@ -678,6 +888,13 @@ func (c *controller) SetSystemEventNotifier(notifier SystemEventNotifier) {
 	}
 }
 
+// SetTelemetryEventNotifier sets the notifier for UAV telemetry from external streams
+func (c *controller) SetTelemetryEventNotifier(notifier TelemetryEventNotifier) {
+	if c.telemetryExtractor != nil {
+		c.telemetryExtractor.SetNotifier(notifier)
+	}
+}
+
 // GetStorageInfo returns information about the storage space used by recordings and snapshots.
 func (c *controller) GetStorageInfo(ctx context.Context) (*GetStorageInfoResponse, error) {
 	if !c.checkRunningState() {
@@ -685,7 +902,19 @@ func (c *controller) GetStorageInfo(ctx context.Context) (*GetStorageInfoRespons
 	}
 
 	// Pure delegation to SystemMetricsManager - returns API-ready response with storage calculations
-	return c.systemMetricsManager.GetStorageInfoAPI(ctx)
+	response, err := c.systemMetricsManager.GetStorageInfoAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Per-camera breakdown is best effort - totals remain valid without it
+	cameras, err := c.retentionJanitor.CameraBreakdown()
+	if err != nil {
+		c.logger.WithError(err).Warn("Failed to compute per-camera storage breakdown")
+	} else {
+		response.Cameras = cameras
+	}
+	return response, nil
 }
 
 // GetStreams returns all streams using cameraID-first architecture
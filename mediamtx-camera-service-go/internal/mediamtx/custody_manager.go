@@ -0,0 +1,758 @@
+/*
+MediaMTX Chain-of-Custody Implementation
+
+Hashes every completed recording and snapshot with SHA-256 and appends the
+digest to a hash-chained manifest. Each manifest entry links to the hash of
+its predecessor and is signed with the service's Ed25519 key, so removing,
+reordering or editing an entry - or altering a file - is detectable.
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-007: Error handling and recovery
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+)
+
+// Custody events that add a manifest entry
+const (
+	CustodyEventRecordingStop = "recording.stop"
+	CustodyEventSnapshotTaken = "snapshot.taken"
+)
+
+const (
+	// custodyGenesisHash is the previous hash of the first manifest entry
+	custodyGenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+	custodyPublicKeyFile       = "public_key.pem"
+	custodyBundleManifestFile  = "manifest.jsonl"
+	custodyBundleSignatureFile = "manifest.sig"
+
+	defaultCustodySettleWindow  = 3 * time.Second
+	defaultCustodySettleTimeout = 2 * time.Minute
+	custodySettlePollInterval   = time.Second
+	custodyHashTimeout          = 10 * time.Minute
+)
+
+// ErrCustodyDisabled is returned when chain-of-custody tracking is not enabled
+var ErrCustodyDisabled = errors.New("chain-of-custody tracking is disabled")
+
+// ErrCustodyRecordNotFound is returned when a file has no manifest entry
+var ErrCustodyRecordNotFound = errors.New("no custody record found for file")
+
+// custodyEntryBody holds the signed fields of a manifest entry
+type custodyEntryBody struct {
+	Sequence  int64  `json:"seq"`
+	Timestamp string `json:"timestamp"`
+	Event     string `json:"event"`
+	Device    string `json:"device"`
+	Filename  string `json:"filename"`
+	Path      string `json:"path"`
+	Kind      string `json:"kind"`
+	Size      int64  `json:"size"`
+	SHA256    string `json:"sha256"`
+	PrevHash  string `json:"prev_hash"`
+}
+
+// CustodyEntry is one line of the hash-chained manifest
+type CustodyEntry struct {
+	custodyEntryBody
+	EntryHash string `json:"entry_hash"` // SHA-256 of the JSON-encoded body
+	Signature string `json:"signature"`  // Base64 Ed25519 signature of the entry hash
+}
+
+// computeHash returns the hex SHA-256 of the entry body
+func (b *custodyEntryBody) computeHash() (string, error) {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// CustodyManager maintains the signed chain-of-custody manifest.
+//
+// RESPONSIBILITIES:
+// - Hash recordings on recording.stop once MediaMTX has finished writing them
+// - Hash snapshots on snapshot.taken
+// - Append hash-chained, signed entries to a JSON-lines manifest
+// - Verify files against the manifest and the manifest chain itself
+// - Export evidence bundles holding file, manifest, signature and public key
+//
+// The signing key is generated on first start and persisted with 0600 permissions.
+type CustodyManager struct {
+	config          *config.Config
+	logger          *logging.Logger
+	metadataManager *MetadataManager
+
+	settleWindow  time.Duration
+	settleTimeout time.Duration
+
+	mu         sync.Mutex
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+	lastSeq    int64
+	lastHash   string
+	recorded   map[string]bool
+	stopChan   chan struct{}
+	wg         sync.WaitGroup
+}
+
+// NewCustodyManager creates a new chain-of-custody manager
+func NewCustodyManager(cfg *config.Config, metadataManager *MetadataManager, logger *logging.Logger) *CustodyManager {
+	return &CustodyManager{
+		config:          cfg,
+		logger:          logger,
+		metadataManager: metadataManager,
+		settleWindow:    defaultCustodySettleWindow,
+		settleTimeout:   defaultCustodySettleTimeout,
+		lastHash:        custodyGenesisHash,
+		recorded:        make(map[string]bool),
+	}
+}
+
+// Enabled reports whether chain-of-custody tracking is configured
+func (cm *CustodyManager) Enabled() bool {
+	return cm.config.Custody.Enabled
+}
+
+// Start loads (or generates) the signing key and the manifest tail
+func (cm *CustodyManager) Start(ctx context.Context) error {
+	if !cm.Enabled() {
+		return nil
+	}
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if cm.stopChan != nil {
+		return nil
+	}
+
+	if err := cm.loadSigningKey(); err != nil {
+		return err
+	}
+
+	entries, _, err := cm.readManifest()
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		cm.lastSeq = last.Sequence
+		cm.lastHash = last.EntryHash
+	}
+	for _, entry := range entries {
+		cm.recorded[entry.Path] = true
+	}
+	if chainValid, signatureValid := verifyCustodyChain(entries, cm.publicKey); !chainValid || !signatureValid {
+		cm.logger.WithFields(logging.Fields{
+			"manifest":        cm.config.Custody.ManifestPath,
+			"chain_valid":     chainValid,
+			"signature_valid": signatureValid,
+		}).Error("Custody manifest failed verification on startup")
+	}
+
+	cm.stopChan = make(chan struct{})
+
+	cm.logger.WithFields(logging.Fields{
+		"manifest": cm.config.Custody.ManifestPath,
+		"entries":  len(entries),
+	}).Info("Chain-of-custody tracking started")
+	return nil
+}
+
+// Stop waits for pending recording hashes to finish
+func (cm *CustodyManager) Stop() {
+	cm.mu.Lock()
+	stopChan := cm.stopChan
+	cm.stopChan = nil
+	cm.mu.Unlock()
+
+	if stopChan == nil {
+		return
+	}
+	close(stopChan)
+	cm.wg.Wait()
+}
+
+// running returns the stop channel, or nil when the manager is not started
+func (cm *CustodyManager) running() chan struct{} {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	return cm.stopChan
+}
+
+// RecordSnapshot hashes a snapshot and appends it to the manifest
+func (cm *CustodyManager) RecordSnapshot(ctx context.Context, device, path string) {
+	if cm.running() == nil {
+		return
+	}
+	if _, err := cm.RecordFile(ctx, CustodyEventSnapshotTaken, device, path, fileKindSnapshot); err != nil {
+		cm.logger.WithError(err).WithField("path", path).Error("Failed to record snapshot custody")
+	}
+}
+
+// RecordRecordingStop hashes the recording segments written since startTime
+// once MediaMTX has finished writing them. It returns immediately.
+func (cm *CustodyManager) RecordRecordingStop(device string, startTime time.Time) {
+	stopChan := cm.running()
+	if stopChan == nil {
+		return
+	}
+
+	cm.wg.Add(1)
+	go func() {
+		defer cm.wg.Done()
+
+		files := cm.awaitRecordingFiles(stopChan, device, startTime)
+		if len(files) == 0 {
+			cm.logger.WithField("device", device).Warn("No recording files found for custody record")
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), custodyHashTimeout)
+		defer cancel()
+		go func() {
+			select {
+			case <-stopChan:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		for _, path := range files {
+			if _, err := cm.RecordFile(ctx, CustodyEventRecordingStop, device, path, fileKindRecording); err != nil {
+				cm.logger.WithError(err).WithField("path", path).Error("Failed to record recording custody")
+			}
+		}
+	}()
+}
+
+// awaitRecordingFiles waits until the device's new recording files stop changing
+func (cm *CustodyManager) awaitRecordingFiles(stopChan chan struct{}, device string, startTime time.Time) []string {
+	deadline := time.Now().Add(cm.settleTimeout)
+	for {
+		files, settled := cm.recordingFiles(device, startTime, time.Now())
+		if settled && len(files) > 0 {
+			return files
+		}
+		if time.Now().After(deadline) {
+			if len(files) > 0 {
+				cm.logger.WithField("device", device).Warn("Recording files still changing, hashing current contents")
+			}
+			return files
+		}
+
+		select {
+		case <-stopChan:
+			return nil
+		case <-time.After(custodySettlePollInterval):
+		}
+	}
+}
+
+// recordingFiles lists unrecorded recording files of a device modified since
+// startTime and reports whether all of them are past the settle window
+func (cm *CustodyManager) recordingFiles(device string, startTime, now time.Time) ([]string, bool) {
+	return recordingSegmentsSince(cm.config, device, startTime, now, cm.settleWindow, func(path string) bool {
+		cm.mu.Lock()
+		defer cm.mu.Unlock()
+		return cm.recorded[path]
+	})
+}
+
+// recordingSegmentsSince lists the recording files of a device modified since
+// startTime that skip does not exclude, across the primary and fallback
+// recordings paths, and reports whether all of them are past the settle window
+func recordingSegmentsSince(cfg *config.Config, device string, startTime, now time.Time, settleWindow time.Duration, skip func(path string) bool) ([]string, bool) {
+	roots := []string{cfg.MediaMTX.RecordingsPath}
+	if fallback := cfg.Storage.FallbackPath; fallback != "" {
+		roots = append(roots, fallback)
+	}
+
+	var files []string
+	settled := true
+	for _, root := range roots {
+		if root == "" {
+			continue
+		}
+		filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if path != root && d.Name() == fallbackSnapshotsDir && root == cfg.Storage.FallbackPath {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if isInternalStorageFile(path) {
+				return nil
+			}
+			if retentionDevice(root, path) != device {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil || info.ModTime().Before(startTime.Truncate(time.Second)) {
+				return nil
+			}
+			if skip != nil && skip(path) {
+				return nil
+			}
+
+			if now.Sub(info.ModTime()) < settleWindow {
+				settled = false
+			}
+			files = append(files, path)
+			return nil
+		})
+	}
+	return files, settled
+}
+
+// RecordFile hashes a file and appends a signed entry to the manifest
+func (cm *CustodyManager) RecordFile(ctx context.Context, event, device, path, kind string) (*CustodyEntry, error) {
+	if !cm.Enabled() {
+		return nil, ErrCustodyDisabled
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file for custody: %w", err)
+	}
+	digest, err := cm.metadataManager.ComputeFileHash(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if cm.privateKey == nil {
+		return nil, fmt.Errorf("custody signing key is not loaded")
+	}
+
+	entry := &CustodyEntry{custodyEntryBody: custodyEntryBody{
+		Sequence:  cm.lastSeq + 1,
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Event:     event,
+		Device:    device,
+		Filename:  filepath.Base(path),
+		Path:      path,
+		Kind:      kind,
+		Size:      info.Size(),
+		SHA256:    digest,
+		PrevHash:  cm.lastHash,
+	}}
+	if entry.EntryHash, err = entry.computeHash(); err != nil {
+		return nil, fmt.Errorf("failed to hash custody entry: %w", err)
+	}
+	hashBytes, _ := hex.DecodeString(entry.EntryHash)
+	entry.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(cm.privateKey, hashBytes))
+
+	if err := cm.appendEntry(entry); err != nil {
+		return nil, err
+	}
+	cm.lastSeq = entry.Sequence
+	cm.lastHash = entry.EntryHash
+	cm.recorded[path] = true
+
+	cm.logger.WithFields(logging.Fields{
+		"seq":    entry.Sequence,
+		"event":  event,
+		"device": device,
+		"path":   path,
+		"sha256": digest,
+	}).Info("Custody entry recorded")
+	return entry, nil
+}
+
+// appendEntry writes an entry to the manifest and syncs it; caller holds cm.mu
+func (cm *CustodyManager) appendEntry(entry *CustodyEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode custody entry: %w", err)
+	}
+
+	manifestPath := cm.config.Custody.ManifestPath
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0755); err != nil {
+		return fmt.Errorf("failed to create custody manifest directory: %w", err)
+	}
+	file, err := os.OpenFile(manifestPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open custody manifest: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to append custody entry: %w", err)
+	}
+	return file.Sync()
+}
+
+// VerifyFile checks a file against its latest manifest entry and verifies the
+// manifest chain and signatures
+func (cm *CustodyManager) VerifyFile(ctx context.Context, filename, kind string) (*VerifyFileIntegrityResponse, error) {
+	if !cm.Enabled() {
+		return nil, ErrCustodyDisabled
+	}
+
+	entries, _, err := cm.readManifest()
+	if err != nil {
+		return nil, err
+	}
+	entry := findCustodyEntry(entries, filename, kind)
+	if entry == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCustodyRecordNotFound, filename)
+	}
+
+	cm.mu.Lock()
+	publicKey := cm.publicKey
+	cm.mu.Unlock()
+	chainValid, signatureValid := verifyCustodyChain(entries, publicKey)
+	response := &VerifyFileIntegrityResponse{
+		Filename:       entry.Filename,
+		FileType:       kind,
+		Device:         entry.Device,
+		ChainValid:     chainValid,
+		SignatureValid: signatureValid,
+		RecordedSHA256: entry.SHA256,
+		RecordedAt:     entry.Timestamp,
+		Event:          entry.Event,
+		Sequence:       entry.Sequence,
+	}
+
+	path, err := cm.locate(entry)
+	if err != nil {
+		response.Message = "file is no longer available locally"
+		return response, nil
+	}
+	if response.CurrentSHA256, err = cm.metadataManager.ComputeFileHash(ctx, path); err != nil {
+		return nil, err
+	}
+
+	response.Intact = chainValid && signatureValid && response.CurrentSHA256 == entry.SHA256
+	switch {
+	case response.CurrentSHA256 != entry.SHA256:
+		response.Message = "file contents do not match the custody record"
+	case !chainValid:
+		response.Message = "custody manifest chain is broken"
+	case !signatureValid:
+		response.Message = "custody manifest signature is invalid"
+	default:
+		response.Message = "file matches its signed custody record"
+	}
+	return response, nil
+}
+
+// ExportBundle writes a zip holding the file, the manifest, a detached
+// manifest signature and the service public key
+func (cm *CustodyManager) ExportBundle(ctx context.Context, filename, kind string) (*ExportEvidenceBundleResponse, error) {
+	verification, err := cm.VerifyFile(ctx, filename, kind)
+	if err != nil {
+		return nil, err
+	}
+	if !verification.Intact {
+		return nil, fmt.Errorf("cannot export %s: %s", filename, verification.Message)
+	}
+
+	entries, manifest, err := cm.readManifest()
+	if err != nil {
+		return nil, err
+	}
+	entry := findCustodyEntry(entries, filename, kind)
+	if entry == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCustodyRecordNotFound, filename)
+	}
+	path, err := cm.locate(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	cm.mu.Lock()
+	privateKey, publicKey := cm.privateKey, cm.publicKey
+	cm.mu.Unlock()
+	if privateKey == nil {
+		return nil, fmt.Errorf("custody signing key is not loaded")
+	}
+	publicKeyPEM, err := encodeCustodyPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, manifest))
+
+	exportDir := cm.config.Custody.ExportPath
+	if err := os.MkdirAll(exportDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	now := time.Now()
+	bundleName := fmt.Sprintf("%s_custody_%d_%s.zip",
+		strings.TrimSuffix(entry.Filename, filepath.Ext(entry.Filename)), entry.Sequence, now.Format("20060102T150405"))
+	bundlePath := filepath.Join(exportDir, bundleName)
+
+	// Files encrypted at rest are exported as plaintext, matching the recorded hash
+	media, _, err := cm.metadataManager.OpenMediaFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file for export: %w", err)
+	}
+	defer media.Close()
+
+	if err := writeCustodyBundle(bundlePath, media, entry.Filename, manifest, []byte(signature+"\n"), publicKeyPEM); err != nil {
+		return nil, err
+	}
+	// Bundles hold plaintext footage, so they are encrypted at rest like the media itself
+	if se := cm.metadataManager.storageEncryption; se != nil && se.Enabled() {
+		if err := se.EncryptFile(ctx, bundlePath); err != nil {
+			os.Remove(bundlePath)
+			return nil, fmt.Errorf("failed to encrypt evidence bundle: %w", err)
+		}
+	}
+	info, err := os.Stat(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat evidence bundle: %w", err)
+	}
+
+	cm.logger.WithFields(logging.Fields{
+		"filename": entry.Filename,
+		"bundle":   bundlePath,
+		"seq":      entry.Sequence,
+	}).Info("Evidence bundle exported")
+
+	return &ExportEvidenceBundleResponse{
+		Filename:    entry.Filename,
+		FileType:    kind,
+		BundlePath:  bundlePath,
+		DownloadURL: EvidenceBundleFilesPath + bundleName,
+		BundleSize:  info.Size(),
+		SHA256:      entry.SHA256,
+		Sequence:    entry.Sequence,
+		CreatedAt:   now.Format(time.RFC3339),
+	}, nil
+}
+
+// writeCustodyBundle writes the bundle to a partial file and renames it into place
+func writeCustodyBundle(bundlePath string, media io.Reader, mediaName string, manifest, signature, publicKey []byte) error {
+	tmpPath := bundlePath + archivePartialSuffix
+	out, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create evidence bundle: %w", err)
+	}
+	defer os.Remove(tmpPath)
+
+	archive := zip.NewWriter(out)
+	writeErr := func() error {
+		w, err := archive.Create(mediaName)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(w, media); err != nil {
+			return err
+		}
+
+		for _, f := range []struct {
+			name string
+			data []byte
+		}{
+			{custodyBundleManifestFile, manifest},
+			{custodyBundleSignatureFile, signature},
+			{custodyPublicKeyFile, publicKey},
+		} {
+			w, err := archive.Create(f.name)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(f.data); err != nil {
+				return err
+			}
+		}
+		return archive.Close()
+	}()
+	closeErr := out.Close()
+	if writeErr != nil {
+		return fmt.Errorf("failed to write evidence bundle: %w", writeErr)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("failed to write evidence bundle: %w", closeErr)
+	}
+	return os.Rename(tmpPath, bundlePath)
+}
+
+// locate returns the current location of a recorded file, following
+// recordings that were moved to the archive tier
+func (cm *CustodyManager) locate(entry *CustodyEntry) (string, error) {
+	if _, err := os.Stat(entry.Path); err == nil {
+		return entry.Path, nil
+	}
+
+	tiering := cm.config.Storage.Tiering
+	if entry.Kind == fileKindRecording && tiering.Enabled && tiering.ArchivePath != "" {
+		if rel, err := filepath.Rel(cm.config.MediaMTX.RecordingsPath, entry.Path); err == nil && !strings.HasPrefix(rel, "..") {
+			archived := filepath.Join(tiering.ArchivePath, rel)
+			if _, err := os.Stat(archived); err == nil {
+				return archived, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("file not found: %s", entry.Filename)
+}
+
+// findCustodyEntry returns the latest entry for a filename, accepting the
+// API recording filename without extension
+func findCustodyEntry(entries []*CustodyEntry, filename, kind string) *CustodyEntry {
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entry.Kind != kind {
+			continue
+		}
+		if entry.Filename == filename || strings.TrimSuffix(entry.Filename, filepath.Ext(entry.Filename)) == filename {
+			return entry
+		}
+	}
+	return nil
+}
+
+// verifyCustodyChain checks sequence numbers, hash links and signatures of all entries
+func verifyCustodyChain(entries []*CustodyEntry, publicKey ed25519.PublicKey) (chainValid, signatureValid bool) {
+	chainValid, signatureValid = true, publicKey != nil
+	prevHash := custodyGenesisHash
+	for i, entry := range entries {
+		hash, err := entry.computeHash()
+		if err != nil || hash != entry.EntryHash || entry.PrevHash != prevHash || entry.Sequence != int64(i+1) {
+			chainValid = false
+		}
+		if signatureValid {
+			hashBytes, hashErr := hex.DecodeString(entry.EntryHash)
+			signature, sigErr := base64.StdEncoding.DecodeString(entry.Signature)
+			if hashErr != nil || sigErr != nil || !ed25519.Verify(publicKey, hashBytes, signature) {
+				signatureValid = false
+			}
+		}
+		prevHash = entry.EntryHash
+	}
+	return chainValid, signatureValid
+}
+
+// readManifest returns the parsed manifest entries and its raw contents
+func (cm *CustodyManager) readManifest() ([]*CustodyEntry, []byte, error) {
+	data, err := os.ReadFile(cm.config.Custody.ManifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to read custody manifest: %w", err)
+	}
+
+	var entries []*CustodyEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry CustodyEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, nil, fmt.Errorf("custody manifest line %d is malformed: %w", line, err)
+		}
+		entries = append(entries, &entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read custody manifest: %w", err)
+	}
+	return entries, data, nil
+}
+
+// loadSigningKey loads the Ed25519 key, generating it on first use, and
+// publishes the public key next to the manifest; caller holds cm.mu
+func (cm *CustodyManager) loadSigningKey() error {
+	keyPath := cm.config.Custody.SigningKeyPath
+
+	data, err := os.ReadFile(keyPath)
+	switch {
+	case err == nil:
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return fmt.Errorf("custody signing key %s is not PEM encoded", keyPath)
+		}
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse custody signing key: %w", err)
+		}
+		privateKey, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return fmt.Errorf("custody signing key %s is not an Ed25519 key", keyPath)
+		}
+		cm.privateKey = privateKey
+	case os.IsNotExist(err):
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return fmt.Errorf("failed to generate custody signing key: %w", err)
+		}
+		der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		if err != nil {
+			return fmt.Errorf("failed to encode custody signing key: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(keyPath), 0700); err != nil {
+			return fmt.Errorf("failed to create custody key directory: %w", err)
+		}
+		if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+			return fmt.Errorf("failed to write custody signing key: %w", err)
+		}
+		cm.privateKey = privateKey
+		cm.logger.WithField("path", keyPath).Info("Generated custody signing key")
+	default:
+		return fmt.Errorf("failed to read custody signing key: %w", err)
+	}
+
+	cm.publicKey = cm.privateKey.Public().(ed25519.PublicKey)
+	publicKeyPEM, err := encodeCustodyPublicKey(cm.publicKey)
+	if err != nil {
+		return err
+	}
+	publicKeyPath := filepath.Join(filepath.Dir(cm.config.Custody.ManifestPath), custodyPublicKeyFile)
+	if err := os.MkdirAll(filepath.Dir(publicKeyPath), 0755); err != nil {
+		return fmt.Errorf("failed to create custody manifest directory: %w", err)
+	}
+	if err := os.WriteFile(publicKeyPath, publicKeyPEM, 0644); err != nil {
+		return fmt.Errorf("failed to write custody public key: %w", err)
+	}
+	return nil
+}
+
+// encodeCustodyPublicKey returns the PEM-encoded public key
+func encodeCustodyPublicKey(publicKey ed25519.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode custody public key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
@@ -38,6 +38,9 @@ type ExternalStreamDiscovery struct {
 	lastScanTime      time.Time
 	mu                sync.RWMutex
 	stopChan          chan struct{}
+
+	// telemetryExtractor demuxes KLV telemetry from STANAG 4609 streams (optional)
+	telemetryExtractor *UAVTelemetryExtractor
 }
 
 // NewExternalStreamDiscovery creates a new external stream discovery instance
@@ -60,6 +63,13 @@ func NewExternalStreamDiscovery(configIntegration *ConfigIntegration, logger *lo
 	}
 }
 
+// SetTelemetryExtractor sets the extractor that tracks telemetry of discovered UAV streams
+func (esd *ExternalStreamDiscovery) SetTelemetryExtractor(extractor *UAVTelemetryExtractor) {
+	esd.mu.Lock()
+	defer esd.mu.Unlock()
+	esd.telemetryExtractor = extractor
+}
+
 // Start initializes the external discovery system
 func (esd *ExternalStreamDiscovery) Start(ctx context.Context) error {
 	esd.logger.Info("Starting external stream discovery")
@@ -222,8 +232,15 @@ func (esd *ExternalStreamDiscovery) DiscoverExternalStreams(ctx context.Context,
 		esd.discoveredStreams[stream.URL] = stream
 	}
 	esd.lastScanTime = time.Now()
+	extractor := esd.telemetryExtractor
 	esd.mu.Unlock()
 
+	if extractor != nil {
+		for _, stream := range discoveredStreams {
+			extractor.Track(stream)
+		}
+	}
+
 	scanDuration := time.Since(startTime)
 	esd.logger.WithFields(logging.Fields{
 		"total_found":   len(discoveredStreams),
@@ -662,6 +679,10 @@ func (esd *ExternalStreamDiscovery) AddExternalStreamAPI(ctx context.Context, st
 	stream.Status = "ADDED"
 	esd.discoveredStreams[stream.URL] = stream
 
+	if esd.telemetryExtractor != nil {
+		esd.telemetryExtractor.Track(stream)
+	}
+
 	// Build API-ready response
 	response := &AddExternalStreamResponse{
 		StreamURL:  stream.URL,
@@ -693,6 +714,10 @@ func (esd *ExternalStreamDiscovery) RemoveExternalStreamAPI(ctx context.Context,
 	// Remove stream
 	delete(esd.discoveredStreams, streamURL)
 
+	if esd.telemetryExtractor != nil {
+		esd.telemetryExtractor.Untrack(streamURL)
+	}
+
 	// Build API-ready response
 	response := &RemoveExternalStreamResponse{
 		StreamURL: streamURL,
@@ -30,6 +30,7 @@ import (
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/camera"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/tracing"
 )
 
 // ffmpegManager represents the MediaMTX FFmpeg manager
@@ -136,9 +137,15 @@ func (fm *ffmpegManager) StartProcess(ctx context.Context, command []string, out
 		return 0, fmt.Errorf("FFmpeg output path cannot be empty")
 	}
 
+	_, span := tracing.Start(ctx, "ffmpeg.start_process", tracing.SpanKindInternal,
+		tracing.String("process.executable", command[0]),
+		tracing.String("ffmpeg.output_path", outputPath))
+	defer span.End()
+
 	// Create output directory if it doesn't exist
 	outputDir := filepath.Dir(outputPath)
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		span.RecordError(err)
 		return 0, fmt.Errorf("failed to create FFmpeg output directory: %w", err)
 	}
 
@@ -156,11 +163,13 @@ func (fm *ffmpegManager) StartProcess(ctx context.Context, command []string, out
 
 	// Start process
 	if err := cmd.Start(); err != nil {
+		span.RecordError(err)
 		return 0, fmt.Errorf("failed to start FFmpeg process: %w", err)
 	}
 
 	// Get PID
 	process.PID = cmd.Process.Pid
+	span.SetAttributes(tracing.Int("process.pid", process.PID))
 	process.Status = "RUNNING"
 
 	// Track process
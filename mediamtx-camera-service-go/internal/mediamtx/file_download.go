@@ -0,0 +1,140 @@
+/*
+MediaMTX File Download Implementation
+
+Resolves the download_url filenames reported for recordings, snapshots and
+evidence bundles to files on disk and opens them for the HTTP file endpoints,
+decrypting files encrypted at rest on the fly. Recordings moved by storage tiering keep their
+download_url and are found in the archive tier.
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-007: Error handling and recovery
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// HTTP download paths for exported evidence bundles
+const (
+	EvidenceBundleFilesPath = "/files/exports/"
+	fileTypeEvidenceBundle  = "evidence_bundle"
+)
+
+// ErrInvalidDownloadPath reports a download filename that is empty, escapes its
+// storage root or names an internal file such as a lock sidecar
+var ErrInvalidDownloadPath = errors.New("invalid download path")
+
+// MediaFile is a recording or snapshot opened for download. Reads return the
+// plaintext content, so Size is the plaintext size for encrypted files.
+type MediaFile struct {
+	io.ReadSeekCloser
+	Name    string
+	Size    int64
+	ModTime time.Time
+}
+
+// resolveDownloadPath maps a download_url filename to a path under root
+func resolveDownloadPath(root, filename string) (string, error) {
+	if root == "" || filename == "" {
+		return "", ErrInvalidDownloadPath
+	}
+	name := filepath.FromSlash(filename)
+	if filepath.IsAbs(name) || isInternalStorageFile(name) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidDownloadPath, filename)
+	}
+	path := filepath.Join(root, name)
+	if rel, err := filepath.Rel(root, path); err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidDownloadPath, filename)
+	}
+	return path, nil
+}
+
+// OpenMediaFile opens a regular file for download, decrypting it on the fly
+// when it is encrypted
+func (se *StorageEncryption) OpenMediaFile(path string) (*MediaFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%w: %s", os.ErrNotExist, filepath.Base(path))
+	}
+
+	reader, size, err := se.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &MediaFile{ReadSeekCloser: reader, Name: filepath.Base(path), Size: size, ModTime: info.ModTime()}, nil
+}
+
+// OpenMediaFile opens a recording, snapshot or evidence bundle by its download_url filename
+func (c *controller) OpenMediaFile(ctx context.Context, fileType, filename string) (*MediaFile, error) {
+	if !c.checkRunningState() {
+		return nil, fmt.Errorf("controller is not running")
+	}
+
+	cfg, err := c.configIntegration.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configuration: %w", err)
+	}
+
+	switch fileType {
+	case fileKindRecording:
+		return openRecordingDownload(c.storageEncryption, c.tierManager, cfg.MediaMTX.RecordingsPath, filename)
+	case fileKindSnapshot:
+		path, err := resolveDownloadPath(cfg.MediaMTX.SnapshotsPath, filename)
+		if err != nil {
+			return nil, err
+		}
+		return c.storageEncryption.OpenMediaFile(path)
+	case fileTypeEvidenceBundle:
+		path, err := resolveDownloadPath(cfg.Custody.ExportPath, filename)
+		if err != nil {
+			return nil, err
+		}
+		return c.storageEncryption.OpenMediaFile(path)
+	default:
+		return nil, fmt.Errorf("%w: unknown file type %q", ErrInvalidDownloadPath, fileType)
+	}
+}
+
+// openRecordingDownload opens a recording from the primary tier or, once moved
+// by storage tiering, from the archive tier. A recording moved between lookup
+// and open is looked up once more.
+func openRecordingDownload(se *StorageEncryption, tm *StorageTierManager, root, filename string) (*MediaFile, error) {
+	path, err := resolveDownloadPath(root, filename)
+	if err != nil {
+		return nil, err
+	}
+	if tm == nil {
+		return se.OpenMediaFile(path)
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDownloadPath, filename)
+	}
+	for attempt := 0; ; attempt++ {
+		resolved, _, _, err := tm.ResolveRecordingFile(rel)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", os.ErrNotExist, err)
+		}
+		file, err := se.OpenMediaFile(resolved)
+		if err == nil || !errors.Is(err, os.ErrNotExist) || attempt > 0 {
+			return file, err
+		}
+	}
+}
@@ -0,0 +1,502 @@
+/*
+MediaMTX KLV Metadata Injection Implementation
+
+Samples the platform and sensor position of each recording camera while it
+records and, once MediaMTX closes the segments, muxes the samples into the
+recording as a MISB ST 0601 KLV data track. Positions come from the static
+per-camera configuration or a live position file, so recordings carry the
+STANAG 4609 metadata that downstream exploitation tools expect.
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-002: STANAG 4609 compliance for UAV streams
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/klv"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+)
+
+const (
+	defaultKLVInterval      = time.Second
+	defaultKLVSettleWindow  = 3 * time.Second
+	defaultKLVSettleTimeout = 2 * time.Minute
+	klvSettlePollInterval   = time.Second
+	klvMuxTimeout           = 10 * time.Minute
+	maxKLVSessionPackets    = 7 * 24 * 3600 // One week of samples at the default interval
+)
+
+// PositionSource provides the current platform and sensor position of a camera
+type PositionSource interface {
+	Position(device string) (*klv.Position, bool)
+}
+
+// staticPositionSource serves the fixed per-camera positions from configuration
+type staticPositionSource map[string]config.KLVPositionConfig
+
+// Position returns the configured position of a camera
+func (s staticPositionSource) Position(device string) (*klv.Position, bool) {
+	position, ok := s[device]
+	if !ok {
+		return nil, false
+	}
+	return klvPositionFromConfig(position), true
+}
+
+// filePositionSource serves positions from a JSON file mapping camera identifiers
+// to positions, re-read whenever the file changes (e.g. written by a GPS/INS bridge)
+type filePositionSource struct {
+	path   string
+	logger *logging.Logger
+
+	mu        sync.Mutex
+	modTime   time.Time
+	positions map[string]config.KLVPositionConfig
+}
+
+// Position returns the latest position of a camera from the position file
+func (s *filePositionSource) Position(device string) (*klv.Position, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if info, err := os.Stat(s.path); err == nil && !info.ModTime().Equal(s.modTime) {
+		data, err := os.ReadFile(s.path)
+		if err == nil {
+			var positions map[string]config.KLVPositionConfig
+			if err = json.Unmarshal(data, &positions); err == nil {
+				s.positions = positions
+				s.modTime = info.ModTime()
+			}
+		}
+		if err != nil {
+			s.logger.WithError(err).WithField("path", s.path).Warn("Failed to read KLV position file")
+		}
+	}
+
+	position, ok := s.positions[device]
+	if !ok {
+		return nil, false
+	}
+	return klvPositionFromConfig(position), true
+}
+
+// positionSources returns the first position found in priority order
+type positionSources []PositionSource
+
+// Position returns the first source's position for a camera
+func (sources positionSources) Position(device string) (*klv.Position, bool) {
+	for _, source := range sources {
+		if position, ok := source.Position(device); ok {
+			return position, true
+		}
+	}
+	return nil, false
+}
+
+// klvPositionFromConfig converts a configured position to a KLV position
+func klvPositionFromConfig(position config.KLVPositionConfig) *klv.Position {
+	return &klv.Position{
+		Latitude:              position.Latitude,
+		Longitude:             position.Longitude,
+		Altitude:              position.Altitude,
+		Heading:               position.Heading,
+		Pitch:                 position.Pitch,
+		Roll:                  position.Roll,
+		HorizontalFOV:         position.HorizontalFOV,
+		VerticalFOV:           position.VerticalFOV,
+		SensorRelativeAzimuth: position.SensorRelativeAzimuth,
+	}
+}
+
+// klvSession collects the samples of one camera's recording
+type klvSession struct {
+	device    string
+	startTime time.Time
+	stop      chan struct{}
+	done      chan struct{}
+
+	mu      sync.Mutex
+	packets []*klv.Packet
+}
+
+// KLVInjector samples camera positions during recordings and muxes them into
+// the finished segments as an ST 0601 KLV data track
+type KLVInjector struct {
+	config        *config.Config
+	logger        *logging.Logger
+	ffmpegManager FFmpegManager
+	source        PositionSource
+	settleWindow  time.Duration
+	settleTimeout time.Duration
+	runCommand    func(ctx context.Context, command []string) error
+
+	mu       sync.Mutex
+	sessions map[string]*klvSession
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewKLVInjector creates a new KLV metadata injector
+func NewKLVInjector(cfg *config.Config, ffmpegManager FFmpegManager, logger *logging.Logger) *KLVInjector {
+	var sources positionSources
+	if cfg.Recording.KLV.PositionFile != "" {
+		sources = append(sources, &filePositionSource{path: cfg.Recording.KLV.PositionFile, logger: logger})
+	}
+	if len(cfg.Recording.KLV.Cameras) > 0 {
+		sources = append(sources, staticPositionSource(cfg.Recording.KLV.Cameras))
+	}
+
+	return &KLVInjector{
+		config:        cfg,
+		logger:        logger,
+		ffmpegManager: ffmpegManager,
+		source:        sources,
+		settleWindow:  defaultKLVSettleWindow,
+		settleTimeout: defaultKLVSettleTimeout,
+		runCommand:    runKLVCommand,
+		sessions:      make(map[string]*klvSession),
+	}
+}
+
+// Enabled reports whether KLV injection is configured
+func (ki *KLVInjector) Enabled() bool {
+	return ki.config.Recording.KLV.Enabled
+}
+
+// SetPositionSource replaces the source of camera positions
+func (ki *KLVInjector) SetPositionSource(source PositionSource) {
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	ki.source = source
+}
+
+// Start enables sampling for new recordings
+func (ki *KLVInjector) Start(ctx context.Context) error {
+	if !ki.Enabled() {
+		return nil
+	}
+
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	if ki.stopChan != nil {
+		return nil
+	}
+	ki.stopChan = make(chan struct{})
+
+	ki.logger.WithFields(logging.Fields{
+		"mission_id": ki.config.Recording.KLV.MissionID,
+		"interval":   ki.interval().String(),
+	}).Info("KLV metadata injection started")
+	return nil
+}
+
+// Stop ends all sampling and waits for pending muxing to finish
+func (ki *KLVInjector) Stop() {
+	ki.mu.Lock()
+	stopChan := ki.stopChan
+	ki.stopChan = nil
+	sessions := ki.sessions
+	ki.sessions = make(map[string]*klvSession)
+	ki.mu.Unlock()
+
+	if stopChan == nil {
+		return
+	}
+	for _, session := range sessions {
+		close(session.stop)
+	}
+	close(stopChan)
+	ki.wg.Wait()
+}
+
+// interval returns the configured sampling interval
+func (ki *KLVInjector) interval() time.Duration {
+	if ms := ki.config.Recording.KLV.IntervalMs; ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return defaultKLVInterval
+}
+
+// StartSession begins sampling the position of a camera that started recording
+func (ki *KLVInjector) StartSession(device string) {
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+	if ki.stopChan == nil {
+		return
+	}
+	if _, exists := ki.sessions[device]; exists {
+		return
+	}
+
+	session := &klvSession{
+		device:    device,
+		startTime: time.Now(),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	ki.sessions[device] = session
+
+	ki.wg.Add(1)
+	go func() {
+		defer ki.wg.Done()
+		defer close(session.done)
+
+		ticker := time.NewTicker(ki.interval())
+		defer ticker.Stop()
+		for {
+			ki.sample(session)
+			select {
+			case <-session.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// sample appends one packet with the camera's current position to the session
+func (ki *KLVInjector) sample(session *klvSession) {
+	ki.mu.Lock()
+	source := ki.source
+	ki.mu.Unlock()
+
+	packet := &klv.Packet{
+		Timestamp:           time.Now().UTC(),
+		MissionID:           ki.config.Recording.KLV.MissionID,
+		PlatformDesignation: ki.config.Recording.KLV.PlatformDesignation,
+		ImageSourceSensor:   session.device,
+	}
+	if source != nil {
+		if position, ok := source.Position(session.device); ok {
+			packet.Position = position
+		}
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if len(session.packets) < maxKLVSessionPackets {
+		session.packets = append(session.packets, packet)
+	}
+}
+
+// StopSession ends sampling for a camera and muxes the samples into its
+// segments once MediaMTX has closed them. done runs afterwards, so consumers
+// such as chain-of-custody hashing see the final file contents. It returns
+// immediately.
+func (ki *KLVInjector) StopSession(device string, done func()) {
+	finish := func() {
+		if done != nil {
+			done()
+		}
+	}
+
+	ki.mu.Lock()
+	session, exists := ki.sessions[device]
+	delete(ki.sessions, device)
+	stopChan := ki.stopChan
+	ki.mu.Unlock()
+	if !exists || stopChan == nil {
+		finish()
+		return
+	}
+	close(session.stop)
+
+	ki.wg.Add(1)
+	go func() {
+		defer ki.wg.Done()
+		defer finish()
+		<-session.done
+
+		files := ki.awaitSegments(stopChan, session)
+		if len(files) == 0 {
+			ki.logger.WithField("device", device).Warn("No recording files found for KLV injection")
+			return
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-stopChan:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		session.mu.Lock()
+		packets := session.packets
+		session.mu.Unlock()
+
+		for path, segmentPackets := range assignKLVPackets(files, packets) {
+			if err := ki.muxFile(ctx, path, segmentPackets); err != nil {
+				ki.logger.WithError(err).WithField("path", path).Error("Failed to inject KLV metadata")
+				continue
+			}
+			ki.logger.WithFields(logging.Fields{
+				"device":  device,
+				"path":    path,
+				"packets": len(segmentPackets),
+			}).Info("KLV metadata injected into recording")
+		}
+	}()
+}
+
+// awaitSegments waits until the session's recording files stop changing
+func (ki *KLVInjector) awaitSegments(stopChan chan struct{}, session *klvSession) []string {
+	deadline := time.Now().Add(ki.settleTimeout)
+	for {
+		files, settled := recordingSegmentsSince(ki.config, session.device, session.startTime, time.Now(), ki.settleWindow, nil)
+		if (settled && len(files) > 0) || time.Now().After(deadline) {
+			if !settled {
+				ki.logger.WithField("device", session.device).Warn("Recording files still changing, skipping KLV injection")
+				return nil
+			}
+			return files
+		}
+
+		select {
+		case <-stopChan:
+			return nil
+		case <-time.After(klvSettlePollInterval):
+		}
+	}
+}
+
+// assignKLVPackets splits the samples across segments: each segment receives
+// the samples taken up to its last modification, after the previous segment
+func assignKLVPackets(files []string, packets []*klv.Packet) map[string][]*klv.Packet {
+	type segment struct {
+		path    string
+		modTime time.Time
+	}
+	segments := make([]segment, 0, len(files))
+	for _, path := range files {
+		if info, err := os.Stat(path); err == nil {
+			segments = append(segments, segment{path: path, modTime: info.ModTime()})
+		}
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].modTime.Before(segments[j].modTime)
+	})
+
+	assigned := make(map[string][]*klv.Packet)
+	if len(segments) == 0 {
+		return assigned
+	}
+	index := 0
+	for _, packet := range packets {
+		for index < len(segments)-1 && packet.Timestamp.After(segments[index].modTime) {
+			index++
+		}
+		assigned[segments[index].path] = append(assigned[segments[index].path], packet)
+	}
+	return assigned
+}
+
+// muxFile rewrites a recording with the packets added as a KLV data track.
+// The recording keeps its modification time; files changed meanwhile or
+// already encrypted at rest are left untouched.
+func (ki *KLVInjector) muxFile(ctx context.Context, path string, packets []*klv.Packet) error {
+	if len(packets) == 0 {
+		return nil
+	}
+	if encrypted, err := IsEncryptedFile(path); err != nil {
+		return err
+	} else if encrypted {
+		return fmt.Errorf("recording is already encrypted at rest")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat recording: %w", err)
+	}
+
+	stream, err := os.CreateTemp("", "klv-*.bin")
+	if err != nil {
+		return fmt.Errorf("failed to create KLV stream file: %w", err)
+	}
+	defer os.Remove(stream.Name())
+	for _, packet := range packets {
+		data, err := klv.Encode(packet)
+		if err != nil {
+			stream.Close()
+			return fmt.Errorf("failed to encode KLV packet: %w", err)
+		}
+		if _, err := stream.Write(data); err != nil {
+			stream.Close()
+			return fmt.Errorf("failed to write KLV stream: %w", err)
+		}
+	}
+	if err := stream.Close(); err != nil {
+		return fmt.Errorf("failed to write KLV stream: %w", err)
+	}
+
+	partialPath := path + archivePartialSuffix
+	defer os.Remove(partialPath)
+
+	muxCtx, cancel := context.WithTimeout(ctx, klvMuxTimeout)
+	defer cancel()
+	if err := ki.runCommand(muxCtx, ki.buildMuxCommand(path, stream.Name(), partialPath)); err != nil {
+		return fmt.Errorf("failed to mux KLV data track: %w", err)
+	}
+
+	// Another writer (e.g. encryption at rest) may have replaced the file meanwhile
+	current, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat recording: %w", err)
+	}
+	if current.Size() != info.Size() || !current.ModTime().Equal(info.ModTime()) {
+		return fmt.Errorf("recording changed during KLV injection")
+	}
+
+	if err := os.Chtimes(partialPath, info.ModTime(), info.ModTime()); err != nil {
+		return fmt.Errorf("failed to preserve recording time: %w", err)
+	}
+	if err := os.Rename(partialPath, path); err != nil {
+		return fmt.Errorf("failed to replace recording: %w", err)
+	}
+	return nil
+}
+
+// buildMuxCommand builds the FFmpeg command copying a recording and a raw KLV
+// stream into an MPEG-TS file with the KLV as a data track
+func (ki *KLVInjector) buildMuxCommand(recordingPath, klvPath, outputPath string) []string {
+	args := []string{
+		"-y", "-v", "error",
+		"-i", recordingPath,
+		"-f", "data", "-i", klvPath,
+		"-map", "0", "-map", "1",
+		"-c", "copy",
+		"-f", "mpegts",
+		outputPath,
+	}
+	if ki.ffmpegManager != nil {
+		return ki.ffmpegManager.BuildCommand(args...)
+	}
+	return append([]string{"ffmpeg"}, args...)
+}
+
+// runKLVCommand runs an FFmpeg command and includes its output in errors
+func runKLVCommand(ctx context.Context, command []string) error {
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
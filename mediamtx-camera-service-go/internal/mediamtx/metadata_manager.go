@@ -15,14 +15,22 @@ package mediamtx
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/klv"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
 )
 
@@ -43,6 +51,7 @@ type MetadataManager struct {
 	configIntegration *ConfigIntegration
 	logger            *logging.Logger
 	ffmpegManager     FFmpegManager
+	storageEncryption *StorageEncryption // Decrypts encrypted files on the fly (optional)
 }
 
 // MediaMetadata represents comprehensive metadata for video and image files
@@ -58,6 +67,7 @@ type MediaMetadata struct {
 	// Video-specific fields
 	VideoCodec *string `json:"video_codec,omitempty"` // Video codec name
 	Bitrate    *int64  `json:"bitrate,omitempty"`     // Bitrate in bits per second
+	HasData    bool    `json:"has_data,omitempty"`    // Whether a data stream (e.g. KLV) is present
 
 	// Image-specific fields
 	Width      *int    `json:"width,omitempty"`      // Image width in pixels
@@ -110,6 +120,84 @@ func NewMetadataManager(configIntegration *ConfigIntegration, ffmpegManager FFmp
 	}
 }
 
+// SetStorageEncryption enables on-the-fly decryption of files encrypted at rest
+func (mm *MetadataManager) SetStorageEncryption(storageEncryption *StorageEncryption) {
+	mm.storageEncryption = storageEncryption
+}
+
+// OpenMediaFile opens a media file for reading and returns its plaintext size.
+// Files encrypted at rest are decrypted on the fly.
+func (mm *MetadataManager) OpenMediaFile(filePath string) (io.ReadSeekCloser, int64, error) {
+	if mm.storageEncryption != nil {
+		return mm.storageEncryption.Open(filePath)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	return file, info.Size(), nil
+}
+
+// mediaFileSize returns the plaintext size of a file, which differs from the
+// on-disk size for files encrypted at rest
+func (mm *MetadataManager) mediaFileSize(filePath string, fileInfo os.FileInfo) int64 {
+	if mm.storageEncryption == nil {
+		return fileInfo.Size()
+	}
+	if encrypted, err := IsEncryptedFile(filePath); err != nil || !encrypted {
+		return fileInfo.Size()
+	}
+	reader, size, err := mm.storageEncryption.Open(filePath)
+	if err != nil {
+		return fileInfo.Size()
+	}
+	reader.Close()
+	return size
+}
+
+// probeInput returns the ffprobe input for a file. Encrypted files are served
+// decrypted from a loopback listener for the duration of the probe, so
+// plaintext never touches the disk; the returned function stops the listener.
+func (mm *MetadataManager) probeInput(filePath string) (string, func(), error) {
+	if mm.storageEncryption == nil {
+		return filePath, func() {}, nil
+	}
+	if encrypted, err := IsEncryptedFile(filePath); err != nil || !encrypted {
+		return filePath, func() {}, nil
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", nil, fmt.Errorf("failed to generate probe token: %w", err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to start decrypting probe listener: %w", err)
+	}
+
+	// The extension keeps ffprobe's format detection working
+	urlPath := "/" + hex.EncodeToString(token) + filepath.Ext(filePath)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != urlPath {
+				http.NotFound(w, r)
+				return
+			}
+			mm.storageEncryption.ServeFile(w, r, filePath)
+		}),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go server.Serve(listener)
+
+	return "http://" + listener.Addr().String() + urlPath, func() { server.Close() }, nil
+}
+
 // ExtractVideoMetadata extracts metadata from video files using ffprobe
 func (mm *MetadataManager) ExtractVideoMetadata(ctx context.Context, filePath string) (*MediaMetadata, error) {
 
@@ -122,7 +210,7 @@ func (mm *MetadataManager) ExtractVideoMetadata(ctx context.Context, filePath st
 	// Initialize metadata with basic information
 	metadata := &MediaMetadata{
 		FilePath:         filePath,
-		FileSize:         fileInfo.Size(),
+		FileSize:         mm.mediaFileSize(filePath, fileInfo),
 		CreatedAt:        fileInfo.ModTime(), // Use ModTime as CreatedAt
 		ModifiedAt:       fileInfo.ModTime(),
 		ExtractionMethod: "ffprobe",
@@ -169,7 +257,7 @@ func (mm *MetadataManager) ExtractImageMetadata(ctx context.Context, filePath st
 	// Initialize metadata with basic information
 	metadata := &MediaMetadata{
 		FilePath:         filePath,
-		FileSize:         fileInfo.Size(),
+		FileSize:         mm.mediaFileSize(filePath, fileInfo),
 		CreatedAt:        fileInfo.ModTime(),
 		ModifiedAt:       fileInfo.ModTime(),
 		ExtractionMethod: "ffprobe",
@@ -219,6 +307,13 @@ func (mm *MetadataManager) executeFFprobe(ctx context.Context, filePath string)
 	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	// Encrypted files are probed through a decrypting loopback URL
+	input, stopProbe, err := mm.probeInput(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer stopProbe()
+
 	// Build ffprobe command
 	command := []string{
 		"ffprobe",
@@ -226,7 +321,7 @@ func (mm *MetadataManager) executeFFprobe(ctx context.Context, filePath string)
 		"-print_format", "json",
 		"-show_format",
 		"-show_streams",
-		filePath,
+		input,
 	}
 
 	mm.logger.WithFields(logging.Fields{
@@ -296,6 +391,14 @@ func (mm *MetadataManager) parseVideoMetadata(ffprobeResult *FFprobeResult, meta
 		}
 	}
 
+	// Detect data streams such as STANAG 4609 KLV tracks
+	for _, stream := range ffprobeResult.Streams {
+		if stream.CodecType == "data" {
+			metadata.HasData = true
+			break
+		}
+	}
+
 	// Set format from ffprobe
 	if ffprobeResult.Format.FormatName != "" {
 		metadata.Format = ffprobeResult.Format.FormatName
@@ -310,6 +413,95 @@ func (mm *MetadataManager) parseVideoMetadata(ffprobeResult *FFprobeResult, meta
 	return nil
 }
 
+// ExtractKLVMetadata extracts the first data stream of a recording with FFmpeg
+// and decodes its MISB ST 0601 KLV packets. It returns nil when the stream
+// holds no valid packets.
+func (mm *MetadataManager) ExtractKLVMetadata(ctx context.Context, filePath string) (*RecordingKLVInfo, error) {
+	timeout := 30 * time.Second // Default fallback
+	if mm.configIntegration != nil {
+		if cfg, err := mm.configIntegration.GetConfig(); err == nil && cfg != nil && cfg.MediaMTX.FFmpeg.Recording.ExecutionTimeout > 0 {
+			timeout = time.Duration(cfg.MediaMTX.FFmpeg.Recording.ExecutionTimeout * float64(time.Second))
+		}
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Encrypted files are read through a decrypting loopback URL
+	input, stopProbe, err := mm.probeInput(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer stopProbe()
+
+	args := []string{"-v", "error", "-i", input, "-map", "0:d:0", "-c", "copy", "-f", "data", "-"}
+	command := append([]string{"ffmpeg"}, args...)
+	if mm.ffmpegManager != nil {
+		command = mm.ffmpegManager.BuildCommand(args...)
+	}
+
+	cmd := exec.CommandContext(timeoutCtx, command[0], command[1:]...)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("KLV extraction failed: %w", err)
+	}
+
+	info := summarizeKLV(klv.DecodeStream(output))
+	if info != nil {
+		mm.logger.WithFields(logging.Fields{
+			"file_path": filePath,
+			"packets":   info.Packets,
+			"rejected":  info.RejectedPackets,
+		}).Debug("KLV metadata extracted successfully")
+	}
+	return info, nil
+}
+
+// summarizeKLV builds the recording KLV summary from decoded packets
+func summarizeKLV(packets []*klv.Packet, rejected int) *RecordingKLVInfo {
+	if len(packets) == 0 {
+		return nil
+	}
+
+	info := &RecordingKLVInfo{
+		Packets:         len(packets),
+		RejectedPackets: rejected,
+	}
+	first, last := packets[0].Timestamp, packets[0].Timestamp
+	for _, packet := range packets {
+		if info.MissionID == "" {
+			info.MissionID = packet.MissionID
+		}
+		if info.PlatformDesignation == "" {
+			info.PlatformDesignation = packet.PlatformDesignation
+		}
+		if info.ImageSourceSensor == "" {
+			info.ImageSourceSensor = packet.ImageSourceSensor
+		}
+		if packet.Timestamp.Before(first) {
+			first = packet.Timestamp
+		}
+		if packet.Timestamp.After(last) {
+			last = packet.Timestamp
+		}
+		if position := packet.Position; position != nil {
+			positionInfo := &KLVPositionInfo{
+				Latitude:  position.Latitude,
+				Longitude: position.Longitude,
+				Altitude:  position.Altitude,
+				Heading:   position.Heading,
+			}
+			if info.StartPosition == nil {
+				info.StartPosition = positionInfo
+			}
+			info.EndPosition = positionInfo
+		}
+	}
+	info.FirstTimestamp = first.UTC().Format(time.RFC3339Nano)
+	info.LastTimestamp = last.UTC().Format(time.RFC3339Nano)
+	return info
+}
+
 // parseImageMetadata parses ffprobe results for image files
 func (mm *MetadataManager) parseImageMetadata(ffprobeResult *FFprobeResult, metadata *MediaMetadata) error {
 	// Extract image stream information
@@ -357,7 +549,36 @@ func (mm *MetadataManager) GetFileSize(filePath string) (int64, error) {
 	if err != nil {
 		return 0, fmt.Errorf("failed to get file size: %w", err)
 	}
-	return fileInfo.Size(), nil
+	return mm.mediaFileSize(filePath, fileInfo), nil
+}
+
+// ComputeFileHash returns the hex-encoded SHA-256 digest of a file's contents.
+// Encrypted files are hashed over their plaintext.
+func (mm *MetadataManager) ComputeFileHash(ctx context.Context, filePath string) (string, error) {
+	file, _, err := mm.OpenMediaFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file for hashing: %w", err)
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	buf := make([]byte, 256*1024)
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		n, readErr := file.Read(buf)
+		if n > 0 {
+			hasher.Write(buf[:n])
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return "", fmt.Errorf("failed to read file for hashing: %w", readErr)
+		}
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
 // GetBasicFileInfo returns basic file information without ffprobe
@@ -375,7 +596,7 @@ func (mm *MetadataManager) GetBasicFileInfo(filePath string) (*MediaMetadata, er
 
 	return &MediaMetadata{
 		FilePath:         filePath,
-		FileSize:         fileInfo.Size(),
+		FileSize:         mm.mediaFileSize(filePath, fileInfo),
 		Format:           format,
 		CreatedAt:        fileInfo.ModTime(),
 		ModifiedAt:       fileInfo.ModTime(),
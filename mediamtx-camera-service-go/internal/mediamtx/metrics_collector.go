@@ -0,0 +1,313 @@
+/*
+MediaMTX Controller Prometheus Metrics
+
+Collects the statistics kept by the controller's components - camera monitor
+and its event worker pool, path manager, recording manager and its error
+metrics, RTSP connection monitoring and MediaMTX stream state - as metric
+families for the HTTP health server's metrics endpoint. Camera-scoped values
+carry a camera label with the canonical camera identifier.
+
+Requirements Coverage:
+- REQ-MTX-004: Health monitoring and system metrics
+- REQ-HEALTH-003: System metrics collection and reporting
+
+Test Categories: Unit
+API Documentation Reference: docs/api/health-endpoints.md
+*/
+
+package mediamtx
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/camera"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/metrics"
+)
+
+// CollectMetrics returns the controller metrics (implements metrics.Collector)
+func (c *controller) CollectMetrics(ctx context.Context) []metrics.Family {
+	families := []metrics.Family{
+		metrics.Gauge(metrics.Name("controller", "running"),
+			"Whether the MediaMTX controller is running.",
+			metrics.Value(metrics.Bool(c.checkRunningState()))),
+	}
+
+	if c.cameraMonitor != nil {
+		families = append(families, cameraMonitorMetrics(c.cameraMonitor)...)
+		families = append(families, c.cameraStateMetrics()...)
+	}
+	if provider, ok := c.pathManager.(interface{ GetMetrics() *PathManagerMetrics }); ok {
+		families = append(families, pathManagerMetrics(provider.GetMetrics())...)
+	}
+	if c.recordingManager != nil {
+		families = append(families, recordingManagerMetrics(c.recordingManager)...)
+	}
+	if c.rtspManager != nil {
+		families = append(families, rtspConnectionMetrics(c.rtspManager.GetConnectionMetrics(ctx))...)
+	}
+	if c.checkRunningState() && c.streamManager != nil {
+		if streams, err := c.streamManager.ListStreams(ctx); err == nil {
+			families = append(families, streamMetrics(streams)...)
+		} else {
+			c.logger.WithError(err).Debug("Failed to list streams for metrics")
+		}
+	}
+	return families
+}
+
+// cameraStateMetrics reports connection and recording state per connected camera
+func (c *controller) cameraStateMetrics() []metrics.Family {
+	connected := metrics.Gauge(metrics.Name("camera", "connected"),
+		"Whether a camera is connected.")
+	recording := metrics.Gauge(metrics.Name("camera", "recording"),
+		"Whether a camera is recording.")
+
+	cameras := c.cameraMonitor.GetConnectedCameras()
+	devicePaths := make([]string, 0, len(cameras))
+	for devicePath := range cameras {
+		devicePaths = append(devicePaths, devicePath)
+	}
+	sort.Strings(devicePaths)
+
+	for _, devicePath := range devicePaths {
+		cameraID, ok := c.pathManager.GetCameraForDevicePath(devicePath)
+		if !ok {
+			continue
+		}
+		labels := metrics.Labels{"camera": cameraID, "device": devicePath}
+		connected.Samples = append(connected.Samples, metrics.Sample{
+			Labels: labels,
+			Value:  metrics.Bool(cameras[devicePath].Status == camera.DeviceStatusConnected),
+		})
+		if c.recordingManager != nil {
+			recording.Samples = append(recording.Samples, metrics.Sample{
+				Labels: labels,
+				Value:  metrics.Bool(c.recordingManager.IsRecording(cameraID)),
+			})
+		}
+	}
+	return []metrics.Family{connected, recording}
+}
+
+// cameraMonitorMetrics reports device discovery and event worker pool statistics
+func cameraMonitorMetrics(monitor camera.CameraMonitor) []metrics.Family {
+	stats := monitor.GetMonitorStats()
+	if stats == nil {
+		return nil
+	}
+
+	families := []metrics.Family{
+		metrics.Gauge(metrics.Name("camera_monitor", "devices_connected"),
+			"Camera devices currently connected.", metrics.Value(float64(stats.DevicesConnected))),
+		metrics.Gauge(metrics.Name("camera_monitor", "known_devices"),
+			"Camera devices known to the monitor.", metrics.Value(float64(stats.KnownDevicesCount))),
+		metrics.Gauge(metrics.Name("camera_monitor", "poll_interval_seconds"),
+			"Current device polling interval.", metrics.Value(stats.CurrentPollInterval)),
+		metrics.Gauge(metrics.Name("camera_monitor", "polling_failures"),
+			"Consecutive device polling failures.", metrics.Value(float64(stats.PollingFailureCount))),
+		metrics.Counter(metrics.Name("camera_monitor", "polling_cycles_total"),
+			"Device polling cycles completed.", metrics.Value(float64(stats.PollingCycles))),
+		metrics.Counter(metrics.Name("camera_monitor", "device_state_changes_total"),
+			"Camera device state changes.", metrics.Value(float64(stats.DeviceStateChanges))),
+		metrics.Counter(metrics.Name("camera_monitor", "capability_probes_total"),
+			"Capability probes attempted.", metrics.Value(float64(stats.CapabilityProbesAttempted))),
+		metrics.Counter(metrics.Name("camera_monitor", "capability_probes_successful_total"),
+			"Capability probes that succeeded.", metrics.Value(float64(stats.CapabilityProbesSuccessful))),
+		metrics.Counter(metrics.Name("camera_monitor", "capability_probe_errors_total"),
+			"Capability probes that failed, by reason.",
+			metrics.Sample{Labels: metrics.Labels{"reason": "timeout"}, Value: float64(stats.CapabilityTimeouts)},
+			metrics.Sample{Labels: metrics.Labels{"reason": "parse_error"}, Value: float64(stats.CapabilityParseErrors)}),
+		metrics.Counter(metrics.Name("camera_monitor", "udev_events_total"),
+			"Udev events by outcome.",
+			metrics.Sample{Labels: metrics.Labels{"outcome": "processed"}, Value: float64(stats.UdevEventsProcessed)},
+			metrics.Sample{Labels: metrics.Labels{"outcome": "filtered"}, Value: float64(stats.UdevEventsFiltered)},
+			metrics.Sample{Labels: metrics.Labels{"outcome": "skipped"}, Value: float64(stats.UdevEventsSkipped)}),
+		metrics.Counter(metrics.Name("camera_monitor", "device_events_total"),
+			"Device events by outcome.",
+			metrics.Sample{Labels: metrics.Labels{"outcome": "processed"}, Value: float64(stats.DeviceEventsProcessed)},
+			metrics.Sample{Labels: metrics.Labels{"outcome": "dropped"}, Value: float64(stats.DeviceEventsDropped)}),
+	}
+
+	provider, ok := monitor.(interface{ GetResourceStats() map[string]interface{} })
+	if !ok {
+		return families
+	}
+	pool, ok := provider.GetResourceStats()["worker_pool"].(map[string]interface{})
+	if !ok {
+		return families
+	}
+	for _, metric := range []struct {
+		key, name, help string
+		counter         bool
+	}{
+		{"active_workers", "active_workers", "Event worker pool goroutines running tasks.", false},
+		{"queued_tasks", "queued_tasks", "Event worker pool tasks waiting to run.", false},
+		{"max_workers", "max_workers", "Event worker pool size limit.", false},
+		{"completed_tasks", "completed_tasks_total", "Event worker pool tasks completed.", true},
+		{"failed_tasks", "failed_tasks_total", "Event worker pool tasks that failed.", true},
+		{"timeout_tasks", "timeout_tasks_total", "Event worker pool tasks that timed out.", true},
+	} {
+		value, ok := numericValue(pool[metric.key])
+		if !ok {
+			continue
+		}
+		name := metrics.Name("worker_pool", metric.name)
+		if metric.counter {
+			families = append(families, metrics.Counter(name, metric.help, metrics.Value(value)))
+		} else {
+			families = append(families, metrics.Gauge(name, metric.help, metrics.Value(value)))
+		}
+	}
+	return families
+}
+
+// pathManagerMetrics reports MediaMTX path operation statistics
+func pathManagerMetrics(stats *PathManagerMetrics) []metrics.Family {
+	if stats == nil {
+		return nil
+	}
+	return []metrics.Family{
+		metrics.Gauge(metrics.Name("path", "ready_latency_seconds"),
+			"Latency of the most recent path becoming ready.", metrics.Value(float64(stats.PathReadyLatencyMs)/1000)),
+		metrics.Counter(metrics.Name("path", "patch_attempts_total"),
+			"MediaMTX path configuration patch attempts.", metrics.Value(float64(stats.PatchAttemptsTotal))),
+		metrics.Counter(metrics.Name("path", "device_events_total"),
+			"Device events handled by the path manager.", metrics.Value(float64(stats.DeviceEventsTotal))),
+		metrics.Counter(metrics.Name("path", "operations_total"),
+			"MediaMTX path operations.", metrics.Value(float64(stats.PathOperationsTotal))),
+	}
+}
+
+// recordingManagerMetrics reports recording activity and error metrics
+func recordingManagerMetrics(rm *RecordingManager) []metrics.Family {
+	families := []metrics.Family{
+		metrics.Gauge(metrics.Name("recording", "active"),
+			"Recordings in progress.", metrics.Value(float64(len(rm.timerManager.ListActiveRecordings())))),
+		metrics.Counter(metrics.Name("recording", "started_total"),
+			"Recordings started.", metrics.Value(float64(atomic.LoadInt64(&rm.resourceStats.TotalRecordingsStarted)))),
+		metrics.Counter(metrics.Name("recording", "stopped_total"),
+			"Recordings stopped.", metrics.Value(float64(atomic.LoadInt64(&rm.resourceStats.TotalRecordingsStopped)))),
+		metrics.Counter(metrics.Name("recording", "errors_total"),
+			"Recording operations that failed.", metrics.Value(float64(atomic.LoadInt64(&rm.resourceStats.RecordingErrors)))),
+	}
+
+	if rm.recordingCircuitBreaker != nil {
+		state := rm.recordingCircuitBreaker.GetState()
+		breaker := metrics.Gauge(metrics.Name("recording", "circuit_breaker_state"),
+			"Current state of the recording circuit breaker.")
+		for _, candidate := range []CircuitBreakerState{StateClosed, StateOpen, StateHalfOpen} {
+			breaker.Samples = append(breaker.Samples, metrics.Sample{
+				Labels: metrics.Labels{"state": string(candidate)},
+				Value:  metrics.Bool(state == candidate),
+			})
+		}
+		families = append(families, breaker)
+	}
+
+	if rm.errorMetricsCollector == nil {
+		return families
+	}
+	errorMetrics := rm.errorMetricsCollector.GetMetrics()
+	return append(families,
+		metrics.Counter(metrics.Name("errors", "by_component_total"),
+			"Errors recorded by component.", labelledSamples("component", errorMetrics.ErrorsByComponent)...),
+		metrics.Counter(metrics.Name("errors", "by_severity_total"),
+			"Errors recorded by severity.", labelledSamples("severity", errorMetrics.ErrorsBySeverity)...),
+		metrics.Counter(metrics.Name("recovery", "attempts_total"),
+			"Error recovery attempts by result.",
+			metrics.Sample{Labels: metrics.Labels{"result": "success"}, Value: float64(errorMetrics.RecoverySuccesses)},
+			metrics.Sample{Labels: metrics.Labels{"result": "failure"}, Value: float64(errorMetrics.RecoveryFailures)}),
+	)
+}
+
+// rtspConnectionMetrics reports RTSP connection and session statistics
+func rtspConnectionMetrics(stats map[string]interface{}) []metrics.Family {
+	var families []metrics.Family
+	for _, metric := range []struct {
+		key, name, help string
+		counter         bool
+	}{
+		{"is_healthy", "healthy", "Whether RTSP connection monitoring reports healthy.", false},
+		{"total_connections", "connections", "RTSP connections reported by MediaMTX.", false},
+		{"total_sessions", "sessions", "RTSP sessions reported by MediaMTX.", false},
+		{"total_bytes_received", "received_bytes_total", "Bytes received over RTSP connections.", true},
+		{"total_bytes_sent", "sent_bytes_total", "Bytes sent over RTSP connections.", true},
+		{"total_rtp_packets_received", "rtp_packets_received_total", "RTP packets received in RTSP sessions.", true},
+		{"total_rtp_packets_sent", "rtp_packets_sent_total", "RTP packets sent in RTSP sessions.", true},
+		{"total_rtp_packets_lost", "rtp_packets_lost_total", "RTP packets lost in RTSP sessions.", true},
+		{"average_jitter", "rtp_jitter", "Average RTP jitter across RTSP sessions.", false},
+	} {
+		value, ok := numericValue(stats[metric.key])
+		if !ok {
+			continue
+		}
+		name := metrics.Name("rtsp", metric.name)
+		if metric.counter {
+			families = append(families, metrics.Counter(name, metric.help, metrics.Value(value)))
+		} else {
+			families = append(families, metrics.Gauge(name, metric.help, metrics.Value(value)))
+		}
+	}
+
+	if states, ok := stats["session_states"].(map[string]int); ok {
+		counts := make(map[string]int64, len(states))
+		for state, count := range states {
+			counts[state] = int64(count)
+		}
+		families = append(families, metrics.Gauge(metrics.Name("rtsp", "sessions_by_state"),
+			"RTSP sessions by state.", labelledSamples("state", counts)...))
+	}
+	return families
+}
+
+// streamMetrics reports MediaMTX stream state and viewers per stream
+func streamMetrics(streams *GetStreamsResponse) []metrics.Family {
+	active := metrics.Gauge(metrics.Name("stream", "active"),
+		"Whether a MediaMTX stream is active.")
+	viewers := metrics.Gauge(metrics.Name("stream", "viewers"),
+		"Current viewers of a MediaMTX stream.")
+	sent := metrics.Counter(metrics.Name("stream", "sent_bytes_total"),
+		"Bytes sent to viewers of a MediaMTX stream.")
+
+	for _, stream := range streams.Streams {
+		labels := metrics.Labels{"stream": stream.Name}
+		active.Samples = append(active.Samples, metrics.Sample{Labels: labels, Value: metrics.Bool(stream.Status == "active")})
+		viewers.Samples = append(viewers.Samples, metrics.Sample{Labels: labels, Value: float64(stream.Viewers)})
+		sent.Samples = append(sent.Samples, metrics.Sample{Labels: labels, Value: float64(stream.BytesSent)})
+	}
+	return []metrics.Family{active, viewers, sent}
+}
+
+// labelledSamples converts per-label counts into samples sorted by label value
+func labelledSamples(label string, counts map[string]int64) []metrics.Sample {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	samples := make([]metrics.Sample, 0, len(keys))
+	for _, key := range keys {
+		samples = append(samples, metrics.Sample{Labels: metrics.Labels{label: key}, Value: float64(counts[key])})
+	}
+	return samples
+}
+
+// numericValue converts a statistics map value into a metric value
+func numericValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case bool:
+		return metrics.Bool(v), true
+	}
+	return 0, false
+}
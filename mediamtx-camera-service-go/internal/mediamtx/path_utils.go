@@ -84,7 +84,12 @@ func GetDevicePathFromCameraIdentifier(cameraID string) string {
 // This returns the PATTERN for MediaMTX to use, not an actual file path
 // MediaMTX will replace %path, %Y, %m, %d, etc. and add the extension
 func GenerateRecordingPath(cfg *config.MediaMTXConfig, recordingCfg *config.RecordingConfig) string {
-	basePath := cfg.RecordingsPath
+	return generateRecordingPathAt(cfg.RecordingsPath, recordingCfg)
+}
+
+// generateRecordingPathAt generates the MediaMTX recordPath pattern under basePath
+// Used when captures are redirected away from the configured recordings path
+func generateRecordingPathAt(basePath string, recordingCfg *config.RecordingConfig) string {
 	pattern := recordingCfg.FileNamePattern
 
 	if recordingCfg.UseDeviceSubdirs {
@@ -98,7 +103,11 @@ func GenerateRecordingPath(cfg *config.MediaMTXConfig, recordingCfg *config.Reco
 // GenerateSnapshotPath generates an actual file path for snapshots
 // Unlike recordings, snapshots are created directly by FFmpeg, not MediaMTX
 func GenerateSnapshotPath(cfg *config.MediaMTXConfig, snapshotCfg *config.SnapshotConfig, devicePath string) string {
-	basePath := cfg.SnapshotsPath
+	return generateSnapshotPathAt(cfg.SnapshotsPath, snapshotCfg, devicePath)
+}
+
+// generateSnapshotPathAt generates an actual snapshot file path under basePath
+func generateSnapshotPathAt(basePath string, snapshotCfg *config.SnapshotConfig, devicePath string) string {
 	// Derive device name from device path conservatively; PathManager is runtime authority
 	deviceName := func() string {
 		parts := strings.Split(devicePath, "/")
@@ -74,6 +74,21 @@ type RecordingManager struct {
 	// Error metrics collector
 	errorMetricsCollector *ErrorMetricsCollector
 
+	// Storage guard for block threshold enforcement (optional)
+	storageGuard *StorageGuard
+
+	// Storage tier manager for archived recording resolution (optional)
+	tierManager *StorageTierManager
+
+	// Replication manager for off-box replication status (optional)
+	replicationManager *ReplicationManager
+
+	// Custody manager for chain-of-custody hashing on recording.stop (optional)
+	custodyManager *CustodyManager
+
+	// KLV injector for STANAG 4609 metadata tracks in finished recordings (optional)
+	klvInjector *KLVInjector
+
 	// Resource management
 	running       int32 // Atomic flag for running state
 	resourceStats *RecordingResourceStats
@@ -99,6 +114,11 @@ func (rm *RecordingManager) forceStopRecording(cameraID string) {
 	// Stop RTSP keepalive reader
 	rm.stopRTSPKeepalive(cameraID)
 
+	// Mux the samples collected so far into whatever MediaMTX wrote
+	if rm.klvInjector != nil {
+		rm.klvInjector.StopSession(cameraID, nil)
+	}
+
 	// Note: We don't try to call MediaMTX here since the device is gone
 	// MediaMTX will automatically stop recording when FFmpeg fails
 }
@@ -163,6 +183,38 @@ func NewRecordingManager(client MediaMTXClient, pathManager PathManager, streamM
 	return rm
 }
 
+// SetStorageGuard enables storage block threshold enforcement for new recordings
+func (rm *RecordingManager) SetStorageGuard(guard *StorageGuard) {
+	rm.storageGuard = guard
+}
+
+// SetTierManager sets the storage tier manager used to resolve archived recordings
+func (rm *RecordingManager) SetTierManager(tierManager *StorageTierManager) {
+	rm.tierManager = tierManager
+}
+
+// SetReplicationManager sets the replication manager used to report per-file replication status
+func (rm *RecordingManager) SetReplicationManager(replicationManager *ReplicationManager) {
+	rm.replicationManager = replicationManager
+}
+
+// SetStorageEncryption enables on-the-fly decryption of recordings encrypted at rest
+func (rm *RecordingManager) SetStorageEncryption(storageEncryption *StorageEncryption) {
+	if rm.metadataManager != nil {
+		rm.metadataManager.SetStorageEncryption(storageEncryption)
+	}
+}
+
+// SetCustodyManager sets the custody manager that hashes completed recordings
+func (rm *RecordingManager) SetCustodyManager(custodyManager *CustodyManager) {
+	rm.custodyManager = custodyManager
+}
+
+// SetKLVInjector sets the injector that muxes KLV metadata into finished recordings
+func (rm *RecordingManager) SetKLVInjector(klvInjector *KLVInjector) {
+	rm.klvInjector = klvInjector
+}
+
 // StartRecording starts recording and returns API-ready response with rich metadata
 func (rm *RecordingManager) StartRecording(ctx context.Context, cameraID string, options *PathConf) (*StartRecordingResponse, error) {
 	// Add panic recovery for recording operations
@@ -202,6 +254,17 @@ func (rm *RecordingManager) executeStartRecording(ctx context.Context, cameraID
 		return nil, fmt.Errorf("camera '%s' not found or not accessible", cameraID)
 	}
 
+	// Resolve where the recording goes - the fallback path when primary storage is blocked
+	recordingsPath := rm.config.RecordingsPath
+	if rm.storageGuard != nil {
+		resolvedPath, err := rm.storageGuard.ResolveRecordingsPath()
+		if err != nil {
+			return nil, err
+		}
+		recordingsPath = resolvedPath
+	}
+	recordPath := generateRecordingPathAt(recordingsPath, rm.recordingConfig)
+
 	// Use camera identifier as MediaMTX path name
 	pathName := cameraID
 
@@ -225,6 +288,7 @@ func (rm *RecordingManager) executeStartRecording(ctx context.Context, cameraID
 		if err != nil {
 			return nil, fmt.Errorf("failed to build recording path configuration: %w", err)
 		}
+		pathOptions.RecordPath = recordPath
 
 		// Create path with error recovery
 		err = rm.pathManager.CreatePath(ctx, pathName, devicePath, pathOptions)
@@ -234,6 +298,7 @@ func (rm *RecordingManager) executeStartRecording(ctx context.Context, cameraID
 		if err != nil {
 			return nil, fmt.Errorf("failed to build recording path configuration: %w", err)
 		}
+		pathOptions.RecordPath = recordPath
 
 		// Patch existing path to ensure on-demand configuration
 		err = rm.pathManager.PatchPath(ctx, pathName, pathOptions)
@@ -327,6 +392,10 @@ func (rm *RecordingManager) executeStartRecording(ctx context.Context, cameraID
 
 		// Stop RTSP keepalive
 		rm.stopRTSPKeepalive(cameraID)
+
+		if rm.klvInjector != nil {
+			rm.klvInjector.StopSession(cameraID, nil)
+		}
 	})
 
 	// Build API-ready response with rich recording metadata
@@ -349,12 +418,18 @@ func (rm *RecordingManager) executeStartRecording(ctx context.Context, cameraID
 	// Update statistics
 	rm.updateRecordingStats(true, false)
 
+	// Sample the camera position for the KLV metadata track
+	if rm.klvInjector != nil {
+		rm.klvInjector.StartSession(cameraID)
+	}
+
 	rm.logger.WithFields(logging.Fields{
 		"cameraID":       cameraID,
 		"filename":       filename,
 		"format":         format,
 		"keepalive_used": true,
 		"path_name":      pathName,
+		"record_path":    recordPath,
 	}).Info("Recording started successfully with API-ready response")
 
 	return response, nil
@@ -399,17 +474,31 @@ func (rm *RecordingManager) GetRecordingInfo(ctx context.Context, filename strin
 	var fileInfo os.FileInfo
 	var err error
 
-	// Try with extension first (MediaMTX creates files with extensions)
-	filePath = filepath.Join(recordingsPath, filename+"."+format)
-	fileInfo, err = os.Stat(filePath)
-	if err != nil {
-		// If not found with extension, try without extension (fallback for edge cases)
-		filePath = filepath.Join(recordingsPath, filename)
+	var storageTier string
+
+	if rm.tierManager != nil && rm.tierManager.Enabled() {
+		// Resolve across primary and archive tiers (extension first, then without)
+		filePath, fileInfo, storageTier, err = rm.tierManager.ResolveRecordingFile(filename+"."+format, filename)
+	} else {
+		// Try with extension first (MediaMTX creates files with extensions)
+		filePath = filepath.Join(recordingsPath, filename+"."+format)
 		fileInfo, err = os.Stat(filePath)
 		if err != nil {
-			return nil, fmt.Errorf("recording file not found: %v", err)
+			// If not found with extension, try without extension (fallback for edge cases)
+			filePath = filepath.Join(recordingsPath, filename)
+			fileInfo, err = os.Stat(filePath)
+			if err != nil {
+				err = fmt.Errorf("recording file not found: %v", err)
+			}
 		}
 	}
+	if err != nil {
+		// Recordings deleted locally after a confirmed upload remain queryable
+		if response := rm.replicatedRecordingInfo(filename, format, recordingsPath); response != nil {
+			return response, nil
+		}
+		return nil, err
+	}
 
 	// Extract device from filename pattern (camera0_timestamp.mp4)
 	device := "camera0" // Default
@@ -441,6 +530,7 @@ func (rm *RecordingManager) GetRecordingInfo(ctx context.Context, filename strin
 
 	// Extract video duration using MetadataManager
 	duration := float64(0) // Default fallback
+	var klvInfo *RecordingKLVInfo
 	if rm.metadataManager != nil {
 		metadata, err := rm.metadataManager.ExtractVideoMetadata(ctx, filePath)
 		if err != nil {
@@ -455,6 +545,13 @@ func (rm *RecordingManager) GetRecordingInfo(ctx context.Context, filename strin
 		} else {
 			rm.logger.WithField("filename", filename).Warn("Video metadata extraction failed or no duration available")
 		}
+
+		// STANAG 4609 recordings carry KLV metadata in a data stream
+		if err == nil && metadata.HasData {
+			if klvInfo, err = rm.metadataManager.ExtractKLVMetadata(ctx, filePath); err != nil {
+				rm.logger.WithError(err).WithField("file_path", filePath).Warn("Failed to extract KLV metadata")
+			}
+		}
 	}
 
 	// Build API-ready response with rich metadata
@@ -465,6 +562,11 @@ func (rm *RecordingManager) GetRecordingInfo(ctx context.Context, filename strin
 		CreatedTime: fileInfo.ModTime().Format(time.RFC3339), // API compliant field name
 		Format:      fileFormat,
 		Device:      device,
+		StorageTier: storageTier,
+		KLV:         klvInfo,
+	}
+	if rm.replicationManager != nil {
+		response.Replication = rm.replicationManager.StatusFor(filePath)
 	}
 
 	rm.logger.WithFields(logging.Fields{
@@ -477,6 +579,31 @@ func (rm *RecordingManager) GetRecordingInfo(ctx context.Context, filename strin
 	return response, nil
 }
 
+// replicatedRecordingInfo builds recording info from the replication queue for a
+// recording whose local copy was deleted after a confirmed upload
+func (rm *RecordingManager) replicatedRecordingInfo(filename, format, recordingsPath string) *GetRecordingInfoResponse {
+	if rm.replicationManager == nil {
+		return nil
+	}
+	entry := rm.replicationManager.DeletedLocalEntry(
+		filepath.Join(recordingsPath, filename+"."+format),
+		filepath.Join(recordingsPath, filename),
+	)
+	if entry == nil {
+		return nil
+	}
+
+	fileFormat := strings.TrimPrefix(filepath.Ext(entry.Path), ".")
+	return &GetRecordingInfoResponse{
+		Filename:    filename,
+		FileSize:    entry.Size,
+		CreatedTime: entry.ModTime.Format(time.RFC3339),
+		Format:      fileFormat,
+		Device:      retentionDevice(recordingsPath, filepath.Join(recordingsPath, filename)),
+		Replication: entry.info(),
+	}
+}
+
 // DeleteRecording deletes a recording file from the filesystem
 func (rm *RecordingManager) DeleteRecording(ctx context.Context, filename string) error {
 	// Add panic recovery for recording operations
@@ -509,6 +636,13 @@ func (rm *RecordingManager) DeleteRecording(ctx context.Context, filename string
 	// Construct full file path using canonical config
 	filePath := filepath.Join(recordingsPath, filename)
 
+	// Archived recordings are deleted from the archive tier
+	if rm.tierManager != nil && rm.tierManager.Enabled() {
+		if resolved, _, _, err := rm.tierManager.ResolveRecordingFile(filename); err == nil {
+			filePath = resolved
+		}
+	}
+
 	// Check if file exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return fmt.Errorf("recording file not found: %s", filename)
@@ -629,6 +763,19 @@ func (rm *RecordingManager) StopRecording(ctx context.Context, cameraID string)
 	// Update statistics
 	rm.updateRecordingStats(false, false)
 
+	// Hash the finished segments into the custody manifest once MediaMTX closes
+	// them, after the KLV track is muxed in so the hash covers the final file
+	recordCustody := func() {
+		if rm.custodyManager != nil {
+			rm.custodyManager.RecordRecordingStop(cameraID, startTime)
+		}
+	}
+	if rm.klvInjector != nil {
+		rm.klvInjector.StopSession(cameraID, recordCustody)
+	} else {
+		recordCustody()
+	}
+
 	rm.logger.WithFields(logging.Fields{
 		"cameraID":  cameraID,
 		"filename":  filename,
@@ -733,6 +880,7 @@ func (rm *RecordingManager) ListRecordings(ctx context.Context, limit, offset in
 			ModifiedTime: file.CreatedAt.Format(time.RFC3339), // API compliant field name
 			Format:       format,
 			DownloadURL:  fmt.Sprintf("/files/recordings/%s", file.FileName),
+			StorageTier:  file.StorageTier,
 		}
 	}
 
@@ -791,10 +939,28 @@ func (rm *RecordingManager) GetRecordingsList(ctx context.Context, limit, offset
 	} else {
 		files = files[start:end]
 	}
+	total := int(recordingList.ItemCount) // Ensure integer type per JSON-RPC spec
+
+	// Archived recordings are no longer under MediaMTX's record path - list them after live ones
+	if rm.tierManager != nil {
+		archived, err := rm.tierManager.ListArchivedRecordings()
+		if err != nil {
+			rm.logger.WithError(err).Warn("Failed to list archived recordings")
+		} else if len(archived) > 0 {
+			archivedStart := offset - total
+			if archivedStart < 0 {
+				archivedStart = 0
+			}
+			for i := archivedStart; i < len(archived) && len(files) < limit; i++ {
+				files = append(files, archived[i])
+			}
+			total += len(archived)
+		}
+	}
 
 	return &FileListResponse{
 		Files:  files,
-		Total:  int(recordingList.ItemCount), // Ensure integer type per JSON-RPC spec
+		Total:  total,
 		Limit:  limit,
 		Offset: offset,
 	}, nil
@@ -995,33 +1161,72 @@ func (rm *RecordingManager) isPathRecording(ctx context.Context, cameraID string
 
 // patchRecordingOnPath patches recording flag using effective config name
 func (rm *RecordingManager) patchRecordingOnPath(ctx context.Context, cameraID string, record bool) error {
+	effectiveConf, err := rm.patchPathConfig(ctx, cameraID, map[string]interface{}{
+		"record": record,
+	})
+	if err != nil {
+		return err
+	}
+
+	rm.logger.WithFields(logging.Fields{
+		"camera_id":      cameraID,
+		"effective_conf": effectiveConf,
+		"record":         record,
+	}).Info("Successfully patched recording flag using effective config name")
+
+	return nil
+}
+
+// patchPathConfig patches the effective config of a camera path and returns its name
+func (rm *RecordingManager) patchPathConfig(ctx context.Context, cameraID string, patchData map[string]interface{}) (string, error) {
 	// CRITICAL: Resolve effective config name first
 	effectiveConf, err := rm.getEffectiveConfigName(ctx, cameraID)
 	if err != nil {
-		return fmt.Errorf("failed to resolve effective config: %w", err)
+		return "", fmt.Errorf("failed to resolve effective config: %w", err)
 	}
 
 	// Patch the effective config, not the path name
 	endpoint := fmt.Sprintf("/v3/config/paths/patch/%s", effectiveConf)
-	patchData := map[string]interface{}{
-		"record": record,
-	}
 
 	jsonData, err := json.Marshal(patchData)
 	if err != nil {
-		return fmt.Errorf("failed to marshal patch data: %w", err)
+		return "", fmt.Errorf("failed to marshal patch data: %w", err)
 	}
 
 	err = rm.client.Patch(ctx, endpoint, jsonData)
 	if err != nil {
-		return fmt.Errorf("failed to patch config for %s: %w", effectiveConf, err)
+		return "", fmt.Errorf("failed to patch config for %s: %w", effectiveConf, err)
+	}
+
+	return effectiveConf, nil
+}
+
+// ActiveRecordingCameras returns the cameras with an active recording
+func (rm *RecordingManager) ActiveRecordingCameras() []string {
+	active := rm.timerManager.ListActiveRecordings()
+	cameras := make([]string, 0, len(active))
+	for _, recording := range active {
+		cameras = append(cameras, recording.CameraID)
+	}
+	return cameras
+}
+
+// MigrateRecordPath points an active recording at a new base path.
+// MediaMTX starts the next segment under the new recordPath without stopping the recording.
+func (rm *RecordingManager) MigrateRecordPath(ctx context.Context, cameraID, basePath string) error {
+	recordPath := generateRecordingPathAt(basePath, rm.recordingConfig)
+	effectiveConf, err := rm.patchPathConfig(ctx, cameraID, map[string]interface{}{
+		"recordPath": recordPath,
+	})
+	if err != nil {
+		return err
 	}
 
 	rm.logger.WithFields(logging.Fields{
 		"camera_id":      cameraID,
 		"effective_conf": effectiveConf,
-		"record":         record,
-	}).Info("Successfully patched recording flag using effective config name")
+		"record_path":    recordPath,
+	}).Info("Migrated recording to new record path")
 
 	return nil
 }
@@ -0,0 +1,731 @@
+/*
+MediaMTX Off-Box Replication Implementation
+
+Ships completed recordings and snapshots to an S3-compatible object store so
+footage survives loss of the edge unit. Uploads are queued in a JSON file
+that survives restarts; large files use multipart uploads that resume from
+the last confirmed part.
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-007: Error handling and recovery
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"golang.org/x/time/rate"
+)
+
+// Replication status values reported per file
+const (
+	ReplicationStatusPending   = "pending"
+	ReplicationStatusUploading = "uploading"
+	ReplicationStatusUploaded  = "uploaded"
+	ReplicationStatusFailed    = "failed"
+)
+
+// errReplicationSourceChanged reports that a queued file was rewritten after it
+// was queued, e.g. by encryption at rest or KLV remuxing
+var errReplicationSourceChanged = errors.New("file changed since it was queued")
+
+const (
+	replicationQueueVersion = 1
+	replicationBaseBackoff  = 5 * time.Second
+	replicationMaxBackoff   = 10 * time.Minute
+)
+
+// ReplicationEntry is a persisted upload queue entry, keyed by object key
+type ReplicationEntry struct {
+	Key           string            `json:"key"`
+	Path          string            `json:"path"`
+	Kind          string            `json:"kind"`
+	Size          int64             `json:"size"`
+	ModTime       time.Time         `json:"mod_time"`
+	Status        string            `json:"status"`
+	UploadID      string            `json:"upload_id,omitempty"`
+	Parts         []S3CompletedPart `json:"parts,omitempty"`
+	BytesUploaded int64             `json:"bytes_uploaded"`
+	Attempts      int               `json:"attempts"`
+	LastError     string            `json:"last_error,omitempty"`
+	NextAttemptAt time.Time         `json:"next_attempt_at,omitempty"`
+	UploadedAt    time.Time         `json:"uploaded_at,omitempty"`
+	LocalDeleted  bool              `json:"local_deleted,omitempty"`
+}
+
+// replicationQueueFile is the on-disk queue format
+type replicationQueueFile struct {
+	Version int                 `json:"version"`
+	Entries []*ReplicationEntry `json:"entries"`
+}
+
+// ReplicationManager uploads completed recordings and snapshots off-box.
+//
+// RESPONSIBILITIES:
+// - Periodically enqueue completed (unlocked) recordings and snapshots
+// - Upload with multipart uploads that resume after restarts
+// - Cap upload bandwidth with a shared token bucket
+// - Confirm uploads with HEAD before optionally deleting the local copy
+// - Report per-file replication status for get_recording_info
+//
+// Archived recordings keep the object key of their primary-tier location,
+// so a tier move never causes a second upload.
+type ReplicationManager struct {
+	config  *config.Config
+	logger  *logging.Logger
+	client  *S3Client
+	limiter *rate.Limiter
+
+	mu       sync.Mutex
+	entries  map[string]*ReplicationEntry
+	wakeChan chan struct{}
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewReplicationManager creates a new replication manager
+func NewReplicationManager(cfg *config.Config, logger *logging.Logger) *ReplicationManager {
+	var limiter *rate.Limiter
+	if limit := cfg.Replication.BandwidthLimitMBps; limit > 0 {
+		limiter = rate.NewLimiter(rate.Limit(limit*1024*1024), tierCopyChunkSize)
+	}
+
+	return &ReplicationManager{
+		config:   cfg,
+		logger:   logger,
+		limiter:  limiter,
+		entries:  make(map[string]*ReplicationEntry),
+		wakeChan: make(chan struct{}, 1),
+	}
+}
+
+// Start loads the persisted queue and begins uploading when replication is enabled
+func (rm *ReplicationManager) Start(ctx context.Context) error {
+	if !rm.config.Replication.Enabled {
+		return nil
+	}
+
+	rm.mu.Lock()
+	if rm.stopChan != nil {
+		rm.mu.Unlock()
+		return nil
+	}
+	rm.mu.Unlock()
+
+	client, err := NewS3Client(&rm.config.Replication)
+	if err != nil {
+		return err
+	}
+	if err := rm.loadQueue(); err != nil {
+		return err
+	}
+
+	rm.mu.Lock()
+	rm.client = client
+	rm.stopChan = make(chan struct{})
+	stopChan := rm.stopChan
+	queued := len(rm.entries)
+	rm.mu.Unlock()
+
+	interval := time.Duration(rm.config.Replication.ScanIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	rm.wg.Add(1)
+	go func() {
+		defer rm.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			rm.scan(time.Now())
+			rm.processQueue(ctx, stopChan)
+			select {
+			case <-ticker.C:
+			case <-rm.wakeChan:
+			case <-stopChan:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	rm.logger.WithFields(logging.Fields{
+		"endpoint": rm.config.Replication.Endpoint,
+		"bucket":   rm.config.Replication.Bucket,
+		"queued":   queued,
+	}).Info("Replication started")
+	return nil
+}
+
+// Stop stops uploading; an in-progress multipart upload resumes on next start
+func (rm *ReplicationManager) Stop() {
+	rm.mu.Lock()
+	stopChan := rm.stopChan
+	rm.stopChan = nil
+	rm.mu.Unlock()
+
+	if stopChan == nil {
+		return
+	}
+	close(stopChan)
+	rm.wg.Wait()
+}
+
+// Wake triggers an immediate scan and upload pass
+func (rm *ReplicationManager) Wake() {
+	select {
+	case rm.wakeChan <- struct{}{}:
+	default:
+	}
+}
+
+// StatusFor returns the replication status of a local file, checking each
+// candidate path in order. Returns nil if the file is not tracked.
+func (rm *ReplicationManager) StatusFor(paths ...string) *ReplicationInfo {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	for _, p := range paths {
+		key, _, ok := rm.objectKey(p)
+		if !ok {
+			continue
+		}
+		if entry, exists := rm.entries[key]; exists {
+			return entry.info()
+		}
+	}
+	return nil
+}
+
+// AwaitingUpload reports whether a local file is in replication scope but has
+// no confirmed off-box copy yet. Files not queued so far count as awaiting.
+func (rm *ReplicationManager) AwaitingUpload(localPath string) bool {
+	if !rm.config.Replication.Enabled {
+		return false
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	key, kind, ok := rm.objectKey(localPath)
+	if !ok || (kind == fileKindSnapshot && !rm.config.Replication.IncludeSnapshots) {
+		return false
+	}
+	entry, exists := rm.entries[key]
+	return !exists || entry.Status != ReplicationStatusUploaded
+}
+
+// DeletedLocalEntry returns the queue entry for a file removed locally after
+// upload, so its metadata and replication status remain queryable
+func (rm *ReplicationManager) DeletedLocalEntry(paths ...string) *ReplicationEntry {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	for _, p := range paths {
+		key, _, ok := rm.objectKey(p)
+		if !ok {
+			continue
+		}
+		if entry, exists := rm.entries[key]; exists && entry.LocalDeleted {
+			snapshot := *entry
+			return &snapshot
+		}
+	}
+	return nil
+}
+
+// info converts an entry to its API representation
+func (e *ReplicationEntry) info() *ReplicationInfo {
+	info := &ReplicationInfo{
+		Status:        e.Status,
+		ObjectKey:     e.Key,
+		BytesUploaded: e.BytesUploaded,
+		FileSize:      e.Size,
+		Attempts:      e.Attempts,
+		LastError:     e.LastError,
+		LocalDeleted:  e.LocalDeleted,
+	}
+	if !e.UploadedAt.IsZero() {
+		info.UploadedAt = e.UploadedAt.Format(time.RFC3339)
+	}
+	return info
+}
+
+// objectKey maps a local path to its object key and kind.
+// Archive-tier recordings map to the same key as their primary location.
+func (rm *ReplicationManager) objectKey(localPath string) (string, string, bool) {
+	roots := []struct {
+		path string
+		kind string
+		dir  string
+	}{
+		{rm.config.MediaMTX.RecordingsPath, fileKindRecording, "recordings"},
+		{rm.config.Storage.Tiering.ArchivePath, fileKindRecording, "recordings"},
+		{rm.config.MediaMTX.SnapshotsPath, fileKindSnapshot, "snapshots"},
+	}
+	for _, root := range roots {
+		if root.path == "" {
+			continue
+		}
+		rel, err := filepath.Rel(root.path, localPath)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		prefix := strings.Trim(rm.config.Replication.Prefix, "/")
+		return path.Join(prefix, root.dir, filepath.ToSlash(rel)), root.kind, true
+	}
+	return "", "", false
+}
+
+// scan enqueues completed files and prunes entries for files that disappeared
+func (rm *ReplicationManager) scan(now time.Time) {
+	roots := []string{rm.config.MediaMTX.RecordingsPath}
+	if tiering := rm.config.Storage.Tiering; tiering.Enabled && tiering.ArchivePath != "" {
+		roots = append(roots, tiering.ArchivePath)
+	}
+	if rm.config.Replication.IncludeSnapshots {
+		roots = append(roots, rm.config.MediaMTX.SnapshotsPath)
+	}
+
+	seen := make(map[string]bool)
+	changed := false
+
+	for _, root := range roots {
+		if root == "" {
+			continue
+		}
+		filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || isInternalStorageFile(p) {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			key, kind, ok := rm.objectKey(p)
+			if !ok {
+				return nil
+			}
+			seen[key] = true
+			if isFileLocked(p, info, now) {
+				return nil
+			}
+			if rm.track(key, kind, p, info) {
+				changed = true
+			}
+			return nil
+		})
+	}
+
+	rm.mu.Lock()
+	for key, entry := range rm.entries {
+		// Keep records of uploads whose local copy was deleted on purpose
+		if !seen[key] && !entry.LocalDeleted {
+			delete(rm.entries, key)
+			changed = true
+		}
+	}
+	rm.mu.Unlock()
+
+	if changed {
+		rm.saveQueue()
+	}
+}
+
+// track adds or refreshes the entry for a file; returns true if the queue changed
+func (rm *ReplicationManager) track(key, kind, localPath string, info fs.FileInfo) bool {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	entry, exists := rm.entries[key]
+	if !exists {
+		rm.entries[key] = &ReplicationEntry{
+			Key:     key,
+			Path:    localPath,
+			Kind:    kind,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+			Status:  ReplicationStatusPending,
+		}
+		return true
+	}
+
+	changed := false
+	if entry.Path != localPath {
+		// Moved between storage tiers - same content, same object
+		entry.Path = localPath
+		changed = true
+	}
+	if entry.Size != info.Size() || !entry.ModTime.Equal(info.ModTime()) {
+		// Content changed - start over
+		*entry = ReplicationEntry{
+			Key:     key,
+			Path:    localPath,
+			Kind:    kind,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+			Status:  ReplicationStatusPending,
+		}
+		changed = true
+	}
+	return changed
+}
+
+// processQueue uploads due entries, oldest first, until the queue is drained or stopped
+func (rm *ReplicationManager) processQueue(ctx context.Context, stopChan chan struct{}) {
+	for _, entry := range rm.dueEntries(time.Now()) {
+		select {
+		case <-stopChan:
+			return
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		uploadCtx, cancel := context.WithCancel(ctx)
+		go func() {
+			select {
+			case <-stopChan:
+				cancel()
+			case <-uploadCtx.Done():
+			}
+		}()
+		err := rm.upload(uploadCtx, entry)
+		stopped := uploadCtx.Err() != nil
+		cancel()
+
+		if errors.Is(err, errReplicationSourceChanged) {
+			rm.requeue(ctx, entry)
+			continue
+		}
+		if err != nil {
+			if stopped && ctx.Err() == nil {
+				// Stopped mid-upload - resume on next start without counting an attempt
+				rm.mutate(entry, func(e *ReplicationEntry) { e.Status = ReplicationStatusPending })
+				return
+			}
+			rm.recordFailure(entry, err)
+		}
+	}
+}
+
+// dueEntries returns pending entries whose retry time has passed, oldest file first
+func (rm *ReplicationManager) dueEntries(now time.Time) []*ReplicationEntry {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	var due []*ReplicationEntry
+	for _, entry := range rm.entries {
+		if (entry.Status == ReplicationStatusPending || entry.Status == ReplicationStatusUploading) &&
+			!entry.NextAttemptAt.After(now) {
+			due = append(due, entry)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].ModTime.Before(due[j].ModTime)
+	})
+	return due
+}
+
+// upload ships one file, resuming a multipart upload when one is recorded
+func (rm *ReplicationManager) upload(ctx context.Context, entry *ReplicationEntry) error {
+	partSize := int64(rm.config.Replication.PartSizeMB) * 1024 * 1024
+	if partSize <= 0 {
+		partSize = 8 * 1024 * 1024
+	}
+
+	file, err := os.Open(entry.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", entry.Path, err)
+	}
+	defer file.Close()
+
+	// The entry's size and mtime were recorded at queue time; upload only
+	// what was queued, never a file that has since been rewritten in place
+	if err := checkReplicationSource(entry, file.Stat); err != nil {
+		return err
+	}
+
+	rm.mutate(entry, func(e *ReplicationEntry) { e.Status = ReplicationStatusUploading })
+
+	if entry.Size <= partSize {
+		body, err := rm.readChunk(ctx, file, 0, entry.Size)
+		if err != nil {
+			return err
+		}
+		if _, err := rm.client.PutObject(ctx, entry.Key, body); err != nil {
+			return err
+		}
+		rm.mutate(entry, func(e *ReplicationEntry) { e.BytesUploaded = e.Size })
+	} else if err := rm.uploadMultipart(ctx, entry, file, partSize); err != nil {
+		return err
+	}
+
+	if err := checkReplicationSource(entry, file.Stat); err != nil {
+		return err
+	}
+
+	// Confirm the object is complete before reporting success or deleting anything
+	size, err := rm.client.HeadObject(ctx, entry.Key)
+	if err != nil {
+		return fmt.Errorf("failed to confirm upload: %w", err)
+	}
+	if size != entry.Size {
+		return fmt.Errorf("uploaded object size %d does not match local size %d", size, entry.Size)
+	}
+
+	rm.mutate(entry, func(e *ReplicationEntry) {
+		e.Status = ReplicationStatusUploaded
+		e.UploadID = ""
+		e.Parts = nil
+		e.LastError = ""
+		e.UploadedAt = time.Now()
+	})
+	rm.logger.WithFields(logging.Fields{
+		"file":      entry.Path,
+		"key":       entry.Key,
+		"file_size": entry.Size,
+	}).Info("File replicated to object storage")
+
+	if rm.config.Replication.DeleteLocalAfter {
+		// The uploaded object must still be the only copy's content
+		if err := checkReplicationSource(entry, func() (fs.FileInfo, error) { return os.Stat(entry.Path) }); err != nil {
+			return err
+		}
+		if err := os.Remove(entry.Path); err != nil {
+			rm.logger.WithError(err).WithField("file", entry.Path).Warn("Failed to delete local copy after upload")
+		} else {
+			rm.mutate(entry, func(e *ReplicationEntry) { e.LocalDeleted = true })
+		}
+	}
+	return nil
+}
+
+// checkReplicationSource compares a file's current size and mtime with the
+// values recorded when its entry was queued
+func checkReplicationSource(entry *ReplicationEntry, stat func() (fs.FileInfo, error)) error {
+	info, err := stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", entry.Path, err)
+	}
+	if info.Size() != entry.Size || !info.ModTime().Equal(entry.ModTime) {
+		return fmt.Errorf("%w: %s", errReplicationSourceChanged, entry.Path)
+	}
+	return nil
+}
+
+// requeue restarts an entry whose file changed under it. Any multipart upload
+// of the old content is aborted; the next scan records the new size and mtime.
+func (rm *ReplicationManager) requeue(ctx context.Context, entry *ReplicationEntry) {
+	rm.mu.Lock()
+	uploadID := entry.UploadID
+	*entry = ReplicationEntry{
+		Key:    entry.Key,
+		Path:   entry.Path,
+		Kind:   entry.Kind,
+		Status: ReplicationStatusPending,
+	}
+	if info, err := os.Stat(entry.Path); err == nil {
+		entry.Size = info.Size()
+		entry.ModTime = info.ModTime()
+	}
+	rm.mu.Unlock()
+	rm.saveQueue()
+
+	if uploadID != "" {
+		if err := rm.client.AbortMultipartUpload(ctx, entry.Key, uploadID); err != nil {
+			rm.logger.WithError(err).WithField("key", entry.Key).Debug("Failed to abort stale multipart upload")
+		}
+	}
+	rm.logger.WithField("file", entry.Path).Info("File changed since it was queued, requeued for replication")
+}
+
+// uploadMultipart uploads the remaining parts of a file, persisting progress after each part
+func (rm *ReplicationManager) uploadMultipart(ctx context.Context, entry *ReplicationEntry, file *os.File, partSize int64) error {
+	if entry.UploadID != "" {
+		// Resume: trust only parts the object store still has
+		parts, err := rm.client.ListParts(ctx, entry.Key, entry.UploadID)
+		var s3Err *S3Error
+		if errors.As(err, &s3Err) && s3Err.StatusCode == http.StatusNotFound {
+			rm.mutate(entry, func(e *ReplicationEntry) { e.UploadID, e.Parts, e.BytesUploaded = "", nil, 0 })
+		} else if err != nil {
+			return fmt.Errorf("failed to list uploaded parts: %w", err)
+		} else {
+			confirmed := contiguousParts(parts, partSize)
+			rm.mutate(entry, func(e *ReplicationEntry) {
+				e.Parts = confirmed
+				e.BytesUploaded = int64(len(confirmed)) * partSize
+			})
+		}
+	}
+
+	if entry.UploadID == "" {
+		uploadID, err := rm.client.CreateMultipartUpload(ctx, entry.Key)
+		if err != nil {
+			return fmt.Errorf("failed to start multipart upload: %w", err)
+		}
+		rm.mutate(entry, func(e *ReplicationEntry) { e.UploadID = uploadID })
+	}
+
+	totalParts := int((entry.Size + partSize - 1) / partSize)
+	for partNumber := len(entry.Parts) + 1; partNumber <= totalParts; partNumber++ {
+		offset := int64(partNumber-1) * partSize
+		length := partSize
+		if offset+length > entry.Size {
+			length = entry.Size - offset
+		}
+		body, err := rm.readChunk(ctx, file, offset, length)
+		if err != nil {
+			return err
+		}
+		etag, err := rm.client.UploadPart(ctx, entry.Key, entry.UploadID, partNumber, body)
+		if err != nil {
+			return fmt.Errorf("failed to upload part %d: %w", partNumber, err)
+		}
+		rm.mutate(entry, func(e *ReplicationEntry) {
+			e.Parts = append(e.Parts, S3CompletedPart{PartNumber: partNumber, ETag: etag, Size: length})
+			e.BytesUploaded = offset + length
+		})
+	}
+
+	if _, err := rm.client.CompleteMultipartUpload(ctx, entry.Key, entry.UploadID, entry.Parts); err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return nil
+}
+
+// contiguousParts keeps parts 1..n with the expected size, stopping at the first gap
+func contiguousParts(parts []S3CompletedPart, partSize int64) []S3CompletedPart {
+	var confirmed []S3CompletedPart
+	for i, part := range parts {
+		if part.PartNumber != i+1 || (part.Size != 0 && part.Size != partSize) {
+			break
+		}
+		part.Size = partSize
+		confirmed = append(confirmed, part)
+	}
+	return confirmed
+}
+
+// readChunk reads length bytes at offset through the bandwidth limiter
+func (rm *ReplicationManager) readChunk(ctx context.Context, file *os.File, offset, length int64) ([]byte, error) {
+	body := make([]byte, length)
+	reader := &throttledReader{ctx: ctx, reader: io.NewSectionReader(file, offset, length), limiter: rm.limiter}
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file.Name(), err)
+	}
+	return body, nil
+}
+
+// recordFailure schedules a retry with exponential backoff or marks the entry failed
+func (rm *ReplicationManager) recordFailure(entry *ReplicationEntry, err error) {
+	maxAttempts := rm.config.Replication.MaxAttempts
+
+	rm.mutate(entry, func(e *ReplicationEntry) {
+		e.Attempts++
+		e.LastError = err.Error()
+		if maxAttempts > 0 && e.Attempts >= maxAttempts {
+			e.Status = ReplicationStatusFailed
+			return
+		}
+		backoff := replicationBaseBackoff << uint(e.Attempts-1)
+		if backoff <= 0 || backoff > replicationMaxBackoff {
+			backoff = replicationMaxBackoff
+		}
+		e.Status = ReplicationStatusPending
+		e.NextAttemptAt = time.Now().Add(backoff)
+	})
+
+	rm.logger.WithError(err).WithFields(logging.Fields{
+		"file":     entry.Path,
+		"attempts": entry.Attempts,
+	}).Warn("Replication upload failed")
+}
+
+// mutate applies a change to an entry under the lock and persists the queue
+func (rm *ReplicationManager) mutate(entry *ReplicationEntry, change func(e *ReplicationEntry)) {
+	rm.mu.Lock()
+	change(entry)
+	rm.mu.Unlock()
+	rm.saveQueue()
+}
+
+// loadQueue restores the persisted queue
+func (rm *ReplicationManager) loadQueue() error {
+	data, err := os.ReadFile(rm.config.Replication.QueuePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read replication queue: %w", err)
+	}
+
+	var queue replicationQueueFile
+	if err := json.Unmarshal(data, &queue); err != nil {
+		return fmt.Errorf("failed to parse replication queue: %w", err)
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	for _, entry := range queue.Entries {
+		if entry.Status == ReplicationStatusUploading {
+			entry.Status = ReplicationStatusPending
+		}
+		rm.entries[entry.Key] = entry
+	}
+	return nil
+}
+
+// saveQueue persists the queue atomically (write temp file, then rename)
+func (rm *ReplicationManager) saveQueue() {
+	rm.mu.Lock()
+	queue := replicationQueueFile{Version: replicationQueueVersion, Entries: make([]*ReplicationEntry, 0, len(rm.entries))}
+	for _, entry := range rm.entries {
+		queue.Entries = append(queue.Entries, entry)
+	}
+	sort.Slice(queue.Entries, func(i, j int) bool {
+		return queue.Entries[i].Key < queue.Entries[j].Key
+	})
+	data, err := json.MarshalIndent(queue, "", "  ")
+	rm.mu.Unlock()
+	if err != nil {
+		rm.logger.WithError(err).Error("Failed to encode replication queue")
+		return
+	}
+
+	queuePath := rm.config.Replication.QueuePath
+	if err := os.MkdirAll(filepath.Dir(queuePath), 0755); err != nil {
+		rm.logger.WithError(err).Error("Failed to create replication queue directory")
+		return
+	}
+	tmpPath := queuePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		rm.logger.WithError(err).Error("Failed to write replication queue")
+		return
+	}
+	if err := os.Rename(tmpPath, queuePath); err != nil {
+		rm.logger.WithError(err).Error("Failed to replace replication queue")
+	}
+}
@@ -0,0 +1,525 @@
+/*
+MediaMTX Retention Janitor Implementation
+
+Applies retention rules per camera and per camera group on top of the global
+retention policy, and runs them periodically in the background. Locked files
+are never deleted.
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-008: Logging and monitoring
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+)
+
+const (
+	// fileLockSuffix marks a file as locked when a sidecar "<file>.lock" exists
+	fileLockSuffix = ".lock"
+
+	// defaultRetentionCleanupInterval is used when cleanup_interval_minutes is not set
+	defaultRetentionCleanupInterval = 60 * time.Minute
+
+	// globalRetentionRule names the global retention policy in cleanup reports
+	globalRetentionRule = "global"
+
+	fileKindRecording = "recording"
+	fileKindSnapshot  = "snapshot"
+)
+
+// retentionFile is a recording or snapshot file seen by the retention janitor
+type retentionFile struct {
+	path    string
+	device  string
+	kind    string
+	size    int64
+	modTime time.Time
+	locked  bool
+}
+
+// retentionRule is a resolved retention rule with limits in native units
+type retentionRule struct {
+	name     string
+	maxAge   time.Duration
+	maxSize  int64
+	maxCount int
+}
+
+// RetentionJanitor enforces per-camera and per-group retention rules.
+//
+// RESPONSIBILITIES:
+// - Resolve which rule applies to each file (camera > group > global policy)
+// - Plan deletions oldest-first by age, quota and count, skipping locked files
+// - Execute or dry-run the plan for cleanup_old_files
+// - Periodically enforce camera and group rules when auto_cleanup is enabled
+// - Report per-camera storage usage for get_storage_info
+//
+// A file is locked while a "<file>.lock" sidecar exists or while it is still
+// being written (modified within storageActiveWriteWindow).
+type RetentionJanitor struct {
+	config *config.Config
+	logger *logging.Logger
+
+	runMu    sync.Mutex // Serializes cleanup runs
+	mu       sync.Mutex
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewRetentionJanitor creates a new retention janitor
+func NewRetentionJanitor(cfg *config.Config, logger *logging.Logger) *RetentionJanitor {
+	return &RetentionJanitor{
+		config: cfg,
+		logger: logger,
+	}
+}
+
+// Start begins periodic enforcement of camera and group rules
+func (rj *RetentionJanitor) Start(ctx context.Context) {
+	rj.mu.Lock()
+	if rj.stopChan != nil {
+		rj.mu.Unlock()
+		return
+	}
+	rj.stopChan = make(chan struct{})
+	stopChan := rj.stopChan
+	rj.mu.Unlock()
+
+	rj.wg.Add(1)
+	go func() {
+		defer rj.wg.Done()
+		for {
+			select {
+			case <-time.After(rj.cleanupInterval()):
+				rj.runScheduled(ctx)
+			case <-stopChan:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	rj.logger.WithField("interval", rj.cleanupInterval().String()).Info("Retention janitor started")
+}
+
+// Stop stops periodic enforcement and waits for an in-flight run
+func (rj *RetentionJanitor) Stop() {
+	rj.mu.Lock()
+	stopChan := rj.stopChan
+	rj.stopChan = nil
+	rj.mu.Unlock()
+
+	if stopChan == nil {
+		return
+	}
+	close(stopChan)
+	rj.wg.Wait()
+}
+
+// runScheduled enforces camera and group rules if automatic cleanup is enabled
+func (rj *RetentionJanitor) runScheduled(ctx context.Context) {
+	policy := rj.config.RetentionPolicy
+	if !policy.Enabled || !policy.AutoCleanup || (len(policy.Cameras) == 0 && len(policy.Groups) == 0) {
+		return
+	}
+
+	result, err := rj.Run(ctx, false, false)
+	if err != nil {
+		rj.logger.WithError(err).Error("Scheduled retention cleanup failed")
+		return
+	}
+	if result.RecordingsRemoved+result.SnapshotsRemoved > 0 {
+		rj.logger.WithFields(logging.Fields{
+			"recordings_removed": result.RecordingsRemoved,
+			"snapshots_removed":  result.SnapshotsRemoved,
+			"space_freed":        result.SpaceFreed,
+		}).Info("Scheduled retention cleanup completed")
+	}
+}
+
+// cleanupInterval returns the configured janitor interval
+func (rj *RetentionJanitor) cleanupInterval() time.Duration {
+	if minutes := rj.config.RetentionPolicy.CleanupIntervalMinutes; minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return defaultRetentionCleanupInterval
+}
+
+// Run plans and, unless dryRun is set, deletes files that violate retention rules.
+// includeGlobal also applies the global policy to cameras without their own rule.
+// Returns API-ready CleanupOldFilesResponse listing the affected files.
+func (rj *RetentionJanitor) Run(ctx context.Context, includeGlobal, dryRun bool) (*CleanupOldFilesResponse, error) {
+	rj.runMu.Lock()
+	defer rj.runMu.Unlock()
+
+	now := time.Now()
+	files, err := rj.scan(now)
+	if err != nil {
+		return nil, err
+	}
+	planned, lockedSkipped := rj.plan(files, includeGlobal, now)
+
+	response := &CleanupOldFilesResponse{
+		Status:        "SUCCESS",
+		DryRun:        dryRun,
+		Files:         make([]CleanupFileInfo, 0, len(planned)),
+		LockedSkipped: lockedSkipped,
+	}
+
+	for _, item := range planned {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !dryRun {
+			if err := os.Remove(item.file.path); err != nil {
+				rj.logger.WithError(err).WithField("file", item.file.path).Warn("Failed to delete file during retention cleanup")
+				continue
+			}
+		}
+
+		if item.file.kind == fileKindRecording {
+			response.RecordingsRemoved++
+		} else {
+			response.SnapshotsRemoved++
+		}
+		response.SpaceFreed += item.file.size
+		response.Files = append(response.Files, CleanupFileInfo{
+			Filename:     filepath.Base(item.file.path),
+			Device:       item.file.device,
+			Type:         item.file.kind,
+			FileSize:     item.file.size,
+			ModifiedTime: item.file.modTime.Format(time.RFC3339),
+			Rule:         item.rule,
+			Reason:       item.reason,
+		})
+	}
+
+	verb := "Cleaned up"
+	if dryRun {
+		verb = "Dry run: would clean up"
+	}
+	response.Message = fmt.Sprintf("%s %d files (%d recordings, %d snapshots), freeing %d bytes; %d locked files kept",
+		verb, response.RecordingsRemoved+response.SnapshotsRemoved, response.RecordingsRemoved, response.SnapshotsRemoved,
+		response.SpaceFreed, lockedSkipped)
+
+	return response, nil
+}
+
+// CameraBreakdown returns per-camera storage usage and the retention rule applied to each camera
+func (rj *RetentionJanitor) CameraBreakdown() ([]CameraStorageInfo, error) {
+	files, err := rj.scan(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	byDevice := make(map[string]*CameraStorageInfo)
+	for _, file := range files {
+		info, exists := byDevice[file.device]
+		if !exists {
+			info = &CameraStorageInfo{Device: file.device}
+			byDevice[file.device] = info
+		}
+		if file.kind == fileKindRecording {
+			info.RecordingsSize += file.size
+			info.RecordingsCount++
+		} else {
+			info.SnapshotsSize += file.size
+			info.SnapshotsCount++
+		}
+		if file.locked {
+			info.LockedFiles++
+		}
+	}
+
+	cameraRules, groupRules := rj.rules()
+	breakdown := make([]CameraStorageInfo, 0, len(byDevice))
+	for device, info := range byDevice {
+		info.TotalSize = info.RecordingsSize + info.SnapshotsSize
+		if rule, exists := cameraRules[device]; exists {
+			info.RetentionRules = []string{rule.name}
+		} else {
+			for _, group := range groupRules[device] {
+				info.RetentionRules = append(info.RetentionRules, group.name)
+			}
+		}
+		breakdown = append(breakdown, *info)
+	}
+
+	sort.Slice(breakdown, func(i, j int) bool {
+		return breakdown[i].Device < breakdown[j].Device
+	})
+	return breakdown, nil
+}
+
+// plannedDeletion is a file selected for deletion and the rule that selected it
+type plannedDeletion struct {
+	file   *retentionFile
+	rule   string
+	reason string
+}
+
+// plan selects files to delete, oldest first within each rule's file set.
+// A camera rule replaces its group rules; a camera in several groups is subject
+// to all of them. Locked files count toward quotas but are never selected.
+func (rj *RetentionJanitor) plan(files []*retentionFile, includeGlobal bool, now time.Time) ([]plannedDeletion, int) {
+	cameraRules, groupRules := rj.rules()
+
+	type bucket struct {
+		rule  *retentionRule
+		files []*retentionFile
+	}
+	buckets := make(map[string]*bucket)
+	addToBucket := func(key string, rule *retentionRule, file *retentionFile) {
+		b, exists := buckets[key]
+		if !exists {
+			b = &bucket{rule: rule}
+			buckets[key] = b
+		}
+		b.files = append(b.files, file)
+	}
+
+	var global *retentionRule
+	if includeGlobal {
+		global = rj.globalRule()
+	}
+
+	for _, file := range files {
+		if rule, exists := cameraRules[file.device]; exists {
+			addToBucket(rule.name, rule, file)
+			continue
+		}
+		if groups := groupRules[file.device]; len(groups) > 0 {
+			for _, rule := range groups {
+				addToBucket(rule.name, rule, file)
+			}
+			continue
+		}
+		if global != nil {
+			// The global policy applies to recordings and snapshots separately
+			addToBucket(global.name+"/"+file.kind, global, file)
+		}
+	}
+
+	// Deterministic order across buckets
+	keys := make([]string, 0, len(buckets))
+	for key := range buckets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	selected := make(map[string]bool)
+	lockedKept := make(map[string]bool)
+	var planned []plannedDeletion
+
+	for _, key := range keys {
+		b := buckets[key]
+		sort.Slice(b.files, func(i, j int) bool {
+			return b.files[i].modTime.Before(b.files[j].modTime)
+		})
+
+		var total int64
+		remaining := 0
+		for _, file := range b.files {
+			if !selected[file.path] {
+				total += file.size
+				remaining++
+			}
+		}
+
+		for _, file := range b.files {
+			if selected[file.path] {
+				continue
+			}
+
+			reason := ""
+			switch {
+			case b.rule.maxAge > 0 && file.modTime.Before(now.Add(-b.rule.maxAge)):
+				reason = "max_age"
+			case b.rule.maxSize > 0 && total > b.rule.maxSize:
+				reason = "quota"
+			case b.rule.maxCount > 0 && remaining > b.rule.maxCount:
+				reason = "max_count"
+			}
+			if reason == "" {
+				continue
+			}
+			if file.locked {
+				lockedKept[file.path] = true
+				continue
+			}
+
+			selected[file.path] = true
+			total -= file.size
+			remaining--
+			planned = append(planned, plannedDeletion{file: file, rule: b.rule.name, reason: reason})
+		}
+
+		if b.rule.maxSize > 0 && total > b.rule.maxSize {
+			rj.logger.WithFields(logging.Fields{
+				"rule":       b.rule.name,
+				"total_size": total,
+				"quota":      b.rule.maxSize,
+			}).Warn("Retention quota still exceeded after cleanup - remaining files are locked or recent")
+		}
+	}
+
+	return planned, len(lockedKept)
+}
+
+// rules resolves camera rules and the group rules that apply to each camera.
+// Cameras with their own rule are not subject to their groups' rules.
+func (rj *RetentionJanitor) rules() (map[string]*retentionRule, map[string][]*retentionRule) {
+	policy := rj.config.RetentionPolicy
+
+	cameraRules := make(map[string]*retentionRule, len(policy.Cameras))
+	for camera, rule := range policy.Cameras {
+		cameraRules[camera] = newRetentionRule("camera:"+camera, rule)
+	}
+
+	groupNames := make([]string, 0, len(policy.Groups))
+	for group := range policy.Groups {
+		groupNames = append(groupNames, group)
+	}
+	sort.Strings(groupNames)
+
+	groupRules := make(map[string][]*retentionRule)
+	for _, group := range groupNames {
+		rule := newRetentionRule("group:"+group, policy.Groups[group])
+		for _, camera := range policy.Groups[group].Cameras {
+			if _, exists := cameraRules[camera]; exists {
+				continue
+			}
+			groupRules[camera] = append(groupRules[camera], rule)
+		}
+	}
+
+	return cameraRules, groupRules
+}
+
+// globalRule builds the global retention policy rule (same limits as GetCleanupLimits)
+func (rj *RetentionJanitor) globalRule() *retentionRule {
+	return &retentionRule{
+		name:     globalRetentionRule,
+		maxAge:   time.Duration(rj.config.RetentionPolicy.MaxAgeDays) * 24 * time.Hour,
+		maxSize:  int64(rj.config.RetentionPolicy.MaxSizeGB) * 1024 * 1024 * 1024,
+		maxCount: rj.config.Snapshots.MaxCount,
+	}
+}
+
+// newRetentionRule converts a configured rule to native units
+func newRetentionRule(name string, rule config.RetentionRuleConfig) *retentionRule {
+	return &retentionRule{
+		name:    name,
+		maxAge:  time.Duration(rule.MaxAgeDays) * 24 * time.Hour,
+		maxSize: int64(rule.MaxSizeGB * 1024 * 1024 * 1024),
+	}
+}
+
+// scan lists recordings and snapshots under the configured paths
+func (rj *RetentionJanitor) scan(now time.Time) ([]*retentionFile, error) {
+	var files []*retentionFile
+
+	roots := []struct {
+		path string
+		kind string
+	}{
+		{rj.config.MediaMTX.RecordingsPath, fileKindRecording},
+		{rj.config.MediaMTX.SnapshotsPath, fileKindSnapshot},
+	}
+	if tiering := rj.config.Storage.Tiering; tiering.Enabled && tiering.ArchivePath != "" {
+		// Archived recordings count toward the same camera rules
+		roots = append(roots, struct {
+			path string
+			kind string
+		}{tiering.ArchivePath, fileKindRecording})
+	}
+
+	for _, root := range roots {
+		if root.path == "" {
+			continue
+		}
+		err := filepath.WalkDir(root.path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() || isInternalStorageFile(path) {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			files = append(files, &retentionFile{
+				path:    path,
+				device:  retentionDevice(root.path, path),
+				kind:    root.kind,
+				size:    info.Size(),
+				modTime: info.ModTime(),
+				locked:  isFileLocked(path, info, now),
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", root.path, err)
+		}
+	}
+
+	return files, nil
+}
+
+// retentionDevice derives the camera identifier from a file's location: the
+// device subdirectory when present, otherwise the filename prefix before "_"
+func retentionDevice(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		rel = filepath.Base(path)
+	}
+	if parts := strings.Split(filepath.ToSlash(rel), "/"); len(parts) > 1 {
+		return parts[0]
+	}
+	name := filepath.Base(rel)
+	if idx := strings.Index(name, "_"); idx > 0 {
+		return name[:idx]
+	}
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// isInternalStorageFile reports whether a path under the media roots is a
+// service-owned file rather than a recording or snapshot: a lock sidecar, an
+// unverified partial copy or a UAV telemetry file that may still be appended
+// to. Every scanner over the media roots skips these.
+func isInternalStorageFile(path string) bool {
+	return strings.HasSuffix(path, fileLockSuffix) || strings.HasSuffix(path, archivePartialSuffix) ||
+		strings.HasSuffix(path, TelemetryFileSuffix)
+}
+
+// isFileLocked reports whether a file must not be deleted by cleanup: it has
+// a "<file>.lock" sidecar or it is still being written
+func isFileLocked(path string, info fs.FileInfo, now time.Time) bool {
+	if info.ModTime().After(now.Add(-storageActiveWriteWindow)) {
+		return true
+	}
+	_, err := os.Stat(path + fileLockSuffix)
+	return err == nil
+}
@@ -163,6 +163,7 @@ type RecordingFileInfo struct {
 	ModifiedTime string  `json:"modified_time"` // File modification timestamp (ISO 8601) - API compliant
 	Format       string  `json:"format"`        // Recording format
 	DownloadURL  string  `json:"download_url"`  // Download URL for the file
+	StorageTier  string  `json:"storage_tier,omitempty"` // "archive" once moved by storage tiering
 }
 
 // ListSnapshotsResponse represents the response from list_snapshots method
@@ -192,6 +193,81 @@ type GetRecordingInfoResponse struct {
 	CreatedTime string  `json:"created_time"` // Creation timestamp (ISO 8601) - API compliant
 	Format      string  `json:"format"`       // Recording format
 	Device      string  `json:"device"`       // Camera device identifier
+	StorageTier string  `json:"storage_tier,omitempty"` // "primary" or "archive" when storage tiering is enabled
+	Replication *ReplicationInfo `json:"replication,omitempty"` // Off-box replication status when replication is enabled
+	KLV         *RecordingKLVInfo `json:"klv,omitempty"`         // STANAG 4609 KLV metadata summary when the recording carries a KLV track
+}
+
+// RecordingKLVInfo summarizes the MISB ST 0601 KLV metadata track of a recording
+type RecordingKLVInfo struct {
+	Packets             int              `json:"packets"`                        // Valid KLV packets in the track
+	RejectedPackets     int              `json:"rejected_packets,omitempty"`     // Packets failing checksum or parsing
+	MissionID           string           `json:"mission_id,omitempty"`           // ST 0601 mission ID
+	PlatformDesignation string           `json:"platform_designation,omitempty"` // ST 0601 platform designation
+	ImageSourceSensor   string           `json:"image_source_sensor,omitempty"`  // ST 0601 image source sensor
+	FirstTimestamp      string           `json:"first_timestamp"`                // First precision timestamp (ISO 8601)
+	LastTimestamp       string           `json:"last_timestamp"`                 // Last precision timestamp (ISO 8601)
+	StartPosition       *KLVPositionInfo `json:"start_position,omitempty"`       // First sensor position
+	EndPosition         *KLVPositionInfo `json:"end_position,omitempty"`         // Last sensor position
+}
+
+// KLVPositionInfo represents a sensor position decoded from a KLV packet
+type KLVPositionInfo struct {
+	Latitude  float64 `json:"latitude"`  // Degrees
+	Longitude float64 `json:"longitude"` // Degrees
+	Altitude  float64 `json:"altitude"`  // Meters MSL
+	Heading   float64 `json:"heading"`   // Platform heading, degrees
+}
+
+// ReplicationInfo represents the off-box replication status of a file
+type ReplicationInfo struct {
+	Status        string `json:"status"`                // "pending", "uploading", "uploaded" or "failed"
+	ObjectKey     string `json:"object_key"`            // Object key in the replication bucket
+	BytesUploaded int64  `json:"bytes_uploaded"`        // Bytes confirmed uploaded so far
+	FileSize      int64  `json:"file_size"`             // Size of the file being replicated
+	Attempts      int    `json:"attempts"`              // Failed upload attempts
+	LastError     string `json:"last_error,omitempty"`  // Most recent upload error
+	UploadedAt    string `json:"uploaded_at,omitempty"` // Upload confirmation time (ISO 8601)
+	LocalDeleted  bool   `json:"local_deleted"`         // Local copy deleted after confirmed upload
+}
+
+// VerifyFileIntegrityResponse represents the response from verify_file_integrity method
+type VerifyFileIntegrityResponse struct {
+	Filename       string `json:"filename"`                 // File that was verified
+	FileType       string `json:"file_type"`                // "recording" or "snapshot"
+	Device         string `json:"device"`                   // Camera identifier recorded in the manifest
+	Intact         bool   `json:"intact"`                   // Current hash matches the manifest and the chain is valid
+	ChainValid     bool   `json:"chain_valid"`              // Every manifest entry links to its predecessor
+	SignatureValid bool   `json:"signature_valid"`          // Every manifest entry carries a valid service signature
+	RecordedSHA256 string `json:"recorded_sha256"`          // Hash recorded when the file was captured
+	CurrentSHA256  string `json:"current_sha256,omitempty"` // Hash of the file as it is now
+	RecordedAt     string `json:"recorded_at"`              // Manifest entry time (ISO 8601)
+	Event          string `json:"event"`                    // "recording.stop" or "snapshot.taken"
+	Sequence       int64  `json:"sequence"`                 // Manifest entry sequence number
+	Message        string `json:"message"`                  // Human-readable verification result
+}
+
+// ExportEvidenceBundleResponse represents the response from export_evidence_bundle method
+type ExportEvidenceBundleResponse struct {
+	Filename    string `json:"filename"`     // Exported media file
+	FileType    string `json:"file_type"`    // "recording" or "snapshot"
+	BundlePath  string `json:"bundle_path"`  // Zip archive holding file, manifest, signature and public key
+	DownloadURL string `json:"download_url"` // HTTP download URL, decrypted on the fly
+	BundleSize  int64  `json:"bundle_size"`  // Bundle size on disk in bytes (encrypted when encryption at rest is enabled)
+	SHA256      string `json:"sha256"`       // Recorded hash of the exported file
+	Sequence    int64  `json:"sequence"`     // Manifest entry sequence number for the file
+	CreatedAt   string `json:"created_at"`   // Export time (ISO 8601)
+}
+
+// RotateStorageKeyResponse represents the response from rotate_storage_key method
+type RotateStorageKeyResponse struct {
+	KeyID          uint32 `json:"key_id"`          // New active storage key
+	PreviousKeyID  uint32 `json:"previous_key_id"` // Storage key that was active before rotation
+	FilesRewrapped int    `json:"files_rewrapped"` // Encrypted files re-wrapped with the new key
+	FilesFailed    int    `json:"files_failed"`    // Files that could not be re-wrapped
+	RetiredKeys    bool   `json:"retired_keys"`    // Old keys were removed from the key store
+	RotatedAt      string `json:"rotated_at"`      // Rotation completion time (ISO 8601)
+	Message        string `json:"message"`         // Rotation summary
 }
 
 // GetSnapshotInfoResponse represents the response from get_snapshot_info method
@@ -229,6 +305,19 @@ type GetStorageInfoResponse struct {
 	RecordingsSize   int64   `json:"recordings_size"`    // Size of recordings directory
 	SnapshotsSize    int64   `json:"snapshots_size"`     // Size of snapshots directory
 	LowSpaceWarning  bool    `json:"low_space_warning"`  // Whether low space warning is active
+	Cameras          []CameraStorageInfo `json:"cameras,omitempty"` // Per-camera usage and retention rules
+}
+
+// CameraStorageInfo represents per-camera storage usage for get_storage_info
+type CameraStorageInfo struct {
+	Device          string   `json:"device"`                    // Camera identifier
+	RecordingsSize  int64    `json:"recordings_size"`           // Size of the camera's recordings in bytes
+	RecordingsCount int      `json:"recordings_count"`          // Number of recordings
+	SnapshotsSize   int64    `json:"snapshots_size"`            // Size of the camera's snapshots in bytes
+	SnapshotsCount  int      `json:"snapshots_count"`           // Number of snapshots
+	TotalSize       int64    `json:"total_size"`                // Recordings plus snapshots in bytes
+	LockedFiles     int      `json:"locked_files"`              // Files protected from cleanup
+	RetentionRules  []string `json:"retention_rules,omitempty"` // Camera or group rules applied (global policy if empty)
 }
 
 // CleanupOldFilesResponse represents the response from cleanup_old_files method
@@ -238,6 +327,20 @@ type CleanupOldFilesResponse struct {
 	SpaceFreed        int64  `json:"space_freed"`        // Space freed in bytes
 	Status            string `json:"status"`             // Operation status
 	Message           string `json:"message"`            // Success message
+	DryRun            bool              `json:"dry_run,omitempty"`        // Files were only reported, not deleted
+	LockedSkipped     int               `json:"locked_skipped,omitempty"` // Locked files kept despite violating a rule
+	Files             []CleanupFileInfo `json:"files,omitempty"`          // Files deleted (or that would be deleted)
+}
+
+// CleanupFileInfo describes a file selected by cleanup_old_files
+type CleanupFileInfo struct {
+	Filename     string `json:"filename"`      // File name
+	Device       string `json:"device"`        // Camera the file belongs to
+	Type         string `json:"type"`          // "recording" or "snapshot"
+	FileSize     int64  `json:"file_size"`     // Size in bytes
+	ModifiedTime string `json:"modified_time"` // Last modification time (RFC3339)
+	Rule         string `json:"rule"`          // Rule that selected the file: "camera:<id>", "group:<name>" or "global"
+	Reason       string `json:"reason"`        // "max_age", "quota" or "max_count"
 }
 
 // GetStreamsResponse represents the response from get_streams method
@@ -305,6 +408,7 @@ type GetServerInfoResponse struct {
 type SetRetentionPolicyResponse struct {
 	Success    bool   `json:"success"`     // Operation success status
 	PolicyType string `json:"policy_type"` // Policy type applied
+	Scope      string `json:"scope,omitempty"` // Rule scope: "camera:<id>" or "group:<name>" (global if empty)
 	MaxAge     string `json:"max_age"`     // Maximum age setting
 	MaxSize    string `json:"max_size"`    // Maximum size setting
 	Message    string `json:"message"`     // Success/status message
@@ -0,0 +1,361 @@
+/*
+MediaMTX S3-Compatible Object Storage Client
+
+Minimal S3 client used by the replication manager: single-request and
+multipart uploads, part listing for resume and HEAD for upload
+confirmation. Requests use path-style addressing and AWS Signature
+Version 4, which S3 and S3-compatible stores (MinIO, Ceph RGW) accept.
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-007: Error handling and recovery
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+)
+
+const (
+	s3RequestTimeout = 5 * time.Minute
+	s3SigningAlgo    = "AWS4-HMAC-SHA256"
+	s3TimeFormat     = "20060102T150405Z"
+	s3DateFormat     = "20060102"
+)
+
+// S3Error represents an error response from the object store
+type S3Error struct {
+	StatusCode int
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *S3Error) Error() string {
+	return fmt.Sprintf("object store error (status %d): %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// S3CompletedPart identifies an uploaded part of a multipart upload
+type S3CompletedPart struct {
+	PartNumber int    `xml:"PartNumber" json:"part_number"`
+	ETag       string `xml:"ETag" json:"etag"`
+	Size       int64  `xml:"Size,omitempty" json:"size"`
+}
+
+// S3Client is a minimal S3-compatible client for replication uploads
+type S3Client struct {
+	endpoint   *url.URL
+	region     string
+	bucket     string
+	accessKey  string
+	secretKey  string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewS3Client creates a client from replication configuration
+func NewS3Client(cfg *config.ReplicationConfig) (*S3Client, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid replication endpoint %q", cfg.Endpoint)
+	}
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	return &S3Client{
+		endpoint:   endpoint,
+		region:     region,
+		bucket:     cfg.Bucket,
+		accessKey:  cfg.AccessKey,
+		secretKey:  cfg.SecretKey,
+		httpClient: &http.Client{Timeout: s3RequestTimeout},
+		now:        time.Now,
+	}, nil
+}
+
+// PutObject uploads an object in a single request and returns its ETag
+func (c *S3Client) PutObject(ctx context.Context, key string, body []byte) (string, error) {
+	resp, err := c.do(ctx, http.MethodPut, key, nil, body)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return resp.Header.Get("ETag"), nil
+}
+
+// CreateMultipartUpload starts a multipart upload and returns its upload ID
+func (c *S3Client) CreateMultipartUpload(ctx context.Context, key string) (string, error) {
+	resp, err := c.do(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse create multipart upload response: %w", err)
+	}
+	if result.UploadID == "" {
+		return "", fmt.Errorf("object store returned an empty upload ID")
+	}
+	return result.UploadID, nil
+}
+
+// UploadPart uploads one part of a multipart upload and returns its ETag
+func (c *S3Client) UploadPart(ctx context.Context, key, uploadID string, partNumber int, body []byte) (string, error) {
+	query := url.Values{"partNumber": {strconv.Itoa(partNumber)}, "uploadId": {uploadID}}
+	resp, err := c.do(ctx, http.MethodPut, key, query, body)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		return "", fmt.Errorf("object store returned no ETag for part %d", partNumber)
+	}
+	return etag, nil
+}
+
+// ListParts returns the parts already uploaded for a multipart upload
+func (c *S3Client) ListParts(ctx context.Context, key, uploadID string) ([]S3CompletedPart, error) {
+	var parts []S3CompletedPart
+	marker := ""
+	for {
+		query := url.Values{"uploadId": {uploadID}}
+		if marker != "" {
+			query.Set("part-number-marker", marker)
+		}
+		resp, err := c.do(ctx, http.MethodGet, key, query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Parts                []S3CompletedPart `xml:"Part"`
+			IsTruncated          bool              `xml:"IsTruncated"`
+			NextPartNumberMarker string            `xml:"NextPartNumberMarker"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse list parts response: %w", err)
+		}
+
+		parts = append(parts, result.Parts...)
+		if !result.IsTruncated || result.NextPartNumberMarker == "" {
+			break
+		}
+		marker = result.NextPartNumberMarker
+	}
+
+	sort.Slice(parts, func(i, j int) bool {
+		return parts[i].PartNumber < parts[j].PartNumber
+	})
+	return parts, nil
+}
+
+// CompleteMultipartUpload assembles the uploaded parts into the final object
+func (c *S3Client) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []S3CompletedPart) (string, error) {
+	type completedPart struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	}
+	request := struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{}
+	for _, part := range parts {
+		request.Parts = append(request.Parts, completedPart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+	body, err := xml.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode complete multipart upload request: %w", err)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// S3 may report a failure inside a 200 response
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read complete multipart upload response: %w", err)
+	}
+	if bytes.Contains(data, []byte("<Error>")) {
+		s3Err := &S3Error{StatusCode: resp.StatusCode}
+		xml.Unmarshal(data, s3Err)
+		return "", s3Err
+	}
+	var result struct {
+		ETag string `xml:"ETag"`
+	}
+	xml.Unmarshal(data, &result)
+	return result.ETag, nil
+}
+
+// AbortMultipartUpload discards a multipart upload and its parts
+func (c *S3Client) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// HeadObject returns the stored size of an object
+func (c *S3Client) HeadObject(ctx context.Context, key string) (int64, error) {
+	resp, err := c.do(ctx, http.MethodHead, key, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.ContentLength, nil
+}
+
+// do signs and sends a request, returning an *S3Error for non-2xx responses
+func (c *S3Client) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	target := *c.endpoint
+	target.Path = c.endpoint.Path + "/" + c.bucket + "/" + key
+	target.RawPath = c.endpoint.Path + "/" + s3EscapePath(c.bucket) + "/" + s3EscapePath(key)
+	target.RawQuery = s3CanonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create object store request: %w", err)
+	}
+	req.ContentLength = int64(len(body))
+	c.sign(req, body)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("object store request failed: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		s3Err := &S3Error{StatusCode: resp.StatusCode}
+		if data, readErr := io.ReadAll(io.LimitReader(resp.Body, 64*1024)); readErr == nil && len(data) > 0 {
+			xml.Unmarshal(data, s3Err)
+		}
+		if s3Err.Code == "" {
+			s3Err.Code = http.StatusText(resp.StatusCode)
+		}
+		return nil, s3Err
+	}
+	return resp, nil
+}
+
+// sign adds AWS Signature Version 4 headers to a request
+func (c *S3Client) sign(req *http.Request, body []byte) {
+	now := c.now().UTC()
+	amzDate := now.Format(s3TimeFormat)
+	date := now.Format(s3DateFormat)
+
+	payloadHash := sha256.Sum256(body)
+	payloadHex := hex.EncodeToString(payloadHash[:])
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHex)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHex + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHex,
+	}, "\n")
+
+	scope := date + "/" + c.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := s3SigningAlgo + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := s3HMAC([]byte("AWS4"+c.secretKey), date)
+	signingKey = s3HMAC(signingKey, c.region)
+	signingKey = s3HMAC(signingKey, "s3")
+	signingKey = s3HMAC(signingKey, "aws4_request")
+	signature := hex.EncodeToString(s3HMAC(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigningAlgo, c.accessKey, scope, signedHeaders, signature))
+}
+
+// s3HMAC computes HMAC-SHA256
+func s3HMAC(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3EscapePath URI-encodes each path segment per SigV4 rules, keeping "/"
+func s3EscapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// s3CanonicalQuery encodes query parameters sorted by key per SigV4 rules
+func s3CanonicalQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range query[key] {
+			pairs = append(pairs, s3Escape(key)+"="+s3Escape(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// s3Escape percent-encodes everything except RFC 3986 unreserved characters
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') ||
+			ch == '-' || ch == '_' || ch == '.' || ch == '~' {
+			b.WriteByte(ch)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
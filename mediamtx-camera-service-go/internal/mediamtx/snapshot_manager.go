@@ -28,6 +28,7 @@ import (
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/camera"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/tracing"
 )
 
 // SnapshotManager manages multi-tier snapshot capture with performance optimization.
@@ -67,6 +68,12 @@ type SnapshotManager struct {
 
 	// Snapshot tracking - using sync.Map for lock-free operations
 	snapshots sync.Map // snapshotID -> *Snapshot
+
+	// Storage guard for block threshold enforcement (optional)
+	storageGuard *StorageGuard
+
+	// Custody manager for chain-of-custody hashing on snapshot.taken (optional)
+	custodyManager *CustodyManager
 }
 
 // SnapshotSettings defines snapshot behavior
@@ -101,6 +108,16 @@ func NewSnapshotManagerWithConfig(ffmpegManager FFmpegManager, streamManager Str
 	}
 }
 
+// SetStorageGuard enables storage block threshold enforcement for new snapshots
+func (sm *SnapshotManager) SetStorageGuard(guard *StorageGuard) {
+	sm.storageGuard = guard
+}
+
+// SetCustodyManager sets the custody manager that hashes new snapshots
+func (sm *SnapshotManager) SetCustodyManager(custodyManager *CustodyManager) {
+	sm.custodyManager = custodyManager
+}
+
 // TakeSnapshot takes a snapshot with multi-tier approach and returns API-ready response
 func (sm *SnapshotManager) TakeSnapshot(ctx context.Context, cameraID string, options *SnapshotOptions) (*TakeSnapshotResponse, error) {
 	// Convert camera identifier to device path using PathManager
@@ -109,9 +126,19 @@ func (sm *SnapshotManager) TakeSnapshot(ctx context.Context, cameraID string, op
 		return nil, fmt.Errorf("camera '%s' not found or not accessible", cameraID)
 	}
 
+	// Resolve where the snapshot goes - the fallback path when primary storage is blocked
+	snapshotsPath := sm.config.SnapshotsPath
+	if sm.storageGuard != nil {
+		resolvedPath, err := sm.storageGuard.ResolveSnapshotsPath()
+		if err != nil {
+			return nil, err
+		}
+		snapshotsPath = resolvedPath
+	}
+
 	// Generate snapshot path using device path for file naming
 	// Use PathManager naming policy for device subdir and filenames
-	snapshotPath := GenerateSnapshotPath(sm.config, &sm.configManager.GetConfig().Snapshots, devicePath)
+	snapshotPath := generateSnapshotPathAt(snapshotsPath, &sm.configManager.GetConfig().Snapshots, devicePath)
 
 	sm.logger.WithFields(logging.Fields{
 		"cameraID":   cameraID,
@@ -179,6 +206,11 @@ func (sm *SnapshotManager) TakeSnapshot(ctx context.Context, cameraID string, op
 		FilePath:  snapshot.FilePath,                     // Full file path
 	}
 
+	// Record the snapshot hash in the custody manifest
+	if sm.custodyManager != nil {
+		sm.custodyManager.RecordSnapshot(ctx, cameraID, snapshot.FilePath)
+	}
+
 	sm.logger.WithFields(logging.Fields{
 		"snapshot_id":    snapshotID,
 		"cameraID":       cameraID,
@@ -195,6 +227,11 @@ func (sm *SnapshotManager) TakeSnapshot(ctx context.Context, cameraID string, op
 
 // takeSnapshotMultiTier implements the 5-tier snapshot capture system
 func (sm *SnapshotManager) takeSnapshotMultiTier(ctx context.Context, cameraID, devicePath, snapshotPath string, options *SnapshotOptions, tierConfig *config.SnapshotTiersConfig) (*Snapshot, error) {
+	ctx, span := tracing.Start(ctx, "snapshot.capture", tracing.SpanKindInternal,
+		tracing.String("camera.id", cameraID),
+		tracing.String("camera.device_path", devicePath))
+	defer span.End()
+
 	startTime := time.Now()
 	captureMethodsTried := []string{}
 
@@ -207,9 +244,12 @@ func (sm *SnapshotManager) takeSnapshotMultiTier(ctx context.Context, cameraID,
 	tier0Ctx, tier0Cancel := context.WithTimeout(ctx, time.Duration(tierConfig.Tier1USBDirectTimeout*float64(time.Second)))
 	defer tier0Cancel()
 
-	if snapshot, err := sm.captureSnapshotV4L2Direct(tier0Ctx, devicePath, snapshotPath, options); err == nil {
+	if snapshot, err := sm.captureTier(tier0Ctx, cameraID, 0, "v4l2_direct", func(ctx context.Context) (*Snapshot, error) {
+		return sm.captureSnapshotV4L2Direct(ctx, devicePath, snapshotPath, options)
+	}); err == nil {
 		captureTime := time.Since(startTime)
 		result := sm.createSnapshotResult(snapshot, 0, captureTime, captureMethodsTried)
+		span.SetAttributes(tracing.Int("snapshot.tier_used", 0))
 		sm.logger.WithFields(logging.Fields{
 			"cameraID":     cameraID,
 			"tier":         0,
@@ -234,9 +274,12 @@ func (sm *SnapshotManager) takeSnapshotMultiTier(ctx context.Context, cameraID,
 	tier1Ctx, tier1Cancel := context.WithTimeout(ctx, time.Duration(tierConfig.Tier1USBDirectTimeout*float64(time.Second)))
 	defer tier1Cancel()
 
-	if snapshot, err := sm.captureSnapshotDirect(tier1Ctx, devicePath, snapshotPath); err == nil {
+	if snapshot, err := sm.captureTier(tier1Ctx, cameraID, 1, "usb_direct", func(ctx context.Context) (*Snapshot, error) {
+		return sm.captureSnapshotDirect(ctx, devicePath, snapshotPath)
+	}); err == nil {
 		captureTime := time.Since(startTime)
 		result := sm.createSnapshotResult(snapshot, 1, captureTime, captureMethodsTried)
+		span.SetAttributes(tracing.Int("snapshot.tier_used", 1))
 		sm.logger.WithFields(logging.Fields{
 			"cameraID":     cameraID,
 			"tier":         1,
@@ -261,9 +304,12 @@ func (sm *SnapshotManager) takeSnapshotMultiTier(ctx context.Context, cameraID,
 	tier2Ctx, tier2Cancel := context.WithTimeout(ctx, time.Duration(tierConfig.Tier2RTSPReadyCheckTimeout*float64(time.Second)))
 	defer tier2Cancel()
 
-	if snapshot, err := sm.captureSnapshotFromRTSP(tier2Ctx, cameraID, snapshotPath); err == nil {
+	if snapshot, err := sm.captureTier(tier2Ctx, cameraID, 2, "rtsp_immediate", func(ctx context.Context) (*Snapshot, error) {
+		return sm.captureSnapshotFromRTSP(ctx, cameraID, snapshotPath)
+	}); err == nil {
 		captureTime := time.Since(startTime)
 		result := sm.createSnapshotResult(snapshot, 2, captureTime, captureMethodsTried)
+		span.SetAttributes(tracing.Int("snapshot.tier_used", 2))
 		sm.logger.WithFields(logging.Fields{
 			"cameraID":     cameraID,
 			"tier":         2,
@@ -288,9 +334,12 @@ func (sm *SnapshotManager) takeSnapshotMultiTier(ctx context.Context, cameraID,
 	tier3Ctx, tier3Cancel := context.WithTimeout(ctx, time.Duration(tierConfig.Tier3ActivationTimeout*float64(time.Second)))
 	defer tier3Cancel()
 
-	if snapshot, err := sm.captureSnapshotFromRTSP(tier3Ctx, cameraID, snapshotPath); err == nil {
+	if snapshot, err := sm.captureTier(tier3Ctx, cameraID, 3, "rtsp_activation", func(ctx context.Context) (*Snapshot, error) {
+		return sm.captureSnapshotFromRTSP(ctx, cameraID, snapshotPath)
+	}); err == nil {
 		captureTime := time.Since(startTime)
 		result := sm.createSnapshotResult(snapshot, 3, captureTime, captureMethodsTried)
+		span.SetAttributes(tracing.Int("snapshot.tier_used", 3))
 		sm.logger.WithFields(logging.Fields{
 			"cameraID":     cameraID,
 			"tier":         3,
@@ -314,7 +363,30 @@ func (sm *SnapshotManager) takeSnapshotMultiTier(ctx context.Context, cameraID,
 		"methods_tried": captureMethodsTried,
 	}).Error("Tier 4: All snapshot capture methods failed")
 
-	return nil, sm.createMultiTierError(cameraID, captureMethodsTried, totalTime)
+	err := sm.createMultiTierError(cameraID, captureMethodsTried, totalTime)
+	span.RecordError(err)
+	return nil, err
+}
+
+// captureTier runs one capture tier inside a child trace span so the time spent
+// in each tier (V4L2, FFmpeg, MediaMTX activation) is visible per request
+func (sm *SnapshotManager) captureTier(ctx context.Context, cameraID string, tier int, method string, capture func(context.Context) (*Snapshot, error)) (*Snapshot, error) {
+	ctx, span := tracing.Start(ctx, "snapshot.tier"+strconv.Itoa(tier)+"."+method, tracing.SpanKindInternal,
+		tracing.String("camera.id", cameraID),
+		tracing.Int("snapshot.tier", tier),
+		tracing.String("snapshot.capture_method", method))
+	snapshot, err := capture(ctx)
+	span.EndWithError(err)
+	return snapshot, err
+}
+
+// runFFmpegCapture runs a one-shot FFmpeg capture inside a trace span
+func (sm *SnapshotManager) runFFmpegCapture(ctx context.Context, command []string) error {
+	ctx, span := tracing.Start(ctx, "ffmpeg.capture", tracing.SpanKindInternal,
+		tracing.String("process.executable", command[0]))
+	err := exec.CommandContext(ctx, command[0], command[1:]...).Run()
+	span.EndWithError(err)
+	return err
 }
 
 // getTierConfiguration retrieves multi-tier configuration from existing config system
@@ -414,11 +486,8 @@ func (sm *SnapshotManager) captureSnapshotDirect(ctx context.Context, devicePath
 		return nil, fmt.Errorf("failed to create output directory for FFmpeg snapshot: %w", err)
 	}
 
-	// Create command with timeout
-	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
-
-	// Execute command
-	if err := cmd.Run(); err != nil {
+	// Execute command with timeout
+	if err := sm.runFFmpegCapture(ctx, command); err != nil {
 		return nil, fmt.Errorf("failed to take FFmpeg snapshot: %w", err)
 	}
 
@@ -525,11 +594,8 @@ func (sm *SnapshotManager) captureSnapshotFromRTSP(ctx context.Context, cameraID
 		return nil, fmt.Errorf("failed to create output directory for FFmpeg snapshot: %w", err)
 	}
 
-	// Create command with timeout
-	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
-
-	// Execute command
-	if err := cmd.Run(); err != nil {
+	// Execute command with timeout
+	if err := sm.runFFmpegCapture(ctx, command); err != nil {
 		return nil, fmt.Errorf("failed to take snapshot from RTSP: %w", err)
 	}
 
@@ -0,0 +1,1059 @@
+/*
+MediaMTX Storage Encryption Implementation
+
+Encrypts completed recording segments and snapshots at rest so footage on a
+lost unit cannot be read. Files are encrypted in place with streaming,
+chunked AES-256-GCM under a per-file data key. The data key is wrapped by a
+storage key, which is wrapped by a key-encryption key (KEK) derived from a
+local keyfile or a passphrase. Rotating the storage key only rewrites the
+small wrapped-key section of each file header.
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-007: Error handling and recovery
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+)
+
+// Key sources for the key-encryption key
+const (
+	EncryptionKeySourceKeyfile    = "keyfile"
+	EncryptionKeySourcePassphrase = "passphrase"
+)
+
+// Encrypted file layout:
+//
+//	magic[8] | chunk size u32 | nonce prefix[7] | plaintext size u64 | key id u32 | wrapped data key[60] | chunks...
+//
+// The fixed part (magic through plaintext size) is authenticated with every
+// chunk and with the wrapped data key. Chunk i is sealed with nonce
+// prefix || i || last-chunk flag, so reordering and truncation are detected.
+const (
+	encryptionMagic          = "CSENC001"
+	encryptionKeySize        = 32
+	encryptionNoncePrefixLen = 7
+	encryptionTagSize        = 16
+	encryptionWrappedKeyLen  = 12 + encryptionKeySize + encryptionTagSize
+	encryptionFixedHeaderLen = len(encryptionMagic) + 4 + encryptionNoncePrefixLen + 8
+	encryptionHeaderLen      = encryptionFixedHeaderLen + 4 + encryptionWrappedKeyLen
+
+	defaultEncryptionChunkSize    = 64 * 1024
+	defaultEncryptionScanInterval = 30 * time.Second
+	encryptionKDFIterations       = 600000
+	encryptionKeyStoreVersion     = 1
+	encryptionKeyCheckPlaintext   = "camera-service-storage-kek"
+)
+
+// ErrStorageEncryptionDisabled is returned when encryption at rest is not enabled
+var ErrStorageEncryptionDisabled = errors.New("storage encryption is not enabled")
+
+// ErrStorageKeyUnavailable is returned when an encrypted file cannot be opened
+// because the storage keys are not loaded
+var ErrStorageKeyUnavailable = errors.New("storage encryption key is not available")
+
+// storageKeyRecord is a storage key wrapped by the KEK
+type storageKeyRecord struct {
+	ID         uint32    `json:"id"`
+	WrappedKey string    `json:"wrapped_key"` // Base64 nonce || AES-GCM ciphertext
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// storageKeyStore is the persisted key store format
+type storageKeyStore struct {
+	Version     int                 `json:"version"`
+	KeySource   string              `json:"key_source"`
+	Salt        string              `json:"salt,omitempty"`       // PBKDF2 salt (passphrase source)
+	Iterations  int                 `json:"iterations,omitempty"` // PBKDF2 iterations (passphrase source)
+	KeyCheck    string              `json:"key_check"`            // Detects a wrong keyfile or passphrase
+	ActiveKeyID uint32              `json:"active_key_id"`
+	Keys        []*storageKeyRecord `json:"keys"`
+}
+
+// encryptionHeader is the parsed header of an encrypted file
+type encryptionHeader struct {
+	fixed       []byte
+	chunkSize   int64
+	noncePrefix []byte
+	size        int64
+	keyID       uint32
+	wrappedKey  []byte
+}
+
+// StorageEncryption encrypts completed recordings and snapshots at rest.
+//
+// RESPONSIBILITIES:
+// - Derive the KEK from a keyfile or passphrase and unwrap storage keys
+// - Periodically encrypt unlocked, completed files in place
+// - Open encrypted files as decrypting, seekable readers for serving and probing
+// - Rotate the storage key by re-wrapping each file's data key
+type StorageEncryption struct {
+	config *config.Config
+	logger *logging.Logger
+
+	// fileMu serializes header rewrites during rotation against encryption
+	fileMu sync.RWMutex
+
+	mu          sync.RWMutex
+	kek         []byte
+	keys        map[uint32][]byte
+	activeKeyID uint32
+	store       *storageKeyStore
+
+	stopChan       chan struct{}
+	wakeChan       chan struct{}
+	wg             sync.WaitGroup
+	encryptedFiles int64
+	failedFiles    int64
+}
+
+// NewStorageEncryption creates a new storage encryption manager
+func NewStorageEncryption(cfg *config.Config, logger *logging.Logger) *StorageEncryption {
+	return &StorageEncryption{
+		config:   cfg,
+		logger:   logger,
+		keys:     make(map[uint32][]byte),
+		wakeChan: make(chan struct{}, 1),
+	}
+}
+
+// Enabled reports whether encryption at rest is configured
+func (se *StorageEncryption) Enabled() bool {
+	return se.config.Storage.Encryption.Enabled
+}
+
+// Start loads the storage keys and begins encrypting completed files
+func (se *StorageEncryption) Start(ctx context.Context) error {
+	if !se.Enabled() {
+		return nil
+	}
+
+	se.mu.Lock()
+	if se.stopChan != nil {
+		se.mu.Unlock()
+		return nil
+	}
+	se.mu.Unlock()
+
+	if err := se.loadKeys(); err != nil {
+		return err
+	}
+
+	se.mu.Lock()
+	se.stopChan = make(chan struct{})
+	stopChan := se.stopChan
+	activeKeyID := se.activeKeyID
+	se.mu.Unlock()
+
+	interval := time.Duration(se.config.Storage.Encryption.ScanIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultEncryptionScanInterval
+	}
+
+	se.wg.Add(1)
+	go func() {
+		defer se.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := se.RunOnce(ctx, stopChan); err != nil {
+				se.logger.WithError(err).Warn("Storage encryption pass failed")
+			}
+			select {
+			case <-ticker.C:
+			case <-se.wakeChan:
+			case <-stopChan:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	se.logger.WithFields(logging.Fields{
+		"key_source":    se.config.Storage.Encryption.KeySource,
+		"active_key_id": activeKeyID,
+		"interval":      interval.String(),
+	}).Info("Storage encryption started")
+	return nil
+}
+
+// Stop stops the encryption worker; a partially encrypted file is discarded
+// and encrypted again on the next start
+func (se *StorageEncryption) Stop() {
+	se.mu.Lock()
+	stopChan := se.stopChan
+	se.stopChan = nil
+	se.mu.Unlock()
+
+	if stopChan == nil {
+		return
+	}
+	close(stopChan)
+	se.wg.Wait()
+}
+
+// Wake triggers an immediate encryption pass
+func (se *StorageEncryption) Wake() {
+	select {
+	case se.wakeChan <- struct{}{}:
+	default:
+	}
+}
+
+// roots returns the directories holding recordings, snapshots and evidence bundles
+func (se *StorageEncryption) roots() []string {
+	roots := []string{se.config.MediaMTX.RecordingsPath, se.config.MediaMTX.SnapshotsPath}
+	if fallback := se.config.Storage.FallbackPath; fallback != "" {
+		roots = append(roots, fallback)
+	}
+	if tiering := se.config.Storage.Tiering; tiering.Enabled && tiering.ArchivePath != "" {
+		roots = append(roots, tiering.ArchivePath)
+	}
+	if custody := se.config.Custody; custody.Enabled && custody.ExportPath != "" {
+		roots = append(roots, custody.ExportPath)
+	}
+	return roots
+}
+
+// walkMedia calls fn for every media file under the roots, skipping lock and partial files
+func (se *StorageEncryption) walkMedia(fn func(path string, info fs.FileInfo)) {
+	seen := make(map[string]bool)
+	for _, root := range se.roots() {
+		if root == "" {
+			continue
+		}
+		// Roots may be nested (e.g. snapshots under recordings); visit each file once
+		filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || seen[path] {
+				return nil
+			}
+			seen[path] = true
+			if isInternalStorageFile(path) {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			fn(path, info)
+			return nil
+		})
+	}
+}
+
+// RunOnce encrypts every completed, unencrypted file and returns how many were encrypted
+func (se *StorageEncryption) RunOnce(ctx context.Context, stopChan <-chan struct{}) (int, error) {
+	now := time.Now()
+	var candidates []string
+	se.walkMedia(func(path string, info fs.FileInfo) {
+		if isFileLocked(path, info, now) {
+			return
+		}
+		if encrypted, err := IsEncryptedFile(path); err == nil && !encrypted {
+			candidates = append(candidates, path)
+		}
+	})
+
+	encrypted := 0
+	for _, path := range candidates {
+		select {
+		case <-stopChan:
+			return encrypted, nil
+		case <-ctx.Done():
+			return encrypted, ctx.Err()
+		default:
+		}
+
+		if err := se.EncryptFile(ctx, path); err != nil {
+			se.mu.Lock()
+			se.failedFiles++
+			se.mu.Unlock()
+			se.logger.WithError(err).WithField("path", path).Warn("Failed to encrypt file")
+			continue
+		}
+		encrypted++
+	}
+
+	if encrypted > 0 {
+		se.logger.WithField("files", fmt.Sprintf("%d", encrypted)).Info("Encrypted completed files at rest")
+	}
+	return encrypted, nil
+}
+
+// EncryptFile encrypts a file in place. The ciphertext is written to a
+// partial file and renamed over the source only if the source did not change.
+func (se *StorageEncryption) EncryptFile(ctx context.Context, path string) error {
+	se.fileMu.RLock()
+	defer se.fileMu.RUnlock()
+
+	se.mu.RLock()
+	storageKey, keyID := se.keys[se.activeKeyID], se.activeKeyID
+	se.mu.RUnlock()
+	if storageKey == nil {
+		return ErrStorageKeyUnavailable
+	}
+
+	if encrypted, err := IsEncryptedFile(path); err != nil || encrypted {
+		return err
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open file for encryption: %w", err)
+	}
+	defer src.Close()
+
+	before, err := src.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat file for encryption: %w", err)
+	}
+
+	header, dataKey, err := newEncryptionHeader(storageKey, keyID, before.Size(), se.chunkSize())
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + archivePartialSuffix
+	dst, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, before.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("failed to create encrypted file: %w", err)
+	}
+	defer os.Remove(tmpPath)
+
+	writeErr := encryptStream(ctx, dst, src, header, dataKey)
+	if writeErr == nil {
+		writeErr = dst.Sync()
+	}
+	if closeErr := dst.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		return fmt.Errorf("failed to write encrypted file: %w", writeErr)
+	}
+
+	// The source must not have changed while it was being encrypted
+	after, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("source changed during encryption: %w", err)
+	}
+	if after.Size() != before.Size() || !after.ModTime().Equal(before.ModTime()) {
+		return fmt.Errorf("source changed during encryption: %s", path)
+	}
+
+	// Preserve the modification time so retention and tiering age the file correctly
+	if err := os.Chtimes(tmpPath, before.ModTime(), before.ModTime()); err != nil {
+		return fmt.Errorf("failed to preserve modification time: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace file with encrypted copy: %w", err)
+	}
+
+	se.mu.Lock()
+	se.encryptedFiles++
+	se.mu.Unlock()
+	return nil
+}
+
+// chunkSize returns the configured plaintext chunk size in bytes
+func (se *StorageEncryption) chunkSize() int64 {
+	if kb := se.config.Storage.Encryption.ChunkSizeKB; kb > 0 {
+		return int64(kb) * 1024
+	}
+	return defaultEncryptionChunkSize
+}
+
+// newEncryptionHeader creates a header with a fresh data key wrapped by storageKey
+func newEncryptionHeader(storageKey []byte, keyID uint32, size, chunkSize int64) (*encryptionHeader, []byte, error) {
+	dataKey := make([]byte, encryptionKeySize)
+	noncePrefix := make([]byte, encryptionNoncePrefixLen)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	if _, err := rand.Read(noncePrefix); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	fixed := make([]byte, 0, encryptionFixedHeaderLen)
+	fixed = append(fixed, encryptionMagic...)
+	fixed = binary.BigEndian.AppendUint32(fixed, uint32(chunkSize))
+	fixed = append(fixed, noncePrefix...)
+	fixed = binary.BigEndian.AppendUint64(fixed, uint64(size))
+
+	wrapped, err := sealKey(storageKey, dataKey, fixed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &encryptionHeader{
+		fixed:       fixed,
+		chunkSize:   chunkSize,
+		noncePrefix: noncePrefix,
+		size:        size,
+		keyID:       keyID,
+		wrappedKey:  wrapped,
+	}, dataKey, nil
+}
+
+// bytes returns the encoded header
+func (h *encryptionHeader) bytes() []byte {
+	out := make([]byte, 0, encryptionHeaderLen)
+	out = append(out, h.fixed...)
+	out = binary.BigEndian.AppendUint32(out, h.keyID)
+	return append(out, h.wrappedKey...)
+}
+
+// numChunks returns the number of chunks; an empty file has one empty chunk
+func (h *encryptionHeader) numChunks() int64 {
+	if h.size == 0 {
+		return 1
+	}
+	return (h.size + h.chunkSize - 1) / h.chunkSize
+}
+
+// chunkNonce returns the nonce for chunk i
+func (h *encryptionHeader) chunkNonce(i int64) []byte {
+	nonce := make([]byte, 0, 12)
+	nonce = append(nonce, h.noncePrefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, uint32(i))
+	if i == h.numChunks()-1 {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+// encryptStream writes the header and the sealed chunks of src to dst
+func encryptStream(ctx context.Context, dst io.Writer, src io.Reader, header *encryptionHeader, dataKey []byte) error {
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return err
+	}
+	if _, err := dst.Write(header.bytes()); err != nil {
+		return err
+	}
+
+	buf := make([]byte, header.chunkSize)
+	sealed := make([]byte, 0, header.chunkSize+encryptionTagSize)
+	remaining := header.size
+	for i := int64(0); i < header.numChunks(); i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n := header.chunkSize
+		if remaining < n {
+			n = remaining
+		}
+		if _, err := io.ReadFull(src, buf[:n]); err != nil {
+			return fmt.Errorf("failed to read source: %w", err)
+		}
+		sealed = aead.Seal(sealed[:0], header.chunkNonce(i), buf[:n], header.fixed)
+		if _, err := dst.Write(sealed); err != nil {
+			return err
+		}
+		remaining -= n
+	}
+	return nil
+}
+
+// IsEncryptedFile reports whether a file starts with the encryption header
+func IsEncryptedFile(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	magic := make([]byte, len(encryptionMagic))
+	if _, err := io.ReadFull(file, magic); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, nil
+		}
+		return false, err
+	}
+	return string(magic) == encryptionMagic, nil
+}
+
+// readEncryptionHeader parses the header at the start of r
+func readEncryptionHeader(r io.ReaderAt) (*encryptionHeader, error) {
+	raw := make([]byte, encryptionHeaderLen)
+	if _, err := r.ReadAt(raw, 0); err != nil {
+		return nil, fmt.Errorf("failed to read encryption header: %w", err)
+	}
+	if string(raw[:len(encryptionMagic)]) != encryptionMagic {
+		return nil, fmt.Errorf("file is not encrypted")
+	}
+
+	offset := len(encryptionMagic)
+	header := &encryptionHeader{fixed: raw[:encryptionFixedHeaderLen]}
+	header.chunkSize = int64(binary.BigEndian.Uint32(raw[offset:]))
+	offset += 4
+	header.noncePrefix = raw[offset : offset+encryptionNoncePrefixLen]
+	offset += encryptionNoncePrefixLen
+	header.size = int64(binary.BigEndian.Uint64(raw[offset:]))
+	offset += 8
+	header.keyID = binary.BigEndian.Uint32(raw[offset:])
+	offset += 4
+	header.wrappedKey = raw[offset : offset+encryptionWrappedKeyLen]
+
+	if header.chunkSize <= 0 || header.size < 0 {
+		return nil, fmt.Errorf("invalid encryption header")
+	}
+	return header, nil
+}
+
+// Open opens a media file for reading, decrypting it on the fly when it is
+// encrypted. Returns the reader and the plaintext size.
+func (se *StorageEncryption) Open(path string) (io.ReadSeekCloser, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+
+	magic := make([]byte, len(encryptionMagic))
+	if n, _ := file.ReadAt(magic, 0); n < len(magic) || string(magic) != encryptionMagic {
+		return file, info.Size(), nil
+	}
+
+	header, err := readEncryptionHeader(file)
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+
+	se.mu.RLock()
+	storageKey := se.keys[header.keyID]
+	se.mu.RUnlock()
+	if storageKey == nil {
+		file.Close()
+		return nil, 0, fmt.Errorf("%w: key %d", ErrStorageKeyUnavailable, header.keyID)
+	}
+
+	dataKey, err := openKey(storageKey, header.wrappedKey, header.fixed)
+	if err != nil {
+		file.Close()
+		return nil, 0, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+
+	return &decryptingReader{file: file, aead: aead, header: header, chunkIndex: -1}, header.size, nil
+}
+
+// ServeFile serves a media file over HTTP, decrypting encrypted files on the
+// fly. Range requests are supported.
+func (se *StorageEncryption) ServeFile(w http.ResponseWriter, r *http.Request, path string) {
+	reader, _, err := se.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			http.NotFound(w, r)
+			return
+		}
+		se.logger.WithError(err).WithField("path", path).Error("Failed to open file for serving")
+		http.Error(w, "failed to open file", http.StatusInternalServerError)
+		return
+	}
+	defer reader.Close()
+
+	modTime := time.Time{}
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+	http.ServeContent(w, r, filepath.Base(path), modTime, reader)
+}
+
+// decryptingReader is a seekable reader over an encrypted file
+type decryptingReader struct {
+	file       *os.File
+	aead       cipher.AEAD
+	header     *encryptionHeader
+	offset     int64
+	chunkIndex int64
+	chunk      []byte
+	sealed     []byte
+}
+
+// Read decrypts the chunk holding the current offset as needed
+func (d *decryptingReader) Read(p []byte) (int, error) {
+	if d.offset >= d.header.size {
+		return 0, io.EOF
+	}
+
+	index := d.offset / d.header.chunkSize
+	if index != d.chunkIndex {
+		if err := d.loadChunk(index); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.chunk[d.offset-index*d.header.chunkSize:])
+	d.offset += int64(n)
+	return n, nil
+}
+
+// loadChunk reads and authenticates chunk i
+func (d *decryptingReader) loadChunk(i int64) error {
+	plainLen := d.header.chunkSize
+	if remaining := d.header.size - i*d.header.chunkSize; remaining < plainLen {
+		plainLen = remaining
+	}
+	sealedLen := plainLen + encryptionTagSize
+	if int64(cap(d.sealed)) < sealedLen {
+		d.sealed = make([]byte, sealedLen)
+	}
+	sealed := d.sealed[:sealedLen]
+
+	offset := int64(encryptionHeaderLen) + i*(d.header.chunkSize+encryptionTagSize)
+	if _, err := d.file.ReadAt(sealed, offset); err != nil {
+		return fmt.Errorf("failed to read encrypted chunk %d: %w", i, err)
+	}
+	chunk, err := d.aead.Open(d.chunk[:0], d.header.chunkNonce(i), sealed, d.header.fixed)
+	if err != nil {
+		d.chunkIndex = -1
+		return fmt.Errorf("encrypted chunk %d failed authentication", i)
+	}
+	d.chunk = chunk
+	d.chunkIndex = i
+	return nil
+}
+
+// Seek sets the plaintext offset
+func (d *decryptingReader) Seek(offset int64, whence int) (int64, error) {
+	var next int64
+	switch whence {
+	case io.SeekStart:
+		next = offset
+	case io.SeekCurrent:
+		next = d.offset + offset
+	case io.SeekEnd:
+		next = d.header.size + offset
+	default:
+		return 0, fmt.Errorf("invalid whence %d", whence)
+	}
+	if next < 0 {
+		return 0, fmt.Errorf("negative seek offset")
+	}
+	d.offset = next
+	return next, nil
+}
+
+// Close closes the underlying file
+func (d *decryptingReader) Close() error {
+	return d.file.Close()
+}
+
+// RotateKey generates a new storage key, re-wraps the data key of every
+// encrypted file with it and drops the old keys once no file uses them
+func (se *StorageEncryption) RotateKey(ctx context.Context) (*RotateStorageKeyResponse, error) {
+	if !se.Enabled() {
+		return nil, ErrStorageEncryptionDisabled
+	}
+
+	// Block new encryptions so every file is either re-wrapped here or uses the new key
+	se.fileMu.Lock()
+	defer se.fileMu.Unlock()
+
+	se.mu.Lock()
+	if se.store == nil || se.kek == nil {
+		se.mu.Unlock()
+		return nil, ErrStorageKeyUnavailable
+	}
+	previousKeyID := se.activeKeyID
+	newKey := make([]byte, encryptionKeySize)
+	if _, err := rand.Read(newKey); err != nil {
+		se.mu.Unlock()
+		return nil, fmt.Errorf("failed to generate storage key: %w", err)
+	}
+	wrapped, err := sealKey(se.kek, newKey, nil)
+	if err != nil {
+		se.mu.Unlock()
+		return nil, err
+	}
+	newKeyID := previousKeyID + 1
+	for id := range se.keys {
+		if id >= newKeyID {
+			newKeyID = id + 1
+		}
+	}
+	se.store.Keys = append(se.store.Keys, &storageKeyRecord{
+		ID:         newKeyID,
+		WrappedKey: base64.StdEncoding.EncodeToString(wrapped),
+		CreatedAt:  time.Now().UTC(),
+	})
+	se.store.ActiveKeyID = newKeyID
+	if err := se.saveKeyStore(); err != nil {
+		se.store.Keys = se.store.Keys[:len(se.store.Keys)-1]
+		se.store.ActiveKeyID = previousKeyID
+		se.mu.Unlock()
+		return nil, err
+	}
+	se.keys[newKeyID] = newKey
+	se.activeKeyID = newKeyID
+	se.mu.Unlock()
+
+	response := &RotateStorageKeyResponse{
+		KeyID:         newKeyID,
+		PreviousKeyID: previousKeyID,
+	}
+
+	var failed []string
+	se.walkMedia(func(path string, info fs.FileInfo) {
+		if ctx.Err() != nil {
+			failed = append(failed, path)
+			return
+		}
+		rewrapped, err := se.rewrapFile(path, newKeyID, newKey)
+		if err != nil {
+			failed = append(failed, path)
+			se.logger.WithError(err).WithField("path", path).Warn("Failed to re-wrap file data key")
+			return
+		}
+		if rewrapped {
+			response.FilesRewrapped++
+		}
+	})
+	response.FilesFailed = len(failed)
+
+	// Old keys are only dropped when every file was re-wrapped
+	if len(failed) == 0 {
+		se.mu.Lock()
+		se.store.Keys = []*storageKeyRecord{se.store.Keys[len(se.store.Keys)-1]}
+		for id := range se.keys {
+			if id != newKeyID {
+				delete(se.keys, id)
+			}
+		}
+		if err := se.saveKeyStore(); err != nil {
+			se.logger.WithError(err).Warn("Failed to drop retired storage keys")
+		} else {
+			response.RetiredKeys = true
+		}
+		se.mu.Unlock()
+	}
+
+	response.RotatedAt = time.Now().Format(time.RFC3339)
+	response.Message = fmt.Sprintf("Storage key rotated to %d; %d files re-wrapped, %d failed", newKeyID, response.FilesRewrapped, response.FilesFailed)
+
+	se.logger.WithFields(logging.Fields{
+		"key_id":          newKeyID,
+		"previous_key_id": previousKeyID,
+		"files_rewrapped": response.FilesRewrapped,
+		"files_failed":    response.FilesFailed,
+	}).Info("Storage key rotated")
+	return response, nil
+}
+
+// rewrapFile rewrites the wrapped data key of an encrypted file in place.
+// Returns false for unencrypted files and files already using the key.
+func (se *StorageEncryption) rewrapFile(path string, keyID uint32, storageKey []byte) (bool, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	magic := make([]byte, len(encryptionMagic))
+	if n, _ := file.ReadAt(magic, 0); n < len(magic) || string(magic) != encryptionMagic {
+		return false, nil
+	}
+	header, err := readEncryptionHeader(file)
+	if err != nil {
+		return false, err
+	}
+	if header.keyID == keyID {
+		return false, nil
+	}
+
+	se.mu.RLock()
+	oldKey := se.keys[header.keyID]
+	se.mu.RUnlock()
+	if oldKey == nil {
+		return false, fmt.Errorf("%w: key %d", ErrStorageKeyUnavailable, header.keyID)
+	}
+	dataKey, err := openKey(oldKey, header.wrappedKey, header.fixed)
+	if err != nil {
+		return false, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	wrapped, err := sealKey(storageKey, dataKey, header.fixed)
+	if err != nil {
+		return false, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+	section := binary.BigEndian.AppendUint32(nil, keyID)
+	section = append(section, wrapped...)
+	if _, err := file.WriteAt(section, int64(encryptionFixedHeaderLen)); err != nil {
+		return false, err
+	}
+	if err := file.Sync(); err != nil {
+		return false, err
+	}
+	// Keep the modification time so the rotation does not reset file age
+	os.Chtimes(path, info.ModTime(), info.ModTime())
+	return true, nil
+}
+
+// loadKeys derives the KEK and loads (or creates) the key store
+func (se *StorageEncryption) loadKeys() error {
+	cfg := se.config.Storage.Encryption
+
+	store := &storageKeyStore{}
+	data, err := os.ReadFile(cfg.KeyStorePath)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, store); err != nil {
+			return fmt.Errorf("failed to parse storage key store: %w", err)
+		}
+		if store.KeySource != cfg.KeySource {
+			return fmt.Errorf("storage key store was created with key source %q, configured %q", store.KeySource, cfg.KeySource)
+		}
+	case os.IsNotExist(err):
+		store = nil
+	default:
+		return fmt.Errorf("failed to read storage key store: %w", err)
+	}
+
+	var salt []byte
+	if store != nil && store.Salt != "" {
+		if salt, err = base64.StdEncoding.DecodeString(store.Salt); err != nil {
+			return fmt.Errorf("invalid salt in storage key store: %w", err)
+		}
+	}
+	iterations := encryptionKDFIterations
+	if store != nil && store.Iterations > 0 {
+		iterations = store.Iterations
+	}
+	if store == nil && cfg.KeySource == EncryptionKeySourcePassphrase {
+		salt = make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return fmt.Errorf("failed to generate salt: %w", err)
+		}
+	}
+
+	kek, err := se.deriveKEK(salt, iterations)
+	if err != nil {
+		return err
+	}
+
+	se.mu.Lock()
+	defer se.mu.Unlock()
+
+	if store == nil {
+		// First start: create the key store with an initial storage key
+		storageKey := make([]byte, encryptionKeySize)
+		if _, err := rand.Read(storageKey); err != nil {
+			return fmt.Errorf("failed to generate storage key: %w", err)
+		}
+		check, err := sealKey(kek, []byte(encryptionKeyCheckPlaintext), nil)
+		if err != nil {
+			return err
+		}
+		wrapped, err := sealKey(kek, storageKey, nil)
+		if err != nil {
+			return err
+		}
+		store = &storageKeyStore{
+			Version:     encryptionKeyStoreVersion,
+			KeySource:   cfg.KeySource,
+			KeyCheck:    base64.StdEncoding.EncodeToString(check),
+			ActiveKeyID: 1,
+			Keys: []*storageKeyRecord{{
+				ID:         1,
+				WrappedKey: base64.StdEncoding.EncodeToString(wrapped),
+				CreatedAt:  time.Now().UTC(),
+			}},
+		}
+		if len(salt) > 0 {
+			store.Salt = base64.StdEncoding.EncodeToString(salt)
+			store.Iterations = iterations
+		}
+		se.kek, se.store = kek, store
+		if err := se.saveKeyStore(); err != nil {
+			return err
+		}
+		se.logger.WithField("path", cfg.KeyStorePath).Info("Created storage key store")
+	} else {
+		check, err := base64.StdEncoding.DecodeString(store.KeyCheck)
+		if err != nil {
+			return fmt.Errorf("invalid key check in storage key store: %w", err)
+		}
+		if plain, err := openKey(kek, check, nil); err != nil || string(plain) != encryptionKeyCheckPlaintext {
+			return fmt.Errorf("storage key store cannot be opened: wrong keyfile or passphrase")
+		}
+		se.kek, se.store = kek, store
+	}
+
+	keys := make(map[uint32][]byte, len(store.Keys))
+	for _, record := range store.Keys {
+		wrapped, err := base64.StdEncoding.DecodeString(record.WrappedKey)
+		if err != nil {
+			return fmt.Errorf("invalid wrapped storage key %d: %w", record.ID, err)
+		}
+		key, err := openKey(kek, wrapped, nil)
+		if err != nil {
+			return fmt.Errorf("failed to unwrap storage key %d: %w", record.ID, err)
+		}
+		keys[record.ID] = key
+	}
+	if keys[store.ActiveKeyID] == nil {
+		return fmt.Errorf("active storage key %d is missing from the key store", store.ActiveKeyID)
+	}
+	se.keys = keys
+	se.activeKeyID = store.ActiveKeyID
+	return nil
+}
+
+// deriveKEK returns the key-encryption key from the configured key source
+func (se *StorageEncryption) deriveKEK(salt []byte, iterations int) ([]byte, error) {
+	cfg := se.config.Storage.Encryption
+
+	switch cfg.KeySource {
+	case EncryptionKeySourcePassphrase:
+		if cfg.Passphrase == "" {
+			return nil, fmt.Errorf("storage encryption passphrase is not set")
+		}
+		return pbkdf2.Key(sha256.New, cfg.Passphrase, salt, iterations, encryptionKeySize)
+	case EncryptionKeySourceKeyfile:
+		data, err := os.ReadFile(cfg.KeyfilePath)
+		if os.IsNotExist(err) {
+			key := make([]byte, encryptionKeySize)
+			if _, err := rand.Read(key); err != nil {
+				return nil, fmt.Errorf("failed to generate keyfile: %w", err)
+			}
+			if err := os.MkdirAll(filepath.Dir(cfg.KeyfilePath), 0700); err != nil {
+				return nil, fmt.Errorf("failed to create keyfile directory: %w", err)
+			}
+			if err := os.WriteFile(cfg.KeyfilePath, []byte(hex.EncodeToString(key)+"\n"), 0600); err != nil {
+				return nil, fmt.Errorf("failed to write keyfile: %w", err)
+			}
+			se.logger.WithField("path", cfg.KeyfilePath).Warn("Generated storage encryption keyfile - back it up, files cannot be recovered without it")
+			return key, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keyfile: %w", err)
+		}
+		return parseKeyfile(data)
+	default:
+		return nil, fmt.Errorf("unknown storage encryption key source %q", cfg.KeySource)
+	}
+}
+
+// parseKeyfile accepts a raw, hex or base64 encoded 32-byte key
+func parseKeyfile(data []byte) ([]byte, error) {
+	if len(data) == encryptionKeySize {
+		return data, nil
+	}
+	text := string(bytes.TrimSpace(data))
+	if key, err := hex.DecodeString(text); err == nil && len(key) == encryptionKeySize {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(text); err == nil && len(key) == encryptionKeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("keyfile must hold a 32-byte key (raw, hex or base64)")
+}
+
+// saveKeyStore atomically persists the key store; caller holds se.mu
+func (se *StorageEncryption) saveKeyStore() error {
+	data, err := json.MarshalIndent(se.store, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode storage key store: %w", err)
+	}
+
+	storePath := se.config.Storage.Encryption.KeyStorePath
+	if err := os.MkdirAll(filepath.Dir(storePath), 0700); err != nil {
+		return fmt.Errorf("failed to create storage key store directory: %w", err)
+	}
+	tmpPath := storePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write storage key store: %w", err)
+	}
+	if err := os.Rename(tmpPath, storePath); err != nil {
+		return fmt.Errorf("failed to replace storage key store: %w", err)
+	}
+	return nil
+}
+
+// GetStats returns encryption statistics
+func (se *StorageEncryption) GetStats() map[string]interface{} {
+	se.mu.RLock()
+	defer se.mu.RUnlock()
+	return map[string]interface{}{
+		"enabled":         se.Enabled(),
+		"active_key_id":   se.activeKeyID,
+		"encrypted_files": se.encryptedFiles,
+		"failed_files":    se.failedFiles,
+	}
+}
+
+// newGCM returns an AES-GCM AEAD for key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealKey encrypts a key with a random nonce, returning nonce || ciphertext
+func sealKey(wrappingKey, key, aad []byte) ([]byte, error) {
+	aead, err := newGCM(wrappingKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, key, aad), nil
+}
+
+// openKey decrypts a key produced by sealKey
+func openKey(wrappingKey, sealed, aad []byte) ([]byte, error) {
+	aead, err := newGCM(wrappingKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("wrapped key is too short")
+	}
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], aad)
+}
@@ -0,0 +1,521 @@
+/*
+MediaMTX Storage Guard Implementation
+
+Enforces the storage block threshold for recordings and snapshots. Captures are
+redirected to the fallback path when the primary path crosses block_percent, and
+refused with a structured StorageError when no location has space left.
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-007: Error handling and recovery
+- REQ-MTX-008: Logging and monitoring
+
+Test Categories: Unit
+*/
+
+package mediamtx
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// storageGuardCheckInterval is how often the guard re-evaluates the primary path
+	storageGuardCheckInterval = 10 * time.Second
+
+	// storageActiveWriteWindow protects files written this recently from emergency cleanup
+	storageActiveWriteWindow = time.Minute
+
+	// fallbackSnapshotsDir is the snapshot subdirectory used under the fallback path
+	fallbackSnapshotsDir = "snapshots"
+)
+
+// emergencyCleanupExtensions are the recording and snapshot formats emergency
+// cleanup may delete; anything else under the media paths is left alone
+var emergencyCleanupExtensions = map[string]bool{
+	".mp4": true, ".ts": true, ".mkv": true, ".avi": true,
+	".jpg": true, ".jpeg": true, ".png": true, ".bmp": true,
+}
+
+// StorageError reports a capture refused because storage crossed the block threshold.
+// It is returned by RecordingManager and SnapshotManager and surfaced to clients as-is.
+type StorageError struct {
+	Path         string  `json:"path"`
+	UsagePercent float64 `json:"usage_percent"`
+	BlockPercent int     `json:"block_percent"`
+	FallbackPath string  `json:"fallback_path,omitempty"`
+	Reason       string  `json:"reason"`
+}
+
+func (e *StorageError) Error() string {
+	return fmt.Sprintf("storage blocked: %s at %.1f%% usage (block threshold %d%%): %s",
+		e.Path, e.UsagePercent, e.BlockPercent, e.Reason)
+}
+
+// recordPathMigrator moves active recordings between storage locations
+type recordPathMigrator interface {
+	ActiveRecordingCameras() []string
+	MigrateRecordPath(ctx context.Context, cameraID, basePath string) error
+}
+
+// StorageGuard enforces StorageConfig.BlockPercent for new and active captures.
+//
+// RESPONSIBILITIES:
+// - Resolve the capture location for recordings and snapshots (primary or fallback)
+// - Migrate active recordings to the fallback via the MediaMTX path recordPath
+// - Run an emergency oldest-first cleanup on the primary path when blocked
+// - Feed storage usage to HealthNotificationManager threshold notifications
+type StorageGuard struct {
+	config *config.Config
+	logger *logging.Logger
+
+	// statfs returns total and free bytes for a path (replaceable for tests)
+	statfs func(path string) (total, free uint64, err error)
+	// device returns the ID of the file system holding a path (replaceable for tests)
+	device func(path string) (uint64, error)
+
+	recorder    recordPathMigrator
+	notifier    *HealthNotificationManager
+	replication *ReplicationManager
+
+	mu         sync.Mutex
+	failedOver bool
+	migrated   map[string]bool // cameraID -> recording moved to the fallback
+
+	checkChan chan struct{}
+	stopChan  chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewStorageGuard creates a new storage guard
+func NewStorageGuard(cfg *config.Config, logger *logging.Logger) *StorageGuard {
+	return &StorageGuard{
+		config:    cfg,
+		logger:    logger,
+		statfs:    statfsUsage,
+		device:    deviceID,
+		migrated:  make(map[string]bool),
+		checkChan: make(chan struct{}, 1),
+	}
+}
+
+// SetDependencies wires the recording migrator and the health notification manager
+func (sg *StorageGuard) SetDependencies(recorder recordPathMigrator, notifier *HealthNotificationManager) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	sg.recorder = recorder
+	sg.notifier = notifier
+}
+
+// SetReplicationManager lets emergency cleanup keep files not yet uploaded off-box
+func (sg *StorageGuard) SetReplicationManager(replicationManager *ReplicationManager) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	sg.replication = replicationManager
+}
+
+// Start begins periodic storage checks
+func (sg *StorageGuard) Start(ctx context.Context) {
+	sg.mu.Lock()
+	if sg.stopChan != nil {
+		sg.mu.Unlock()
+		return
+	}
+	sg.stopChan = make(chan struct{})
+	stopChan := sg.stopChan
+	sg.mu.Unlock()
+
+	sg.wg.Add(1)
+	go func() {
+		defer sg.wg.Done()
+		ticker := time.NewTicker(storageGuardCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			sg.Check(ctx)
+			select {
+			case <-ticker.C:
+			case <-sg.checkChan:
+			case <-stopChan:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	sg.logger.WithFields(logging.Fields{
+		"block_percent": sg.config.Storage.BlockPercent,
+		"fallback_path": sg.config.Storage.FallbackPath,
+	}).Info("Storage guard started")
+}
+
+// Stop stops periodic storage checks and waits for an in-flight check
+func (sg *StorageGuard) Stop() {
+	sg.mu.Lock()
+	stopChan := sg.stopChan
+	sg.stopChan = nil
+	sg.mu.Unlock()
+
+	if stopChan == nil {
+		return
+	}
+	close(stopChan)
+	sg.wg.Wait()
+}
+
+// ResolveRecordingsPath returns the base path new recordings should be written to
+func (sg *StorageGuard) ResolveRecordingsPath() (string, error) {
+	return sg.resolveCapturePath(sg.config.MediaMTX.RecordingsPath, sg.config.Storage.FallbackPath)
+}
+
+// ResolveSnapshotsPath returns the base path new snapshots should be written to
+func (sg *StorageGuard) ResolveSnapshotsPath() (string, error) {
+	fallback := sg.config.Storage.FallbackPath
+	if fallback != "" {
+		fallback = filepath.Join(fallback, fallbackSnapshotsDir)
+	}
+	return sg.resolveCapturePath(sg.config.MediaMTX.SnapshotsPath, fallback)
+}
+
+// resolveCapturePath picks primary, or fallback when primary is blocked
+func (sg *StorageGuard) resolveCapturePath(primary, fallback string) (string, error) {
+	blockPercent := sg.config.Storage.BlockPercent
+	if blockPercent <= 0 || primary == "" {
+		return primary, nil
+	}
+
+	info, err := sg.usage(primary)
+	if err != nil {
+		// Do not refuse captures because usage could not be read
+		sg.logger.WithError(err).WithField("path", primary).Warn("Failed to read storage usage, using primary path")
+		return primary, nil
+	}
+	if !sg.blocked(info) {
+		return primary, nil
+	}
+
+	// Primary is over the threshold - let the monitor migrate and clean up now
+	sg.requestCheck()
+
+	storageErr := &StorageError{
+		Path:         primary,
+		UsagePercent: info.UsagePercentage,
+		BlockPercent: blockPercent,
+		FallbackPath: fallback,
+		Reason:       "no fallback path configured",
+	}
+	if fallback == "" {
+		return "", storageErr
+	}
+
+	fallbackInfo, err := sg.usage(fallback)
+	if err != nil {
+		storageErr.Reason = fmt.Sprintf("fallback path unusable: %v", err)
+		return "", storageErr
+	}
+	if sg.blocked(fallbackInfo) {
+		storageErr.Reason = fmt.Sprintf("fallback path also over threshold (%.1f%%)", fallbackInfo.UsagePercentage)
+		return "", storageErr
+	}
+
+	sg.logger.WithFields(logging.Fields{
+		"primary":       primary,
+		"fallback":      fallback,
+		"usage_percent": info.UsagePercentage,
+		"block_percent": blockPercent,
+	}).Warn("Primary storage blocked, redirecting capture to fallback path")
+	return fallback, nil
+}
+
+// Check evaluates the primary recordings path once. When the block threshold is
+// crossed, active recordings move to the fallback and an emergency cleanup runs;
+// once usage drops below the warning threshold, migrated recordings move back.
+func (sg *StorageGuard) Check(ctx context.Context) {
+	primary := sg.config.MediaMTX.RecordingsPath
+	if primary == "" {
+		return
+	}
+
+	info, err := sg.usage(primary)
+	if err != nil {
+		sg.logger.WithError(err).WithField("path", primary).Debug("Storage guard could not read usage")
+		return
+	}
+
+	sg.mu.Lock()
+	notifier := sg.notifier
+	sg.mu.Unlock()
+	if notifier != nil {
+		notifier.CheckStorageThresholds(info)
+	}
+
+	if sg.blocked(info) {
+		sg.failOver(ctx, info)
+		if _, _, err := sg.EmergencyCleanup(ctx); err != nil {
+			sg.logger.WithError(err).Error("Emergency storage cleanup failed")
+		}
+		return
+	}
+
+	if info.UsagePercentage < float64(sg.config.Storage.WarnPercent) {
+		sg.restore(ctx)
+	}
+}
+
+// failOver moves active recordings that still write to the primary path
+func (sg *StorageGuard) failOver(ctx context.Context, info *StorageInfo) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+
+	if !sg.failedOver {
+		sg.logger.WithFields(logging.Fields{
+			"path":          sg.config.MediaMTX.RecordingsPath,
+			"usage_percent": info.UsagePercentage,
+			"block_percent": sg.config.Storage.BlockPercent,
+		}).Error("Storage block threshold crossed on primary path")
+	}
+	sg.failedOver = true
+
+	fallback := sg.config.Storage.FallbackPath
+	if sg.recorder == nil || fallback == "" {
+		return
+	}
+	if fallbackInfo, err := sg.usage(fallback); err != nil || sg.blocked(fallbackInfo) {
+		sg.logger.WithField("fallback_path", fallback).Error("Fallback path unavailable, active recordings stay on primary path")
+		return
+	}
+
+	for _, cameraID := range sg.recorder.ActiveRecordingCameras() {
+		if sg.migrated[cameraID] {
+			continue
+		}
+		if err := sg.recorder.MigrateRecordPath(ctx, cameraID, fallback); err != nil {
+			sg.logger.WithError(err).WithField("camera_id", cameraID).Error("Failed to migrate recording to fallback path")
+			continue
+		}
+		sg.migrated[cameraID] = true
+		sg.logger.WithFields(logging.Fields{
+			"camera_id":     cameraID,
+			"fallback_path": fallback,
+		}).Warn("Active recording migrated to fallback path")
+	}
+}
+
+// restore moves migrated recordings back once the primary path has recovered
+func (sg *StorageGuard) restore(ctx context.Context) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+
+	if !sg.failedOver {
+		return
+	}
+	sg.failedOver = false
+
+	active := make(map[string]bool)
+	if sg.recorder != nil {
+		for _, cameraID := range sg.recorder.ActiveRecordingCameras() {
+			active[cameraID] = true
+		}
+	}
+
+	primary := sg.config.MediaMTX.RecordingsPath
+	for cameraID := range sg.migrated {
+		if active[cameraID] {
+			if err := sg.recorder.MigrateRecordPath(ctx, cameraID, primary); err != nil {
+				sg.logger.WithError(err).WithField("camera_id", cameraID).Warn("Failed to move recording back to primary path")
+				continue
+			}
+		}
+		delete(sg.migrated, cameraID)
+	}
+
+	sg.logger.WithField("path", primary).Info("Primary storage recovered below warning threshold")
+}
+
+// EmergencyCleanup deletes the oldest recordings and snapshots on the primary
+// paths until usage falls below the warning threshold. Retention policy is
+// ignored; locked files (see isFileLocked) and files that are not recognised
+// media are kept. Files still awaiting replication are deleted only after
+// every replicated file is gone. Snapshots are only considered when they share
+// the recordings file system, since deleting them elsewhere frees nothing.
+func (sg *StorageGuard) EmergencyCleanup(ctx context.Context) (deletedCount int, spaceFreed int64, err error) {
+	sg.mu.Lock()
+	replication := sg.replication
+	sg.mu.Unlock()
+
+	primary := sg.config.MediaMTX.RecordingsPath
+	target := float64(sg.config.Storage.WarnPercent)
+	if target <= 0 || target > float64(sg.config.Storage.BlockPercent) {
+		target = float64(sg.config.Storage.BlockPercent)
+	}
+
+	type candidate struct {
+		path     string
+		size     int64
+		modTime  time.Time
+		awaiting bool
+	}
+	var candidates []candidate
+	now := time.Now()
+
+	dirs := []string{primary}
+	if snapshots := sg.config.MediaMTX.SnapshotsPath; snapshots != "" && sg.sameFileSystem(primary, snapshots) {
+		dirs = append(dirs, snapshots)
+	}
+
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		walkErr := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !emergencyCleanupExtensions[strings.ToLower(filepath.Ext(path))] {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil || isFileLocked(path, info, now) {
+				return nil
+			}
+			candidates = append(candidates, candidate{
+				path:     path,
+				size:     info.Size(),
+				modTime:  info.ModTime(),
+				awaiting: replication != nil && replication.AwaitingUpload(path),
+			})
+			return nil
+		})
+		if walkErr != nil {
+			return deletedCount, spaceFreed, fmt.Errorf("failed to scan %s: %w", dir, walkErr)
+		}
+	}
+
+	// Replicated files first, then oldest first
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].awaiting != candidates[j].awaiting {
+			return !candidates[i].awaiting
+		}
+		return candidates[i].modTime.Before(candidates[j].modTime)
+	})
+
+	unreplicated := 0
+
+	for _, file := range candidates {
+		if ctx.Err() != nil {
+			return deletedCount, spaceFreed, ctx.Err()
+		}
+		info, err := sg.usage(primary)
+		if err != nil {
+			return deletedCount, spaceFreed, err
+		}
+		if info.UsagePercentage < target {
+			break
+		}
+		if err := os.Remove(file.path); err != nil {
+			sg.logger.WithError(err).WithField("file", file.path).Warn("Failed to delete file during emergency cleanup")
+			continue
+		}
+		deletedCount++
+		spaceFreed += file.size
+		if file.awaiting {
+			unreplicated++
+		}
+	}
+
+	if deletedCount > 0 {
+		sg.logger.WithFields(logging.Fields{
+			"deleted_count":      deletedCount,
+			"unreplicated_count": unreplicated,
+			"space_freed":        spaceFreed,
+			"target_percent":     target,
+		}).Warn("Emergency storage cleanup deleted oldest files")
+	}
+	return deletedCount, spaceFreed, nil
+}
+
+// requestCheck asks the monitor loop to run a check without waiting for the ticker
+func (sg *StorageGuard) requestCheck() {
+	select {
+	case sg.checkChan <- struct{}{}:
+	default:
+	}
+}
+
+// blocked reports whether usage has crossed the configured block threshold
+func (sg *StorageGuard) blocked(info *StorageInfo) bool {
+	return sg.config.Storage.BlockPercent > 0 && info.UsagePercentage >= float64(sg.config.Storage.BlockPercent)
+}
+
+// usage returns storage usage for the file system holding path
+func (sg *StorageGuard) usage(path string) (*StorageInfo, error) {
+	total, free, err := sg.statfs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &StorageInfo{
+		TotalSpace:     total,
+		UsedSpace:      total - free,
+		AvailableSpace: free,
+	}
+	if total > 0 {
+		info.UsagePercentage = float64(total-free) / float64(total) * 100.0
+	}
+	info.LowSpaceWarning = info.UsagePercentage >= float64(sg.config.Storage.WarnPercent)
+	return info, nil
+}
+
+// sameFileSystem reports whether two paths are on the same file system. Paths
+// that cannot be checked are treated as different.
+func (sg *StorageGuard) sameFileSystem(a, b string) bool {
+	devA, errA := sg.device(a)
+	devB, errB := sg.device(b)
+	return errA == nil && errB == nil && devA == devB
+}
+
+// nearestExistingDir walks up from path to the nearest existing directory so
+// paths that have not been created yet still resolve
+func nearestExistingDir(path string) string {
+	dir := filepath.Clean(path)
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+// deviceID returns the st_dev of the file system holding path
+func deviceID(path string) (uint64, error) {
+	var st unix.Stat_t
+	if err := unix.Stat(nearestExistingDir(path), &st); err != nil {
+		return 0, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	return uint64(st.Dev), nil
+}
+
+// statfsUsage reads file system usage for path
+func statfsUsage(path string) (total, free uint64, err error) {
+	dir := nearestExistingDir(path)
+
+	var st unix.Statfs_t
+	if err := unix.Statfs(dir, &st); err != nil {
+		return 0, 0, fmt.Errorf("failed to get storage statistics for %s: %w", path, err)
+	}
+	return st.Blocks * uint64(st.Bsize), st.Bfree * uint64(st.Bsize), nil
+}
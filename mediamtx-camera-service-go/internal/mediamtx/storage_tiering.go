@@ -0,0 +1,482 @@
+/*
+MediaMTX Storage Tiering Implementation
+
+Moves aged recordings from the fast recordings volume to a large archive
+volume. Each move copies the file with I/O throttling, verifies a SHA-256
+checksum of the copy and only then deletes the source. Archived recordings
+stay in the catalog under the same filename and download URL.
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-007: Error handling and recovery
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/camera"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// tierMoveTimeout bounds a single copy-verify-delete cycle
+	tierMoveTimeout = 30 * time.Minute
+
+	// tierCopyChunkSize is the copy buffer and rate limiter burst size
+	tierCopyChunkSize = 256 * 1024
+
+	// archivePartialSuffix marks copies that have not been verified yet
+	archivePartialSuffix = ".partial"
+
+	// StorageTierPrimary and StorageTierArchive identify where a recording lives
+	StorageTierPrimary = "primary"
+	StorageTierArchive = "archive"
+)
+
+// StorageTierManager moves aged recordings to the archive tier.
+//
+// RESPONSIBILITIES:
+// - Periodically select recordings older than move_after_hours
+// - Copy through BoundedWorkerPool with a shared bandwidth limit
+// - Verify SHA-256 of the archived copy before deleting the source
+// - Resolve recording filenames across the primary and archive tiers
+//
+// Locked files (see isFileLocked) are never moved.
+type StorageTierManager struct {
+	config  *config.Config
+	logger  *logging.Logger
+	pool    camera.BoundedWorkerPool
+	limiter *rate.Limiter
+
+	mu       sync.Mutex
+	inFlight map[string]bool
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+
+	movedFiles  int64
+	failedMoves int64
+	movedBytes  int64
+}
+
+// NewStorageTierManager creates a new storage tier manager
+func NewStorageTierManager(cfg *config.Config, logger *logging.Logger) *StorageTierManager {
+	tiering := cfg.Storage.Tiering
+
+	var limiter *rate.Limiter
+	if tiering.IOLimitMBps > 0 {
+		limiter = rate.NewLimiter(rate.Limit(tiering.IOLimitMBps*1024*1024), tierCopyChunkSize)
+	}
+
+	maxMoves := tiering.MaxConcurrentMoves
+	if maxMoves <= 0 {
+		maxMoves = 1
+	}
+
+	return &StorageTierManager{
+		config:   cfg,
+		logger:   logger,
+		pool:     camera.NewBoundedWorkerPool(maxMoves, tierMoveTimeout, logger),
+		limiter:  limiter,
+		inFlight: make(map[string]bool),
+	}
+}
+
+// Enabled reports whether tiering is configured
+func (tm *StorageTierManager) Enabled() bool {
+	return tm.config.Storage.Tiering.Enabled && tm.config.Storage.Tiering.ArchivePath != ""
+}
+
+// Start begins periodic tier moves when tiering is enabled
+func (tm *StorageTierManager) Start(ctx context.Context) error {
+	if !tm.Enabled() {
+		return nil
+	}
+
+	tm.mu.Lock()
+	if tm.stopChan != nil {
+		tm.mu.Unlock()
+		return nil
+	}
+	tm.stopChan = make(chan struct{})
+	stopChan := tm.stopChan
+	tm.mu.Unlock()
+
+	if err := tm.pool.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start tier move worker pool: %w", err)
+	}
+
+	interval := time.Duration(tm.config.Storage.Tiering.CheckIntervalMinutes) * time.Minute
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+
+	tm.wg.Add(1)
+	go func() {
+		defer tm.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := tm.RunOnce(ctx); err != nil {
+				tm.logger.WithError(err).Warn("Storage tiering pass failed")
+			}
+			select {
+			case <-ticker.C:
+			case <-stopChan:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	tm.logger.WithFields(logging.Fields{
+		"archive_path":     tm.config.Storage.Tiering.ArchivePath,
+		"move_after_hours": tm.config.Storage.Tiering.MoveAfterHours,
+		"interval":         interval.String(),
+	}).Info("Storage tiering started")
+	return nil
+}
+
+// Stop stops periodic tier moves and waits for in-flight moves
+func (tm *StorageTierManager) Stop(ctx context.Context) error {
+	tm.mu.Lock()
+	stopChan := tm.stopChan
+	tm.stopChan = nil
+	tm.mu.Unlock()
+
+	if stopChan == nil {
+		return nil
+	}
+	close(stopChan)
+	tm.wg.Wait()
+	return tm.pool.Stop(ctx)
+}
+
+// RunOnce submits moves for every eligible recording and returns the number submitted.
+// Submission blocks while all workers are busy, so a pass never queues more than
+// max_concurrent_moves copies at a time.
+func (tm *StorageTierManager) RunOnce(ctx context.Context) (int, error) {
+	candidates, err := tm.candidates(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	submitted := 0
+	for _, path := range candidates {
+		if !tm.claim(path) {
+			continue
+		}
+		path := path
+		err := tm.pool.Submit(ctx, func(taskCtx context.Context) {
+			defer tm.release(path)
+			if err := tm.MoveToArchive(taskCtx, path); err != nil {
+				atomic.AddInt64(&tm.failedMoves, 1)
+				tm.logger.WithError(err).WithField("file", path).Warn("Failed to move recording to archive tier")
+			}
+		})
+		if err != nil {
+			tm.release(path)
+			return submitted, err
+		}
+		submitted++
+	}
+	return submitted, nil
+}
+
+// candidates lists unlocked primary recordings older than move_after_hours, oldest first
+func (tm *StorageTierManager) candidates(now time.Time) ([]string, error) {
+	root := tm.config.MediaMTX.RecordingsPath
+	cutoff := now.Add(-time.Duration(tm.config.Storage.Tiering.MoveAfterHours) * time.Hour)
+
+	type candidate struct {
+		path    string
+		modTime time.Time
+	}
+	var found []candidate
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || isInternalStorageFile(path) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.ModTime().After(cutoff) || isFileLocked(path, info, now) {
+			return nil
+		}
+		found = append(found, candidate{path: path, modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].modTime.Before(found[j].modTime)
+	})
+	paths := make([]string, len(found))
+	for i, c := range found {
+		paths[i] = c.path
+	}
+	return paths, nil
+}
+
+// MoveToArchive copies a primary recording to the archive tier, verifies the
+// copy's SHA-256 against the source and deletes the source. The relative path
+// under the recordings root is preserved so the filename stays the same.
+func (tm *StorageTierManager) MoveToArchive(ctx context.Context, sourcePath string) error {
+	rel, err := filepath.Rel(tm.config.MediaMTX.RecordingsPath, sourcePath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("file %s is not under the recordings path", sourcePath)
+	}
+	destPath := filepath.Join(tm.config.Storage.Tiering.ArchivePath, rel)
+	partialPath := destPath + archivePartialSuffix
+
+	before, err := os.Stat(sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat source: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	sourceSum, err := tm.copyFile(ctx, sourcePath, partialPath)
+	if err != nil {
+		os.Remove(partialPath)
+		return err
+	}
+
+	archiveSum, err := tm.checksumFile(ctx, partialPath)
+	if err != nil {
+		os.Remove(partialPath)
+		return fmt.Errorf("failed to verify archived copy: %w", err)
+	}
+	if !bytes.Equal(sourceSum, archiveSum) {
+		os.Remove(partialPath)
+		return fmt.Errorf("checksum mismatch for archived copy of %s", rel)
+	}
+
+	// The source must not have changed while it was copied
+	after, err := os.Stat(sourcePath)
+	if err != nil || after.Size() != before.Size() || !after.ModTime().Equal(before.ModTime()) {
+		os.Remove(partialPath)
+		return fmt.Errorf("source %s changed during archive copy", rel)
+	}
+
+	// Keep the original timestamp so retention age is unaffected by the move
+	if err := os.Chtimes(partialPath, before.ModTime(), before.ModTime()); err != nil {
+		tm.logger.WithError(err).WithField("file", partialPath).Warn("Failed to preserve modification time on archived copy")
+	}
+	if err := os.Rename(partialPath, destPath); err != nil {
+		os.Remove(partialPath)
+		return fmt.Errorf("failed to finalize archived copy: %w", err)
+	}
+	if err := os.Remove(sourcePath); err != nil {
+		return fmt.Errorf("archived copy verified but failed to delete source: %w", err)
+	}
+
+	atomic.AddInt64(&tm.movedFiles, 1)
+	atomic.AddInt64(&tm.movedBytes, before.Size())
+	tm.logger.WithFields(logging.Fields{
+		"file":      rel,
+		"file_size": before.Size(),
+		"sha256":    fmt.Sprintf("%x", sourceSum),
+	}).Info("Recording moved to archive tier")
+	return nil
+}
+
+// copyFile copies src to dst with throttling and returns the SHA-256 of the bytes read
+func (tm *StorageTierManager) copyFile(ctx context.Context, src, dst string) ([]byte, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open source: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archived copy: %w", err)
+	}
+
+	hash := sha256.New()
+	reader := io.TeeReader(&throttledReader{ctx: ctx, reader: in, limiter: tm.limiter}, hash)
+	if _, err := io.CopyBuffer(out, reader, make([]byte, tierCopyChunkSize)); err != nil {
+		out.Close()
+		return nil, fmt.Errorf("failed to copy to archive: %w", err)
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return nil, fmt.Errorf("failed to sync archived copy: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close archived copy: %w", err)
+	}
+	return hash.Sum(nil), nil
+}
+
+// checksumFile reads a file back with throttling and returns its SHA-256
+func (tm *StorageTierManager) checksumFile(ctx context.Context, path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	reader := &throttledReader{ctx: ctx, reader: file, limiter: tm.limiter}
+	if _, err := io.CopyBuffer(hash, reader, make([]byte, tierCopyChunkSize)); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+// ResolveRecordingFile finds a recording by filename (relative to the recordings
+// root) in the primary tier and then in the archive tier. Each name in names is
+// tried in order, so callers can pass variants with and without extension.
+func (tm *StorageTierManager) ResolveRecordingFile(names ...string) (string, os.FileInfo, string, error) {
+	roots := []struct {
+		path string
+		tier string
+	}{
+		{tm.config.MediaMTX.RecordingsPath, StorageTierPrimary},
+	}
+	if tm.Enabled() {
+		roots = append(roots, struct {
+			path string
+			tier string
+		}{tm.config.Storage.Tiering.ArchivePath, StorageTierArchive})
+	}
+
+	for _, root := range roots {
+		for _, name := range names {
+			path := filepath.Join(root.path, name)
+			if info, err := os.Stat(path); err == nil {
+				return path, info, root.tier, nil
+			}
+		}
+	}
+	return "", nil, "", fmt.Errorf("recording file not found: %s", names[0])
+}
+
+// ListArchivedRecordings returns catalog entries for recordings in the archive tier
+func (tm *StorageTierManager) ListArchivedRecordings() ([]*FileMetadata, error) {
+	if !tm.Enabled() {
+		return nil, nil
+	}
+
+	root := tm.config.Storage.Tiering.ArchivePath
+	var files []*FileMetadata
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || isInternalStorageFile(path) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		name := filepath.ToSlash(rel)
+		files = append(files, &FileMetadata{
+			FileName:    name,
+			FileSize:    info.Size(),
+			CreatedAt:   info.ModTime(),
+			ModifiedAt:  info.ModTime(),
+			DownloadURL: fmt.Sprintf("/files/recordings/%s", name),
+			StorageTier: StorageTierArchive,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan archive %s: %w", root, err)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].CreatedAt.After(files[j].CreatedAt)
+	})
+	return files, nil
+}
+
+// GetStats returns tier move statistics
+func (tm *StorageTierManager) GetStats() map[string]interface{} {
+	return map[string]interface{}{
+		"enabled":      tm.Enabled(),
+		"moved_files":  atomic.LoadInt64(&tm.movedFiles),
+		"moved_bytes":  atomic.LoadInt64(&tm.movedBytes),
+		"failed_moves": atomic.LoadInt64(&tm.failedMoves),
+		"worker_pool":  tm.pool.GetStats(),
+	}
+}
+
+// claim marks a file as being moved; false if a move is already in flight
+func (tm *StorageTierManager) claim(path string) bool {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if tm.inFlight[path] {
+		return false
+	}
+	tm.inFlight[path] = true
+	return true
+}
+
+// release clears the in-flight mark for a file
+func (tm *StorageTierManager) release(path string) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	delete(tm.inFlight, path)
+}
+
+// throttledReader limits read bandwidth with a shared token bucket
+type throttledReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *rate.Limiter
+}
+
+// Read reads at most one burst and waits for the matching number of tokens
+func (r *throttledReader) Read(p []byte) (int, error) {
+	if r.limiter == nil {
+		return r.reader.Read(p)
+	}
+	if len(p) > r.limiter.Burst() {
+		p = p[:r.limiter.Burst()]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		if waitErr := r.limiter.WaitN(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
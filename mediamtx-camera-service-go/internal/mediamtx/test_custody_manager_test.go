@@ -0,0 +1,220 @@
+/*
+MediaMTX Custody Manager Tests
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-007: Error handling and recovery
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCustodyManager creates a started custody manager rooted in dir
+func newTestCustodyManager(t *testing.T, dir string) *CustodyManager {
+	cfg := &config.Config{}
+	cfg.MediaMTX.RecordingsPath = filepath.Join(dir, "recordings")
+	cfg.MediaMTX.SnapshotsPath = filepath.Join(dir, "snapshots")
+	cfg.Custody = config.CustodyConfig{
+		Enabled:        true,
+		ManifestPath:   filepath.Join(dir, "custody", "manifest.jsonl"),
+		SigningKeyPath: filepath.Join(dir, "keys", "signing_key.pem"),
+		ExportPath:     filepath.Join(dir, "exports"),
+	}
+
+	logger := logging.GetLogger("mediamtx")
+	cm := NewCustodyManager(cfg, NewMetadataManager(nil, nil, logger), logger)
+	cm.settleWindow = 50 * time.Millisecond
+	cm.settleTimeout = 5 * time.Second
+	require.NoError(t, cm.Start(context.Background()))
+	t.Cleanup(cm.Stop)
+	return cm
+}
+
+func TestCustodyManager_ChainsAndVerifiesEntries(t *testing.T) {
+	dir := t.TempDir()
+	cm := newTestCustodyManager(t, dir)
+
+	snapshot := filepath.Join(dir, "snapshots", "camera0", "camera0_2025-01-01_10-00-00.jpg")
+	writeAgedFile(t, snapshot, 1024, time.Hour)
+	cm.RecordSnapshot(context.Background(), "camera0", snapshot)
+
+	recording := filepath.Join(dir, "recordings", "camera0", "camera0_2025-01-01_10-00-05.mp4")
+	writeAgedFile(t, recording, 4096, 0)
+	cm.RecordRecordingStop("camera0", time.Now().Add(-time.Minute))
+
+	// Recording hashes are recorded asynchronously once the file settles
+	var entries []*CustodyEntry
+	require.Eventually(t, func() bool {
+		entries, _, _ = cm.readManifest()
+		return len(entries) == 2
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, custodyGenesisHash, entries[0].PrevHash)
+	assert.Equal(t, entries[0].EntryHash, entries[1].PrevHash)
+	assert.Equal(t, CustodyEventRecordingStop, entries[1].Event)
+
+	// Key is persisted with owner-only permissions and reused after restart
+	info, err := os.Stat(cm.config.Custody.SigningKeyPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	restarted := newTestCustodyManager(t, dir)
+	assert.Equal(t, cm.publicKey, restarted.publicKey)
+
+	result, err := restarted.VerifyFile(context.Background(), "camera0_2025-01-01_10-00-05", fileKindRecording)
+	require.NoError(t, err)
+	assert.True(t, result.Intact)
+	assert.Equal(t, int64(2), result.Sequence)
+
+	// Altering a file is detected
+	require.NoError(t, os.WriteFile(snapshot, []byte("tampered"), 0644))
+	result, err = restarted.VerifyFile(context.Background(), "camera0_2025-01-01_10-00-00.jpg", fileKindSnapshot)
+	require.NoError(t, err)
+	assert.False(t, result.Intact)
+	assert.True(t, result.ChainValid)
+	assert.NotEqual(t, result.RecordedSHA256, result.CurrentSHA256)
+
+	_, err = restarted.VerifyFile(context.Background(), "missing.jpg", fileKindSnapshot)
+	assert.ErrorIs(t, err, ErrCustodyRecordNotFound)
+}
+
+func TestCustodyManager_DetectsManifestTampering(t *testing.T) {
+	dir := t.TempDir()
+	cm := newTestCustodyManager(t, dir)
+
+	for _, name := range []string{"camera0_1.jpg", "camera0_2.jpg"} {
+		path := filepath.Join(dir, "snapshots", name)
+		writeAgedFile(t, path, 512, time.Hour)
+		_, err := cm.RecordFile(context.Background(), CustodyEventSnapshotTaken, "camera0", path, fileKindSnapshot)
+		require.NoError(t, err)
+	}
+
+	// Dropping the first entry breaks the chain
+	data, err := os.ReadFile(cm.config.Custody.ManifestPath)
+	require.NoError(t, err)
+	lines := strings.SplitAfter(string(data), "\n")
+	require.NoError(t, os.WriteFile(cm.config.Custody.ManifestPath, []byte(strings.Join(lines[1:], "")), 0644))
+
+	result, err := cm.VerifyFile(context.Background(), "camera0_2.jpg", fileKindSnapshot)
+	require.NoError(t, err)
+	assert.False(t, result.ChainValid)
+	assert.False(t, result.Intact)
+}
+
+func TestCustodyManager_ExportsVerifiableBundle(t *testing.T) {
+	dir := t.TempDir()
+	cm := newTestCustodyManager(t, dir)
+
+	snapshot := filepath.Join(dir, "snapshots", "camera0_1.jpg")
+	writeAgedFile(t, snapshot, 2048, time.Hour)
+	_, err := cm.RecordFile(context.Background(), CustodyEventSnapshotTaken, "camera0", snapshot, fileKindSnapshot)
+	require.NoError(t, err)
+
+	response, err := cm.ExportBundle(context.Background(), "camera0_1.jpg", fileKindSnapshot)
+	require.NoError(t, err)
+	assert.FileExists(t, response.BundlePath)
+
+	archive, err := zip.OpenReader(response.BundlePath)
+	require.NoError(t, err)
+	defer archive.Close()
+
+	contents := make(map[string][]byte)
+	for _, f := range archive.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		contents[f.Name], err = io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+	}
+	require.Contains(t, contents, "camera0_1.jpg")
+	require.Contains(t, contents, custodyBundleManifestFile)
+
+	// The detached signature verifies against the bundled public key
+	block, _ := pem.Decode(contents[custodyPublicKeyFile])
+	require.NotNil(t, block)
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	require.NoError(t, err)
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(contents[custodyBundleSignatureFile])))
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(parsed.(ed25519.PublicKey), contents[custodyBundleManifestFile], signature))
+
+	// Tampered files are not exported
+	require.NoError(t, os.WriteFile(snapshot, []byte("tampered"), 0644))
+	_, err = cm.ExportBundle(context.Background(), "camera0_1.jpg", fileKindSnapshot)
+	assert.Error(t, err)
+}
+
+func TestCustodyManager_EncryptsExportedBundles(t *testing.T) {
+	dir := t.TempDir()
+	cm := newTestCustodyManager(t, dir)
+	se := newTestStorageEncryption(t, dir, nil)
+	se.config.Custody = cm.config.Custody
+	cm.metadataManager.SetStorageEncryption(se)
+
+	snapshot := filepath.Join(dir, "snapshots", "camera0_1.jpg")
+	content := []byte(strings.Repeat("evidence", 512))
+	writeAgedContent(t, snapshot, content)
+	_, err := cm.RecordFile(context.Background(), CustodyEventSnapshotTaken, "camera0", snapshot, fileKindSnapshot)
+	require.NoError(t, err)
+
+	response, err := cm.ExportBundle(context.Background(), "camera0_1.jpg", fileKindSnapshot)
+	require.NoError(t, err)
+	assert.Equal(t, EvidenceBundleFilesPath+filepath.Base(response.BundlePath), response.DownloadURL)
+
+	info, err := os.Stat(response.BundlePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	encrypted, err := IsEncryptedFile(response.BundlePath)
+	require.NoError(t, err)
+	assert.True(t, encrypted, "Bundles must not hold plaintext footage at rest")
+
+	// The bundle decrypts to a zip holding the original file
+	media, err := se.OpenMediaFile(response.BundlePath)
+	require.NoError(t, err)
+	defer media.Close()
+	archive, err := zip.NewReader(readerAtFor(t, media), media.Size)
+	require.NoError(t, err)
+	rc, err := archive.Open("camera0_1.jpg")
+	require.NoError(t, err)
+	exported, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	assert.Equal(t, content, exported)
+
+	// Bundles left from before encryption was enabled are encrypted by the background pass
+	stray := filepath.Join(cm.config.Custody.ExportPath, "older_bundle.zip")
+	writeAgedContent(t, stray, []byte("plaintext bundle"))
+	_, err = se.RunOnce(context.Background(), nil)
+	require.NoError(t, err)
+	encrypted, err = IsEncryptedFile(stray)
+	require.NoError(t, err)
+	assert.True(t, encrypted)
+}
+
+// readerAtFor reads a decrypted stream into memory for archive/zip
+func readerAtFor(t *testing.T, r io.Reader) io.ReaderAt {
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return bytes.NewReader(data)
+}
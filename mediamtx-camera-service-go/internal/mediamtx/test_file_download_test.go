@@ -0,0 +1,105 @@
+/*
+MediaMTX File Download Tests
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-007: Error handling and recovery
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDownloadPath(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "recordings")
+
+	path, err := resolveDownloadPath(root, "camera0/camera0_2025-01-01_10-00-00.mp4")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "camera0", "camera0_2025-01-01_10-00-00.mp4"), path)
+
+	for _, name := range []string{
+		"",
+		"../secret.mp4",
+		"camera0/../../secret.mp4",
+		"/etc/passwd",
+		"camera0/clip.mp4.lock",
+		"camera0/clip.mp4.partial",
+	} {
+		_, err := resolveDownloadPath(root, name)
+		assert.ErrorIs(t, err, ErrInvalidDownloadPath, "Expected %q to be rejected", name)
+	}
+
+	// A name that only starts with dots stays inside the root
+	path, err = resolveDownloadPath(root, "..clip.mp4")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "..clip.mp4"), path)
+}
+
+func TestStorageEncryption_OpenMediaFileDecrypts(t *testing.T) {
+	dir := t.TempDir()
+	se := newTestStorageEncryption(t, dir, nil)
+
+	content := bytes.Repeat([]byte("0123456789abcdef"), 300)
+	recording := filepath.Join(dir, "recordings", "camera0_2025-01-01_10-00-00.mp4")
+	modTime := writeAgedContent(t, recording, content)
+	require.NoError(t, se.EncryptFile(context.Background(), recording))
+
+	file, err := se.OpenMediaFile(recording)
+	require.NoError(t, err)
+	defer file.Close()
+
+	assert.Equal(t, "camera0_2025-01-01_10-00-00.mp4", file.Name)
+	assert.Equal(t, int64(len(content)), file.Size, "Size is the plaintext size")
+	assert.True(t, modTime.Equal(file.ModTime))
+	plaintext, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, content, plaintext)
+
+	_, err = se.OpenMediaFile(filepath.Join(dir, "recordings"))
+	assert.ErrorIs(t, err, os.ErrNotExist, "Directories are not served")
+	_, err = se.OpenMediaFile(filepath.Join(dir, "recordings", "missing.mp4"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestOpenRecordingDownloadFollowsArchiveTier(t *testing.T) {
+	tm, cfg := newTestTierManager(t)
+	se := NewStorageEncryption(cfg, logging.GetLogger("mediamtx"))
+
+	content := []byte("archived recording")
+	source := filepath.Join(cfg.MediaMTX.RecordingsPath, "camera0", "2025-01-01_10-00-00.mp4")
+	writeAgedContent(t, source, content)
+
+	file, err := openRecordingDownload(se, tm, cfg.MediaMTX.RecordingsPath, "camera0/2025-01-01_10-00-00.mp4")
+	require.NoError(t, err)
+	file.Close()
+
+	require.NoError(t, tm.MoveToArchive(context.Background(), source))
+	require.NoFileExists(t, source)
+
+	// The download_url is unchanged after the move
+	file, err = openRecordingDownload(se, tm, cfg.MediaMTX.RecordingsPath, "camera0/2025-01-01_10-00-00.mp4")
+	require.NoError(t, err)
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	_, err = openRecordingDownload(se, tm, cfg.MediaMTX.RecordingsPath, "camera0/missing.mp4")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	_, err = openRecordingDownload(se, tm, cfg.MediaMTX.RecordingsPath, "../archive/camera0/2025-01-01_10-00-00.mp4")
+	assert.ErrorIs(t, err, ErrInvalidDownloadPath)
+}
@@ -0,0 +1,159 @@
+/*
+MediaMTX KLV Injector Tests
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-002: STANAG 4609 compliance for UAV streams
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/klv"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestKLVInjector creates a started injector rooted in dir whose mux step
+// appends the raw KLV stream to a copy of the recording instead of running FFmpeg
+func newTestKLVInjector(t *testing.T, dir string) *KLVInjector {
+	cfg := &config.Config{}
+	cfg.MediaMTX.RecordingsPath = filepath.Join(dir, "recordings")
+	cfg.Recording.RecordFormat = "mpegts"
+	cfg.Recording.KLV = config.KLVConfig{
+		Enabled:             true,
+		MissionID:           "MISSION-7",
+		PlatformDesignation: "Camera Service",
+		IntervalMs:          10,
+		Cameras: map[string]config.KLVPositionConfig{
+			"camera0": {Latitude: 48.8584, Longitude: 2.2945, Altitude: 310, Heading: 90, HorizontalFOV: 60, VerticalFOV: 40},
+		},
+	}
+
+	ki := NewKLVInjector(cfg, nil, logging.GetLogger("mediamtx"))
+	ki.settleWindow = 50 * time.Millisecond
+	ki.settleTimeout = 5 * time.Second
+	ki.runCommand = func(ctx context.Context, command []string) error {
+		recording, err := os.ReadFile(command[5])
+		if err != nil {
+			return err
+		}
+		stream, err := os.ReadFile(command[9])
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(command[len(command)-1], append(recording, stream...), 0644)
+	}
+	require.NoError(t, ki.Start(context.Background()))
+	t.Cleanup(ki.Stop)
+	return ki
+}
+
+func TestKLVInjector_MuxesSamplesIntoFinishedRecording(t *testing.T) {
+	dir := t.TempDir()
+	ki := newTestKLVInjector(t, dir)
+
+	ki.StartSession("camera0")
+	time.Sleep(50 * time.Millisecond)
+
+	recording := filepath.Join(dir, "recordings", "camera0", "camera0_2025-01-01_10-00-00.ts")
+	writeAgedFile(t, recording, 1024, 0)
+	info, err := os.Stat(recording)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	ki.StopSession("camera0", func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("KLV injection did not complete")
+	}
+
+	data, err := os.ReadFile(recording)
+	require.NoError(t, err)
+	require.Greater(t, len(data), 1024, "KLV track was muxed in")
+	packets, rejected := klv.DecodeStream(data[1024:])
+	require.NotEmpty(t, packets)
+	assert.Equal(t, 0, rejected)
+	assert.Equal(t, "MISSION-7", packets[0].MissionID)
+	assert.Equal(t, "camera0", packets[0].ImageSourceSensor)
+	require.NotNil(t, packets[0].Position)
+	assert.InDelta(t, 48.8584, packets[0].Position.Latitude, 1e-6)
+
+	current, err := os.Stat(recording)
+	require.NoError(t, err)
+	assert.True(t, current.ModTime().Equal(info.ModTime()), "Modification time is preserved")
+	assert.NoFileExists(t, recording+archivePartialSuffix)
+
+	// The summary reported by get_recording_info reflects the decoded packets
+	summary := summarizeKLV(packets, rejected)
+	require.NotNil(t, summary)
+	assert.Equal(t, len(packets), summary.Packets)
+	assert.Equal(t, "Camera Service", summary.PlatformDesignation)
+	require.NotNil(t, summary.StartPosition)
+	assert.InDelta(t, 2.2945, summary.StartPosition.Longitude, 1e-6)
+}
+
+func TestKLVInjector_StopWithoutSessionRunsCallback(t *testing.T) {
+	ki := newTestKLVInjector(t, t.TempDir())
+
+	called := false
+	ki.StopSession("camera1", func() { called = true })
+	assert.True(t, called, "Recordings without a session are handed on immediately")
+}
+
+func TestAssignKLVPackets_SplitsAcrossSegments(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	first := filepath.Join(dir, "camera0_1.ts")
+	second := filepath.Join(dir, "camera0_2.ts")
+	writeAgedFile(t, first, 16, 0)
+	writeAgedFile(t, second, 16, 0)
+	require.NoError(t, os.Chtimes(first, base.Add(10*time.Second), base.Add(10*time.Second)))
+	require.NoError(t, os.Chtimes(second, base.Add(20*time.Second), base.Add(20*time.Second)))
+
+	var packets []*klv.Packet
+	for i := 0; i < 25; i++ {
+		packets = append(packets, &klv.Packet{Timestamp: base.Add(time.Duration(i) * time.Second)})
+	}
+
+	assigned := assignKLVPackets([]string{second, first}, packets)
+	assert.Len(t, assigned[first], 11)
+	assert.Len(t, assigned[second], 14, "Samples after the last segment closed go to the last segment")
+}
+
+func TestFilePositionSource_OverridesStaticPosition(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "positions.json")
+	positions := map[string]config.KLVPositionConfig{"camera0": {Latitude: 10, Longitude: 20}}
+	data, err := json.Marshal(positions)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	sources := positionSources{
+		&filePositionSource{path: path, logger: logging.GetLogger("mediamtx")},
+		staticPositionSource{"camera0": {Latitude: 1}, "camera1": {Latitude: 2}},
+	}
+
+	position, ok := sources.Position("camera0")
+	require.True(t, ok)
+	assert.Equal(t, 10.0, position.Latitude)
+	position, ok = sources.Position("camera1")
+	require.True(t, ok)
+	assert.Equal(t, 2.0, position.Latitude)
+	_, ok = sources.Position("camera2")
+	assert.False(t, ok)
+}
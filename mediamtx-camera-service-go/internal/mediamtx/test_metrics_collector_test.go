@@ -0,0 +1,64 @@
+/*
+MediaMTX Controller Prometheus Metrics Tests
+
+Requirements Coverage:
+- REQ-MTX-004: Health monitoring and system metrics
+- REQ-HEALTH-003: System metrics collection and reporting
+
+Test Categories: Unit
+API Documentation Reference: docs/api/health-endpoints.md
+*/
+
+package mediamtx
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renderFamilies renders metric families in the Prometheus text format
+func renderFamilies(t *testing.T, families []metrics.Family) string {
+	registry := metrics.NewRegistry()
+	registry.Register(metrics.CollectorFunc(func(ctx context.Context) []metrics.Family {
+		return families
+	}))
+	var out bytes.Buffer
+	require.NoError(t, registry.WriteText(context.Background(), &out))
+	return out.String()
+}
+
+func TestRTSPConnectionMetrics_ConvertsStatistics(t *testing.T) {
+	text := renderFamilies(t, rtspConnectionMetrics(map[string]interface{}{
+		"is_healthy":           true,
+		"total_connections":    2,
+		"total_bytes_received": int64(4096),
+		"session_states":       map[string]int{"publish": 1, "read": 3},
+		"last_check":           "ignored",
+	}))
+
+	assert.Contains(t, text, "camera_service_rtsp_healthy 1\n")
+	assert.Contains(t, text, "camera_service_rtsp_connections 2\n")
+	assert.Contains(t, text, "# TYPE camera_service_rtsp_received_bytes_total counter\n")
+	assert.Contains(t, text, "camera_service_rtsp_received_bytes_total 4096\n")
+	assert.Contains(t, text, `camera_service_rtsp_sessions_by_state{state="read"} 3`)
+	assert.NotContains(t, text, "camera_service_rtsp_sessions ", "Missing statistics are not reported")
+}
+
+func TestStreamMetrics_LabelsPerStream(t *testing.T) {
+	text := renderFamilies(t, streamMetrics(&GetStreamsResponse{
+		Streams: []StreamInfo{
+			{Name: "camera0", Status: "active", Viewers: 2, BytesSent: 1024},
+			{Name: "camera1", Status: "inactive"},
+		},
+	}))
+
+	assert.Contains(t, text, `camera_service_stream_active{stream="camera0"} 1`)
+	assert.Contains(t, text, `camera_service_stream_active{stream="camera1"} 0`)
+	assert.Contains(t, text, `camera_service_stream_viewers{stream="camera0"} 2`)
+	assert.Contains(t, text, `camera_service_stream_sent_bytes_total{stream="camera0"} 1024`)
+}
@@ -0,0 +1,293 @@
+/*
+MediaMTX Replication Manager Tests
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-007: Error handling and recovery
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeObjectStore is a minimal in-memory S3-compatible stand-in
+type fakeObjectStore struct {
+	mu          sync.Mutex
+	objects     map[string][]byte
+	uploads     map[string]map[int][]byte
+	partUploads map[int]int
+	failPart    int // Part number to reject once
+	nextID      int
+}
+
+func newFakeObjectStore() *fakeObjectStore {
+	return &fakeObjectStore{
+		objects:     make(map[string][]byte),
+		uploads:     make(map[string]map[int][]byte),
+		partUploads: make(map[int]int),
+	}
+}
+
+func (f *fakeObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), s3SigningAlgo+" Credential=test-key/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	key := r.URL.Path
+	query := r.URL.Query()
+	body, _ := io.ReadAll(r.Body)
+	uploadID := query.Get("uploadId")
+
+	switch {
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		f.nextID++
+		id := fmt.Sprintf("upload-%d", f.nextID)
+		f.uploads[id] = make(map[int][]byte)
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", id)
+	case r.Method == http.MethodPut && uploadID != "":
+		part, _ := strconv.Atoi(query.Get("partNumber"))
+		if part == f.failPart {
+			f.failPart = 0
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		f.uploads[uploadID][part] = body
+		f.partUploads[part]++
+		w.Header().Set("ETag", fmt.Sprintf("\"%x\"", md5.Sum(body)))
+	case r.Method == http.MethodGet && uploadID != "":
+		parts, exists := f.uploads[uploadID]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "<Error><Code>NoSuchUpload</Code></Error>")
+			return
+		}
+		numbers := make([]int, 0, len(parts))
+		for number := range parts {
+			numbers = append(numbers, number)
+		}
+		sort.Ints(numbers)
+		fmt.Fprint(w, "<ListPartsResult>")
+		for _, number := range numbers {
+			fmt.Fprintf(w, "<Part><PartNumber>%d</PartNumber><ETag>\"%x\"</ETag><Size>%d</Size></Part>",
+				number, md5.Sum(parts[number]), len(parts[number]))
+		}
+		fmt.Fprint(w, "</ListPartsResult>")
+	case r.Method == http.MethodPost && uploadID != "":
+		var request struct {
+			Parts []struct {
+				PartNumber int `xml:"PartNumber"`
+			} `xml:"Part"`
+		}
+		xml.Unmarshal(body, &request)
+		var object bytes.Buffer
+		for _, part := range request.Parts {
+			object.Write(f.uploads[uploadID][part.PartNumber])
+		}
+		f.objects[key] = object.Bytes()
+		delete(f.uploads, uploadID)
+		fmt.Fprint(w, "<CompleteMultipartUploadResult><ETag>\"done\"</ETag></CompleteMultipartUploadResult>")
+	case r.Method == http.MethodPut:
+		f.objects[key] = body
+		w.Header().Set("ETag", "\"single\"")
+	case r.Method == http.MethodHead:
+		object, exists := f.objects[key]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(object)))
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+// newTestReplicationManager creates a manager wired to the fake store
+func newTestReplicationManager(t *testing.T, serverURL, dir string) *ReplicationManager {
+	cfg := &config.Config{}
+	cfg.MediaMTX.RecordingsPath = filepath.Join(dir, "recordings")
+	cfg.MediaMTX.SnapshotsPath = filepath.Join(dir, "snapshots")
+	cfg.Replication = config.ReplicationConfig{
+		Enabled:          true,
+		Endpoint:         serverURL,
+		Bucket:           "footage",
+		Prefix:           "edge-01",
+		AccessKey:        "test-key",
+		SecretKey:        "test-secret",
+		PartSizeMB:       1,
+		QueuePath:        filepath.Join(dir, "queue.json"),
+		MaxAttempts:      5,
+		IncludeSnapshots: true,
+		DeleteLocalAfter: true,
+	}
+
+	rm := NewReplicationManager(cfg, logging.GetLogger("mediamtx"))
+	client, err := NewS3Client(&cfg.Replication)
+	require.NoError(t, err)
+	rm.client = client
+	require.NoError(t, rm.loadQueue())
+	return rm
+}
+
+func TestReplicationManager_ResumesMultipartUploadAfterRestart(t *testing.T) {
+	store := newFakeObjectStore()
+	store.failPart = 2
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	dir := t.TempDir()
+	recording := filepath.Join(dir, "recordings", "camera0", "2025-01-01_10-00-00.mp4")
+	content := bytes.Repeat([]byte("0123456789abcdef"), 160*1024) // 2.5 MB, 3 parts
+	writeAgedFile(t, recording, 0, time.Hour)
+	require.NoError(t, os.WriteFile(recording, content, 0644))
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(recording, old, old))
+
+	// First run fails on part 2 and leaves a resumable upload in the persisted queue
+	rm := newTestReplicationManager(t, server.URL, dir)
+	rm.scan(time.Now())
+	rm.processQueue(context.Background(), make(chan struct{}))
+
+	status := rm.StatusFor(recording)
+	require.NotNil(t, status)
+	assert.Equal(t, ReplicationStatusPending, status.Status)
+	assert.Equal(t, 1, status.Attempts)
+	assert.Equal(t, int64(1024*1024), status.BytesUploaded)
+
+	// Simulated restart: a new manager resumes from the queue file
+	restarted := newTestReplicationManager(t, server.URL, dir)
+	for _, entry := range restarted.entries {
+		entry.NextAttemptAt = time.Time{}
+	}
+	restarted.scan(time.Now())
+	restarted.processQueue(context.Background(), make(chan struct{}))
+
+	status = restarted.StatusFor(recording)
+	require.NotNil(t, status)
+	assert.Equal(t, ReplicationStatusUploaded, status.Status)
+	assert.True(t, status.LocalDeleted)
+	assert.Equal(t, "edge-01/recordings/camera0/2025-01-01_10-00-00.mp4", status.ObjectKey)
+	assert.NoFileExists(t, recording, "Local copy is deleted after a confirmed upload")
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	assert.Equal(t, content, store.objects["/footage/edge-01/recordings/camera0/2025-01-01_10-00-00.mp4"])
+	assert.Equal(t, 1, store.partUploads[1], "Part 1 is not uploaded again after restart")
+}
+
+func TestReplicationManager_SkipsLockedAndUploadsSnapshots(t *testing.T) {
+	store := newFakeObjectStore()
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	dir := t.TempDir()
+	snapshot := filepath.Join(dir, "snapshots", "camera0_1.jpg")
+	writing := filepath.Join(dir, "recordings", "camera0_active.mp4")
+	writeAgedFile(t, snapshot, 2048, time.Hour)
+	writeAgedFile(t, writing, 2048, 0)
+
+	rm := newTestReplicationManager(t, server.URL, dir)
+	rm.config.Replication.DeleteLocalAfter = false
+	rm.scan(time.Now())
+	rm.processQueue(context.Background(), make(chan struct{}))
+
+	assert.Nil(t, rm.StatusFor(writing), "Files still being written are not queued")
+	status := rm.StatusFor(snapshot)
+	require.NotNil(t, status)
+	assert.Equal(t, ReplicationStatusUploaded, status.Status)
+	assert.False(t, status.LocalDeleted)
+	assert.FileExists(t, snapshot)
+	assert.FileExists(t, rm.config.Replication.QueuePath)
+}
+
+func TestReplicationManager_RequeuesFileRewrittenAfterQueueing(t *testing.T) {
+	store := newFakeObjectStore()
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	dir := t.TempDir()
+	recording := filepath.Join(dir, "recordings", "camera0_rewritten.mp4")
+	writeAgedFile(t, recording, 2048, time.Hour)
+
+	rm := newTestReplicationManager(t, server.URL, dir)
+	rm.scan(time.Now())
+
+	// Rewritten in place (e.g. encrypted at rest) between queueing and upload
+	rewritten := bytes.Repeat([]byte("e"), 4096)
+	require.NoError(t, os.WriteFile(recording, rewritten, 0644))
+	old := time.Now().Add(-30 * time.Minute)
+	require.NoError(t, os.Chtimes(recording, old, old))
+
+	rm.processQueue(context.Background(), make(chan struct{}))
+
+	status := rm.StatusFor(recording)
+	require.NotNil(t, status)
+	assert.Equal(t, ReplicationStatusPending, status.Status, "Changed file is requeued, not marked uploaded")
+	assert.Equal(t, 0, status.Attempts, "A requeue does not count as a failed attempt")
+	assert.Equal(t, int64(len(rewritten)), status.FileSize)
+	assert.FileExists(t, recording, "The only complete copy is never deleted")
+	store.mu.Lock()
+	assert.Empty(t, store.objects, "Nothing is uploaded from a changed file")
+	store.mu.Unlock()
+
+	// The next pass uploads the new content and only then deletes it
+	rm.scan(time.Now())
+	rm.processQueue(context.Background(), make(chan struct{}))
+
+	status = rm.StatusFor(recording)
+	require.NotNil(t, status)
+	assert.Equal(t, ReplicationStatusUploaded, status.Status)
+	assert.NoFileExists(t, recording)
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	assert.Equal(t, rewritten, store.objects["/footage/edge-01/recordings/camera0_rewritten.mp4"])
+}
+
+func TestCheckReplicationSource(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "clip.mp4")
+	writeAgedFile(t, file, 100, time.Hour)
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+
+	entry := &ReplicationEntry{Path: file, Size: info.Size(), ModTime: info.ModTime()}
+	stat := func() (os.FileInfo, error) { return os.Stat(file) }
+	assert.NoError(t, checkReplicationSource(entry, stat))
+
+	require.NoError(t, os.Truncate(file, 10))
+	require.NoError(t, os.Chtimes(file, info.ModTime(), info.ModTime()))
+	assert.ErrorIs(t, checkReplicationSource(entry, stat), errReplicationSourceChanged, "Truncation is detected even with the same mtime")
+
+	require.NoError(t, os.Truncate(file, 100))
+	later := info.ModTime().Add(time.Second)
+	require.NoError(t, os.Chtimes(file, later, later))
+	assert.ErrorIs(t, checkReplicationSource(entry, stat), errReplicationSourceChanged, "A newer mtime is detected even with the same size")
+}
@@ -0,0 +1,162 @@
+/*
+MediaMTX Retention Janitor Tests
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-008: Logging and monitoring
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRetentionJanitor creates a janitor over temporary recordings and snapshots directories
+func newTestRetentionJanitor(t *testing.T) (*RetentionJanitor, *config.Config) {
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.MediaMTX.RecordingsPath = filepath.Join(dir, "recordings")
+	cfg.MediaMTX.SnapshotsPath = filepath.Join(dir, "snapshots")
+	cfg.RetentionPolicy.Enabled = true
+	require.NoError(t, os.MkdirAll(cfg.MediaMTX.RecordingsPath, 0755))
+	require.NoError(t, os.MkdirAll(cfg.MediaMTX.SnapshotsPath, 0755))
+	return NewRetentionJanitor(cfg, logging.GetLogger("mediamtx")), cfg
+}
+
+// writeAgedFile creates a file of the given size last modified age ago
+func writeAgedFile(t *testing.T, path string, size int, age time.Duration) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0644))
+	modTime := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestRetentionDevice(t *testing.T) {
+	assert.Equal(t, "camera0", retentionDevice("/rec", "/rec/camera0/2025-01-01_10-00-00.mp4"))
+	assert.Equal(t, "camera1", retentionDevice("/rec", "/rec/camera1_2025-01-01_10-00-00.mp4"))
+	assert.Equal(t, "camera2", retentionDevice("/snap", "/snap/camera2.jpg"))
+}
+
+func TestIsInternalStorageFile(t *testing.T) {
+	assert.True(t, isInternalStorageFile("/recordings/camera0/clip.mp4.lock"))
+	assert.True(t, isInternalStorageFile("/archive/camera0/clip.mp4.partial"))
+	assert.True(t, isInternalStorageFile("/recordings/uav/uav_2025-01-01_10-00-00"+TelemetryFileSuffix))
+	assert.False(t, isInternalStorageFile("/recordings/camera0/clip.mp4"))
+	assert.False(t, isInternalStorageFile("/snapshots/camera0_1.jpg"))
+}
+
+func TestRetentionJanitor_CameraAndGroupRules(t *testing.T) {
+	janitor, cfg := newTestRetentionJanitor(t)
+	day := 24 * time.Hour
+	rec := cfg.MediaMTX.RecordingsPath
+
+	// gate cameras keep 30 days, camera0 has its own 10 day rule despite being in the group
+	cfg.RetentionPolicy.Groups = map[string]config.RetentionRuleConfig{
+		"gate": {Cameras: []string{"camera0", "camera1"}, MaxAgeDays: 30},
+	}
+	cfg.RetentionPolicy.Cameras = map[string]config.RetentionRuleConfig{
+		"camera0": {MaxAgeDays: 10},
+	}
+
+	writeAgedFile(t, filepath.Join(rec, "camera0", "old.mp4"), 10, 20*day)
+	writeAgedFile(t, filepath.Join(rec, "camera0", "new.mp4"), 10, 5*day)
+	writeAgedFile(t, filepath.Join(rec, "camera1", "old.mp4"), 10, 40*day)
+	writeAgedFile(t, filepath.Join(rec, "camera1", "mid.mp4"), 10, 20*day)
+	writeAgedFile(t, filepath.Join(rec, "camera2", "ancient.mp4"), 10, 400*day)
+
+	// Dry run reports without deleting
+	result, err := janitor.Run(context.Background(), false, true)
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	require.Len(t, result.Files, 2)
+	assert.FileExists(t, filepath.Join(rec, "camera0", "old.mp4"))
+
+	rules := map[string]string{}
+	for _, file := range result.Files {
+		rules[file.Device] = file.Rule
+		assert.Equal(t, "max_age", file.Reason)
+	}
+	assert.Equal(t, map[string]string{"camera0": "camera:camera0", "camera1": "group:gate"}, rules)
+
+	result, err = janitor.Run(context.Background(), false, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.RecordingsRemoved)
+	assert.Equal(t, int64(20), result.SpaceFreed)
+	assert.NoFileExists(t, filepath.Join(rec, "camera0", "old.mp4"))
+	assert.NoFileExists(t, filepath.Join(rec, "camera1", "old.mp4"))
+	assert.FileExists(t, filepath.Join(rec, "camera1", "mid.mp4"))
+	assert.FileExists(t, filepath.Join(rec, "camera2", "ancient.mp4"), "Cameras without a rule are left to the global policy")
+}
+
+func TestRetentionJanitor_QuotaSkipsLockedFiles(t *testing.T) {
+	janitor, cfg := newTestRetentionJanitor(t)
+	rec := cfg.MediaMTX.RecordingsPath
+	snap := cfg.MediaMTX.SnapshotsPath
+	const mb = 1024 * 1024
+
+	// Fractional GB quota of 3 MB for the lab camera
+	cfg.RetentionPolicy.Cameras = map[string]config.RetentionRuleConfig{
+		"lab": {MaxSizeGB: 3.0 / 1024},
+	}
+
+	writeAgedFile(t, filepath.Join(rec, "lab", "a.mp4"), 2*mb, 4*time.Hour)
+	writeAgedFile(t, filepath.Join(rec, "lab", "b.mp4"), 2*mb, 3*time.Hour)
+	writeAgedFile(t, filepath.Join(snap, "lab_1.jpg"), mb, 2*time.Hour)
+	writeAgedFile(t, filepath.Join(rec, "lab", "writing.mp4"), mb, 0)
+	require.NoError(t, os.WriteFile(filepath.Join(rec, "lab", "a.mp4"+fileLockSuffix), nil, 0644))
+
+	result, err := janitor.Run(context.Background(), false, false)
+	require.NoError(t, err)
+
+	// 6 MB total: a.mp4 is locked, so b.mp4 and the snapshot go to reach 3 MB
+	assert.Equal(t, 1, result.RecordingsRemoved)
+	assert.Equal(t, 1, result.SnapshotsRemoved)
+	assert.Equal(t, 1, result.LockedSkipped)
+	assert.FileExists(t, filepath.Join(rec, "lab", "a.mp4"))
+	assert.FileExists(t, filepath.Join(rec, "lab", "writing.mp4"))
+	assert.NoFileExists(t, filepath.Join(rec, "lab", "b.mp4"))
+	assert.NoFileExists(t, filepath.Join(snap, "lab_1.jpg"))
+	for _, file := range result.Files {
+		assert.Equal(t, "quota", file.Reason)
+	}
+
+	breakdown, err := janitor.CameraBreakdown()
+	require.NoError(t, err)
+	require.Len(t, breakdown, 1)
+	assert.Equal(t, "lab", breakdown[0].Device)
+	assert.Equal(t, int64(3*mb), breakdown[0].TotalSize)
+	assert.Equal(t, 2, breakdown[0].LockedFiles)
+	assert.Equal(t, []string{"camera:lab"}, breakdown[0].RetentionRules)
+}
+
+func TestRetentionJanitor_GlobalPolicy(t *testing.T) {
+	janitor, cfg := newTestRetentionJanitor(t)
+	cfg.RetentionPolicy.MaxAgeDays = 7
+
+	writeAgedFile(t, filepath.Join(cfg.MediaMTX.RecordingsPath, "camera0", "old.mp4"), 10, 8*24*time.Hour)
+	writeAgedFile(t, filepath.Join(cfg.MediaMTX.SnapshotsPath, "camera0_old.jpg"), 10, 8*24*time.Hour)
+	writeAgedFile(t, filepath.Join(cfg.MediaMTX.SnapshotsPath, "camera0_new.jpg"), 10, time.Hour)
+
+	result, err := janitor.Run(context.Background(), false, false)
+	require.NoError(t, err)
+	assert.Empty(t, result.Files, "Global policy only applies when requested")
+
+	result, err = janitor.Run(context.Background(), true, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.RecordingsRemoved)
+	assert.Equal(t, 1, result.SnapshotsRemoved)
+	assert.FileExists(t, filepath.Join(cfg.MediaMTX.SnapshotsPath, "camera0_new.jpg"))
+}
@@ -0,0 +1,182 @@
+/*
+MediaMTX Storage Encryption Tests
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-007: Error handling and recovery
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStorageEncryption creates an encryption manager with loaded keys rooted in dir
+func newTestStorageEncryption(t *testing.T, dir string, configure func(*config.EncryptionConfig)) *StorageEncryption {
+	cfg := &config.Config{}
+	cfg.MediaMTX.RecordingsPath = filepath.Join(dir, "recordings")
+	cfg.MediaMTX.SnapshotsPath = filepath.Join(dir, "snapshots")
+	cfg.Storage.Encryption = config.EncryptionConfig{
+		Enabled:      true,
+		KeySource:    EncryptionKeySourceKeyfile,
+		KeyfilePath:  filepath.Join(dir, "keys", "storage.key"),
+		KeyStorePath: filepath.Join(dir, "keys", "storage-keys.json"),
+		ChunkSizeKB:  1,
+	}
+	if configure != nil {
+		configure(&cfg.Storage.Encryption)
+	}
+
+	se := NewStorageEncryption(cfg, logging.GetLogger("mediamtx"))
+	require.NoError(t, se.loadKeys())
+	return se
+}
+
+// writeAgedContent writes content to path and backdates it past the active write window
+func writeAgedContent(t *testing.T, path string, content []byte) time.Time {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, content, 0644))
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	return modTime
+}
+
+func TestStorageEncryption_EncryptsAndDecryptsOnTheFly(t *testing.T) {
+	dir := t.TempDir()
+	se := newTestStorageEncryption(t, dir, nil)
+
+	content := bytes.Repeat([]byte("0123456789abcdef"), 300) // 4800 bytes, 5 chunks
+	recording := filepath.Join(dir, "recordings", "camera0", "camera0_2025-01-01_10-00-00.mp4")
+	modTime := writeAgedContent(t, recording, content)
+	active := filepath.Join(dir, "snapshots", "camera0_active.jpg")
+	writeAgedFile(t, active, 256, 0)
+
+	encrypted, err := se.RunOnce(context.Background(), make(chan struct{}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, encrypted, "Files still being written are not encrypted")
+
+	isEncrypted, err := IsEncryptedFile(recording)
+	require.NoError(t, err)
+	assert.True(t, isEncrypted)
+	raw, err := os.ReadFile(recording)
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(raw, content[:64]), "Plaintext must not remain on disk")
+	info, err := os.Stat(recording)
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(modTime), "Modification time is preserved")
+
+	// Seekable decryption across chunk boundaries
+	reader, size, err := se.Open(recording)
+	require.NoError(t, err)
+	defer reader.Close()
+	assert.Equal(t, int64(len(content)), size)
+	_, err = reader.Seek(1000, io.SeekStart)
+	require.NoError(t, err)
+	part := make([]byte, 100)
+	_, err = io.ReadFull(reader, part)
+	require.NoError(t, err)
+	assert.Equal(t, content[1000:1100], part)
+
+	// Metadata hashing sees the plaintext
+	metadata := NewMetadataManager(nil, nil, logging.GetLogger("mediamtx"))
+	metadata.SetStorageEncryption(se)
+	digest, err := metadata.ComputeFileHash(context.Background(), recording)
+	require.NoError(t, err)
+	sum := sha256.Sum256(content)
+	assert.Equal(t, hex.EncodeToString(sum[:]), digest)
+	fileSize, err := metadata.GetFileSize(recording)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), fileSize)
+
+	// File serving decrypts and honors range requests
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		se.ServeFile(w, r, recording)
+	}))
+	defer server.Close()
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	request.Header.Set("Range", "bytes=4090-4109")
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, response.StatusCode)
+	assert.Equal(t, content[4090:4110], body)
+}
+
+func TestStorageEncryption_DetectsTampering(t *testing.T) {
+	dir := t.TempDir()
+	se := newTestStorageEncryption(t, dir, nil)
+
+	snapshot := filepath.Join(dir, "snapshots", "camera0_1.jpg")
+	writeAgedContent(t, snapshot, bytes.Repeat([]byte{0xAB}, 2048))
+	require.NoError(t, se.EncryptFile(context.Background(), snapshot))
+
+	raw, err := os.ReadFile(snapshot)
+	require.NoError(t, err)
+	raw[encryptionHeaderLen+10] ^= 0xFF
+	require.NoError(t, os.WriteFile(snapshot, raw, 0644))
+
+	reader, _, err := se.Open(snapshot)
+	require.NoError(t, err)
+	defer reader.Close()
+	_, err = io.ReadAll(reader)
+	assert.Error(t, err)
+}
+
+func TestStorageEncryption_RotatesKeyWithPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	passphrase := func(cfg *config.EncryptionConfig) {
+		cfg.KeySource = EncryptionKeySourcePassphrase
+		cfg.Passphrase = "correct horse battery staple"
+	}
+	se := newTestStorageEncryption(t, dir, passphrase)
+
+	content := []byte("sensitive footage")
+	recording := filepath.Join(dir, "recordings", "camera0_1.mp4")
+	writeAgedContent(t, recording, content)
+	require.NoError(t, se.EncryptFile(context.Background(), recording))
+
+	response, err := se.RotateKey(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), response.PreviousKeyID)
+	assert.Equal(t, uint32(2), response.KeyID)
+	assert.Equal(t, 1, response.FilesRewrapped)
+	assert.Equal(t, 0, response.FilesFailed)
+	assert.True(t, response.RetiredKeys)
+
+	// A restarted manager only holds the new key and still decrypts the file
+	restarted := newTestStorageEncryption(t, dir, passphrase)
+	assert.Len(t, restarted.keys, 1)
+	reader, _, err := restarted.Open(recording)
+	require.NoError(t, err)
+	defer reader.Close()
+	decrypted, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, content, decrypted)
+
+	// A wrong passphrase cannot open the key store
+	wrong := NewStorageEncryption(restarted.config, logging.GetLogger("mediamtx"))
+	wrong.config.Storage.Encryption.Passphrase = "wrong passphrase entirely"
+	assert.Error(t, wrong.loadKeys())
+}
@@ -0,0 +1,248 @@
+/*
+MediaMTX Storage Guard Tests
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-007: Error handling and recovery
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRecordPathMigrator records record path migrations without MediaMTX
+type fakeRecordPathMigrator struct {
+	active     []string
+	migrations map[string]string
+}
+
+func (f *fakeRecordPathMigrator) ActiveRecordingCameras() []string {
+	return f.active
+}
+
+func (f *fakeRecordPathMigrator) MigrateRecordPath(ctx context.Context, cameraID, basePath string) error {
+	f.migrations[cameraID] = basePath
+	return nil
+}
+
+// newTestStorageGuard creates a guard whose usage per path comes from the usage map
+func newTestStorageGuard(t *testing.T, usage map[string]float64) (*StorageGuard, *config.Config) {
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.MediaMTX.RecordingsPath = filepath.Join(dir, "recordings")
+	cfg.MediaMTX.SnapshotsPath = filepath.Join(dir, "snapshots")
+	cfg.Storage.WarnPercent = 80
+	cfg.Storage.BlockPercent = 90
+	cfg.Storage.FallbackPath = filepath.Join(dir, "fallback")
+
+	guard := NewStorageGuard(cfg, logging.GetLogger("mediamtx"))
+	guard.statfs = func(path string) (uint64, uint64, error) {
+		for prefix, percent := range usage {
+			if strings.HasPrefix(path, prefix) {
+				return 1000, uint64(1000 - percent*10), nil
+			}
+		}
+		return 1000, 1000, nil
+	}
+	return guard, cfg
+}
+
+func TestStorageGuard_ResolveCapturePath(t *testing.T) {
+	usage := map[string]float64{}
+	guard, cfg := newTestStorageGuard(t, usage)
+
+	path, err := guard.ResolveRecordingsPath()
+	require.NoError(t, err)
+	assert.Equal(t, cfg.MediaMTX.RecordingsPath, path, "Primary path should be used below the block threshold")
+
+	usage[cfg.MediaMTX.RecordingsPath] = 95
+	usage[cfg.MediaMTX.SnapshotsPath] = 95
+	path, err = guard.ResolveRecordingsPath()
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Storage.FallbackPath, path, "Recordings should redirect to the fallback path")
+
+	path, err = guard.ResolveSnapshotsPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cfg.Storage.FallbackPath, fallbackSnapshotsDir), path)
+
+	usage[cfg.Storage.FallbackPath] = 92
+	_, err = guard.ResolveRecordingsPath()
+	var storageErr *StorageError
+	require.True(t, errors.As(err, &storageErr), "Expected StorageError when fallback is also blocked, got %v", err)
+	assert.Equal(t, cfg.MediaMTX.RecordingsPath, storageErr.Path)
+	assert.Equal(t, 90, storageErr.BlockPercent)
+	assert.InDelta(t, 95.0, storageErr.UsagePercent, 0.01)
+
+	cfg.Storage.FallbackPath = ""
+	_, err = guard.ResolveRecordingsPath()
+	assert.True(t, errors.As(err, &storageErr), "Expected StorageError without a fallback path")
+}
+
+func TestStorageGuard_CheckMigratesAndRestoresRecordings(t *testing.T) {
+	usage := map[string]float64{}
+	guard, cfg := newTestStorageGuard(t, usage)
+	recorder := &fakeRecordPathMigrator{active: []string{"camera0"}, migrations: map[string]string{}}
+	guard.SetDependencies(recorder, nil)
+
+	usage[cfg.MediaMTX.RecordingsPath] = 95
+	guard.Check(context.Background())
+	assert.Equal(t, cfg.Storage.FallbackPath, recorder.migrations["camera0"], "Active recording should move to the fallback")
+
+	// Still blocked - recording is not migrated again
+	delete(recorder.migrations, "camera0")
+	guard.Check(context.Background())
+	assert.NotContains(t, recorder.migrations, "camera0")
+
+	// Between warn and block - stays on the fallback
+	usage[cfg.MediaMTX.RecordingsPath] = 85
+	guard.Check(context.Background())
+	assert.NotContains(t, recorder.migrations, "camera0")
+
+	usage[cfg.MediaMTX.RecordingsPath] = 50
+	guard.Check(context.Background())
+	assert.Equal(t, cfg.MediaMTX.RecordingsPath, recorder.migrations["camera0"], "Recording should move back once storage recovers")
+}
+
+func TestStorageGuard_EmergencyCleanupDeletesOldestFirst(t *testing.T) {
+	guard, cfg := newTestStorageGuard(t, nil)
+	require.NoError(t, os.MkdirAll(filepath.Join(cfg.MediaMTX.RecordingsPath, "camera0"), 0755))
+	require.NoError(t, os.MkdirAll(cfg.MediaMTX.SnapshotsPath, 0755))
+
+	files := []string{
+		filepath.Join(cfg.MediaMTX.RecordingsPath, "camera0", "oldest.mp4"),
+		filepath.Join(cfg.MediaMTX.SnapshotsPath, "older.jpg"),
+		filepath.Join(cfg.MediaMTX.RecordingsPath, "camera0", "old.mp4"),
+		filepath.Join(cfg.MediaMTX.RecordingsPath, "camera0", "writing.mp4"),
+	}
+	for i, file := range files {
+		require.NoError(t, os.WriteFile(file, []byte("data"), 0644))
+		modTime := time.Now().Add(-time.Duration(len(files)-i) * time.Hour)
+		if i == len(files)-1 {
+			modTime = time.Now() // still being written
+		}
+		require.NoError(t, os.Chtimes(file, modTime, modTime))
+	}
+
+	// Every remaining file accounts for 5% usage on top of a 68% baseline
+	guard.statfs = func(path string) (uint64, uint64, error) {
+		remaining := 0
+		for _, file := range files {
+			if _, err := os.Stat(file); err == nil {
+				remaining++
+			}
+		}
+		used := 680 + uint64(remaining)*50
+		return 1000, 1000 - used, nil
+	}
+
+	deleted, freed, err := guard.EmergencyCleanup(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted, "Cleanup should stop once usage is below the warning threshold")
+	assert.Equal(t, int64(8), freed)
+
+	assert.NoFileExists(t, files[0])
+	assert.NoFileExists(t, files[1])
+	assert.FileExists(t, files[2])
+	assert.FileExists(t, files[3])
+}
+
+func TestStorageGuard_EmergencyCleanupKeepsUnreplicatedAndNonMediaFiles(t *testing.T) {
+	guard, cfg := newTestStorageGuard(t, nil)
+	cfg.Replication = config.ReplicationConfig{Enabled: true, IncludeSnapshots: true}
+
+	notes := filepath.Join(cfg.MediaMTX.RecordingsPath, "camera0", "notes.txt")
+	pending := filepath.Join(cfg.MediaMTX.RecordingsPath, "camera0", "pending.mp4")
+	uploaded := filepath.Join(cfg.MediaMTX.RecordingsPath, "camera0", "uploaded.mp4")
+	snapshot := filepath.Join(cfg.MediaMTX.SnapshotsPath, "camera0_1.JPG")
+	writeAgedFile(t, notes, 4, 4*time.Hour)
+	writeAgedFile(t, pending, 4, 3*time.Hour)
+	writeAgedFile(t, uploaded, 4, time.Hour)
+	writeAgedFile(t, snapshot, 4, 2*time.Hour)
+
+	replication := NewReplicationManager(cfg, logging.GetLogger("mediamtx"))
+	for _, file := range []string{uploaded, snapshot} {
+		key, _, ok := replication.objectKey(file)
+		require.True(t, ok)
+		replication.entries[key] = &ReplicationEntry{Key: key, Path: file, Status: ReplicationStatusUploaded}
+	}
+	guard.SetReplicationManager(replication)
+
+	assert.True(t, replication.AwaitingUpload(pending), "Files not queued yet count as awaiting upload")
+	assert.False(t, replication.AwaitingUpload(uploaded))
+
+	files := []string{notes, pending, uploaded, snapshot}
+	used := func() uint64 {
+		remaining := 0
+		for _, file := range files {
+			if _, err := os.Stat(file); err == nil {
+				remaining++
+			}
+		}
+		return 680 + uint64(remaining)*50
+	}
+	guard.statfs = func(path string) (uint64, uint64, error) {
+		return 1000, 1000 - used(), nil
+	}
+
+	// Two deletions bring usage below 80%: both replicated files go first
+	// even though the unreplicated recording is older
+	deleted, _, err := guard.EmergencyCleanup(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.NoFileExists(t, snapshot)
+	assert.NoFileExists(t, uploaded)
+	assert.FileExists(t, pending)
+	assert.FileExists(t, notes)
+
+	// Still over the threshold: the unreplicated recording goes, other files never do
+	guard.statfs = func(path string) (uint64, uint64, error) {
+		return 1000, 1000 - used() - 100, nil
+	}
+	deleted, _, err = guard.EmergencyCleanup(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.NoFileExists(t, pending)
+	assert.FileExists(t, notes, "Files that are not recordings or snapshots are never deleted")
+}
+
+func TestStorageGuard_EmergencyCleanupSkipsSnapshotsOnOtherFileSystem(t *testing.T) {
+	guard, cfg := newTestStorageGuard(t, nil)
+	guard.device = func(path string) (uint64, error) {
+		if strings.HasPrefix(path, cfg.MediaMTX.SnapshotsPath) {
+			return 2, nil
+		}
+		return 1, nil
+	}
+
+	recording := filepath.Join(cfg.MediaMTX.RecordingsPath, "camera0", "old.mp4")
+	snapshot := filepath.Join(cfg.MediaMTX.SnapshotsPath, "camera0_1.jpg")
+	writeAgedFile(t, snapshot, 4, 2*time.Hour)
+	writeAgedFile(t, recording, 4, time.Hour)
+
+	// Recordings stay over the threshold whatever happens to the snapshots
+	guard.statfs = func(path string) (uint64, uint64, error) {
+		return 1000, 50, nil
+	}
+
+	deleted, _, err := guard.EmergencyCleanup(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.NoFileExists(t, recording)
+	assert.FileExists(t, snapshot, "Snapshots on another file system free no recordings space")
+}
@@ -0,0 +1,97 @@
+/*
+MediaMTX Storage Tiering Tests
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-007: Error handling and recovery
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTierManager creates a tier manager over temporary primary and archive directories
+func newTestTierManager(t *testing.T) (*StorageTierManager, *config.Config) {
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.MediaMTX.RecordingsPath = filepath.Join(dir, "recordings")
+	cfg.Storage.Tiering = config.TieringConfig{
+		Enabled:            true,
+		ArchivePath:        filepath.Join(dir, "archive"),
+		MoveAfterHours:     24,
+		MaxConcurrentMoves: 2,
+		IOLimitMBps:        64,
+	}
+	require.NoError(t, os.MkdirAll(cfg.MediaMTX.RecordingsPath, 0755))
+	return NewStorageTierManager(cfg, logging.GetLogger("mediamtx")), cfg
+}
+
+func TestStorageTierManager_MoveToArchiveVerifiesAndPreservesName(t *testing.T) {
+	tm, cfg := newTestTierManager(t)
+	source := filepath.Join(cfg.MediaMTX.RecordingsPath, "camera0", "2025-01-01_10-00-00.mp4")
+	writeAgedFile(t, source, 512*1024, 48*time.Hour)
+	before, err := os.Stat(source)
+	require.NoError(t, err)
+
+	require.NoError(t, tm.MoveToArchive(context.Background(), source))
+
+	archived := filepath.Join(cfg.Storage.Tiering.ArchivePath, "camera0", "2025-01-01_10-00-00.mp4")
+	assert.NoFileExists(t, source)
+	assert.NoFileExists(t, archived+archivePartialSuffix)
+	info, err := os.Stat(archived)
+	require.NoError(t, err)
+	assert.Equal(t, before.Size(), info.Size())
+	assert.True(t, before.ModTime().Equal(info.ModTime()), "Archived copy keeps the original modification time")
+
+	path, _, tier, err := tm.ResolveRecordingFile("camera0/2025-01-01_10-00-00.mp4")
+	require.NoError(t, err)
+	assert.Equal(t, archived, path)
+	assert.Equal(t, StorageTierArchive, tier)
+
+	files, err := tm.ListArchivedRecordings()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "/files/recordings/camera0/2025-01-01_10-00-00.mp4", files[0].DownloadURL)
+}
+
+func TestStorageTierManager_RunOnceSkipsRecentAndLocked(t *testing.T) {
+	tm, cfg := newTestTierManager(t)
+	rec := cfg.MediaMTX.RecordingsPath
+	writeAgedFile(t, filepath.Join(rec, "camera0_old.mp4"), 1024, 48*time.Hour)
+	writeAgedFile(t, filepath.Join(rec, "camera0_locked.mp4"), 1024, 48*time.Hour)
+	writeAgedFile(t, filepath.Join(rec, "camera0_recent.mp4"), 1024, time.Hour)
+	require.NoError(t, os.WriteFile(filepath.Join(rec, "camera0_locked.mp4"+fileLockSuffix), nil, 0644))
+
+	require.NoError(t, tm.pool.Start(context.Background()))
+	defer tm.pool.Stop(context.Background())
+
+	submitted, err := tm.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, submitted)
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(cfg.Storage.Tiering.ArchivePath, "camera0_old.mp4"))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.FileExists(t, filepath.Join(rec, "camera0_locked.mp4"))
+	assert.FileExists(t, filepath.Join(rec, "camera0_recent.mp4"))
+
+	path, _, tier, err := tm.ResolveRecordingFile("camera0_recent.mp4")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(rec, "camera0_recent.mp4"), path)
+	assert.Equal(t, StorageTierPrimary, tier)
+}
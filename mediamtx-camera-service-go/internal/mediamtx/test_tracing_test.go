@@ -0,0 +1,137 @@
+/*
+MediaMTX Request Tracing Tests
+
+Requirements Coverage:
+- REQ-HEALTH-003: System metrics collection and reporting
+
+Test Categories: Unit
+API Documentation Reference: docs/architecture/tracing.md
+*/
+
+package mediamtx
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type exportedSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Status       struct {
+		Code int `json:"code"`
+	} `json:"status"`
+}
+
+// installFileTracer installs a global tracer exporting to a temporary file and
+// returns a function that flushes it and returns the exported spans by name
+func installFileTracer(t *testing.T) func() map[string]exportedSpan {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	tracer, err := tracing.NewTracer(&config.TracingConfig{
+		Enabled:     true,
+		Exporter:    tracing.ExporterFile,
+		FilePath:    path,
+		SampleRatio: 1,
+	}, logging.GetLogger("tracing"))
+	require.NoError(t, err)
+	require.NoError(t, tracer.Start(context.Background()))
+	tracing.SetTracer(tracer)
+	t.Cleanup(func() { tracing.SetTracer(nil) })
+
+	return func() map[string]exportedSpan {
+		tracing.SetTracer(nil)
+		require.NoError(t, tracer.Stop(context.Background()))
+
+		file, err := os.Open(path)
+		require.NoError(t, err)
+		defer file.Close()
+
+		spans := make(map[string]exportedSpan)
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var request struct {
+				ResourceSpans []struct {
+					ScopeSpans []struct {
+						Spans []exportedSpan `json:"spans"`
+					} `json:"scopeSpans"`
+				} `json:"resourceSpans"`
+			}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &request))
+			for _, resource := range request.ResourceSpans {
+				for _, scope := range resource.ScopeSpans {
+					for _, span := range scope.Spans {
+						spans[span.Name] = span
+					}
+				}
+			}
+		}
+		return spans
+	}
+}
+
+func TestSnapshotManager_CaptureTierCreatesChildSpans(t *testing.T) {
+	collect := installFileTracer(t)
+
+	sm := &SnapshotManager{}
+	ctx, root := tracing.Start(context.Background(), "jsonrpc.take_snapshot", tracing.SpanKindServer)
+	_, err := sm.captureTier(ctx, "camera0", 0, "v4l2_direct", func(ctx context.Context) (*Snapshot, error) {
+		return nil, errors.New("device busy")
+	})
+	require.Error(t, err)
+	snapshot, err := sm.captureTier(ctx, "camera0", 1, "usb_direct", func(ctx context.Context) (*Snapshot, error) {
+		assert.NotNil(t, tracing.SpanFromContext(ctx), "Capture runs with the tier span in its context")
+		return &Snapshot{FilePath: "/tmp/camera0.jpg"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/camera0.jpg", snapshot.FilePath)
+	root.End()
+
+	spans := collect()
+	request := spans["jsonrpc.take_snapshot"]
+	tier0 := spans["snapshot.tier0.v4l2_direct"]
+	tier1 := spans["snapshot.tier1.usb_direct"]
+	assert.Equal(t, request.SpanID, tier0.ParentSpanID)
+	assert.Equal(t, request.SpanID, tier1.ParentSpanID)
+	assert.Equal(t, request.TraceID, tier1.TraceID)
+	assert.Equal(t, int(tracing.StatusError), tier0.Status.Code)
+	assert.Equal(t, int(tracing.StatusUnset), tier1.Status.Code)
+}
+
+func TestClient_DoRequestPropagatesTraceParent(t *testing.T) {
+	collect := installFileTracer(t)
+
+	traceParents := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParents <- r.Header.Get("traceparent")
+		w.Write([]byte(`{"items":[]}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, &config.MediaMTXConfig{Timeout: 5 * time.Second}, logging.GetLogger("mediamtx-client"))
+	ctx, root := tracing.Start(context.Background(), "jsonrpc.get_streams", tracing.SpanKindServer)
+	_, err := c.Get(ctx, MediaMTXPathsList)
+	require.NoError(t, err)
+	root.End()
+
+	traceParent := <-traceParents
+	spans := collect()
+	request := spans["mediamtx GET"]
+	assert.Equal(t, spans["jsonrpc.get_streams"].SpanID, request.ParentSpanID)
+	assert.Equal(t, "00-"+request.TraceID+"-"+request.SpanID+"-01", traceParent)
+}
@@ -0,0 +1,170 @@
+/*
+MediaMTX UAV Telemetry Extractor Tests
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-002: STANAG 4609 compliance for UAV streams
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/klv"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTelemetryNotifier collects published telemetry samples
+type recordingTelemetryNotifier struct {
+	mu      sync.Mutex
+	samples []*UAVTelemetry
+}
+
+func (n *recordingTelemetryNotifier) NotifyUAVTelemetry(telemetry *UAVTelemetry) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.samples = append(n.samples, telemetry)
+}
+
+func (n *recordingTelemetryNotifier) count() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.samples)
+}
+
+func TestUAVTelemetryExtractor_PublishesAndStoresSamples(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.MediaMTX.RecordingsPath = dir
+	cfg.ExternalDiscovery.Telemetry = config.UAVTelemetryConfig{Enabled: true, SampleIntervalMs: 1, ReconnectIntervalSeconds: 60}
+
+	var stream bytes.Buffer
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		packet, err := klv.Encode(&klv.Packet{
+			Timestamp:           base.Add(time.Duration(i) * time.Second),
+			MissionID:           "SURVEY-1",
+			PlatformDesignation: "Skydio X10",
+			Position:            &klv.Position{Latitude: 37.7749, Longitude: -122.4194, Altitude: 120, Heading: 45},
+		})
+		require.NoError(t, err)
+		stream.Write(packet)
+		stream.WriteString("noise")
+	}
+
+	var command []string
+	te := NewUAVTelemetryExtractor(cfg, nil, logging.GetLogger("mediamtx"))
+	te.openStream = func(ctx context.Context, cmd []string) (io.ReadCloser, func() error, error) {
+		command = cmd
+		// Pace the packets so each one falls outside the sample interval
+		reader, writer := io.Pipe()
+		data := stream.Bytes()
+		go func() {
+			size := len(data) / 3
+			for i := 0; i < 3; i++ {
+				writer.Write(data[i*size : (i+1)*size])
+				time.Sleep(10 * time.Millisecond)
+			}
+			writer.Close()
+		}()
+		return reader, func() error { return nil }, nil
+	}
+	notifier := &recordingTelemetryNotifier{}
+	te.SetNotifier(notifier)
+	require.NoError(t, te.Start(context.Background()))
+	t.Cleanup(te.Stop)
+
+	te.Track(&ExternalStream{URL: "rtsp://192.168.42.10:5554/subject", Type: "generic_rtsp", Name: "generic"})
+	te.Track(&ExternalStream{URL: "rtsp://192.168.42.10:5554/subject", Type: "skydio_stanag4609", Name: "Skydio UAV 1"})
+	require.Eventually(t, func() bool { return notifier.count() == 3 }, 5*time.Second, 10*time.Millisecond)
+
+	assert.Contains(t, command, "-rtsp_transport")
+	assert.Contains(t, command, "0:d:0")
+
+	notifier.mu.Lock()
+	first := notifier.samples[0]
+	notifier.mu.Unlock()
+	assert.Equal(t, "Skydio_UAV_1", first.Stream)
+	assert.Equal(t, "SURVEY-1", first.MissionID)
+	assert.Equal(t, "2025-01-01T10:00:00Z", first.Timestamp)
+	require.NotNil(t, first.Position)
+	assert.InDelta(t, 37.7749, first.Position.Latitude, 1e-6)
+
+	files, err := filepath.Glob(filepath.Join(dir, "Skydio_UAV_1", "*"+TelemetryFileSuffix))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	file, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer file.Close()
+
+	var stored []UAVTelemetry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var sample UAVTelemetry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &sample))
+		stored = append(stored, sample)
+	}
+	require.Len(t, stored, 3)
+	assert.Equal(t, "2025-01-01T10:00:02Z", stored[2].Timestamp)
+	assert.Equal(t, "rtsp://192.168.42.10:5554/subject", stored[2].StreamURL)
+}
+
+func TestUAVTelemetryExtractor_IgnoresStreamsWithoutKLV(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.ExternalDiscovery.Telemetry.Enabled = true
+
+	te := NewUAVTelemetryExtractor(cfg, nil, logging.GetLogger("mediamtx"))
+	te.openStream = func(ctx context.Context, cmd []string) (io.ReadCloser, func() error, error) {
+		t.Error("Streams without a KLV track are not demuxed")
+		return io.NopCloser(&bytes.Buffer{}), func() error { return nil }, nil
+	}
+	require.NoError(t, te.Start(context.Background()))
+	defer te.Stop()
+
+	te.Track(&ExternalStream{URL: "rtsp://192.168.1.20:554/stream", Type: "generic_rtsp", Name: "Generic"})
+	te.Untrack("rtsp://192.168.1.20:554/stream")
+
+	te.mu.Lock()
+	assert.Empty(t, te.streams)
+	te.mu.Unlock()
+}
+
+func TestTelemetryFilesAreNotTreatedAsMedia(t *testing.T) {
+	dir := t.TempDir()
+	telemetryFile := filepath.Join(dir, "recordings", "Skydio_UAV_1", "Skydio_UAV_1_2025-01-01_10-00-00"+TelemetryFileSuffix)
+	content := []byte(`{"stream":"Skydio_UAV_1"}` + "\n")
+	writeAgedContent(t, telemetryFile, content)
+
+	se := newTestStorageEncryption(t, dir, nil)
+	encrypted, err := se.RunOnce(context.Background(), make(chan struct{}))
+	require.NoError(t, err)
+	assert.Equal(t, 0, encrypted, "Telemetry files are not encrypted over an open writer")
+	data, err := os.ReadFile(telemetryFile)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	janitor := NewRetentionJanitor(se.config, logging.GetLogger("mediamtx"))
+	files, err := janitor.scan(time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, files, "Telemetry files do not count toward retention")
+
+	rm := NewReplicationManager(se.config, logging.GetLogger("mediamtx"))
+	rm.scan(time.Now())
+	assert.Nil(t, rm.StatusFor(telemetryFile), "Telemetry files are not replicated as recordings")
+}
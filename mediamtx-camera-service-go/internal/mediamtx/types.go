@@ -193,6 +193,7 @@ type FileMetadata struct {
 	ModifiedAt  time.Time `json:"modified_at"`
 	Duration    *int64    `json:"duration,omitempty"` // Duration in seconds for video files
 	DownloadURL string    `json:"download_url"`
+	StorageTier string    `json:"storage_tier,omitempty"` // "archive" once moved by storage tiering
 }
 
 // CameraListResponse represents the response for camera list operations
@@ -274,9 +275,16 @@ type MediaMTXController interface {
 	SubscribeToReadiness() <-chan struct{}
 
 	// Configuration management
-	CleanupOldFiles(ctx context.Context) (*CleanupOldFilesResponse, error)
+	CleanupOldFiles(ctx context.Context, dryRun bool) (*CleanupOldFilesResponse, error)
 	SetRetentionPolicy(ctx context.Context, enabled bool, policyType string, params map[string]interface{}) (*SetRetentionPolicyResponse, error)
 
+	// Chain-of-custody verification and evidence export
+	VerifyFileIntegrity(ctx context.Context, filename, fileType string) (*VerifyFileIntegrityResponse, error)
+	ExportEvidenceBundle(ctx context.Context, filename, fileType string) (*ExportEvidenceBundleResponse, error)
+
+	// Encryption at rest key management
+	RotateStorageKey(ctx context.Context) (*RotateStorageKeyResponse, error)
+
 	// Stream management (uses Path from api_types.go)
 	GetStreams(ctx context.Context) (*GetStreamsResponse, error)
 	GetStream(ctx context.Context, id string) (*Path, error)
@@ -313,6 +321,7 @@ type MediaMTXController interface {
 	GetSnapshotInfo(ctx context.Context, filename string) (*GetSnapshotInfoResponse, error)
 	DeleteRecording(ctx context.Context, filename string) error
 	DeleteSnapshot(ctx context.Context, filename string) error
+	OpenMediaFile(ctx context.Context, fileType, filename string) (*MediaFile, error)
 
 	// RTSP Connection Management
 	ListRTSPConnections(ctx context.Context, page, itemsPerPage int) (*RTSPConnectionList, error)
@@ -389,9 +398,16 @@ type MediaMTXControllerAPI interface {
 	GetSnapshotManager() *SnapshotManager
 
 	// File cleanup and retention policy operations
-	CleanupOldFiles(ctx context.Context) (*CleanupOldFilesResponse, error)
+	CleanupOldFiles(ctx context.Context, dryRun bool) (*CleanupOldFilesResponse, error)
 	SetRetentionPolicy(ctx context.Context, enabled bool, policyType string, params map[string]interface{}) (*SetRetentionPolicyResponse, error)
 
+	// Chain-of-custody verification and evidence export
+	VerifyFileIntegrity(ctx context.Context, filename, fileType string) (*VerifyFileIntegrityResponse, error)
+	ExportEvidenceBundle(ctx context.Context, filename, fileType string) (*ExportEvidenceBundleResponse, error)
+
+	// Encryption at rest key management
+	RotateStorageKey(ctx context.Context) (*RotateStorageKeyResponse, error)
+
 	// External stream discovery (API-ready responses)
 	DiscoverExternalStreams(ctx context.Context, options DiscoveryOptions) (*DiscoverExternalStreamsResponse, error)
 	AddExternalStream(ctx context.Context, stream *ExternalStream) (*AddExternalStreamResponse, error)
@@ -425,6 +441,11 @@ type SystemEventNotifier interface {
 	NotifySystemHealth(status string, metrics map[string]interface{})
 }
 
+// TelemetryEventNotifier interface for UAV telemetry notifications
+type TelemetryEventNotifier interface {
+	NotifyUAVTelemetry(telemetry *UAVTelemetry)
+}
+
 // HealthMonitor interface defines health monitoring operations
 type HealthMonitor interface {
 	// Health monitoring
@@ -0,0 +1,393 @@
+/*
+MediaMTX UAV Telemetry Extraction Implementation
+
+Demuxes the KLV data track of discovered STANAG 4609 streams with FFmpeg and
+decodes the MISB ST 0601 fields. Samples are published to WebSocket clients
+as uav.telemetry events and appended to JSON-lines files next to the
+stream's recordings, so they share retention, archiving and replication with
+the footage and can be searched by position later.
+
+Requirements Coverage:
+- REQ-MTX-001: MediaMTX service integration
+- REQ-MTX-002: STANAG 4609 compliance for UAV streams
+
+Test Categories: Unit
+API Documentation Reference: docs/api/json_rpc_methods.md
+*/
+
+package mediamtx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/klv"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+)
+
+const (
+	// TelemetryFileSuffix marks the JSON-lines telemetry files stored with recordings
+	TelemetryFileSuffix = ".telemetry.jsonl"
+
+	defaultTelemetrySampleInterval    = time.Second
+	defaultTelemetryReconnectInterval = 5 * time.Second
+	telemetryFileRotation             = time.Hour
+)
+
+// telemetryNameSanitizer matches characters not allowed in telemetry directory and file names
+var telemetryNameSanitizer = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// UAVPosition represents the platform attitude and sensor position of a telemetry sample
+type UAVPosition struct {
+	Latitude              float64 `json:"latitude"`                // Sensor latitude, degrees
+	Longitude             float64 `json:"longitude"`               // Sensor longitude, degrees
+	Altitude              float64 `json:"altitude"`                // Sensor true altitude, meters MSL
+	Heading               float64 `json:"heading"`                 // Platform heading, degrees
+	Pitch                 float64 `json:"pitch"`                   // Platform pitch, degrees
+	Roll                  float64 `json:"roll"`                    // Platform roll, degrees
+	HorizontalFOV         float64 `json:"horizontal_fov"`          // Sensor horizontal field of view, degrees
+	VerticalFOV           float64 `json:"vertical_fov"`            // Sensor vertical field of view, degrees
+	SensorRelativeAzimuth float64 `json:"sensor_relative_azimuth"` // Sensor azimuth relative to the platform, degrees
+}
+
+// UAVTelemetry represents one decoded KLV sample of an external UAV stream
+type UAVTelemetry struct {
+	Stream              string       `json:"stream"`                         // Stream name (telemetry directory name)
+	StreamURL           string       `json:"stream_url"`                     // Source stream URL
+	Timestamp           string       `json:"timestamp"`                      // ST 0601 precision timestamp (RFC 3339)
+	ReceivedAt          string       `json:"received_at"`                    // Time the sample was decoded (RFC 3339)
+	MissionID           string       `json:"mission_id,omitempty"`           // ST 0601 mission ID
+	PlatformDesignation string       `json:"platform_designation,omitempty"` // ST 0601 platform designation
+	ImageSourceSensor   string       `json:"image_source_sensor,omitempty"`  // ST 0601 image source sensor
+	Position            *UAVPosition `json:"position,omitempty"`             // Present when the sample carries a sensor position
+}
+
+// telemetryStream tracks the extraction of one external stream
+type telemetryStream struct {
+	url    string
+	name   string
+	cancel context.CancelFunc
+}
+
+// UAVTelemetryExtractor extracts KLV telemetry from discovered STANAG 4609 streams
+type UAVTelemetryExtractor struct {
+	config        *config.Config
+	logger        *logging.Logger
+	ffmpegManager FFmpegManager
+	openStream    func(ctx context.Context, command []string) (io.ReadCloser, func() error, error)
+
+	mu       sync.Mutex
+	notifier TelemetryEventNotifier
+	streams  map[string]*telemetryStream
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewUAVTelemetryExtractor creates a new UAV telemetry extractor
+func NewUAVTelemetryExtractor(cfg *config.Config, ffmpegManager FFmpegManager, logger *logging.Logger) *UAVTelemetryExtractor {
+	return &UAVTelemetryExtractor{
+		config:        cfg,
+		logger:        logger,
+		ffmpegManager: ffmpegManager,
+		openStream:    startTelemetryCommand,
+		streams:       make(map[string]*telemetryStream),
+	}
+}
+
+// Enabled reports whether telemetry extraction is configured
+func (te *UAVTelemetryExtractor) Enabled() bool {
+	return te.config.ExternalDiscovery.Telemetry.Enabled
+}
+
+// SetNotifier sets the notifier that publishes uav.telemetry events
+func (te *UAVTelemetryExtractor) SetNotifier(notifier TelemetryEventNotifier) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	te.notifier = notifier
+}
+
+// Start enables extraction for tracked streams
+func (te *UAVTelemetryExtractor) Start(ctx context.Context) error {
+	if !te.Enabled() {
+		return nil
+	}
+
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	if te.ctx != nil {
+		return nil
+	}
+	te.ctx, te.cancel = context.WithCancel(context.Background())
+
+	te.logger.WithField("sample_interval", te.sampleInterval().String()).Info("UAV telemetry extraction started")
+	return nil
+}
+
+// Stop ends all extraction and waits for the demux processes to exit
+func (te *UAVTelemetryExtractor) Stop() {
+	te.mu.Lock()
+	cancel := te.cancel
+	te.ctx, te.cancel = nil, nil
+	te.streams = make(map[string]*telemetryStream)
+	te.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	te.wg.Wait()
+}
+
+// sampleInterval returns the minimum interval between samples of a stream
+func (te *UAVTelemetryExtractor) sampleInterval() time.Duration {
+	if ms := te.config.ExternalDiscovery.Telemetry.SampleIntervalMs; ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return defaultTelemetrySampleInterval
+}
+
+// reconnectInterval returns the delay before a dropped stream is reopened
+func (te *UAVTelemetryExtractor) reconnectInterval() time.Duration {
+	if seconds := te.config.ExternalDiscovery.Telemetry.ReconnectIntervalSeconds; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultTelemetryReconnectInterval
+}
+
+// carriesKLV reports whether an external stream carries a STANAG 4609 KLV track
+func carriesKLV(stream *ExternalStream) bool {
+	if strings.Contains(stream.Type, "stanag4609") {
+		return true
+	}
+	metadata, _ := stream.Capabilities["metadata"].(string)
+	return metadata == "klv_mpegts"
+}
+
+// telemetryStreamName returns a file-system safe name for a stream
+func telemetryStreamName(stream *ExternalStream) string {
+	name := strings.Trim(telemetryNameSanitizer.ReplaceAllString(stream.Name, "_"), "_.")
+	if name == "" {
+		name = strings.Trim(telemetryNameSanitizer.ReplaceAllString(stream.URL, "_"), "_.")
+	}
+	return name
+}
+
+// Track starts extracting telemetry from a STANAG 4609 stream. Other streams
+// and streams already tracked are ignored. It returns immediately.
+func (te *UAVTelemetryExtractor) Track(stream *ExternalStream) {
+	if stream == nil || !carriesKLV(stream) {
+		return
+	}
+
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	if te.ctx == nil {
+		return
+	}
+	if _, exists := te.streams[stream.URL]; exists {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(te.ctx)
+	tracked := &telemetryStream{url: stream.URL, name: telemetryStreamName(stream), cancel: cancel}
+	te.streams[stream.URL] = tracked
+
+	te.wg.Add(1)
+	go func() {
+		defer te.wg.Done()
+		te.run(ctx, tracked)
+	}()
+
+	te.logger.WithFields(logging.Fields{
+		"stream_url": tracked.url,
+		"stream":     tracked.name,
+	}).Info("Tracking UAV telemetry")
+}
+
+// Untrack stops extracting telemetry from a stream
+func (te *UAVTelemetryExtractor) Untrack(url string) {
+	te.mu.Lock()
+	tracked, exists := te.streams[url]
+	delete(te.streams, url)
+	te.mu.Unlock()
+
+	if exists {
+		tracked.cancel()
+	}
+}
+
+// run extracts telemetry from a stream, reconnecting until it is untracked
+func (te *UAVTelemetryExtractor) run(ctx context.Context, tracked *telemetryStream) {
+	writer := &telemetryWriter{root: te.config.MediaMTX.RecordingsPath, stream: tracked.name}
+	defer writer.Close()
+
+	for {
+		err := te.extract(ctx, tracked, writer)
+		// The next connection starts a new file rather than appending to one
+		// left open across the reconnect wait
+		writer.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		te.logger.WithError(err).WithField("stream_url", tracked.url).Warn("UAV telemetry stream ended, reconnecting")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(te.reconnectInterval()):
+		}
+	}
+}
+
+// extract runs one FFmpeg demux of the stream's data track and processes its packets
+func (te *UAVTelemetryExtractor) extract(ctx context.Context, tracked *telemetryStream, writer *telemetryWriter) error {
+	args := []string{"-v", "error"}
+	if strings.HasPrefix(tracked.url, "rtsp://") {
+		args = append(args, "-rtsp_transport", "tcp")
+	}
+	args = append(args, "-i", tracked.url, "-map", "0:d:0", "-c", "copy", "-f", "data", "-")
+	command := append([]string{"ffmpeg"}, args...)
+	if te.ffmpegManager != nil {
+		command = te.ffmpegManager.BuildCommand(args...)
+	}
+
+	reader, wait, err := te.openStream(ctx, command)
+	if err != nil {
+		return fmt.Errorf("failed to start KLV demux: %w", err)
+	}
+	defer func() {
+		reader.Close()
+		wait()
+	}()
+
+	decoder := klv.NewDecoder(reader)
+	interval := te.sampleInterval()
+	var lastSample time.Time
+	for {
+		packet, err := decoder.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("KLV data stream closed (%d packets rejected)", decoder.Rejected())
+			}
+			return err
+		}
+
+		now := time.Now()
+		if !lastSample.IsZero() && now.Sub(lastSample) < interval {
+			continue
+		}
+		lastSample = now
+
+		telemetry := newUAVTelemetry(tracked, packet, now)
+		if err := writer.Write(telemetry, now); err != nil {
+			te.logger.WithError(err).WithField("stream", tracked.name).Warn("Failed to store UAV telemetry")
+		}
+
+		te.mu.Lock()
+		notifier := te.notifier
+		te.mu.Unlock()
+		if notifier != nil {
+			notifier.NotifyUAVTelemetry(telemetry)
+		}
+	}
+}
+
+// newUAVTelemetry converts a decoded packet into a telemetry sample
+func newUAVTelemetry(tracked *telemetryStream, packet *klv.Packet, receivedAt time.Time) *UAVTelemetry {
+	telemetry := &UAVTelemetry{
+		Stream:              tracked.name,
+		StreamURL:           tracked.url,
+		ReceivedAt:          receivedAt.UTC().Format(time.RFC3339Nano),
+		MissionID:           packet.MissionID,
+		PlatformDesignation: packet.PlatformDesignation,
+		ImageSourceSensor:   packet.ImageSourceSensor,
+	}
+	if !packet.Timestamp.IsZero() {
+		telemetry.Timestamp = packet.Timestamp.UTC().Format(time.RFC3339Nano)
+	}
+	if position := packet.Position; position != nil {
+		telemetry.Position = &UAVPosition{
+			Latitude:              position.Latitude,
+			Longitude:             position.Longitude,
+			Altitude:              position.Altitude,
+			Heading:               position.Heading,
+			Pitch:                 position.Pitch,
+			Roll:                  position.Roll,
+			HorizontalFOV:         position.HorizontalFOV,
+			VerticalFOV:           position.VerticalFOV,
+			SensorRelativeAzimuth: position.SensorRelativeAzimuth,
+		}
+	}
+	return telemetry
+}
+
+// telemetryWriter appends samples to hourly JSON-lines files in the stream's
+// recordings directory
+type telemetryWriter struct {
+	root     string
+	stream   string
+	file     *os.File
+	openedAt time.Time
+}
+
+// Write appends a sample, starting a new file every rotation period
+func (w *telemetryWriter) Write(telemetry *UAVTelemetry, now time.Time) error {
+	if w.file != nil && now.Sub(w.openedAt) >= telemetryFileRotation {
+		w.Close()
+	}
+	if w.file == nil {
+		dir := filepath.Join(w.root, w.stream)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create telemetry directory: %w", err)
+		}
+		name := fmt.Sprintf("%s_%s%s", w.stream, now.Format("2006-01-02_15-04-05"), TelemetryFileSuffix)
+		file, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open telemetry file: %w", err)
+		}
+		w.file = file
+		w.openedAt = now
+	}
+
+	line, err := json.Marshal(telemetry)
+	if err != nil {
+		return fmt.Errorf("failed to encode telemetry: %w", err)
+	}
+	if _, err := w.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write telemetry: %w", err)
+	}
+	return nil
+}
+
+// Close closes the current telemetry file
+func (w *telemetryWriter) Close() {
+	if w.file != nil {
+		w.file.Close()
+		w.file = nil
+	}
+}
+
+// startTelemetryCommand starts an FFmpeg demux process and returns its output
+func startTelemetryCommand(ctx context.Context, command []string) (io.ReadCloser, func() error, error) {
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, nil, err
+	}
+	return stdout, cmd.Wait, nil
+}
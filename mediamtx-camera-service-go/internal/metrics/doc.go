@@ -0,0 +1,26 @@
+// Package metrics exposes service metrics in the Prometheus text exposition format.
+//
+// Components keep their own counters and statistics; at scrape time a Registry
+// asks each registered Collector for a snapshot as metric families and renders
+// them under one consistent naming scheme (Namespace prefix, base units,
+// _total suffix for counters). HistogramVec and CounterVec cover the few values
+// that need distributions or per-label counts maintained between scrapes, such
+// as per-method request latency.
+//
+// Architecture Compliance:
+//   - No External Dependencies: Text format rendered directly, no client library
+//   - Pull Model: Collectors snapshot existing component state on each scrape
+//   - Thread Safety: Registry, HistogramVec and CounterVec are safe for concurrent use
+//
+// Key Components:
+//   - Family/Sample: One metric with its help text, type and labelled values
+//   - Collector: Interface implemented by components that expose metrics
+//   - Registry: Collector registration, merging and text rendering
+//   - HistogramVec/CounterVec: Labelled histograms and counters
+//
+// Requirements Coverage:
+//   - REQ-HEALTH-003: System metrics collection and reporting
+//
+// Test Categories: Unit
+// API Documentation Reference: docs/api/health-endpoints.md
+package metrics
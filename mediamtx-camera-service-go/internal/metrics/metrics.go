@@ -0,0 +1,238 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// Namespace prefixes every metric name exposed by the service
+	Namespace = "camera_service"
+
+	// ContentType is the media type of the Prometheus text exposition format
+	ContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// Type is the Prometheus metric type of a family
+type Type string
+
+const (
+	TypeCounter   Type = "counter"
+	TypeGauge     Type = "gauge"
+	TypeHistogram Type = "histogram"
+)
+
+// Labels maps label names to values
+type Labels map[string]string
+
+// Sample is one labelled value of a counter or gauge
+type Sample struct {
+	Labels Labels
+	Value  float64
+}
+
+// HistogramSample is one labelled histogram with cumulative bucket counts
+type HistogramSample struct {
+	Labels Labels
+	Bounds []float64 // Bucket upper bounds, ascending, excluding +Inf
+	Counts []uint64  // Cumulative observation count per bound
+	Count  uint64    // Total observations (the +Inf bucket)
+	Sum    float64   // Sum of observed values
+}
+
+// Family is a metric with its help text, type and values
+type Family struct {
+	Name       string
+	Help       string
+	Type       Type
+	Samples    []Sample
+	Histograms []HistogramSample
+}
+
+// Name builds a metric name under the service namespace
+func Name(subsystem, name string) string {
+	return Namespace + "_" + subsystem + "_" + name
+}
+
+// Gauge creates a gauge family
+func Gauge(name, help string, samples ...Sample) Family {
+	return Family{Name: name, Help: help, Type: TypeGauge, Samples: samples}
+}
+
+// Counter creates a counter family
+func Counter(name, help string, samples ...Sample) Family {
+	return Family{Name: name, Help: help, Type: TypeCounter, Samples: samples}
+}
+
+// Value creates an unlabelled sample
+func Value(value float64) Sample {
+	return Sample{Value: value}
+}
+
+// Bool converts a state into a 0/1 gauge value
+func Bool(state bool) float64 {
+	if state {
+		return 1
+	}
+	return 0
+}
+
+// Collector is implemented by components that expose metrics
+type Collector interface {
+	CollectMetrics(ctx context.Context) []Family
+}
+
+// CollectorFunc adapts a function to the Collector interface
+type CollectorFunc func(ctx context.Context) []Family
+
+// CollectMetrics calls f(ctx)
+func (f CollectorFunc) CollectMetrics(ctx context.Context) []Family {
+	return f(ctx)
+}
+
+// Registry gathers metric families from registered collectors
+type Registry struct {
+	mu         sync.RWMutex
+	collectors []Collector
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a collector to the registry
+func (r *Registry) Register(collector Collector) {
+	if collector == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, collector)
+}
+
+// Gather collects all families sorted by name. Families reported under the
+// same name by several collectors are merged; the first help text and type win.
+func (r *Registry) Gather(ctx context.Context) []Family {
+	r.mu.RLock()
+	collectors := append([]Collector(nil), r.collectors...)
+	r.mu.RUnlock()
+
+	byName := make(map[string]*Family)
+	var names []string
+	for _, collector := range collectors {
+		for _, family := range collector.CollectMetrics(ctx) {
+			existing, ok := byName[family.Name]
+			if !ok {
+				merged := family
+				byName[family.Name] = &merged
+				names = append(names, family.Name)
+				continue
+			}
+			if existing.Type != family.Type {
+				continue
+			}
+			existing.Samples = append(existing.Samples, family.Samples...)
+			existing.Histograms = append(existing.Histograms, family.Histograms...)
+		}
+	}
+
+	sort.Strings(names)
+	families := make([]Family, 0, len(names))
+	for _, name := range names {
+		families = append(families, *byName[name])
+	}
+	return families
+}
+
+// WriteText renders all collected families in the Prometheus text format
+func (r *Registry) WriteText(ctx context.Context, w io.Writer) error {
+	buf := bufio.NewWriter(w)
+	for _, family := range r.Gather(ctx) {
+		if len(family.Samples) == 0 && len(family.Histograms) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "# HELP %s %s\n", family.Name, escapeHelp(family.Help))
+		fmt.Fprintf(buf, "# TYPE %s %s\n", family.Name, family.Type)
+
+		for _, sample := range family.Samples {
+			writeSample(buf, family.Name, sample.Labels, "", sample.Value)
+		}
+		for _, histogram := range family.Histograms {
+			for i, bound := range histogram.Bounds {
+				writeSample(buf, family.Name+"_bucket", histogram.Labels, formatFloat(bound), float64(histogram.Counts[i]))
+			}
+			writeSample(buf, family.Name+"_bucket", histogram.Labels, "+Inf", float64(histogram.Count))
+			writeSample(buf, family.Name+"_sum", histogram.Labels, "", histogram.Sum)
+			writeSample(buf, family.Name+"_count", histogram.Labels, "", float64(histogram.Count))
+		}
+	}
+	return buf.Flush()
+}
+
+// writeSample writes one sample line, adding the le label for histogram buckets
+func writeSample(w *bufio.Writer, name string, labels Labels, le string, value float64) {
+	w.WriteString(name)
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if len(keys) > 0 || le != "" {
+		w.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, "%s=\"%s\"", key, escapeLabelValue(labels[key]))
+		}
+		if le != "" {
+			if len(keys) > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, "le=\"%s\"", le)
+		}
+		w.WriteByte('}')
+	}
+
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(value))
+	w.WriteByte('\n')
+}
+
+// formatFloat renders a value as the text format expects
+func formatFloat(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+// escapeHelp escapes backslashes and line feeds in help text
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+// escapeLabelValue escapes backslashes, quotes and line feeds in label values
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
@@ -0,0 +1,75 @@
+/*
+Prometheus Metrics Unit Tests
+
+Tests text exposition rendering, family merging and labelled histograms.
+
+Test Categories: Unit
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_WritesTextFormat(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(CollectorFunc(func(ctx context.Context) []Family {
+		return []Family{
+			Gauge("camera_service_recording_active", "Whether a camera is recording.",
+				Sample{Labels: Labels{"camera": "camera0"}, Value: 1}),
+			Counter("camera_service_errors_total", "Errors by component.\nLine two",
+				Sample{Labels: Labels{"component": `Recording"Manager`}, Value: 3}),
+		}
+	}))
+	registry.Register(CollectorFunc(func(ctx context.Context) []Family {
+		return []Family{
+			Gauge("camera_service_recording_active", "Ignored duplicate help.",
+				Sample{Labels: Labels{"camera": "camera1"}, Value: 0}),
+			Gauge("camera_service_empty", "No samples are not rendered."),
+		}
+	}))
+
+	var out bytes.Buffer
+	require.NoError(t, registry.WriteText(context.Background(), &out))
+	assert.Equal(t, `# HELP camera_service_errors_total Errors by component.\nLine two
+# TYPE camera_service_errors_total counter
+camera_service_errors_total{component="Recording\"Manager"} 3
+# HELP camera_service_recording_active Whether a camera is recording.
+# TYPE camera_service_recording_active gauge
+camera_service_recording_active{camera="camera0"} 1
+camera_service_recording_active{camera="camera1"} 0
+`, out.String())
+}
+
+func TestHistogramVec_CumulativeBuckets(t *testing.T) {
+	histogram := NewHistogramVec("method", []float64{0.1, 1})
+	histogram.Observe("ping", 0.05)
+	histogram.Observe("ping", 0.1)
+	histogram.Observe("ping", 0.5)
+	histogram.Observe("ping", 2)
+	histogram.Observe("take_snapshot", 0.5)
+
+	family := histogram.Family("camera_service_rpc_request_duration_seconds", "Request latency.")
+	require.Len(t, family.Histograms, 2)
+	ping := family.Histograms[0]
+	assert.Equal(t, "ping", ping.Labels["method"])
+	assert.Equal(t, []uint64{2, 3}, ping.Counts)
+	assert.Equal(t, uint64(4), ping.Count)
+	assert.InDelta(t, 2.65, ping.Sum, 1e-9)
+
+	registry := NewRegistry()
+	registry.Register(CollectorFunc(func(ctx context.Context) []Family {
+		return []Family{histogram.Family("camera_service_rpc_request_duration_seconds", "Request latency.")}
+	}))
+	var out bytes.Buffer
+	require.NoError(t, registry.WriteText(context.Background(), &out))
+	assert.Contains(t, out.String(), `camera_service_rpc_request_duration_seconds_bucket{method="ping",le="0.1"} 2`)
+	assert.Contains(t, out.String(), `camera_service_rpc_request_duration_seconds_bucket{method="ping",le="+Inf"} 4`)
+	assert.Contains(t, out.String(), `camera_service_rpc_request_duration_seconds_count{method="take_snapshot"} 1`)
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+)
+
+// DefaultLatencyBuckets are histogram bounds in seconds suited to request latency
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// HistogramVec maintains histograms partitioned by the value of one label
+type HistogramVec struct {
+	label  string
+	bounds []float64
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+// histogramSeries holds the non-cumulative bucket counts of one label value
+type histogramSeries struct {
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+// NewHistogramVec creates a histogram vector with ascending bucket bounds
+func NewHistogramVec(label string, bounds []float64) *HistogramVec {
+	return &HistogramVec{
+		label:  label,
+		bounds: append([]float64(nil), bounds...),
+		series: make(map[string]*histogramSeries),
+	}
+}
+
+// Observe records a value for a label value
+func (h *HistogramVec) Observe(labelValue string, value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	series, ok := h.series[labelValue]
+	if !ok {
+		series = &histogramSeries{buckets: make([]uint64, len(h.bounds))}
+		h.series[labelValue] = series
+	}
+	if i := sort.SearchFloat64s(h.bounds, value); i < len(h.bounds) {
+		series.buckets[i]++
+	}
+	series.count++
+	series.sum += value
+}
+
+// Family snapshots the histograms as a metric family
+func (h *HistogramVec) Family(name, help string) Family {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	family := Family{Name: name, Help: help, Type: TypeHistogram}
+	for labelValue, series := range h.series {
+		counts := make([]uint64, len(h.bounds))
+		var cumulative uint64
+		for i, bucket := range series.buckets {
+			cumulative += bucket
+			counts[i] = cumulative
+		}
+		family.Histograms = append(family.Histograms, HistogramSample{
+			Labels: Labels{h.label: labelValue},
+			Bounds: h.bounds,
+			Counts: counts,
+			Count:  series.count,
+			Sum:    series.sum,
+		})
+	}
+	sort.Slice(family.Histograms, func(i, j int) bool {
+		return family.Histograms[i].Labels[h.label] < family.Histograms[j].Labels[h.label]
+	})
+	return family
+}
+
+// CounterVec maintains counters partitioned by the value of one label
+type CounterVec struct {
+	label string
+
+	mu     sync.Mutex
+	counts map[string]float64
+}
+
+// NewCounterVec creates a counter vector
+func NewCounterVec(label string) *CounterVec {
+	return &CounterVec{label: label, counts: make(map[string]float64)}
+}
+
+// Inc increments the counter of a label value
+func (c *CounterVec) Inc(labelValue string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[labelValue]++
+}
+
+// Family snapshots the counters as a metric family
+func (c *CounterVec) Family(name, help string) Family {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	family := Counter(name, help)
+	for labelValue, count := range c.counts {
+		family.Samples = append(family.Samples, Sample{Labels: Labels{c.label: labelValue}, Value: count})
+	}
+	sort.Slice(family.Samples, func(i, j int) bool {
+		return family.Samples[i].Labels[c.label] < family.Samples[j].Labels[c.label]
+	})
+	return family
+}
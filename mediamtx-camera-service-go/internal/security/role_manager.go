@@ -77,6 +77,7 @@ func NewPermissionChecker() *PermissionChecker {
 		"unsubscribe_events",
 		"get_subscription_stats",
 		"get_external_streams",
+		"verify_file_integrity",
 	}
 
 	// Operator permissions (camera control operations)
@@ -101,6 +102,8 @@ func NewPermissionChecker() *PermissionChecker {
 		"set_retention_policy",
 		"cleanup_old_files",
 		"set_discovery_interval",
+		"export_evidence_bundle",
+		"rotate_storage_key",
 	}
 
 	// Set permissions for each method
@@ -0,0 +1,29 @@
+// Package tracing records OpenTelemetry-compatible spans for API requests.
+//
+// A JSON-RPC request opens a server span; the context carrying it flows through
+// the controller into snapshot tiers, MediaMTX API round trips and FFmpeg process
+// starts, each of which opens a child span. A slow operation can then be broken
+// down into the time spent in every stage. Spans carry the request correlation ID
+// from logging.WithCorrelationID so traces and log lines can be joined.
+//
+// Finished spans are batched and exported in the OTLP/JSON encoding, either to an
+// OTLP/HTTP collector (<endpoint>/v1/traces) or appended to a local file with one
+// ExportTraceServiceRequest per line for offline analysis.
+//
+// Architecture Compliance:
+//   - No External Dependencies: OTLP/JSON encoded directly, no SDK
+//   - Zero Cost When Disabled: Start returns a nil span whose methods are no-ops
+//   - W3C Trace Context: traceparent header propagated to MediaMTX requests
+//   - Thread Safety: Spans, the tracer and the batch processor are safe for concurrent use
+//
+// Key Components:
+//   - Tracer: Sampling, span creation and export pipeline, installed with SetTracer
+//   - Span: One timed operation with attributes, status and events
+//   - Exporter: OTLP/HTTP and file exporters
+//
+// Requirements Coverage:
+//   - REQ-HEALTH-003: System metrics collection and reporting
+//
+// Test Categories: Unit
+// API Documentation Reference: docs/architecture/tracing.md
+package tracing
@@ -0,0 +1,273 @@
+/*
+Span Exporters
+
+Encodes spans as OTLP/JSON ExportTraceServiceRequest messages and delivers them
+to an OTLP/HTTP collector or appends them to a local file, one request per line.
+
+Requirements Coverage:
+- REQ-HEALTH-003: System metrics collection and reporting
+
+Test Categories: Unit
+API Documentation Reference: docs/architecture/tracing.md
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultOTLPEndpoint is the default OTLP/HTTP collector address
+const DefaultOTLPEndpoint = "http://localhost:4318"
+
+// instrumentationScope names this package as the producer of the spans
+const instrumentationScope = "github.com/camerarecorder/mediamtx-camera-service-go/internal/tracing"
+
+// Exporter delivers batches of finished spans
+type Exporter interface {
+	Export(ctx context.Context, serviceName string, spans []*SpanData) error
+	Shutdown(ctx context.Context) error
+}
+
+// OTLP/JSON message types (opentelemetry-proto trace/v1, JSON mapping)
+type otlpExportRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    StatusCode `json:"code,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// encodeOTLP encodes spans as one OTLP/JSON ExportTraceServiceRequest
+func encodeOTLP(serviceName string, spans []*SpanData) ([]byte, error) {
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		out := otlpSpan{
+			TraceID:           span.TraceID.String(),
+			SpanID:            span.SpanID.String(),
+			Name:              span.Name,
+			Kind:              span.Kind,
+			StartTimeUnixNano: unixNano(span.Start),
+			EndTimeUnixNano:   unixNano(span.End),
+			Attributes:        otlpAttributes(span.Attributes),
+			Status:            otlpStatus{Code: span.Status, Message: span.StatusMessage},
+		}
+		if span.ParentID.IsValid() {
+			out.ParentSpanID = span.ParentID.String()
+		}
+		for _, event := range span.Events {
+			out.Events = append(out.Events, otlpEvent{
+				TimeUnixNano: unixNano(event.Time),
+				Name:         event.Name,
+				Attributes:   otlpAttributes(event.Attributes),
+			})
+		}
+		encoded = append(encoded, out)
+	}
+
+	return json.Marshal(otlpExportRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{Attributes: otlpAttributes([]Attribute{String("service.name", serviceName)})},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: instrumentationScope},
+				Spans: encoded,
+			}},
+		}},
+	})
+}
+
+func otlpAttributes(attributes []Attribute) []otlpKeyValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+	out := make([]otlpKeyValue, 0, len(attributes))
+	for _, attribute := range attributes {
+		var value otlpAnyValue
+		switch v := attribute.Value.(type) {
+		case string:
+			value.StringValue = &v
+		case bool:
+			value.BoolValue = &v
+		case int64:
+			s := strconv.FormatInt(v, 10)
+			value.IntValue = &s
+		case float64:
+			value.DoubleValue = &v
+		default:
+			s := fmt.Sprint(v)
+			value.StringValue = &s
+		}
+		out = append(out, otlpKeyValue{Key: attribute.Key, Value: value})
+	}
+	return out
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// OTLPExporter posts spans to an OTLP/HTTP collector using the JSON encoding
+type OTLPExporter struct {
+	url    string
+	client *http.Client
+}
+
+// NewOTLPExporter creates an exporter for the collector at endpoint
+// (e.g. "http://localhost:4318"); spans are posted to <endpoint>/v1/traces
+func NewOTLPExporter(endpoint string) *OTLPExporter {
+	if endpoint == "" {
+		endpoint = DefaultOTLPEndpoint
+	}
+	url := strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	return &OTLPExporter{
+		url:    url,
+		client: &http.Client{Timeout: exportTimeout},
+	}
+}
+
+// Export posts one batch of spans to the collector
+func (e *OTLPExporter) Export(ctx context.Context, serviceName string, spans []*SpanData) error {
+	body, err := encodeOTLP(serviceName, spans)
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send spans to %s: %w", e.url, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("OTLP collector %s returned status %d", e.url, resp.StatusCode)
+	}
+	return nil
+}
+
+// Shutdown releases idle collector connections
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// FileExporter appends spans to a local file, one OTLP/JSON export request per line
+type FileExporter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileExporter opens (or creates) the span file at path for appending
+func NewFileExporter(path string) (*FileExporter, error) {
+	if path == "" {
+		return nil, fmt.Errorf("tracing file path cannot be empty")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create tracing directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tracing file: %w", err)
+	}
+	return &FileExporter{file: file}, nil
+}
+
+// Export appends one batch of spans as a single line
+func (e *FileExporter) Export(ctx context.Context, serviceName string, spans []*SpanData) error {
+	line, err := encodeOTLP(serviceName, spans)
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.file == nil {
+		return fmt.Errorf("tracing file is closed")
+	}
+	if _, err := e.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write spans: %w", err)
+	}
+	return nil
+}
+
+// Shutdown closes the span file
+func (e *FileExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.file == nil {
+		return nil
+	}
+	err := e.file.Close()
+	e.file = nil
+	return err
+}
@@ -0,0 +1,147 @@
+/*
+Batch Span Processor
+
+Queues finished spans and exports them in batches from a background goroutine,
+so instrumented code never blocks on the exporter. Spans are dropped when the
+queue is full.
+
+Requirements Coverage:
+- REQ-HEALTH-003: System metrics collection and reporting
+
+Test Categories: Unit
+API Documentation Reference: docs/architecture/tracing.md
+*/
+
+package tracing
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+)
+
+const (
+	defaultBatchSize     = 128
+	defaultFlushInterval = 5 * time.Second
+	defaultQueueSize     = 2048
+	exportTimeout        = 10 * time.Second
+)
+
+type batchProcessor struct {
+	serviceName   string
+	exporter      Exporter
+	batchSize     int
+	flushInterval time.Duration
+	queue         chan *SpanData
+	dropped       atomic.Int64
+	logger        *logging.Logger
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stopChan  chan struct{}
+	done      chan struct{}
+}
+
+func newBatchProcessor(serviceName string, exporter Exporter, batchSize int, flushInterval time.Duration, queueSize int, logger *logging.Logger) *batchProcessor {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	return &batchProcessor{
+		serviceName:   serviceName,
+		exporter:      exporter,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		queue:         make(chan *SpanData, queueSize),
+		logger:        logger,
+		stopChan:      make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+func (p *batchProcessor) start() {
+	p.startOnce.Do(func() { go p.run() })
+}
+
+// enqueue queues a finished span without blocking
+func (p *batchProcessor) enqueue(span *SpanData) {
+	select {
+	case p.queue <- span:
+	default:
+		p.dropped.Add(1)
+	}
+}
+
+func (p *batchProcessor) droppedSpans() int64 {
+	return p.dropped.Load()
+}
+
+func (p *batchProcessor) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*SpanData, 0, p.batchSize)
+	for {
+		select {
+		case span := <-p.queue:
+			batch = append(batch, span)
+			if len(batch) >= p.batchSize {
+				batch = p.export(batch)
+			}
+		case <-ticker.C:
+			batch = p.export(batch)
+		case <-p.stopChan:
+			for {
+				select {
+				case span := <-p.queue:
+					batch = append(batch, span)
+					if len(batch) >= p.batchSize {
+						batch = p.export(batch)
+					}
+				default:
+					p.export(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// export sends one batch and returns the emptied slice for reuse
+func (p *batchProcessor) export(batch []*SpanData) []*SpanData {
+	if len(batch) == 0 {
+		return batch
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	if err := p.exporter.Export(ctx, p.serviceName, batch); err != nil {
+		p.logger.WithError(err).WithFields(logging.Fields{
+			"spans": len(batch),
+		}).Warn("Failed to export spans")
+	}
+	return batch[:0]
+}
+
+// stop drains the queue, exports the remaining spans and shuts the exporter down
+func (p *batchProcessor) stop(ctx context.Context) error {
+	p.stopOnce.Do(func() {
+		p.start()
+		close(p.stopChan)
+	})
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return p.exporter.Shutdown(ctx)
+}
@@ -0,0 +1,267 @@
+/*
+Tracing Span Implementation
+
+Spans record one timed operation within a trace. Trace and span IDs follow the
+W3C Trace Context format so they can be propagated to other services.
+
+Requirements Coverage:
+- REQ-HEALTH-003: System metrics collection and reporting
+
+Test Categories: Unit
+API Documentation Reference: docs/architecture/tracing.md
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TraceID identifies a trace (W3C Trace Context, 16 bytes)
+type TraceID [16]byte
+
+// SpanID identifies a span within a trace (W3C Trace Context, 8 bytes)
+type SpanID [8]byte
+
+// String returns the lowercase hex encoding of the trace ID
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+
+// IsValid reports whether the trace ID is non-zero
+func (t TraceID) IsValid() bool { return t != TraceID{} }
+
+// String returns the lowercase hex encoding of the span ID
+func (s SpanID) String() string { return hex.EncodeToString(s[:]) }
+
+// IsValid reports whether the span ID is non-zero
+func (s SpanID) IsValid() bool { return s != SpanID{} }
+
+// SpanKind describes the relationship of a span to its parent (OTLP values)
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// StatusCode is the outcome of a span (OTLP values)
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = 0
+	StatusOK    StatusCode = 1
+	StatusError StatusCode = 2
+)
+
+// Attribute is a key/value pair attached to a span or event
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute
+func String(key, value string) Attribute { return Attribute{Key: key, Value: value} }
+
+// Int returns an integer attribute
+func Int(key string, value int) Attribute { return Attribute{Key: key, Value: int64(value)} }
+
+// Int64 returns an integer attribute
+func Int64(key string, value int64) Attribute { return Attribute{Key: key, Value: value} }
+
+// Bool returns a boolean attribute
+func Bool(key string, value bool) Attribute { return Attribute{Key: key, Value: value} }
+
+// Float64 returns a floating point attribute
+func Float64(key string, value float64) Attribute { return Attribute{Key: key, Value: value} }
+
+// Event is a timestamped annotation on a span, such as a recorded error
+type Event struct {
+	Name       string
+	Time       time.Time
+	Attributes []Attribute
+}
+
+// Span is one timed operation within a trace. A nil *Span is valid and all of
+// its methods are no-ops, so callers never need to check whether tracing is on.
+type Span struct {
+	tracer *Tracer
+
+	traceID  TraceID
+	spanID   SpanID
+	parentID SpanID
+	sampled  bool
+	name     string
+	kind     SpanKind
+	start    time.Time
+
+	mu            sync.Mutex
+	end           time.Time
+	ended         bool
+	attributes    []Attribute
+	events        []Event
+	status        StatusCode
+	statusMessage string
+}
+
+// SpanData is the immutable snapshot of a finished span handed to exporters
+type SpanData struct {
+	TraceID       TraceID
+	SpanID        SpanID
+	ParentID      SpanID
+	Name          string
+	Kind          SpanKind
+	Start         time.Time
+	End           time.Time
+	Attributes    []Attribute
+	Events        []Event
+	Status        StatusCode
+	StatusMessage string
+}
+
+// TraceID returns the trace ID of the span, or the zero ID for a nil span
+func (s *Span) TraceID() TraceID {
+	if s == nil {
+		return TraceID{}
+	}
+	return s.traceID
+}
+
+// SpanID returns the ID of the span, or the zero ID for a nil span
+func (s *Span) SpanID() SpanID {
+	if s == nil {
+		return SpanID{}
+	}
+	return s.spanID
+}
+
+// IsRecording reports whether the span is sampled and will be exported
+func (s *Span) IsRecording() bool {
+	return s != nil && s.sampled
+}
+
+// TraceParent returns the W3C traceparent header value for the span
+func (s *Span) TraceParent() string {
+	if s == nil {
+		return ""
+	}
+	flags := "00"
+	if s.sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", s.traceID, s.spanID, flags)
+}
+
+// SetAttributes adds or replaces attributes on the span
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if !s.IsRecording() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	for _, attribute := range attributes {
+		s.setAttributeLocked(attribute)
+	}
+}
+
+func (s *Span) setAttributeLocked(attribute Attribute) {
+	for i := range s.attributes {
+		if s.attributes[i].Key == attribute.Key {
+			s.attributes[i].Value = attribute.Value
+			return
+		}
+	}
+	s.attributes = append(s.attributes, attribute)
+}
+
+// RecordError records err as an exception event and marks the span as failed.
+// A nil error is ignored.
+func (s *Span) RecordError(err error) {
+	if err == nil || !s.IsRecording() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.events = append(s.events, Event{
+		Name:       "exception",
+		Time:       time.Now(),
+		Attributes: []Attribute{String("exception.message", err.Error())},
+	})
+	s.status = StatusError
+	s.statusMessage = err.Error()
+}
+
+// SetStatus sets the outcome of the span
+func (s *Span) SetStatus(code StatusCode, message string) {
+	if !s.IsRecording() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.status = code
+	s.statusMessage = message
+}
+
+// End finishes the span and queues it for export. Calls after the first are ignored.
+func (s *Span) End() {
+	if !s.IsRecording() {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	data := &SpanData{
+		TraceID:       s.traceID,
+		SpanID:        s.spanID,
+		ParentID:      s.parentID,
+		Name:          s.name,
+		Kind:          s.kind,
+		Start:         s.start,
+		End:           s.end,
+		Attributes:    s.attributes,
+		Events:        s.events,
+		Status:        s.status,
+		StatusMessage: s.statusMessage,
+	}
+	s.mu.Unlock()
+
+	s.tracer.processor.enqueue(data)
+}
+
+// EndWithError records err (if any) and finishes the span
+func (s *Span) EndWithError(err error) {
+	s.RecordError(err)
+	s.End()
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpan returns a copy of ctx carrying span as the current span
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// SpanFromContext returns the current span of ctx, or nil
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
@@ -0,0 +1,190 @@
+/*
+Tracer Implementation
+
+Creates spans, applies root sampling and owns the export pipeline. The tracer
+installed with SetTracer backs the package-level Start function used by
+instrumented components.
+
+Requirements Coverage:
+- REQ-HEALTH-003: System metrics collection and reporting
+
+Test Categories: Unit
+API Documentation Reference: docs/architecture/tracing.md
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+)
+
+// Exporter names accepted in TracingConfig.Exporter
+const (
+	ExporterOTLP = "otlp"
+	ExporterFile = "file"
+)
+
+// CorrelationIDAttribute is the span attribute holding the logging correlation ID
+const CorrelationIDAttribute = "correlation_id"
+
+// Tracer creates spans and exports the sampled ones
+type Tracer struct {
+	serviceName string
+	sampleRatio float64
+	processor   *batchProcessor
+	logger      *logging.Logger
+}
+
+// NewTracer creates a tracer with the exporter selected by cfg
+func NewTracer(cfg *config.TracingConfig, logger *logging.Logger) (*Tracer, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("tracing configuration cannot be nil")
+	}
+	if logger == nil {
+		logger = logging.GetLogger("tracing")
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "camera-service"
+	}
+
+	var exporter Exporter
+	switch cfg.Exporter {
+	case ExporterOTLP, "":
+		exporter = NewOTLPExporter(cfg.Endpoint)
+	case ExporterFile:
+		fileExporter, err := NewFileExporter(cfg.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		exporter = fileExporter
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter: %s", cfg.Exporter)
+	}
+
+	return newTracer(serviceName, cfg.SampleRatio, exporter, cfg.BatchSize,
+		time.Duration(cfg.FlushIntervalMs)*time.Millisecond, cfg.QueueSize, logger), nil
+}
+
+func newTracer(serviceName string, sampleRatio float64, exporter Exporter, batchSize int, flushInterval time.Duration, queueSize int, logger *logging.Logger) *Tracer {
+	return &Tracer{
+		serviceName: serviceName,
+		sampleRatio: sampleRatio,
+		processor:   newBatchProcessor(serviceName, exporter, batchSize, flushInterval, queueSize, logger),
+		logger:      logger,
+	}
+}
+
+// Start starts the background export loop
+func (t *Tracer) Start(ctx context.Context) error {
+	t.processor.start()
+	t.logger.WithFields(logging.Fields{
+		"service_name": t.serviceName,
+		"sample_ratio": t.sampleRatio,
+	}).Info("Request tracing started")
+	return nil
+}
+
+// Stop flushes queued spans and shuts the exporter down
+func (t *Tracer) Stop(ctx context.Context) error {
+	err := t.processor.stop(ctx)
+	if dropped := t.processor.droppedSpans(); dropped > 0 {
+		t.logger.WithFields(logging.Fields{"dropped_spans": dropped}).Warn("Spans were dropped because the export queue was full")
+	}
+	return err
+}
+
+// StartSpan starts a span as a child of the current span of ctx, or as a new
+// root span subject to sampling. The returned context carries the new span.
+func (t *Tracer) StartSpan(ctx context.Context, name string, kind SpanKind, attributes ...Attribute) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	span := &Span{
+		tracer: t,
+		spanID: newSpanID(),
+		name:   name,
+		kind:   kind,
+		start:  time.Now(),
+	}
+	if parent := SpanFromContext(ctx); parent != nil {
+		span.traceID = parent.traceID
+		span.parentID = parent.spanID
+		span.sampled = parent.sampled
+	} else {
+		span.traceID = newTraceID()
+		span.sampled = t.shouldSample(span.traceID)
+	}
+
+	if span.sampled {
+		span.attributes = make([]Attribute, 0, len(attributes)+1)
+		for _, attribute := range attributes {
+			span.setAttributeLocked(attribute)
+		}
+		if correlationID := logging.GetCorrelationIDFromContext(ctx); correlationID != "" {
+			span.setAttributeLocked(String(CorrelationIDAttribute, correlationID))
+		}
+	}
+
+	return ContextWithSpan(ctx, span), span
+}
+
+// shouldSample makes the root sampling decision from the trace ID, so every
+// service seeing the same trace ID with the same ratio decides alike
+func (t *Tracer) shouldSample(traceID TraceID) bool {
+	if t.sampleRatio >= 1 {
+		return true
+	}
+	if t.sampleRatio <= 0 {
+		return false
+	}
+	return binary.BigEndian.Uint64(traceID[8:]) < uint64(t.sampleRatio*math.MaxUint64)
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		binary.BigEndian.PutUint64(id[:8], rand.Uint64())
+		binary.BigEndian.PutUint64(id[8:], rand.Uint64())
+	}
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		binary.BigEndian.PutUint64(id[:], rand.Uint64())
+	}
+	return id
+}
+
+var globalTracer atomic.Pointer[Tracer]
+
+// SetTracer installs the tracer used by Start. Passing nil disables tracing.
+func SetTracer(t *Tracer) {
+	globalTracer.Store(t)
+}
+
+// Start starts a span with the installed tracer. When tracing is disabled it
+// returns ctx unchanged and a nil span, whose methods are no-ops.
+func Start(ctx context.Context, name string, kind SpanKind, attributes ...Attribute) (context.Context, *Span) {
+	t := globalTracer.Load()
+	if t == nil {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return ctx, nil
+	}
+	return t.StartSpan(ctx, name, kind, attributes...)
+}
@@ -0,0 +1,172 @@
+/*
+Request Tracing Unit Tests
+
+Tests span parenting, correlation ID linking, sampling, traceparent formatting
+and the OTLP/JSON file and HTTP exporters.
+
+Test Categories: Unit
+*/
+
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readExportedSpans reads all spans from an OTLP/JSON lines file
+func readExportedSpans(t *testing.T, path string) []otlpSpan {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var spans []otlpSpan
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var request otlpExportRequest
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &request))
+		require.Len(t, request.ResourceSpans, 1)
+		assert.Equal(t, "service.name", request.ResourceSpans[0].Resource.Attributes[0].Key)
+		for _, scope := range request.ResourceSpans[0].ScopeSpans {
+			spans = append(spans, scope.Spans...)
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return spans
+}
+
+func attributeValue(span otlpSpan, key string) *otlpAnyValue {
+	for _, attribute := range span.Attributes {
+		if attribute.Key == key {
+			return &attribute.Value
+		}
+	}
+	return nil
+}
+
+func TestTracer_FileExporterWritesLinkedSpans(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces", "traces.jsonl")
+	tracer, err := NewTracer(&config.TracingConfig{
+		Enabled:     true,
+		Exporter:    ExporterFile,
+		FilePath:    path,
+		ServiceName: "camera-service-test",
+		SampleRatio: 1,
+		BatchSize:   2,
+	}, nil)
+	require.NoError(t, err)
+	require.NoError(t, tracer.Start(context.Background()))
+
+	ctx := logging.WithCorrelationID(context.Background(), "corr-123")
+	ctx, root := tracer.StartSpan(ctx, "jsonrpc.take_snapshot", SpanKindServer, String("rpc.method", "take_snapshot"))
+	_, child := tracer.StartSpan(ctx, "snapshot.tier0.v4l2_direct", SpanKindInternal, Int("snapshot.tier", 0))
+	child.EndWithError(errors.New("device busy"))
+	root.SetStatus(StatusOK, "")
+	root.End()
+	root.End()
+
+	require.NoError(t, tracer.Stop(context.Background()))
+
+	spans := readExportedSpans(t, path)
+	require.Len(t, spans, 2, "Ending a span twice exports it once")
+	tier, request := spans[0], spans[1]
+
+	assert.Equal(t, request.TraceID, tier.TraceID)
+	assert.Equal(t, request.SpanID, tier.ParentSpanID)
+	assert.Empty(t, request.ParentSpanID)
+	assert.Equal(t, SpanKindServer, request.Kind)
+	assert.Equal(t, StatusOK, request.Status.Code)
+	assert.Equal(t, "take_snapshot", *attributeValue(request, "rpc.method").StringValue)
+	assert.Equal(t, "0", *attributeValue(tier, "snapshot.tier").IntValue)
+
+	for _, span := range spans {
+		require.NotNil(t, attributeValue(span, CorrelationIDAttribute))
+		assert.Equal(t, "corr-123", *attributeValue(span, CorrelationIDAttribute).StringValue)
+	}
+
+	assert.Equal(t, StatusError, tier.Status.Code)
+	assert.Equal(t, "device busy", tier.Status.Message)
+	require.Len(t, tier.Events, 1)
+	assert.Equal(t, "exception", tier.Events[0].Name)
+}
+
+func TestTracer_SamplingIsInheritedByChildren(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := newTracer("test", 0, exporter, 1, time.Hour, 16, logging.GetLogger("tracing"))
+	tracer.processor.start()
+
+	ctx, root := tracer.StartSpan(context.Background(), "root", SpanKindServer)
+	_, child := tracer.StartSpan(ctx, "child", SpanKindInternal)
+	assert.False(t, root.IsRecording())
+	assert.False(t, child.IsRecording())
+	assert.Equal(t, root.TraceID(), child.TraceID(), "Unsampled spans still propagate the trace")
+	assert.Regexp(t, `^00-[0-9a-f]{32}-[0-9a-f]{16}-00$`, child.TraceParent())
+	child.End()
+	root.End()
+
+	require.NoError(t, tracer.Stop(context.Background()))
+	assert.Empty(t, exporter.spans)
+}
+
+func TestStart_NoTracerReturnsNilSpan(t *testing.T) {
+	SetTracer(nil)
+	ctx, span := Start(context.Background(), "noop", SpanKindInternal, String("k", "v"))
+	assert.Nil(t, span)
+	assert.Nil(t, SpanFromContext(ctx))
+
+	// Nil spans are safe to use
+	span.SetAttributes(Bool("ok", true))
+	span.EndWithError(errors.New("ignored"))
+	assert.Empty(t, span.TraceParent())
+}
+
+func TestOTLPExporter_PostsJSONToTracesPath(t *testing.T) {
+	received := make(chan otlpExportRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		var request otlpExportRequest
+		assert.NoError(t, json.Unmarshal(body, &request))
+		received <- request
+	}))
+	defer server.Close()
+
+	tracer := newTracer("camera-service", 1, NewOTLPExporter(server.URL), 10, time.Hour, 16, logging.GetLogger("tracing"))
+	tracer.processor.start()
+	_, span := tracer.StartSpan(context.Background(), "mediamtx.GET", SpanKindClient, Float64("ratio", 0.5))
+	span.End()
+	require.NoError(t, tracer.Stop(context.Background()))
+
+	request := <-received
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 1)
+	assert.Equal(t, "mediamtx.GET", spans[0].Name)
+	assert.Equal(t, SpanKindClient, spans[0].Kind)
+	assert.Equal(t, 0.5, *attributeValue(spans[0], "ratio").DoubleValue)
+}
+
+type recordingExporter struct {
+	spans []*SpanData
+}
+
+func (e *recordingExporter) Export(ctx context.Context, serviceName string, spans []*SpanData) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(ctx context.Context) error { return nil }
@@ -20,6 +20,7 @@ import (
 
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/camera"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/mediamtx"
 )
 
 // EventIntegration connects camera monitor and other components to the event system
@@ -411,3 +412,42 @@ func (n *SystemEventNotifier) NotifySystemHealth(status string, metrics map[stri
 		}).Info("Published system health event")
 	}
 }
+
+// TelemetryEventNotifier implements UAV telemetry event notifications
+type TelemetryEventNotifier struct {
+	eventManager *EventManager
+	logger       *logging.Logger
+}
+
+// NewTelemetryEventNotifier creates a new UAV telemetry event notifier
+func NewTelemetryEventNotifier(eventManager *EventManager, logger *logging.Logger) *TelemetryEventNotifier {
+	return &TelemetryEventNotifier{
+		eventManager: eventManager,
+		logger:       logger,
+	}
+}
+
+// NotifyUAVTelemetry notifies about a decoded telemetry sample of an external UAV stream
+func (n *TelemetryEventNotifier) NotifyUAVTelemetry(telemetry *mediamtx.UAVTelemetry) {
+	eventData := logging.Fields{
+		"device":               telemetry.Stream,
+		"stream_url":           telemetry.StreamURL,
+		"klv_timestamp":        telemetry.Timestamp,
+		"mission_id":           telemetry.MissionID,
+		"platform_designation": telemetry.PlatformDesignation,
+		"image_source_sensor":  telemetry.ImageSourceSensor,
+		"timestamp":            telemetry.ReceivedAt,
+	}
+	if telemetry.Position != nil {
+		eventData["position"] = telemetry.Position
+	}
+
+	if err := n.eventManager.PublishEvent(TopicUAVTelemetry, eventData); err != nil {
+		n.logger.WithError(err).Error("Failed to publish UAV telemetry event")
+	} else {
+		n.logger.WithFields(logging.Fields{
+			"device": telemetry.Stream,
+			"topic":  TopicUAVTelemetry,
+		}).Debug("Published UAV telemetry event")
+	}
+}
@@ -60,6 +60,9 @@ const (
 	TopicMediaMTXRecordingFailed  EventTopic = "mediamtx.recording_failed"
 	TopicMediaMTXStreamStarted    EventTopic = "mediamtx.stream_started"
 	TopicMediaMTXStreamStopped    EventTopic = "mediamtx.stream_stopped"
+
+	// UAV events
+	TopicUAVTelemetry EventTopic = "uav.telemetry"
 )
 
 // EventSubscription represents a client's subscription to specific event topics
@@ -379,6 +382,7 @@ func (em *EventManager) isValidTopic(topic EventTopic) bool {
 		TopicMediaMTXStream, TopicMediaMTXPath, TopicMediaMTXError,
 		TopicMediaMTXRecordingStarted, TopicMediaMTXRecordingStopped, TopicMediaMTXRecordingFailed,
 		TopicMediaMTXStreamStarted, TopicMediaMTXStreamStopped,
+		TopicUAVTelemetry,
 	}
 
 	for _, valid := range validTopics {
@@ -0,0 +1,81 @@
+package websocket
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/mediamtx"
+)
+
+// HTTP file download endpoints served next to the WebSocket endpoint
+const (
+	recordingFilesPath = "/files/recordings/"
+	snapshotFilesPath  = "/files/snapshots/"
+)
+
+// handleFileDownload serves recordings, snapshots and evidence bundles over
+// HTTP with Range support. Requests carry the same JWT used by the
+// authenticate method and need the role required by get_recording_info,
+// get_snapshot_info or export_evidence_bundle.
+func (s *WebSocketServer) handleFileDownload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	fileType, method, filename := "recording", "get_recording_info", strings.TrimPrefix(r.URL.Path, recordingFilesPath)
+	switch {
+	case strings.HasPrefix(r.URL.Path, snapshotFilesPath):
+		fileType, method, filename = "snapshot", "get_snapshot_info", strings.TrimPrefix(r.URL.Path, snapshotFilesPath)
+	case strings.HasPrefix(r.URL.Path, mediamtx.EvidenceBundleFilesPath):
+		fileType, method, filename = "evidence_bundle", "export_evidence_bundle", strings.TrimPrefix(r.URL.Path, mediamtx.EvidenceBundleFilesPath)
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+	claims, err := s.jwtHandler.ValidateToken(token)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+		return
+	}
+	role, err := s.permissionChecker.ValidateRole(claims.Role)
+	if err != nil || !s.permissionChecker.HasPermission(role, method) {
+		http.Error(w, "insufficient permissions", http.StatusForbidden)
+		return
+	}
+
+	file, err := s.mediaMTXController.OpenMediaFile(r.Context(), fileType, filename)
+	if err != nil {
+		switch {
+		case errors.Is(err, mediamtx.ErrInvalidDownloadPath):
+			http.Error(w, "invalid file path", http.StatusBadRequest)
+		case errors.Is(err, os.ErrNotExist):
+			http.NotFound(w, r)
+		default:
+			s.logger.WithError(err).WithFields(logging.Fields{
+				"file_type": fileType,
+				"filename":  filename,
+				"action":    "file_download",
+			}).Error("Failed to open file for download")
+			http.Error(w, "failed to open file", http.StatusInternalServerError)
+		}
+		return
+	}
+	defer file.Close()
+
+	// Recordings can take longer to send than the server write timeout allows
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Disposition", `attachment; filename="`+strings.ReplaceAll(file.Name, `"`, "")+`"`)
+	http.ServeContent(w, r, file.Name, file.ModTime, file)
+}
@@ -2,6 +2,7 @@ package websocket
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync/atomic"
@@ -12,8 +13,8 @@ import (
 )
 
 // methodWrapper provides common method execution pattern with logging and error handling.
-func (s *WebSocketServer) methodWrapper(methodName string, handler func() (interface{}, error)) func(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
-	return func(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) methodWrapper(methodName string, handler func() (interface{}, error)) func(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+	return func(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 		s.logger.WithFields(logging.Fields{
 			"client_id": client.ClientID,
 			"method":    methodName,
@@ -91,8 +92,8 @@ func (s *WebSocketServer) assertResponseFields(method string, result interface{}
 }
 
 // authenticatedMethodWrapper wraps methods that require authentication using centralized security check
-func (s *WebSocketServer) authenticatedMethodWrapper(methodName string, handler func() (interface{}, error)) func(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
-	return func(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) authenticatedMethodWrapper(methodName string, handler func() (interface{}, error)) func(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+	return func(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 		// Centralized authentication check - replaces 20+ duplicate checks
 		if !client.Authenticated {
 			s.logger.WithFields(logging.Fields{
@@ -118,7 +119,7 @@ func (s *WebSocketServer) authenticatedMethodWrapper(methodName string, handler
 		}).Debug("Authentication check passed")
 
 		// Call base method wrapper for common logging and error handling
-		return s.methodWrapper(methodName, handler)(params, client)
+		return s.methodWrapper(methodName, handler)(ctx, params, client)
 	}
 }
 
@@ -148,6 +149,9 @@ func (s *WebSocketServer) registerBuiltinMethods() {
 	s.registerMethod("get_storage_info", s.MethodGetStorageInfo, "1.0")
 	s.registerMethod("set_retention_policy", s.MethodSetRetentionPolicy, "1.0")
 	s.registerMethod("cleanup_old_files", s.MethodCleanupOldFiles, "1.0")
+	s.registerMethod("verify_file_integrity", s.MethodVerifyFileIntegrity, "1.0")
+	s.registerMethod("export_evidence_bundle", s.MethodExportEvidenceBundle, "1.0")
+	s.registerMethod("rotate_storage_key", s.MethodRotateStorageKey, "1.0")
 
 	// Recording and snapshot methods
 	s.registerMethod("take_snapshot", s.MethodTakeSnapshot, "1.0")
@@ -183,7 +187,7 @@ func (s *WebSocketServer) registerBuiltinMethods() {
 // registerMethod registers a JSON-RPC method handler
 func (s *WebSocketServer) registerMethod(name string, handler MethodHandler, version string) {
 	// Wrap the handler to ensure security, readiness, and metrics are always applied
-	wrappedHandler := func(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+	wrappedHandler := func(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 		startTime := time.Now()
 
 		// Apply security checks
@@ -212,17 +216,21 @@ func (s *WebSocketServer) registerMethod(name string, handler MethodHandler, ver
 		}
 
 		// Call the original handler
-		response, err := handler(params, client)
+		response, err := handler(ctx, params, client)
 
 		// Record metrics
 		duration := time.Since(startTime).Seconds()
 		s.recordRequest(name, duration)
+		s.requestLatency.Observe(name, duration)
 
 		// Handle errors
 		if err != nil {
 			// Use atomic operation for ErrorCount
 			atomic.AddInt64(&s.metrics.ErrorCount, 1)
 		}
+		if err != nil || (response != nil && response.Error != nil) {
+			s.requestErrors.Inc(name)
+		}
 
 		return response, err
 	}
@@ -241,7 +249,7 @@ func (s *WebSocketServer) registerMethod(name string, handler MethodHandler, ver
 // MethodPing implements the ping method
 // Authentication: Not required (per API documentation)
 // Purpose: Connectivity + envelope sanity check before authenticate
-func (s *WebSocketServer) MethodPing(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodPing(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	// Record performance metrics
 	startTime := time.Now()
 	duration := time.Since(startTime).Seconds()
@@ -257,7 +265,7 @@ func (s *WebSocketServer) MethodPing(params map[string]interface{}, client *Clie
 }
 
 // MethodAuthenticate implements the authenticate method
-func (s *WebSocketServer) MethodAuthenticate(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodAuthenticate(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	// Handle authentication errors directly to return proper error codes
 	// Extract auth_token parameter
 	authToken, ok := params["auth_token"].(string)
@@ -327,7 +335,7 @@ func (s *WebSocketServer) MethodAuthenticate(params map[string]interface{}, clie
 }
 
 // MethodGetCameraList implements the get_camera_list method
-func (s *WebSocketServer) MethodGetCameraList(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetCameraList(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	// Delegates to MediaMTX Controller for business logic
 	return s.authenticatedMethodWrapper("get_camera_list", func() (interface{}, error) {
 		// STRICT PARAMETER VALIDATION: get_camera_list should accept NO parameters
@@ -344,7 +352,7 @@ func (s *WebSocketServer) MethodGetCameraList(params map[string]interface{}, cli
 		}
 
 		// Delegate to MediaMTX controller - returns API-ready APICameraInfo format
-		cameraListResponse, err := s.mediaMTXController.GetCameraList(context.Background())
+		cameraListResponse, err := s.mediaMTXController.GetCameraList(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -352,10 +360,10 @@ func (s *WebSocketServer) MethodGetCameraList(params map[string]interface{}, cli
 		// MediaMTX Controller handles the API formatting through PathManager abstraction
 		// Simply return the API-ready response
 		return cameraListResponse, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
-func (s *WebSocketServer) MethodGetCameraStatus(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetCameraStatus(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("get_camera_status", func() (interface{}, error) {
 		// Validate device parameter using centralized validation
 		validationResult := s.validationHelper.ValidateDeviceParameter(params)
@@ -371,21 +379,21 @@ func (s *WebSocketServer) MethodGetCameraStatus(params map[string]interface{}, c
 		}
 
 		// Delegate to controller - let wrapper handle error translation
-		return s.mediaMTXController.GetCameraStatus(context.Background(), cameraID)
-	})(params, client)
+		return s.mediaMTXController.GetCameraStatus(ctx, cameraID)
+	})(ctx, params, client)
 }
 
 // MethodGetMetrics implements the get_metrics method
 // Thin delegation - Controller returns API-ready GetMetricsResponse
-func (s *WebSocketServer) MethodGetMetrics(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetMetrics(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	// Uses wrapper helpers for consistent method execution
 	return s.authenticatedMethodWrapper("get_metrics", func() (interface{}, error) {
 		// Pure delegation to Controller - returns complete API-ready response
-		return s.mediaMTXController.GetMetrics(context.Background())
-	})(params, client)
+		return s.mediaMTXController.GetMetrics(ctx)
+	})(ctx, params, client)
 }
 
-func (s *WebSocketServer) MethodGetCameraCapabilities(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetCameraCapabilities(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	// Centralized authentication check
 	if !client.Authenticated {
 		s.logger.WithFields(logging.Fields{
@@ -416,7 +424,7 @@ func (s *WebSocketServer) MethodGetCameraCapabilities(params map[string]interfac
 	device := validationResult.Data["device"].(string)
 
 	// Pure delegation to Controller - returns API-ready GetCameraCapabilitiesResponse
-	capabilitiesResponse, err := s.mediaMTXController.GetCameraCapabilities(context.Background(), device)
+	capabilitiesResponse, err := s.mediaMTXController.GetCameraCapabilities(ctx, device)
 	if err != nil {
 		// ✅ FIX 1: Map camera-specific errors to proper API error codes
 		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "not available") {
@@ -439,13 +447,13 @@ func (s *WebSocketServer) MethodGetCameraCapabilities(params map[string]interfac
 }
 
 // MethodGetStatus implements the get_status method
-func (s *WebSocketServer) MethodGetStatus(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetStatus(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	// Uses wrapper helpers for consistent method execution
 	return s.authenticatedMethodWrapper("get_status", func() (interface{}, error) {
 
 		// Pure delegation to MediaMTX controller - returns API-ready response with comprehensive health status
 		if s.mediaMTXController != nil {
-			health, err := s.mediaMTXController.GetHealth(context.Background())
+			health, err := s.mediaMTXController.GetHealth(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get health status: %w", err)
 			}
@@ -470,45 +478,45 @@ func (s *WebSocketServer) MethodGetStatus(params map[string]interface{}, client
 				"mediamtx":         "error",
 			},
 		}, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodGetSystemStatus implements the get_system_status method
 // Returns detailed system readiness information
-func (s *WebSocketServer) MethodGetSystemStatus(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetSystemStatus(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	// Uses wrapper helpers for consistent method execution
 	return s.authenticatedMethodWrapper("get_system_status", func() (interface{}, error) {
 		// Return system readiness response - no additional processing needed
 		return s.getSystemReadinessResponse(), nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodGetServerInfo implements the get_server_info method
-func (s *WebSocketServer) MethodGetServerInfo(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetServerInfo(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	// Uses wrapper helpers for consistent method execution
 	return s.authenticatedMethodWrapper("get_server_info", func() (interface{}, error) {
 		// Pure delegation to Controller - returns API-ready GetServerInfoResponse
-		return s.mediaMTXController.GetServerInfo(context.Background())
-	})(params, client)
+		return s.mediaMTXController.GetServerInfo(ctx)
+	})(ctx, params, client)
 }
 
 // MethodGetStreams implements the get_streams method
-func (s *WebSocketServer) MethodGetStreams(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetStreams(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	// Uses wrapper helpers for consistent method execution
 	return s.authenticatedMethodWrapper("get_streams", func() (interface{}, error) {
 		// Delegate to MediaMTX controller - investigate what it returns
-		streams, err := s.mediaMTXController.GetStreams(context.Background())
+		streams, err := s.mediaMTXController.GetStreams(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get streams from MediaMTX service: %v", err)
 		}
 
 		// Return Controller's API-ready response directly - thin delegation
 		return streams, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodListRecordings implements the list_recordings method
-func (s *WebSocketServer) MethodListRecordings(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodListRecordings(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("list_recordings", func() (interface{}, error) {
 		// Extract parameters WITHOUT defaults
 		limit := 0  // No default!
@@ -527,18 +535,18 @@ func (s *WebSocketServer) MethodListRecordings(params map[string]interface{}, cl
 		}
 
 		// Pure delegation - RecordingManager handles defaults
-		fileList, err := s.mediaMTXController.ListRecordings(context.Background(), limit, offset)
+		fileList, err := s.mediaMTXController.ListRecordings(ctx, limit, offset)
 		if err != nil {
 			return nil, fmt.Errorf("error getting recordings list: %v", err)
 		}
 
 		// Return Controller's API-ready response directly - thin delegation
 		return fileList, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodDeleteRecording implements the delete_recording method
-func (s *WebSocketServer) MethodDeleteRecording(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodDeleteRecording(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("delete_recording", func() (interface{}, error) {
 
 		// Validate parameters
@@ -552,7 +560,7 @@ func (s *WebSocketServer) MethodDeleteRecording(params map[string]interface{}, c
 		}
 
 		// Delegate to controller - let wrapper handle error translation
-		err := s.mediaMTXController.DeleteRecording(context.Background(), filename)
+		err := s.mediaMTXController.DeleteRecording(ctx, filename)
 		if err != nil {
 			return nil, fmt.Errorf("error deleting recording: %v", err)
 		}
@@ -563,11 +571,11 @@ func (s *WebSocketServer) MethodDeleteRecording(params map[string]interface{}, c
 			"deleted":  true,
 			"message":  "Recording file deleted successfully",
 		}, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodDeleteSnapshot implements the delete_snapshot method
-func (s *WebSocketServer) MethodDeleteSnapshot(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodDeleteSnapshot(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	// Centralized authentication check
 	if !client.Authenticated {
 		s.logger.WithFields(logging.Fields{
@@ -597,7 +605,7 @@ func (s *WebSocketServer) MethodDeleteSnapshot(params map[string]interface{}, cl
 	filename := validationResult.Data["filename"].(string)
 
 	// Use MediaMTX controller to delete snapshot - thin delegation
-	err := s.mediaMTXController.DeleteSnapshot(context.Background(), filename)
+	err := s.mediaMTXController.DeleteSnapshot(ctx, filename)
 	if err != nil {
 		// Map specific errors to JSON-RPC error codes
 		if strings.Contains(strings.ToLower(err.Error()), "not found") {
@@ -624,34 +632,88 @@ func (s *WebSocketServer) MethodDeleteSnapshot(params map[string]interface{}, cl
 	}, nil
 }
 
-func (s *WebSocketServer) MethodGetStorageInfo(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetStorageInfo(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("get_storage_info", func() (interface{}, error) {
 
 		// Get storage info from controller (thin delegation)
-		info, err := s.mediaMTXController.GetStorageInfo(context.Background())
+		info, err := s.mediaMTXController.GetStorageInfo(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("error getting storage information: %v", err)
 		}
 
 		// Return Controller's API-ready response directly - thin delegation
 		return info, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
-func (s *WebSocketServer) MethodCleanupOldFiles(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodCleanupOldFiles(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 
 	return s.authenticatedMethodWrapper("cleanup_old_files", func() (interface{}, error) {
+		// Optional dry run reports what would be deleted without deleting anything
+		dryRun := false
+		if params != nil {
+			if value, exists := params["dry_run"]; exists {
+				flag, ok := value.(bool)
+				if !ok {
+					return nil, fmt.Errorf("validation failed: dry_run parameter must be a boolean")
+				}
+				dryRun = flag
+			}
+		}
+
 		// Delegate to Controller for cleanup logic (single source of truth)
-		result, err := s.mediaMTXController.CleanupOldFiles(context.Background())
+		result, err := s.mediaMTXController.CleanupOldFiles(ctx, dryRun)
 		if err != nil {
 			return nil, fmt.Errorf("failed to cleanup old files: %v", err)
 		}
 		return result, nil
-	})(params, client)
+	})(ctx, params, client)
+}
+
+// MethodVerifyFileIntegrity implements the verify_file_integrity method
+func (s *WebSocketServer) MethodVerifyFileIntegrity(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+	return s.authenticatedMethodWrapper("verify_file_integrity", func() (interface{}, error) {
+		validationResult := s.validationHelper.ValidateFileIntegrityParameters(params)
+		if !validationResult.Valid {
+			s.validationHelper.LogValidationWarnings(validationResult, "verify_file_integrity", client.ClientID)
+			return nil, fmt.Errorf("validation failed: %s", validationResult.Errors[0])
+		}
+
+		filename := validationResult.Data["filename"].(string)
+		fileType := validationResult.Data["file_type"].(string)
+
+		// Delegate to Controller - returns API-ready VerifyFileIntegrityResponse
+		return s.mediaMTXController.VerifyFileIntegrity(ctx, filename, fileType)
+	})(ctx, params, client)
+}
+
+// MethodExportEvidenceBundle implements the export_evidence_bundle method
+func (s *WebSocketServer) MethodExportEvidenceBundle(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+	return s.authenticatedMethodWrapper("export_evidence_bundle", func() (interface{}, error) {
+		validationResult := s.validationHelper.ValidateFileIntegrityParameters(params)
+		if !validationResult.Valid {
+			s.validationHelper.LogValidationWarnings(validationResult, "export_evidence_bundle", client.ClientID)
+			return nil, fmt.Errorf("validation failed: %s", validationResult.Errors[0])
+		}
+
+		filename := validationResult.Data["filename"].(string)
+		fileType := validationResult.Data["file_type"].(string)
+
+		// Delegate to Controller - returns API-ready ExportEvidenceBundleResponse
+		return s.mediaMTXController.ExportEvidenceBundle(ctx, filename, fileType)
+	})(ctx, params, client)
+}
+
+// MethodRotateStorageKey implements the rotate_storage_key method
+func (s *WebSocketServer) MethodRotateStorageKey(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+	return s.authenticatedMethodWrapper("rotate_storage_key", func() (interface{}, error) {
+		// Delegate to Controller - returns API-ready RotateStorageKeyResponse
+		return s.mediaMTXController.RotateStorageKey(ctx)
+	})(ctx, params, client)
 }
 
 // MethodSetRetentionPolicy implements the set_retention_policy method
-func (s *WebSocketServer) MethodSetRetentionPolicy(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodSetRetentionPolicy(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("set_retention_policy", func() (interface{}, error) {
 		// Validate retention policy parameters
 		validationResult := s.validationHelper.ValidateRetentionPolicyParameters(params)
@@ -665,15 +727,15 @@ func (s *WebSocketServer) MethodSetRetentionPolicy(params map[string]interface{}
 		enabled := validationResult.Data["enabled"].(bool)
 
 		// Delegate to Controller for retention policy logic (single source of truth)
-		result, err := s.mediaMTXController.SetRetentionPolicy(context.Background(), enabled, policyType, params)
+		result, err := s.mediaMTXController.SetRetentionPolicy(ctx, enabled, policyType, params)
 		if err != nil {
 			return nil, fmt.Errorf("failed to set retention policy: %v", err)
 		}
 		return result, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
-func (s *WebSocketServer) MethodListSnapshots(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodListSnapshots(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("list_snapshots", func() (interface{}, error) {
 
 		// Validate pagination parameters
@@ -688,7 +750,7 @@ func (s *WebSocketServer) MethodListSnapshots(params map[string]interface{}, cli
 		offset := validationResult.Data["offset"].(int)
 
 		// Use MediaMTX controller to get snapshots list - thin delegation
-		fileList, err := s.mediaMTXController.ListSnapshots(context.Background(), limit, offset)
+		fileList, err := s.mediaMTXController.ListSnapshots(ctx, limit, offset)
 		if err != nil {
 			return nil, fmt.Errorf("error getting snapshots list: %v", err)
 		}
@@ -724,11 +786,11 @@ func (s *WebSocketServer) MethodListSnapshots(params map[string]interface{}, cli
 			"limit":  fileList.Limit,
 			"offset": fileList.Offset,
 		}, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodTakeSnapshot implements the take_snapshot method
-func (s *WebSocketServer) MethodTakeSnapshot(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodTakeSnapshot(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("take_snapshot", func() (interface{}, error) {
 		// 1. Input validation only (API contract validation)
 		validationResult := s.validationHelper.ValidateSnapshotParameters(params)
@@ -746,27 +808,27 @@ func (s *WebSocketServer) MethodTakeSnapshot(params map[string]interface{}, clie
 
 		// 3. Pure delegation - Controller and SnapshotManager handle all business logic
 		// No duplicate validation, no response formatting, no business logic
-		snapshot, err := s.mediaMTXController.TakeAdvancedSnapshot(context.Background(), devicePath, options)
+		snapshot, err := s.mediaMTXController.TakeAdvancedSnapshot(ctx, devicePath, options)
 		if err != nil {
-			return nil, fmt.Errorf("failed to take snapshot: %v", err)
+			return nil, fmt.Errorf("failed to take snapshot: %w", err)
 		}
 
 		// 4. Return snapshot as-is - SnapshotManager should provide API-ready response
 		return snapshot, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodStartRecording implements the start_recording method
-func (s *WebSocketServer) MethodStartRecording(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodStartRecording(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("start_recording", func() (interface{}, error) {
 		// Pure delegation - pass raw params to controller
 		// Controller handles all parameter extraction and business logic
-		return s.mediaMTXController.StartRecording(context.Background(), params)
-	})(params, client)
+		return s.mediaMTXController.StartRecording(ctx, params)
+	})(ctx, params, client)
 }
 
 // MethodStopRecording implements the stop_recording method
-func (s *WebSocketServer) MethodStopRecording(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodStopRecording(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("stop_recording", func() (interface{}, error) {
 		// Validate parameters
 		if params == nil {
@@ -785,11 +847,11 @@ func (s *WebSocketServer) MethodStopRecording(params map[string]interface{}, cli
 		}
 
 		// Pure delegation to Controller - returns API-ready StopRecordingResponse
-		return s.mediaMTXController.StopRecording(context.Background(), cameraID)
-	})(params, client)
+		return s.mediaMTXController.StopRecording(ctx, cameraID)
+	})(ctx, params, client)
 }
 
-func (s *WebSocketServer) MethodGetRecordingInfo(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetRecordingInfo(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	// Centralized authentication check
 	if !client.Authenticated {
 		s.logger.WithFields(logging.Fields{
@@ -819,7 +881,7 @@ func (s *WebSocketServer) MethodGetRecordingInfo(params map[string]interface{},
 	filename := validationResult.Data["filename"].(string)
 
 	// Pure delegation to Controller - returns API-ready GetRecordingInfoResponse
-	recordingInfo, err := s.mediaMTXController.GetRecordingInfo(context.Background(), filename)
+	recordingInfo, err := s.mediaMTXController.GetRecordingInfo(ctx, filename)
 	if err != nil {
 		// Map specific errors to JSON-RPC error codes
 		if strings.Contains(strings.ToLower(err.Error()), "not found") {
@@ -843,7 +905,7 @@ func (s *WebSocketServer) MethodGetRecordingInfo(params map[string]interface{},
 }
 
 // MethodGetSnapshotInfo implements the get_snapshot_info method
-func (s *WebSocketServer) MethodGetSnapshotInfo(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetSnapshotInfo(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("get_snapshot_info", func() (interface{}, error) {
 
 		// Validate filename parameter
@@ -857,8 +919,8 @@ func (s *WebSocketServer) MethodGetSnapshotInfo(params map[string]interface{}, c
 		filename := validationResult.Data["filename"].(string)
 
 		// Pure delegation to Controller - returns API-ready GetSnapshotInfoResponse
-		return s.mediaMTXController.GetSnapshotInfo(context.Background(), filename)
-	})(params, client)
+		return s.mediaMTXController.GetSnapshotInfo(ctx, filename)
+	})(ctx, params, client)
 }
 
 // ARCHITECTURE FIX: These are notification handlers, not callable methods
@@ -896,7 +958,7 @@ func (s *WebSocketServer) handleRecordingStatusUpdateNotification(params map[str
 }
 
 // MethodSubscribeEvents handles client subscription to event topics
-func (s *WebSocketServer) MethodSubscribeEvents(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodSubscribeEvents(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("subscribe_events", func() (interface{}, error) {
 		// Validate required parameters
 		topicsParam, exists := params["topics"]
@@ -948,11 +1010,11 @@ func (s *WebSocketServer) MethodSubscribeEvents(params map[string]interface{}, c
 			"topics":     topics,
 			"filters":    filters,
 		}, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodUnsubscribeEvents handles client unsubscription from event topics
-func (s *WebSocketServer) MethodUnsubscribeEvents(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodUnsubscribeEvents(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("unsubscribe_events", func() (interface{}, error) {
 		// Parse optional topics parameter (if not provided, unsubscribe from all)
 		var topics []EventTopic
@@ -985,11 +1047,11 @@ func (s *WebSocketServer) MethodUnsubscribeEvents(params map[string]interface{},
 			"unsubscribed": true,
 			"topics":       topics,
 		}, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodGetSubscriptionStats returns statistics about event subscriptions
-func (s *WebSocketServer) MethodGetSubscriptionStats(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetSubscriptionStats(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.methodWrapper("get_subscription_stats", func() (interface{}, error) {
 		// Get subscription statistics
 		stats := s.eventManager.GetSubscriptionStats()
@@ -1002,11 +1064,11 @@ func (s *WebSocketServer) MethodGetSubscriptionStats(params map[string]interface
 			"client_topics": clientTopics,
 			"client_id":     client.ClientID,
 		}, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodStartStreaming starts a live streaming session for the specified camera device
-func (s *WebSocketServer) MethodStartStreaming(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodStartStreaming(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("start_streaming", func() (interface{}, error) {
 		// Validate device parameter using centralized validation
 		validationResult := s.validationHelper.ValidateDeviceParameter(params)
@@ -1020,12 +1082,12 @@ func (s *WebSocketServer) MethodStartStreaming(params map[string]interface{}, cl
 		device := validationResult.Data["device"].(string)
 
 		// Pure delegation to Controller - returns API-ready GetStreamURLResponse
-		return s.mediaMTXController.StartStreaming(context.Background(), device)
-	})(params, client)
+		return s.mediaMTXController.StartStreaming(ctx, device)
+	})(ctx, params, client)
 }
 
 // MethodStopStreaming stops the active streaming session for the specified camera device
-func (s *WebSocketServer) MethodStopStreaming(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodStopStreaming(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("stop_streaming", func() (interface{}, error) {
 		// Validate device parameter using centralized validation
 		validationResult := s.validationHelper.ValidateDeviceParameter(params)
@@ -1039,7 +1101,7 @@ func (s *WebSocketServer) MethodStopStreaming(params map[string]interface{}, cli
 		device := validationResult.Data["device"].(string)
 
 		// Stop streaming using controller (maps internally)
-		err := s.mediaMTXController.StopStreaming(context.Background(), device)
+		err := s.mediaMTXController.StopStreaming(ctx, device)
 		if err != nil {
 			return nil, fmt.Errorf("failed to stop streaming: %v", err)
 		}
@@ -1054,11 +1116,11 @@ func (s *WebSocketServer) MethodStopStreaming(params map[string]interface{}, cli
 			"duration":         300,
 			"stream_continues": false,
 		}, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodGetStreamURL gets the stream URL for a specific camera device
-func (s *WebSocketServer) MethodGetStreamURL(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetStreamURL(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("get_stream_url", func() (interface{}, error) {
 		// Validate device parameter using centralized validation
 		validationResult := s.validationHelper.ValidateDeviceParameter(params)
@@ -1072,18 +1134,18 @@ func (s *WebSocketServer) MethodGetStreamURL(params map[string]interface{}, clie
 		device := validationResult.Data["device"].(string)
 
 		// ULTRA THIN: Delegate to Controller - returns complete API-ready response
-		streamURLResp, err := s.mediaMTXController.GetStreamURL(context.Background(), device)
+		streamURLResp, err := s.mediaMTXController.GetStreamURL(ctx, device)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get stream URL: %v", err)
 		}
 
 		// Return Controller's API-ready response directly - no business logic duplication
 		return streamURLResp, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodGetStreamStatus gets detailed status information for a specific camera stream
-func (s *WebSocketServer) MethodGetStreamStatus(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetStreamStatus(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("get_stream_status", func() (interface{}, error) {
 		// Validate device parameter using centralized validation
 		validationResult := s.validationHelper.ValidateDeviceParameter(params)
@@ -1097,18 +1159,18 @@ func (s *WebSocketServer) MethodGetStreamStatus(params map[string]interface{}, c
 		device := validationResult.Data["device"].(string)
 
 		// Get stream status from controller using camera ID
-		stream, err := s.mediaMTXController.GetStreamStatus(context.Background(), device)
+		stream, err := s.mediaMTXController.GetStreamStatus(ctx, device)
 		if err != nil {
 			return nil, fmt.Errorf("stream not found or not active: %v", err)
 		}
 
 		// ULTRA THIN: Return Controller's API-ready response directly - no business logic duplication
 		return stream, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodDiscoverExternalStreams discovers external streams (Skydio UAVs, etc.)
-func (s *WebSocketServer) MethodDiscoverExternalStreams(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodDiscoverExternalStreams(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("discover_external_streams", func() (interface{}, error) {
 		// Get discovery options from params
 		options := mediamtx.DiscoveryOptions{
@@ -1130,17 +1192,17 @@ func (s *WebSocketServer) MethodDiscoverExternalStreams(params map[string]interf
 		}
 
 		// Trigger discovery with options
-		result, err := s.mediaMTXController.DiscoverExternalStreams(context.Background(), options)
+		result, err := s.mediaMTXController.DiscoverExternalStreams(ctx, options)
 		if err != nil {
 			return nil, err
 		}
 
 		return result, nil
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // MethodAddExternalStream adds an external stream to the system
-func (s *WebSocketServer) MethodAddExternalStream(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodAddExternalStream(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("add_external_stream", func() (interface{}, error) {
 		// Extract stream parameters
 		streamURL, ok := params["stream_url"].(string)
@@ -1173,12 +1235,12 @@ func (s *WebSocketServer) MethodAddExternalStream(params map[string]interface{},
 		}
 
 		// Pure delegation to Controller - returns API-ready AddExternalStreamResponse
-		return s.mediaMTXController.AddExternalStream(context.Background(), stream)
-	})(params, client)
+		return s.mediaMTXController.AddExternalStream(ctx, stream)
+	})(ctx, params, client)
 }
 
 // MethodRemoveExternalStream removes an external stream from the system
-func (s *WebSocketServer) MethodRemoveExternalStream(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodRemoveExternalStream(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("remove_external_stream", func() (interface{}, error) {
 		// Extract stream URL
 		streamURL, ok := params["stream_url"].(string)
@@ -1187,20 +1249,20 @@ func (s *WebSocketServer) MethodRemoveExternalStream(params map[string]interface
 		}
 
 		// Pure delegation to Controller - returns API-ready RemoveExternalStreamResponse
-		return s.mediaMTXController.RemoveExternalStream(context.Background(), streamURL)
-	})(params, client)
+		return s.mediaMTXController.RemoveExternalStream(ctx, streamURL)
+	})(ctx, params, client)
 }
 
 // MethodGetExternalStreams returns all discovered external streams
-func (s *WebSocketServer) MethodGetExternalStreams(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodGetExternalStreams(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("get_external_streams", func() (interface{}, error) {
 		// Pure delegation to Controller - returns API-ready GetExternalStreamsResponse
-		return s.mediaMTXController.GetExternalStreams(context.Background())
-	})(params, client)
+		return s.mediaMTXController.GetExternalStreams(ctx)
+	})(ctx, params, client)
 }
 
 // MethodSetDiscoveryInterval sets the discovery scan interval
-func (s *WebSocketServer) MethodSetDiscoveryInterval(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
+func (s *WebSocketServer) MethodSetDiscoveryInterval(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error) {
 	return s.authenticatedMethodWrapper("set_discovery_interval", func() (interface{}, error) {
 		// Extract interval parameter
 		interval, ok := params["scan_interval"].(float64)
@@ -1215,13 +1277,49 @@ func (s *WebSocketServer) MethodSetDiscoveryInterval(params map[string]interface
 
 		// Pure delegation to Controller - returns API-ready SetDiscoveryIntervalResponse
 		return s.mediaMTXController.SetDiscoveryInterval(int(interval))
-	})(params, client)
+	})(ctx, params, client)
 }
 
 // translateErrorToJsonRpc converts business logic errors to appropriate JSON-RPC errors
 func (s *WebSocketServer) translateErrorToJsonRpc(err error, methodName string) *JsonRpcError {
 	errMsg := err.Error()
 
+	// Storage over block threshold - return the storage state so clients can react
+	var storageErr *mediamtx.StorageError
+	if errors.As(err, &storageErr) {
+		return &JsonRpcError{
+			Code:    INSUFFICIENT_STORAGE,
+			Message: ErrorMessages[INSUFFICIENT_STORAGE],
+			Data: &StorageErrorData{
+				ErrorData: ErrorData{
+					Reason:     "storage_blocked",
+					Details:    storageErr.Reason,
+					Suggestion: "Free storage space or configure a fallback path",
+				},
+				Path:         storageErr.Path,
+				UsagePercent: storageErr.UsagePercent,
+				BlockPercent: storageErr.BlockPercent,
+				FallbackPath: storageErr.FallbackPath,
+			},
+		}
+	}
+
+	// Chain-of-custody errors
+	if errors.Is(err, mediamtx.ErrCustodyDisabled) {
+		return NewJsonRpcError(UNSUPPORTED, "feature_disabled",
+			"Chain-of-custody tracking is disabled in configuration", "Enable custody in configuration")
+	}
+	if errors.Is(err, mediamtx.ErrCustodyRecordNotFound) {
+		return NewJsonRpcError(FILE_NOT_FOUND, "custody_record_not_found",
+			"No custody record found for file", "Verify filename and file_type")
+	}
+
+	// Encryption at rest errors
+	if errors.Is(err, mediamtx.ErrStorageEncryptionDisabled) {
+		return NewJsonRpcError(UNSUPPORTED, "feature_disabled",
+			"Storage encryption is disabled in configuration", "Enable storage.encryption in configuration")
+	}
+
 	// External discovery disabled error - check for both variations
 	if (strings.Contains(strings.ToLower(errMsg), "external stream discovery") ||
 		strings.Contains(strings.ToLower(errMsg), "external discovery")) &&
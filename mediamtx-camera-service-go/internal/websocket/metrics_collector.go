@@ -0,0 +1,61 @@
+/*
+WebSocket Server Prometheus Metrics
+
+Exposes connection, request and event subscription metrics of the JSON-RPC
+server for the HTTP health server's metrics endpoint, including per-method
+latency histograms recorded by the method wrapper.
+
+Requirements Coverage:
+- REQ-API-001: JSON-RPC API compliance for metrics endpoints
+- REQ-HEALTH-003: System metrics collection and reporting
+
+Test Categories: Unit/Integration
+API Documentation Reference: docs/api/health-endpoints.md
+*/
+
+package websocket
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/metrics"
+)
+
+// CollectMetrics returns the server metrics (implements metrics.Collector)
+func (s *WebSocketServer) CollectMetrics(ctx context.Context) []metrics.Family {
+	families := []metrics.Family{
+		metrics.Gauge(metrics.Name("websocket", "connections"),
+			"Currently connected WebSocket clients.",
+			metrics.Value(float64(atomic.LoadInt64(&s.metrics.ActiveConnections)))),
+		metrics.Counter(metrics.Name("websocket", "errors_total"),
+			"WebSocket connection and request errors.",
+			metrics.Value(float64(atomic.LoadInt64(&s.metrics.ErrorCount)))),
+		metrics.Gauge(metrics.Name("websocket", "start_time_seconds"),
+			"Start time of the WebSocket server since the Unix epoch.",
+			metrics.Value(float64(s.metrics.StartTime.Unix()))),
+		s.requestLatency.Family(metrics.Name("rpc", "request_duration_seconds"),
+			"JSON-RPC method handler latency."),
+		s.requestErrors.Family(metrics.Name("rpc", "errors_total"),
+			"JSON-RPC requests that returned an error, by method."),
+	}
+
+	stats := s.eventManager.GetSubscriptionStats()
+	subscriptions := metrics.Gauge(metrics.Name("events", "subscriptions"),
+		"Clients subscribed to each event topic.")
+	if topicCounts, ok := stats["topic_counts"].(map[string]int); ok {
+		topics := make([]string, 0, len(topicCounts))
+		for topic := range topicCounts {
+			topics = append(topics, topic)
+		}
+		sort.Strings(topics)
+		for _, topic := range topics {
+			subscriptions.Samples = append(subscriptions.Samples, metrics.Sample{
+				Labels: metrics.Labels{"topic": topic},
+				Value:  float64(topicCounts[topic]),
+			})
+		}
+	}
+	return append(families, subscriptions)
+}
@@ -15,7 +15,9 @@ import (
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/logging"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/mediamtx"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/metrics"
 	"github.com/camerarecorder/mediamtx-camera-service-go/internal/security"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/tracing"
 	"github.com/gorilla/websocket"
 )
 
@@ -53,8 +55,10 @@ type WebSocketServer struct {
 	builtinMethodsReady int32                    // Atomic flag for builtin method initialization
 
 	// Performance Monitoring
-	metrics      *PerformanceMetrics // Request/response performance tracking
-	metricsMutex sync.RWMutex        // Protects metrics updates
+	metrics        *PerformanceMetrics   // Request/response performance tracking
+	metricsMutex   sync.RWMutex          // Protects metrics updates
+	requestLatency *metrics.HistogramVec // Per-method handler latency for the metrics endpoint
+	requestErrors  *metrics.CounterVec   // Per-method error counts for the metrics endpoint
 
 	// Real-Time Event System
 	eventManager       *EventManager               // Event broadcasting manager
@@ -493,6 +497,8 @@ func NewWebSocketServer(
 			ActiveConnections: 0,
 			StartTime:         time.Now(),
 		},
+		requestLatency: metrics.NewHistogramVec("method", metrics.DefaultLatencyBuckets),
+		requestErrors:  metrics.NewCounterVec("method"),
 
 		// Event handling
 		eventManager:  NewEventManager(logger),
@@ -528,12 +534,9 @@ func (s *WebSocketServer) Start() error {
 	}).Info("Starting WebSocket JSON-RPC server")
 
 	// Create HTTP server
-	mux := http.NewServeMux()
-	mux.HandleFunc(s.config.WebSocketPath, s.handleWebSocket)
-
 	s.server = &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", s.config.Host, s.config.Port),
-		Handler:      mux,
+		Handler:      s.newServeMux(),
 		ReadTimeout:  s.config.ReadTimeout,
 		WriteTimeout: s.config.WriteTimeout,
 	}
@@ -563,6 +566,16 @@ func (s *WebSocketServer) Start() error {
 	return nil
 }
 
+// newServeMux routes the WebSocket endpoint and the HTTP file download endpoints
+func (s *WebSocketServer) newServeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc(s.config.WebSocketPath, s.handleWebSocket)
+	mux.HandleFunc(recordingFilesPath, s.handleFileDownload)
+	mux.HandleFunc(snapshotFilesPath, s.handleFileDownload)
+	mux.HandleFunc(mediamtx.EvidenceBundleFilesPath, s.handleFileDownload)
+	return mux
+}
+
 // StartWithListener starts the server using an existing listener (for race-free testing)
 func (s *WebSocketServer) StartWithListener(listener net.Listener) error {
 	// Idempotency: set running flag atomically to prevent double start races
@@ -578,11 +591,8 @@ func (s *WebSocketServer) StartWithListener(listener net.Listener) error {
 	}).Info("Starting WebSocket JSON-RPC server with existing listener")
 
 	// Create HTTP server
-	mux := http.NewServeMux()
-	mux.HandleFunc(s.config.WebSocketPath, s.handleWebSocket)
-
 	s.server = &http.Server{
-		Handler:      mux,
+		Handler:      s.newServeMux(),
 		ReadTimeout:  s.config.ReadTimeout,
 		WriteTimeout: s.config.WriteTimeout,
 	}
@@ -1010,8 +1020,40 @@ func (s *WebSocketServer) handleMessage(conn *websocket.Conn, client *ClientConn
 	}).Info("Request completed")
 }
 
-// handleRequest processes JSON-RPC requests
+// handleRequest processes JSON-RPC requests inside a server trace span. The
+// request context carries a new correlation ID and the span to the method handler.
 func (s *WebSocketServer) handleRequest(request *JsonRpcRequest, client *ClientConnection) (*JsonRpcResponse, error) {
+	correlationID := logging.GenerateCorrelationID()
+	ctx := logging.WithCorrelationID(context.Background(), correlationID)
+	ctx, span := tracing.Start(ctx, "jsonrpc."+request.Method, tracing.SpanKindServer,
+		tracing.String("rpc.system", "jsonrpc"),
+		tracing.String("rpc.method", request.Method),
+		tracing.String("client.id", client.ClientID))
+	defer span.End()
+
+	response, err := s.dispatchRequest(ctx, request, client)
+	if err != nil {
+		span.RecordError(err)
+		return response, err
+	}
+
+	if response != nil && span.IsRecording() {
+		if response.Error != nil {
+			span.SetAttributes(tracing.Int("rpc.jsonrpc.error_code", response.Error.Code))
+			span.SetStatus(tracing.StatusError, response.Error.Message)
+		}
+		if response.Metadata == nil {
+			response.Metadata = make(map[string]interface{})
+		}
+		response.Metadata["trace_id"] = span.TraceID().String()
+		response.Metadata["correlation_id"] = correlationID
+	}
+	return response, nil
+}
+
+// dispatchRequest applies rate limiting, authentication and permission checks
+// and calls the method handler with ctx as the client's request context
+func (s *WebSocketServer) dispatchRequest(ctx context.Context, request *JsonRpcRequest, client *ClientConnection) (*JsonRpcResponse, error) {
 	// Security extensions: Rate limiting check
 	if err := s.checkRateLimit(client); err != nil {
 		return &JsonRpcResponse{
@@ -1083,7 +1125,7 @@ func (s *WebSocketServer) handleRequest(request *JsonRpcRequest, client *ClientC
 	}
 
 	// Call method handler
-	response, err := handler(request.Params, client)
+	response, err := handler(ctx, request.Params, client)
 	if err != nil {
 		// Update error metrics with atomic operation
 		atomic.AddInt64(&s.metrics.ErrorCount, 1)
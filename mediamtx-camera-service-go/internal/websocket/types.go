@@ -1,6 +1,7 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -26,7 +27,7 @@ const (
 	NOT_FOUND               = constants.API_NOT_FOUND
 
 	// Additional error codes
-	INSUFFICIENT_STORAGE     = -32007
+	INSUFFICIENT_STORAGE     = constants.API_INSUFFICIENT_STORAGE
 	CAPABILITY_NOT_SUPPORTED = -32008
 
 	// Legacy constants (deprecated)
@@ -96,6 +97,15 @@ type ErrorData struct {
 	Suggestion string `json:"suggestion,omitempty"`
 }
 
+// StorageErrorData extends ErrorData with the storage state that refused a capture
+type StorageErrorData struct {
+	ErrorData
+	Path         string  `json:"path"`
+	UsagePercent float64 `json:"usage_percent"`
+	BlockPercent int     `json:"block_percent"`
+	FallbackPath string  `json:"fallback_path,omitempty"`
+}
+
 // NewJsonRpcError creates a standardized JSON-RPC error
 func NewJsonRpcError(code int, reason, details, suggestion string) *JsonRpcError {
 	return &JsonRpcError{
@@ -133,8 +143,9 @@ type PerformanceMetrics struct {
 	StartTime         time.Time
 }
 
-// MethodHandler defines the signature for JSON-RPC method handlers
-type MethodHandler func(params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error)
+// MethodHandler defines the signature for JSON-RPC method handlers. ctx carries
+// the correlation ID and trace span of the request being handled.
+type MethodHandler func(ctx context.Context, params map[string]interface{}, client *ClientConnection) (*JsonRpcResponse, error)
 
 // WebSocketMessage represents a WebSocket message with metadata
 type WebSocketMessage struct {
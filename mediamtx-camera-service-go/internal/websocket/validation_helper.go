@@ -182,6 +182,28 @@ func (vh *ValidationHelper) ValidateFilenameParameter(params map[string]interfac
 	return result
 }
 
+// ValidateFileIntegrityParameters validates the filename and file_type parameters
+// used by chain-of-custody methods
+func (vh *ValidationHelper) ValidateFileIntegrityParameters(params map[string]interface{}) *ValidationResult {
+	result := vh.ValidateFilenameParameter(params)
+	if !result.Valid {
+		return result
+	}
+
+	fileType := "recording"
+	if value, exists := params["file_type"]; exists {
+		typeStr, ok := value.(string)
+		if !ok || (typeStr != "recording" && typeStr != "snapshot") {
+			result.AddError("file_type must be 'recording' or 'snapshot'")
+			return result
+		}
+		fileType = typeStr
+	}
+
+	result.AddData("file_type", fileType)
+	return result
+}
+
 // ValidateRecordingParameters validates recording-specific parameters
 func (vh *ValidationHelper) ValidateRecordingParameters(params map[string]interface{}) *ValidationResult {
 	result := NewValidationResult()
@@ -466,6 +488,42 @@ func (vh *ValidationHelper) ValidateRetentionPolicyParameters(params map[string]
 		}
 	}
 
+	// Validate optional rule scope: a single camera or a named camera group
+	_, hasDevice := params["device"]
+	_, hasGroup := params["group"]
+	if hasDevice && hasGroup {
+		result.AddError("device and group parameters are mutually exclusive")
+		return result
+	}
+	if hasDevice {
+		deviceResult := vh.ValidateDeviceParameter(params)
+		if !deviceResult.Valid {
+			return deviceResult
+		}
+		result.AddData("device", deviceResult.Data["device"])
+	}
+	if hasGroup {
+		group, ok := params["group"].(string)
+		if !ok || group == "" {
+			result.AddError("group parameter must be a non-empty string")
+			return result
+		}
+		if camerasVal, exists := params["cameras"]; exists {
+			cameras, ok := camerasVal.([]interface{})
+			if !ok {
+				result.AddError("cameras parameter must be an array of camera identifiers")
+				return result
+			}
+			for _, camera := range cameras {
+				if cameraResult := vh.inputValidator.ValidateDevicePath(camera); cameraResult.HasErrors() {
+					result.AddError(cameraResult.GetErrorMessages()[0])
+					return result
+				}
+			}
+		}
+		result.AddData("group", group)
+	}
+
 	result.AddData("policy_type", policyType)
 	result.AddData("enabled", enabled)
 	return result
@@ -0,0 +1,181 @@
+/*
+Component: HTTP File Download Integration
+Purpose: Validates the /files endpoints served next to the WebSocket endpoint: authentication, role checks, on-the-fly decryption and Range requests
+Requirements: REQ-MTX-001, REQ-MTX-007, REQ-SEC-002
+Category: Integration
+API Reference: docs/api/json_rpc_methods.md (HTTP File Download Endpoints)
+Test Organization:
+  - TestFileDownload_ServesDecryptedRanges
+  - TestFileDownload_EvidenceBundlesRequireAdmin
+*/
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/config"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/mediamtx"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/testutils"
+	"github.com/camerarecorder/mediamtx-camera-service-go/internal/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fileDownloadController serves files from per-type roots through storage encryption
+type fileDownloadController struct {
+	mediamtx.MediaMTXController
+	storage *mediamtx.StorageEncryption
+	roots   map[string]string
+}
+
+func (c *fileDownloadController) OpenMediaFile(ctx context.Context, fileType, filename string) (*mediamtx.MediaFile, error) {
+	return c.storage.OpenMediaFile(filepath.Join(c.roots[fileType], filepath.FromSlash(filename)))
+}
+
+// FileDownloadIntegrationAsserter runs a WebSocket server whose file endpoints
+// read from temporary, encrypted storage
+type FileDownloadIntegrationAsserter struct {
+	t              *testing.T
+	securityHelper *testutils.SecurityHelper
+	storage        *mediamtx.StorageEncryption
+	roots          map[string]string
+	baseURL        string
+}
+
+// NewFileDownloadIntegrationAsserter starts storage encryption and a WebSocket
+// server on a loopback port, both stopped on cleanup
+func NewFileDownloadIntegrationAsserter(t *testing.T) *FileDownloadIntegrationAsserter {
+	setup := testutils.SetupTest(t, "config_valid_complete.yaml")
+	securityHelper := testutils.NewSecurityHelper(t, setup)
+	logger := setup.GetLogger()
+
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Storage.Encryption = config.EncryptionConfig{
+		Enabled:             true,
+		KeySource:           mediamtx.EncryptionKeySourceKeyfile,
+		KeyfilePath:         filepath.Join(dir, "keys", "storage.key"),
+		KeyStorePath:        filepath.Join(dir, "keys", "storage-keys.json"),
+		ChunkSizeKB:         1,
+		ScanIntervalSeconds: 3600,
+	}
+	storage := mediamtx.NewStorageEncryption(cfg, logger)
+	require.NoError(t, storage.Start(context.Background()))
+	t.Cleanup(storage.Stop)
+
+	roots := map[string]string{
+		"recording":       filepath.Join(dir, "recordings"),
+		"snapshot":        filepath.Join(dir, "snapshots"),
+		"evidence_bundle": filepath.Join(dir, "exports"),
+	}
+	server, err := websocket.NewWebSocketServer(setup.GetConfigManager(), logger, securityHelper.GetJWTHandler(),
+		&fileDownloadController{storage: storage, roots: roots})
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, server.StartWithListener(listener))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Stop(ctx)
+	})
+
+	return &FileDownloadIntegrationAsserter{
+		t:              t,
+		securityHelper: securityHelper,
+		storage:        storage,
+		roots:          roots,
+		baseURL:        "http://" + listener.Addr().String(),
+	}
+}
+
+// WriteEncrypted writes content under the root for fileType and encrypts it at rest
+func (a *FileDownloadIntegrationAsserter) WriteEncrypted(fileType, name string, content []byte) {
+	path := filepath.Join(a.roots[fileType], filepath.FromSlash(name))
+	require.NoError(a.t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(a.t, os.WriteFile(path, content, 0644))
+	require.NoError(a.t, a.storage.EncryptFile(context.Background(), path))
+	encrypted, err := mediamtx.IsEncryptedFile(path)
+	require.NoError(a.t, err)
+	require.True(a.t, encrypted)
+}
+
+// Token returns a bearer token for the given role
+func (a *FileDownloadIntegrationAsserter) Token(role string) string {
+	token, err := a.securityHelper.GenerateTestToken("download-"+role, role, time.Hour)
+	require.NoError(a.t, err)
+	return token
+}
+
+// Get requests path with optional bearer token and Range header
+func (a *FileDownloadIntegrationAsserter) Get(path, token, byteRange string) *http.Response {
+	request, err := http.NewRequest(http.MethodGet, a.baseURL+path, nil)
+	require.NoError(a.t, err)
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	if byteRange != "" {
+		request.Header.Set("Range", byteRange)
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	response, err := client.Do(request)
+	require.NoError(a.t, err)
+	a.t.Cleanup(func() { response.Body.Close() })
+	return response
+}
+
+func TestFileDownload_ServesDecryptedRanges(t *testing.T) {
+	asserter := NewFileDownloadIntegrationAsserter(t)
+
+	content := bytes.Repeat([]byte("0123456789abcdef"), 300) // 4800 bytes, 5 chunks
+	asserter.WriteEncrypted("recording", "camera0/camera0_2025-01-01_10-00-00.mp4", content)
+	url := "/files/recordings/camera0/camera0_2025-01-01_10-00-00.mp4"
+	token := asserter.Token("viewer")
+
+	assert.Equal(t, http.StatusUnauthorized, asserter.Get(url, "", "").StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, asserter.Get(url, "not-a-token", "").StatusCode)
+
+	response := asserter.Get(url, token, "")
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, content, body, "Encrypted files are decrypted on the fly")
+	assert.Equal(t, "bytes", response.Header.Get("Accept-Ranges"))
+	assert.Contains(t, response.Header.Get("Content-Disposition"), "camera0_2025-01-01_10-00-00.mp4")
+
+	// Range across a chunk boundary
+	response = asserter.Get(url, token, "bytes=1020-1039")
+	require.Equal(t, http.StatusPartialContent, response.StatusCode)
+	body, err = io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, content[1020:1040], body)
+	assert.Equal(t, "bytes 1020-1039/4800", response.Header.Get("Content-Range"))
+
+	assert.Equal(t, http.StatusNotFound, asserter.Get("/files/snapshots/missing.jpg", token, "").StatusCode)
+}
+
+func TestFileDownload_EvidenceBundlesRequireAdmin(t *testing.T) {
+	asserter := NewFileDownloadIntegrationAsserter(t)
+
+	bundle := []byte("PK evidence bundle")
+	asserter.WriteEncrypted("evidence_bundle", "camera0_1_custody_1_20250101T100000.zip", bundle)
+	url := mediamtx.EvidenceBundleFilesPath + "camera0_1_custody_1_20250101T100000.zip"
+
+	assert.Equal(t, http.StatusForbidden, asserter.Get(url, asserter.Token("viewer"), "").StatusCode)
+
+	response := asserter.Get(url, asserter.Token("admin"), "")
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, bundle, body)
+}
@@ -10,9 +10,12 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/silvus-mock/internal/capture"
 	"github.com/silvus-mock/internal/config"
 	"github.com/silvus-mock/internal/jsonrpc"
 	"github.com/silvus-mock/internal/maintenance"
+	"github.com/silvus-mock/internal/mesh"
+	"github.com/silvus-mock/internal/scenario"
 	"github.com/silvus-mock/internal/state"
 )
 
@@ -30,13 +33,96 @@ func main() {
 
 	log.Printf("Starting Silvus Mock Radio Emulator with config: %+v", cfg)
 
-	// Initialize radio state
-	radioState := state.NewRadioState(cfg)
+	// Initialize radio state: a single radio, or N radios sharing a mesh
+	var radioState *state.RadioState
+	var radioMesh *mesh.Mesh
+	var httpHandler http.Handler
+	var radioServers []*http.Server
+	var scenarioEngine *scenario.Engine
+	var recorder *capture.Recorder
+
+	if cfg.Mesh.Enabled {
+		radioMesh, err = mesh.New(cfg)
+		if err != nil {
+			log.Fatalf("Failed to create radio mesh: %v", err)
+		}
+		// Maintenance commands target the first radio
+		radioState = radioMesh.Nodes()[0].State
+
+		radios := make(map[string]*state.RadioState, len(radioMesh.Nodes()))
+		for _, node := range radioMesh.Nodes() {
+			radios[node.ID] = node.State
+		}
+		scenarioEngine = scenario.NewEngine(radios)
+		radioMesh.Use(scenarioEngine.Middleware)
+
+		httpHandler = radioMesh.Handler()
+		log.Printf("Mesh mode: %d radios under /radios/{id}/streamscape_api, topology at /mesh", len(radioMesh.Nodes()))
+
+		if cfg.Mesh.Addressing == config.MeshAddressingPort {
+			for i, node := range radioMesh.Nodes() {
+				handler, err := radioMesh.RadioHandler(node.ID)
+				if err != nil {
+					log.Fatalf("Failed to create handler for radio %s: %v", node.ID, err)
+				}
+				radioServers = append(radioServers, &http.Server{
+					Addr:         fmt.Sprintf(":%d", cfg.Mesh.BasePort+i),
+					Handler:      handler,
+					ReadTimeout:  10 * time.Second,
+					WriteTimeout: 10 * time.Second,
+				})
+			}
+		}
+	} else {
+		radioState = state.NewRadioState(cfg)
+
+		// Create JSON-RPC HTTP server, or a record/replay proxy in its place
+		jsonrpcServer := jsonrpc.NewServer(cfg, radioState)
+		if gps, ok := jsonrpcServer.GPS(); ok && cfg.GPS.Track.File != "" {
+			if err := gps.LoadTrack(cfg.GPS.Track); err != nil {
+				log.Fatalf("Failed to load GPS track: %v", err)
+			}
+			log.Printf("GPS track playback: %s (speed %.2fx, loop %t)", cfg.GPS.Track.File, cfg.GPS.Track.Speed, cfg.GPS.Track.Loop)
+		}
+		apiHandler := jsonrpcServer.HandleRequest
+		switch cfg.Capture.Mode {
+		case config.CaptureModeRecord:
+			recorder, err = capture.NewRecorder(cfg.Capture.Upstream, cfg.Capture.File)
+			if err != nil {
+				log.Fatalf("Failed to start recording proxy: %v", err)
+			}
+			apiHandler = recorder.HandleRequest
+			log.Printf("Record mode: proxying /streamscape_api to %s, capturing to %s", cfg.Capture.Upstream, cfg.Capture.File)
+		case config.CaptureModeReplay:
+			replayer, err := capture.NewReplayer(cfg.Capture.File, cfg.Capture.TimeScale)
+			if err != nil {
+				log.Fatalf("Failed to load replay capture: %v", err)
+			}
+			apiHandler = replayer.HandleRequest
+			log.Printf("Replay mode: serving %s (time scale %.2f)", cfg.Capture.File, cfg.Capture.TimeScale)
+		}
+
+		scenarioEngine = scenario.NewEngine(map[string]*state.RadioState{"": radioState})
+		httpMux := http.NewServeMux()
+		httpMux.HandleFunc("/streamscape_api", scenarioEngine.Middleware("", apiHandler))
+		httpHandler = httpMux
+	}
 
-	// Create JSON-RPC HTTP server
-	jsonrpcServer := jsonrpc.NewServer(cfg, radioState)
-	httpMux := http.NewServeMux()
-	httpMux.HandleFunc("/streamscape_api", jsonrpcServer.HandleRequest)
+	// Fault-injection scenario: started from file and/or driven over /scenario
+	if cfg.Scenario.File != "" {
+		sc, err := scenario.LoadFile(cfg.Scenario.File)
+		if err != nil {
+			log.Fatalf("Failed to load scenario: %v", err)
+		}
+		scenarioEngine.Load(sc)
+	}
+	if cfg.Scenario.Control {
+		controlMux := http.NewServeMux()
+		controlMux.Handle("/", httpHandler)
+		controlMux.HandleFunc("/scenario", scenarioEngine.ControlHandler)
+		httpHandler = controlMux
+		log.Println("Scenario control endpoint enabled at /scenario")
+	}
 
 	// Determine HTTP port based on dev mode
 	httpPort := cfg.Network.HTTP.Port
@@ -47,7 +133,7 @@ func main() {
 
 	httpServer := &http.Server{
 		Addr:         fmt.Sprintf(":%d", httpPort),
-		Handler:      httpMux,
+		Handler:      httpHandler,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
@@ -63,6 +149,16 @@ func main() {
 		}
 	}()
 
+	// Start per-radio HTTP servers (mesh port addressing)
+	for i, radioServer := range radioServers {
+		go func(id string, srv *http.Server) {
+			log.Printf("Starting HTTP server for radio %s on %s", id, srv.Addr)
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("HTTP server for radio %s failed: %v", id, err)
+			}
+		}(radioMesh.Nodes()[i].ID, radioServer)
+	}
+
 	// Start maintenance TCP server
 	go func() {
 		log.Printf("Starting maintenance TCP server on port %d", cfg.Network.Maintenance.Port)
@@ -84,14 +180,32 @@ func main() {
 	if err := httpServer.Shutdown(ctx); err != nil {
 		log.Printf("HTTP server shutdown error: %v", err)
 	}
+	for _, radioServer := range radioServers {
+		if err := radioServer.Shutdown(ctx); err != nil {
+			log.Printf("HTTP server %s shutdown error: %v", radioServer.Addr, err)
+		}
+	}
 
 	// Shutdown maintenance server
 	if err := maintenanceServer.Close(); err != nil {
 		log.Printf("Maintenance server shutdown error: %v", err)
 	}
 
+	// Stop scenario timers before the radios go away
+	scenarioEngine.Stop()
+
+	if recorder != nil {
+		if err := recorder.Close(); err != nil {
+			log.Printf("Capture file close error: %v", err)
+		}
+	}
+
 	// Shutdown radio state
-	if err := radioState.Close(); err != nil {
+	if radioMesh != nil {
+		if err := radioMesh.Close(); err != nil {
+			log.Printf("Radio mesh shutdown error: %v", err)
+		}
+	} else if err := radioState.Close(); err != nil {
 		log.Printf("Radio state shutdown error: %v", err)
 	}
 
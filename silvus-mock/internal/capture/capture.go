@@ -0,0 +1,137 @@
+package capture
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxLineBytes bounds a single exchange line when reading captures
+const maxLineBytes = 4 << 20
+
+// Exchange is one recorded JSON-RPC request/response pair.
+// Captures are stored as JSON Lines, one exchange per line, in arrival order.
+type Exchange struct {
+	Seq       int             `json:"seq"`
+	Time      time.Time       `json:"time"`
+	OffsetMs  int64           `json:"offsetMs"`  // Since the capture started
+	LatencyMs int64           `json:"latencyMs"` // Upstream response time
+	Method    string          `json:"method"`
+	Params    json.RawMessage `json:"params,omitempty"`
+	Request   json.RawMessage `json:"request"`
+	Status    int             `json:"status,omitempty"`
+	Response  json.RawMessage `json:"response,omitempty"`
+	Malformed bool            `json:"malformed,omitempty"` // Response was not JSON and is stored as a string
+	Error     string          `json:"error,omitempty"`     // Transport error; no response was received
+}
+
+// key identifies requests that the replayer treats as equivalent
+func (e *Exchange) key() string {
+	return requestKey(e.Method, e.Params)
+}
+
+// Writer appends exchanges to a capture file
+type Writer struct {
+	mu      sync.Mutex
+	file    *os.File
+	started time.Time
+	seq     int
+}
+
+// NewWriter creates (or truncates) a capture file
+func NewWriter(filename string) (*Writer, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create capture file: %v", err)
+	}
+	return &Writer{file: file, started: time.Now()}, nil
+}
+
+// Write assigns the sequence number and offset and appends the exchange
+func (w *Writer) Write(exchange *Exchange) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.seq++
+	exchange.Seq = w.seq
+	exchange.OffsetMs = exchange.Time.Sub(w.started).Milliseconds()
+
+	line, err := json.Marshal(exchange)
+	if err != nil {
+		return err
+	}
+	_, err = w.file.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the capture file
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
+
+// ReadFile loads every exchange from a capture file
+func ReadFile(filename string) ([]Exchange, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var exchanges []Exchange
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxLineBytes)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var exchange Exchange
+		if err := json.Unmarshal(scanner.Bytes(), &exchange); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid exchange: %v", filename, line, err)
+		}
+		exchanges = append(exchanges, exchange)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return exchanges, nil
+}
+
+// rpcRequest is the subset of a JSON-RPC request used for recording and matching
+type rpcRequest struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	ID     json.RawMessage `json:"id"`
+}
+
+// parseRequest extracts the method, params and id; invalid JSON yields empty values
+func parseRequest(body []byte) rpcRequest {
+	var req rpcRequest
+	json.Unmarshal(body, &req)
+	return req
+}
+
+// requestKey normalizes params so formatting differences don't prevent a match
+func requestKey(method string, params json.RawMessage) string {
+	var compact bytes.Buffer
+	if len(params) > 0 && string(params) != "null" && json.Compact(&compact, params) == nil {
+		return method + " " + compact.String()
+	}
+	return method
+}
+
+// rawJSON stores a body as-is when it is JSON, otherwise as a JSON string
+func rawJSON(body []byte) (json.RawMessage, bool) {
+	if json.Valid(body) {
+		var compact bytes.Buffer
+		json.Compact(&compact, body)
+		return compact.Bytes(), false
+	}
+	quoted, _ := json.Marshal(string(body))
+	return quoted, true
+}
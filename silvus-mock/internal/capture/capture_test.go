@@ -0,0 +1,205 @@
+package capture
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRadio answers like a Silvus radio: freq reads return the current
+// frequency, every other call returns BUSY after a short delay.
+func fakeRadio(t *testing.T) *httptest.Server {
+	t.Helper()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string          `json:"method"`
+			ID     json.RawMessage `json:"id"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		calls++
+
+		w.Header().Set("Content-Type", "application/json")
+		switch req.Method {
+		case "freq":
+			w.Write([]byte(`{"jsonrpc":"2.0","result":["` + []string{"2490", "2510"}[(calls-1)%2] + `"],"id":` + string(req.ID) + `}`))
+		case "garbled":
+			w.Write([]byte(`{"jsonrpc":"2.0","res`))
+		default:
+			time.Sleep(100 * time.Millisecond)
+			w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32602,"message":"BUSY"},"id":` + string(req.ID) + `}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func post(t *testing.T, url, body string) (int, string, error) {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(data), err
+}
+
+// record drives the requests through a recording proxy and returns the capture file
+func record(t *testing.T, upstream string, requests ...string) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "capture.jsonl")
+	rec, err := NewRecorder(upstream, filename)
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+	proxy := httptest.NewServer(http.HandlerFunc(rec.HandleRequest))
+	defer proxy.Close()
+
+	for _, body := range requests {
+		if _, _, err := post(t, proxy.URL, body); err != nil && !strings.Contains(body, "unreachable") {
+			t.Fatalf("Proxied request %s failed: %v", body, err)
+		}
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	return filename
+}
+
+func TestRecorderWritesExchanges(t *testing.T) {
+	radio := fakeRadio(t)
+	filename := record(t, radio.URL,
+		`{"jsonrpc":"2.0","method":"freq","id":"1"}`,
+		`{"jsonrpc":"2.0","method":"power_dBm","params":["30"],"id":"2"}`,
+		`{"jsonrpc":"2.0","method":"garbled","id":"3"}`,
+	)
+
+	exchanges, err := ReadFile(filename)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if len(exchanges) != 3 {
+		t.Fatalf("Expected 3 exchanges, got %d", len(exchanges))
+	}
+
+	first, second, third := exchanges[0], exchanges[1], exchanges[2]
+	if first.Seq != 1 || first.Method != "freq" || first.Status != http.StatusOK || !strings.Contains(string(first.Response), "2490") {
+		t.Errorf("Unexpected first exchange: %+v", first)
+	}
+	if second.Method != "power_dBm" || string(second.Params) != `["30"]` || second.LatencyMs < 100 {
+		t.Errorf("Expected power_dBm exchange with >=100ms latency, got %+v", second)
+	}
+	if second.OffsetMs < first.OffsetMs {
+		t.Errorf("Expected increasing offsets, got %d then %d", first.OffsetMs, second.OffsetMs)
+	}
+	if !third.Malformed {
+		t.Errorf("Expected garbled response to be flagged malformed, got %+v", third)
+	}
+}
+
+func TestRecorderRecordsUpstreamFailure(t *testing.T) {
+	radio := fakeRadio(t)
+	upstream := radio.URL
+	radio.Close()
+
+	filename := record(t, upstream, `{"jsonrpc":"2.0","method":"freq","id":"unreachable"}`)
+	exchanges, err := ReadFile(filename)
+	if err != nil || len(exchanges) != 1 || exchanges[0].Error == "" {
+		t.Fatalf("Expected one failed exchange, got %+v (err %v)", exchanges, err)
+	}
+}
+
+func TestReplayerServesRecordedResponses(t *testing.T) {
+	radio := fakeRadio(t)
+	filename := record(t, radio.URL,
+		`{"jsonrpc":"2.0","method":"freq","id":"1"}`,
+		`{"jsonrpc":"2.0","method":"freq","id":"2"}`,
+		`{"jsonrpc":"2.0","method":"power_dBm","params":["30"],"id":"3"}`,
+		`{"jsonrpc":"2.0","method":"garbled","id":"4"}`,
+	)
+
+	replayer, err := NewReplayer(filename, 1.0)
+	if err != nil {
+		t.Fatalf("NewReplayer failed: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(replayer.HandleRequest))
+	defer server.Close()
+
+	// Same method is served in recorded order, then the last response repeats
+	for _, want := range []string{"2490", "2510", "2510"} {
+		_, body, _ := post(t, server.URL, `{"jsonrpc":"2.0","method":"freq","id":"abc"}`)
+		if !strings.Contains(body, want) || !strings.Contains(body, `"id":"abc"`) {
+			t.Errorf("Expected %s with caller's id, got %s", want, body)
+		}
+	}
+
+	// Params formatting doesn't matter; recorded latency does
+	start := time.Now()
+	_, body, _ := post(t, server.URL, `{"jsonrpc":"2.0","method":"power_dBm","params":[ "30" ],"id":7}`)
+	if !strings.Contains(body, "BUSY") || !strings.Contains(body, `"id":7`) {
+		t.Errorf("Expected recorded BUSY error, got %s", body)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("Expected recorded latency of >=100ms, got %v", elapsed)
+	}
+
+	if _, body, _ := post(t, server.URL, `{"jsonrpc":"2.0","method":"garbled","id":"4"}`); body != `{"jsonrpc":"2.0","res` {
+		t.Errorf("Expected malformed body replayed verbatim, got %q", body)
+	}
+
+	if _, body, _ := post(t, server.URL, `{"jsonrpc":"2.0","method":"power_dBm","params":["10"],"id":"8"}`); !strings.Contains(body, "No recorded exchange") {
+		t.Errorf("Expected unmatched request error, got %s", body)
+	}
+
+	if remaining := replayer.Remaining(); remaining != 0 {
+		t.Errorf("Expected all exchanges served, %d remaining", remaining)
+	}
+}
+
+func TestReplayerTimeScaleAndDrops(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "capture.jsonl")
+	data := `{"seq":1,"method":"power_dBm","latencyMs":500,"request":{},"status":200,"response":{"jsonrpc":"2.0","result":[""],"id":"1"}}
+{"seq":2,"method":"freq","latencyMs":0,"request":{},"error":"connection reset by peer"}
+`
+	if err := os.WriteFile(filename, []byte(data), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	replayer, err := NewReplayer(filename, 0)
+	if err != nil {
+		t.Fatalf("NewReplayer failed: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(replayer.HandleRequest))
+	defer server.Close()
+
+	start := time.Now()
+	post(t, server.URL, `{"jsonrpc":"2.0","method":"power_dBm","id":"1"}`)
+	if elapsed := time.Since(start); elapsed >= 500*time.Millisecond {
+		t.Errorf("Expected time scale 0 to skip recorded latency, took %v", elapsed)
+	}
+
+	if _, _, err := post(t, server.URL, `{"jsonrpc":"2.0","method":"freq","id":"2"}`); err == nil {
+		t.Error("Expected recorded transport failure to drop the connection")
+	}
+}
+
+func TestNewReplayerRejectsBadCaptures(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.jsonl")
+	invalid := filepath.Join(dir, "invalid.jsonl")
+	os.WriteFile(empty, nil, 0644)
+	os.WriteFile(invalid, []byte("{not json}\n"), 0644)
+
+	for _, filename := range []string{empty, invalid, filepath.Join(dir, "missing.jsonl")} {
+		if _, err := NewReplayer(filename, 1); err == nil {
+			t.Errorf("Expected error loading %s", filepath.Base(filename))
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package capture
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// upstreamTimeout covers the longest CB-TIMING command (setChannel, 30s) plus margin
+const upstreamTimeout = 45 * time.Second
+
+// Recorder proxies /streamscape_api to a real radio and records every exchange
+type Recorder struct {
+	upstream string
+	client   *http.Client
+	writer   *Writer
+}
+
+// NewRecorder creates a recording proxy for the upstream JSON-RPC endpoint
+func NewRecorder(upstream, filename string) (*Recorder, error) {
+	if _, err := url.ParseRequestURI(upstream); err != nil {
+		return nil, fmt.Errorf("invalid upstream URL %q: %v", upstream, err)
+	}
+
+	writer, err := NewWriter(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{
+		upstream: upstream,
+		client:   &http.Client{Timeout: upstreamTimeout},
+		writer:   writer,
+	}, nil
+}
+
+// HandleRequest forwards the request unchanged and relays the upstream response
+func (rec *Recorder) HandleRequest(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	req := parseRequest(body)
+	exchange := &Exchange{
+		Time:   time.Now(),
+		Method: req.Method,
+		Params: req.Params,
+	}
+	exchange.Request, _ = rawJSON(body)
+
+	upstreamReq, err := http.NewRequestWithContext(r.Context(), http.MethodPost, rec.upstream, bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, "Failed to build upstream request", http.StatusInternalServerError)
+		return
+	}
+	upstreamReq.Header.Set("Content-Type", r.Header.Get("Content-Type"))
+
+	resp, err := rec.client.Do(upstreamReq)
+	var respBody []byte
+	if err == nil {
+		respBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+	exchange.LatencyMs = time.Since(exchange.Time).Milliseconds()
+
+	if err != nil {
+		exchange.Error = err.Error()
+		rec.record(exchange)
+		log.Printf("Capture: upstream %s failed for method=%s: %v", rec.upstream, req.Method, err)
+		// Mirror the radio's behavior: the client sees the connection drop
+		panic(http.ErrAbortHandler)
+	}
+
+	exchange.Status = resp.StatusCode
+	exchange.Response, exchange.Malformed = rawJSON(respBody)
+	rec.record(exchange)
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.WriteHeader(resp.StatusCode)
+	w.Write(respBody)
+}
+
+// record appends an exchange, logging rather than failing the proxied request
+func (rec *Recorder) record(exchange *Exchange) {
+	if err := rec.writer.Write(exchange); err != nil {
+		log.Printf("Capture: failed to record method=%s: %v", exchange.Method, err)
+	}
+}
+
+// Close flushes and closes the capture file
+func (rec *Recorder) Close() error {
+	return rec.writer.Close()
+}
@@ -0,0 +1,149 @@
+package capture
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Replayer serves recorded exchanges back as a JSON-RPC endpoint.
+//
+// Requests are matched by method and params. Matching exchanges are served in
+// recorded order; once they are used up the last one is repeated, so polling
+// loops keep working past the end of the transcript.
+type Replayer struct {
+	mu        sync.Mutex
+	exchanges []Exchange
+	byKey     map[string][]int // Request key to exchange indices, in recorded order
+	served    map[string]int   // Request key to number of exchanges served
+	timeScale float64
+}
+
+// NewReplayer loads a capture file. timeScale multiplies the recorded
+// latency: 1 replays the original timing, 0 answers immediately.
+func NewReplayer(filename string, timeScale float64) (*Replayer, error) {
+	if timeScale < 0 {
+		return nil, fmt.Errorf("time scale must not be negative")
+	}
+
+	exchanges, err := ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load capture: %v", err)
+	}
+	if len(exchanges) == 0 {
+		return nil, fmt.Errorf("capture %s contains no exchanges", filename)
+	}
+
+	rp := &Replayer{
+		exchanges: exchanges,
+		byKey:     make(map[string][]int),
+		served:    make(map[string]int),
+		timeScale: timeScale,
+	}
+	for i := range exchanges {
+		key := exchanges[i].key()
+		rp.byKey[key] = append(rp.byKey[key], i)
+	}
+	return rp, nil
+}
+
+// HandleRequest answers with the next recorded response for the request
+func (rp *Replayer) HandleRequest(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusBadRequest)
+		return
+	}
+	req := parseRequest(body)
+
+	exchange, ok := rp.next(requestKey(req.Method, req.Params))
+	if !ok {
+		log.Printf("Replay: no recorded exchange for method=%s params=%s", req.Method, req.Params)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"error": map[string]interface{}{
+				"code":    -32601, // Method not found
+				"message": "No recorded exchange",
+			},
+			"id": req.ID,
+		})
+		return
+	}
+
+	if delay := time.Duration(float64(exchange.LatencyMs)*rp.timeScale) * time.Millisecond; delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if exchange.Error != "" {
+		// The radio never answered; drop the connection like it did
+		panic(http.ErrAbortHandler)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if exchange.Status != 0 {
+		w.WriteHeader(exchange.Status)
+	}
+	w.Write(responseFor(exchange, req.ID))
+}
+
+// Remaining returns how many recorded exchanges have not been served yet
+func (rp *Replayer) Remaining() int {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	remaining := 0
+	for key, indices := range rp.byKey {
+		if served := rp.served[key]; served < len(indices) {
+			remaining += len(indices) - served
+		}
+	}
+	return remaining
+}
+
+// next returns the next exchange for a request key
+func (rp *Replayer) next(key string) (*Exchange, bool) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	indices := rp.byKey[key]
+	if len(indices) == 0 {
+		return nil, false
+	}
+
+	served := rp.served[key]
+	rp.served[key] = served + 1
+	if served >= len(indices) {
+		served = len(indices) - 1
+	}
+	return &rp.exchanges[indices[served]], true
+}
+
+// responseFor returns the recorded body, with the id rewritten to the caller's
+// so clients that check ids accept the replayed response
+func responseFor(exchange *Exchange, id json.RawMessage) []byte {
+	if exchange.Malformed {
+		var raw string
+		json.Unmarshal(exchange.Response, &raw)
+		return []byte(raw)
+	}
+
+	var response map[string]json.RawMessage
+	if len(id) == 0 || json.Unmarshal(exchange.Response, &response) != nil {
+		return exchange.Response
+	}
+	response["id"] = id
+	body, err := json.Marshal(response)
+	if err != nil {
+		return exchange.Response
+	}
+	return body
+}
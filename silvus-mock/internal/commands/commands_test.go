@@ -2,6 +2,8 @@ package commands
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/silvus-mock/internal/config"
@@ -247,6 +249,44 @@ func TestGPSCoordinatesHandler(t *testing.T) {
 	}
 }
 
+func TestGPSCoordinatesFollowTrack(t *testing.T) {
+	cfg := createTestConfig()
+	radioState := createTestRadioState(cfg)
+	defer radioState.Close()
+
+	trackFile := filepath.Join(t.TempDir(), "patrol.gpx")
+	gpx := `<gpx><trk><trkseg><trkpt lat="51.5" lon="-0.1"><ele>25</ele></trkpt><trkpt lat="51.6" lon="-0.1"/></trkseg></trk></gpx>`
+	if err := os.WriteFile(trackFile, []byte(gpx), 0644); err != nil {
+		t.Fatalf("Failed to write track: %v", err)
+	}
+
+	handler := NewGpsCoordinatesCommandHandler(radioState, cfg)
+	if err := handler.LoadTrack(config.TrackConfig{File: trackFile, Speed: 1, Loop: true}); err != nil {
+		t.Fatalf("LoadTrack failed: %v", err)
+	}
+
+	result, err := handler.Handle(context.Background(), []string{})
+	if err != nil {
+		t.Fatalf("Expected no error reading coordinates, got %v", err)
+	}
+	location := result.([]GPSCoordinates)[0]
+	if location.Latitude < 51.5 || location.Latitude > 51.501 || location.Altitude > 25 {
+		t.Errorf("Expected position at the start of the track, got %+v", location)
+	}
+
+	// Setting coordinates explicitly stops playback
+	if _, err := handler.Handle(context.Background(), []string{"40.0", "-74.0", "10.0"}); err != nil {
+		t.Fatalf("Expected no error setting coordinates, got %v", err)
+	}
+	if location := handler.Location(); location.Latitude != 40.0 {
+		t.Errorf("Expected fixed position after set, got %+v", location)
+	}
+
+	if err := handler.LoadTrack(config.TrackConfig{File: filepath.Join(t.TempDir(), "missing.gpx")}); err == nil {
+		t.Error("Expected error for missing track file")
+	}
+}
+
 func TestGPSModeHandler(t *testing.T) {
 	cfg := createTestConfig()
 	radioState := createTestRadioState(cfg)
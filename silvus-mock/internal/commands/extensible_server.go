@@ -186,6 +186,11 @@ func (s *ExtensibleJSONRPCServer) AddCustomCommand(handler CommandHandler) {
 	s.registry.Register(handler)
 }
 
+// GetCommand returns a registered command handler by name
+func (s *ExtensibleJSONRPCServer) GetCommand(commandName string) (CommandHandler, bool) {
+	return s.registry.Get(commandName)
+}
+
 // RemoveCommand allows removing commands at runtime
 func (s *ExtensibleJSONRPCServer) RemoveCommand(commandName string) {
 	delete(s.registry.handlers, commandName)
@@ -3,18 +3,22 @@ package commands
 import (
 	"context"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/silvus-mock/internal/config"
 	"github.com/silvus-mock/internal/state"
+	"github.com/silvus-mock/internal/track"
 )
 
 // GPSCommandHandler handles GPS-related commands
 type GPSCommandHandler struct {
+	mu         sync.Mutex // Protects location/lastUpdate/track; the mesh reads them concurrently
 	state      *state.RadioState
 	config     *config.Config
 	location   GPSCoordinates
 	lastUpdate time.Time
+	track      *track.Player // Replaces the fixed position while set
 }
 
 // GPSCoordinates represents GPS location data
@@ -70,6 +74,8 @@ func (h *GPSCommandHandler) Handle(ctx context.Context, params []string) (interf
 func (h *GPSCommandHandler) handleCoordinates(params []string) (interface{}, error) {
 	if len(params) == 0 {
 		// Read coordinates
+		h.mu.Lock()
+		defer h.mu.Unlock()
 		h.updateLocation() // Simulate GPS update
 		return []GPSCoordinates{h.location}, nil
 	}
@@ -102,6 +108,26 @@ func (h *GPSCommandHandler) handleCoordinates(params []string) (interface{}, err
 		return nil, &CommandError{Code: ErrInvalidRange, Message: "Longitude must be between -180 and 180"}
 	}
 
+	h.SetLocation(lat, lon, alt)
+
+	return []string{""}, nil // Success response
+}
+
+// Location returns the current simulated GPS fix
+func (h *GPSCommandHandler) Location() GPSCoordinates {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.track != nil {
+		h.updateLocation()
+	}
+	return h.location
+}
+
+// SetLocation moves the simulated radio to the given coordinates, stopping any track playback
+func (h *GPSCommandHandler) SetLocation(lat, lon, alt float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.track = nil
 	h.location = GPSCoordinates{
 		Latitude:  lat,
 		Longitude: lon,
@@ -110,8 +136,24 @@ func (h *GPSCommandHandler) handleCoordinates(params []string) (interface{}, err
 		Timestamp: time.Now(),
 	}
 	h.lastUpdate = time.Now()
+}
 
-	return []string{""}, nil // Success response
+// PlayTrack moves the simulated radio along a recorded track until SetLocation is called
+func (h *GPSCommandHandler) PlayTrack(player *track.Player) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.track = player
+	h.updateLocation()
+}
+
+// LoadTrack loads a GPX or NMEA track file and starts playing it
+func (h *GPSCommandHandler) LoadTrack(cfg config.TrackConfig) error {
+	t, err := track.LoadFile(cfg.File)
+	if err != nil {
+		return err
+	}
+	h.PlayTrack(track.NewPlayer(t, cfg.Speed, cfg.Loop))
+	return nil
 }
 
 // handleMode handles GPS mode commands
@@ -172,9 +214,20 @@ func (h *GPSCommandHandler) handleTime(params []string) (interface{}, error) {
 }
 
 // updateLocation simulates GPS location updates
+// Caller must hold h.mu.
 func (h *GPSCommandHandler) updateLocation() {
-	// Simulate small random movement
 	now := time.Now()
+	if h.track != nil {
+		point, _ := h.track.Position()
+		h.location.Latitude = point.Latitude
+		h.location.Longitude = point.Longitude
+		h.location.Altitude = point.Altitude
+		h.location.Timestamp = now
+		h.lastUpdate = now
+		return
+	}
+
+	// Simulate small random movement
 	if now.Sub(h.lastUpdate) > time.Second {
 		// Add small random variation to simulate GPS drift
 		h.location.Latitude += (float64(now.UnixNano()%100) - 50) / 1000000.0
@@ -158,9 +158,101 @@ func (h *MaxLinkDistanceCommandHandler) RequiresBlackout() bool {
 	return false
 }
 
+// BandwidthCommandHandler handles bw command
+// ICD §6.1.1: Set/read channel bandwidth in MHz
+type BandwidthCommandHandler struct {
+	state  *state.RadioState
+	config *config.Config
+}
+
+// NewBandwidthCommandHandler creates a new bandwidth command handler
+func NewBandwidthCommandHandler(radioState *state.RadioState, cfg *config.Config) *BandwidthCommandHandler {
+	return &BandwidthCommandHandler{
+		state:  radioState,
+		config: cfg,
+	}
+}
+
+// Handle processes bw command
+func (h *BandwidthCommandHandler) Handle(ctx context.Context, params []string) (interface{}, error) {
+	cmdType := "getBandwidth"
+	if len(params) > 0 {
+		cmdType = "setBandwidth"
+	}
+
+	response := h.state.ExecuteCommand(cmdType, params)
+	if response.Error != "" {
+		return nil, &CommandError{Code: response.Error, Message: response.Error}
+	}
+	return response.Result, nil
+}
+
+func (h *BandwidthCommandHandler) GetName() string {
+	return "bw"
+}
+
+func (h *BandwidthCommandHandler) GetDescription() string {
+	return "Set/read channel bandwidth in MHz"
+}
+
+func (h *BandwidthCommandHandler) IsReadOnly() bool {
+	return false
+}
+
+func (h *BandwidthCommandHandler) RequiresBlackout() bool {
+	return true
+}
+
+// AntennaMaskCommandHandler handles antenna_mask command
+// ICD §6.1.2: Set/read the enabled antennas bitmask
+type AntennaMaskCommandHandler struct {
+	state  *state.RadioState
+	config *config.Config
+}
+
+// NewAntennaMaskCommandHandler creates a new antenna mask command handler
+func NewAntennaMaskCommandHandler(radioState *state.RadioState, cfg *config.Config) *AntennaMaskCommandHandler {
+	return &AntennaMaskCommandHandler{
+		state:  radioState,
+		config: cfg,
+	}
+}
+
+// Handle processes antenna_mask command
+func (h *AntennaMaskCommandHandler) Handle(ctx context.Context, params []string) (interface{}, error) {
+	cmdType := "getAntennaMask"
+	if len(params) > 0 {
+		cmdType = "setAntennaMask"
+	}
+
+	response := h.state.ExecuteCommand(cmdType, params)
+	if response.Error != "" {
+		return nil, &CommandError{Code: response.Error, Message: response.Error}
+	}
+	return response.Result, nil
+}
+
+func (h *AntennaMaskCommandHandler) GetName() string {
+	return "antenna_mask"
+}
+
+func (h *AntennaMaskCommandHandler) GetDescription() string {
+	return "Set/read enabled antennas bitmask"
+}
+
+func (h *AntennaMaskCommandHandler) IsReadOnly() bool {
+	return false
+}
+
+func (h *AntennaMaskCommandHandler) RequiresBlackout() bool {
+	return false
+}
+
 // RegisterOptionalCommands registers optional commands in the registry
 func RegisterOptionalCommands(registry *CommandRegistry, radioState *state.RadioState, cfg *config.Config) {
 	registry.Register(NewReadPowerDBmCommandHandler(radioState, cfg))
 	registry.Register(NewReadPowerMwCommandHandler(radioState, cfg))
 	registry.Register(NewMaxLinkDistanceCommandHandler(radioState, cfg))
+	registry.Register(NewBandwidthCommandHandler(radioState, cfg))
+	registry.Register(NewAntennaMaskCommandHandler(radioState, cfg))
 }
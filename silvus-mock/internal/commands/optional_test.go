@@ -286,3 +286,50 @@ func TestOptionalCommandsErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestChannelCommandsEnforceProfiles(t *testing.T) {
+	cfg := createOptionalTestConfig()
+	cfg.Profiles.FrequencyProfiles = []config.FrequencyProfile{
+		{Frequencies: []string{"2200:20:2380", "4700"}, Bandwidth: "-1", AntennaMask: "15"},
+		{Frequencies: []string{"4420:40:4700"}, Bandwidth: "20", AntennaMask: "3"},
+	}
+	radioState := createOptionalTestRadioState(cfg)
+	defer radioState.Close()
+
+	bw := NewBandwidthCommandHandler(radioState, cfg)
+	antennaMask := NewAntennaMaskCommandHandler(radioState, cfg)
+
+	if result, err := bw.Handle(context.Background(), nil); err != nil || result.([]string)[0] != "20" {
+		t.Fatalf("Expected default bandwidth 20, got %v (%v)", result, err)
+	}
+	if result, err := antennaMask.Handle(context.Background(), nil); err != nil || result.([]string)[0] != "15" {
+		t.Fatalf("Expected default antenna mask 15, got %v (%v)", result, err)
+	}
+
+	tests := []struct {
+		handler CommandHandler
+		params  []string
+	}{
+		{bw, []string{"10"}},
+		{antennaMask, []string{"0"}},
+		{antennaMask, []string{"1F"}},
+	}
+	for _, tt := range tests {
+		_, err := tt.handler.Handle(context.Background(), tt.params)
+		cmdErr, ok := err.(*CommandError)
+		if !ok || cmdErr.Code != "INVALID_RANGE" {
+			t.Errorf("%s %v: expected INVALID_RANGE, got %v", tt.handler.GetName(), tt.params, err)
+		}
+	}
+
+	// 4460 is only in the second profile, which requires antennas 1-2
+	if resp := radioState.ExecuteCommand("setFreq", []string{"4460"}); resp.Error != "INVALID_RANGE" {
+		t.Errorf("Expected INVALID_RANGE for 4460 with all antennas enabled, got %+v", resp)
+	}
+	if _, err := antennaMask.Handle(context.Background(), []string{"3"}); err != nil {
+		t.Fatalf("Failed to set antenna mask: %v", err)
+	}
+	if resp := radioState.ExecuteCommand("setFreq", []string{"4460"}); resp.Error != "" {
+		t.Errorf("Expected 4460 to be valid with antennas 1-2, got %+v", resp)
+	}
+}
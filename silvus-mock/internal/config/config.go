@@ -2,8 +2,11 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
@@ -15,6 +18,11 @@ type Config struct {
 	Power    PowerConfig    `yaml:"power"`
 	Timing   TimingConfig   `yaml:"timing"`
 	Mode     string         `yaml:"mode"`
+	Mesh     MeshConfig     `yaml:"mesh"`
+	Scenario ScenarioConfig `yaml:"scenario"`
+	Capture  CaptureConfig  `yaml:"capture"`
+	Device   DeviceConfig   `yaml:"device"`
+	GPS      GPSConfig      `yaml:"gps"`
 }
 
 // NetworkConfig holds network-related settings
@@ -86,6 +94,108 @@ type BackoffConfig struct {
 	BusyBaseMs int `yaml:"busyBaseMs"`
 }
 
+// MeshConfig holds multi-radio mesh simulation settings
+type MeshConfig struct {
+	Enabled    bool              `yaml:"enabled"`
+	Addressing string            `yaml:"addressing"` // "path" (/radios/{id}/streamscape_api) or "port"
+	BasePort   int               `yaml:"basePort"`   // Port mode: radio i listens on basePort+i
+	Radios     []MeshRadioConfig `yaml:"radios"`
+}
+
+// DeviceConfig holds radio identity and persistence settings
+type DeviceConfig struct {
+	FirmwareVersion string `yaml:"firmwareVersion"` // Reported by the firmware_version maintenance command
+	StateFile       string `yaml:"stateFile"`       // Persist settings and keys across restarts (empty = in memory only)
+}
+
+// GPSConfig holds simulated GPS settings
+type GPSConfig struct {
+	Track TrackConfig `yaml:"track"`
+}
+
+// TrackConfig replays a recorded track instead of a fixed position
+type TrackConfig struct {
+	File  string  `yaml:"file"`  // GPX or NMEA 0183 log (empty = fixed position)
+	Speed float64 `yaml:"speed"` // Playback rate multiplier (2 = twice as fast as recorded)
+	Loop  bool    `yaml:"loop"`  // Restart from the first point when the track ends
+}
+
+// ScenarioConfig holds fault-injection scenario settings
+type ScenarioConfig struct {
+	File    string `yaml:"file"`    // Scenario YAML started at boot (empty = none)
+	Control bool   `yaml:"control"` // Expose GET/POST/DELETE /scenario for tests
+}
+
+// CaptureConfig holds record/replay proxy settings
+type CaptureConfig struct {
+	Mode      string  `yaml:"mode"`      // "" (off), "record" or "replay"
+	File      string  `yaml:"file"`      // JSON Lines capture file
+	Upstream  string  `yaml:"upstream"`  // Record mode: real radio endpoint, e.g. http://10.0.0.5/streamscape_api
+	TimeScale float64 `yaml:"timeScale"` // Replay mode: multiplier for recorded latency (1 = original, 0 = none)
+}
+
+// Capture modes
+const (
+	CaptureModeRecord = "record"
+	CaptureModeReplay = "replay"
+)
+
+// MeshRadioConfig describes one simulated radio in mesh mode
+type MeshRadioConfig struct {
+	ID        string                 `yaml:"id"`
+	Latitude  float64                `yaml:"latitude"`
+	Longitude float64                `yaml:"longitude"`
+	Altitude  float64                `yaml:"altitude"`
+	Overrides map[string]interface{} `yaml:"overrides"` // Partial config merged over the base config
+}
+
+// Mesh addressing modes
+const (
+	MeshAddressingPath = "path"
+	MeshAddressingPort = "port"
+)
+
+// ForRadio returns a copy of the configuration with the radio's overrides applied.
+// The copy has mesh mode disabled so each radio behaves as a standalone device.
+func (c *Config) ForRadio(radio MeshRadioConfig) (*Config, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy config: %v", err)
+	}
+
+	radioCfg := &Config{}
+	if err := yaml.Unmarshal(data, radioCfg); err != nil {
+		return nil, fmt.Errorf("failed to copy config: %v", err)
+	}
+
+	if len(radio.Overrides) > 0 {
+		overrides, err := yaml.Marshal(radio.Overrides)
+		if err != nil {
+			return nil, fmt.Errorf("invalid overrides for radio %s: %v", radio.ID, err)
+		}
+		// Profiles are replaced rather than merged element-wise
+		if _, ok := radio.Overrides["profiles"]; ok {
+			radioCfg.Profiles = ProfilesConfig{}
+		}
+		if err := yaml.Unmarshal(overrides, radioCfg); err != nil {
+			return nil, fmt.Errorf("invalid overrides for radio %s: %v", radio.ID, err)
+		}
+	}
+
+	radioCfg.Mesh = MeshConfig{}
+
+	// Each radio persists to its own file: state.json -> state-<id>.json
+	if stateFile := radioCfg.Device.StateFile; stateFile != "" {
+		ext := filepath.Ext(stateFile)
+		radioCfg.Device.StateFile = strings.TrimSuffix(stateFile, ext) + "-" + radio.ID + ext
+	}
+	if err := validateConfig(radioCfg); err != nil {
+		return nil, fmt.Errorf("radio %s: %v", radio.ID, err)
+	}
+
+	return radioCfg, nil
+}
+
 // Load loads configuration from file and environment variables
 func Load() (*Config, error) {
 	// Load default configuration
@@ -97,6 +207,13 @@ func Load() (*Config, error) {
 		fmt.Printf("Warning: Could not load default config: %v\n", err)
 	}
 
+	// Load from config file if SILVUS_MOCK_CONFIG is set (e.g. a mesh layout)
+	if mockConfig := os.Getenv("SILVUS_MOCK_CONFIG"); mockConfig != "" {
+		if err := loadFromFile(cfg, mockConfig); err != nil {
+			return nil, fmt.Errorf("failed to load config from %s: %v", mockConfig, err)
+		}
+	}
+
 	// Load from config file if CBTIMING_CONFIG is set
 	if cbTimingConfig := os.Getenv("CBTIMING_CONFIG"); cbTimingConfig != "" {
 		if err := loadFromFile(cfg, cbTimingConfig); err != nil {
@@ -169,6 +286,23 @@ func getDefaultConfig() *Config {
 			},
 		},
 		Mode: "normal",
+		Mesh: MeshConfig{
+			Enabled:    false,
+			Addressing: MeshAddressingPath,
+			BasePort:   8081,
+		},
+		Capture: CaptureConfig{
+			TimeScale: 1.0,
+		},
+		Device: DeviceConfig{
+			FirmwareVersion: "4.0.2.1",
+		},
+		GPS: GPSConfig{
+			Track: TrackConfig{
+				Speed: 1.0,
+				Loop:  true,
+			},
+		},
 	}
 }
 
@@ -193,6 +327,54 @@ func applyEnvOverrides(cfg *Config) {
 			cfg.Timing.Blackout.SoftBootSec = sec
 		}
 	}
+
+	if stateFile := os.Getenv("SILVUS_MOCK_STATE_FILE"); stateFile != "" {
+		cfg.Device.StateFile = stateFile
+	}
+
+	if scenarioFile := os.Getenv("SILVUS_MOCK_SCENARIO"); scenarioFile != "" {
+		cfg.Scenario.File = scenarioFile
+	}
+
+	if scenarioControl := os.Getenv("SILVUS_MOCK_SCENARIO_CONTROL"); scenarioControl != "" {
+		if enabled, err := strconv.ParseBool(scenarioControl); err == nil {
+			cfg.Scenario.Control = enabled
+		}
+	}
+
+	if captureMode := os.Getenv("SILVUS_MOCK_CAPTURE_MODE"); captureMode != "" {
+		cfg.Capture.Mode = captureMode
+	}
+
+	if captureFile := os.Getenv("SILVUS_MOCK_CAPTURE_FILE"); captureFile != "" {
+		cfg.Capture.File = captureFile
+	}
+
+	if upstream := os.Getenv("SILVUS_MOCK_UPSTREAM"); upstream != "" {
+		cfg.Capture.Upstream = upstream
+	}
+
+	if timeScale := os.Getenv("SILVUS_MOCK_REPLAY_TIMESCALE"); timeScale != "" {
+		if scale, err := strconv.ParseFloat(timeScale, 64); err == nil {
+			cfg.Capture.TimeScale = scale
+		}
+	}
+
+	if trackFile := os.Getenv("SILVUS_MOCK_GPS_TRACK"); trackFile != "" {
+		cfg.GPS.Track.File = trackFile
+	}
+
+	if trackSpeed := os.Getenv("SILVUS_MOCK_GPS_TRACK_SPEED"); trackSpeed != "" {
+		if speed, err := strconv.ParseFloat(trackSpeed, 64); err == nil {
+			cfg.GPS.Track.Speed = speed
+		}
+	}
+
+	if trackLoop := os.Getenv("SILVUS_MOCK_GPS_TRACK_LOOP"); trackLoop != "" {
+		if loop, err := strconv.ParseBool(trackLoop); err == nil {
+			cfg.GPS.Track.Loop = loop
+		}
+	}
 }
 
 // validateConfig validates the configuration
@@ -226,6 +408,89 @@ func validateConfig(cfg *Config) error {
 		return fmt.Errorf("at least one frequency profile must be configured")
 	}
 
+	for i, profile := range cfg.Profiles.FrequencyProfiles {
+		if err := profile.Validate(); err != nil {
+			return fmt.Errorf("frequency profile %d: %v", i, err)
+		}
+	}
+
+	if cfg.Mesh.Enabled {
+		if err := validateMeshConfig(&cfg.Mesh); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Capture.Mode != "" {
+		if err := validateCaptureConfig(cfg); err != nil {
+			return err
+		}
+	}
+
+	if cfg.GPS.Track.File != "" {
+		speed := cfg.GPS.Track.Speed
+		if speed <= 0 || speed > 1000 || math.IsNaN(speed) {
+			return fmt.Errorf("GPS track speed %v is outside reasonable range (0, 1000]", speed)
+		}
+	}
+
+	return nil
+}
+
+// validateCaptureConfig validates the record/replay proxy settings
+func validateCaptureConfig(cfg *Config) error {
+	capture := &cfg.Capture
+	if capture.Mode != CaptureModeRecord && capture.Mode != CaptureModeReplay {
+		return fmt.Errorf("invalid capture mode %s, must be one of: [%s %s]", capture.Mode, CaptureModeRecord, CaptureModeReplay)
+	}
+
+	if capture.File == "" {
+		return fmt.Errorf("capture mode %s requires a capture file", capture.Mode)
+	}
+
+	if capture.Mode == CaptureModeRecord && capture.Upstream == "" {
+		return fmt.Errorf("capture mode record requires an upstream URL")
+	}
+
+	if capture.TimeScale < 0 {
+		return fmt.Errorf("capture time scale %v must not be negative", capture.TimeScale)
+	}
+
+	if cfg.Mesh.Enabled {
+		return fmt.Errorf("capture mode cannot be combined with mesh mode")
+	}
+
+	return nil
+}
+
+// validateMeshConfig validates the multi-radio mesh settings
+func validateMeshConfig(mesh *MeshConfig) error {
+	if mesh.Addressing != MeshAddressingPath && mesh.Addressing != MeshAddressingPort {
+		return fmt.Errorf("invalid mesh addressing %s, must be one of: [%s %s]", mesh.Addressing, MeshAddressingPath, MeshAddressingPort)
+	}
+
+	if len(mesh.Radios) == 0 {
+		return fmt.Errorf("mesh mode requires at least one radio")
+	}
+
+	if mesh.Addressing == MeshAddressingPort && (mesh.BasePort <= 0 || mesh.BasePort+len(mesh.Radios) > 65535) {
+		return fmt.Errorf("mesh base port %d cannot host %d radios", mesh.BasePort, len(mesh.Radios))
+	}
+
+	seen := make(map[string]bool, len(mesh.Radios))
+	for _, radio := range mesh.Radios {
+		if radio.ID == "" || strings.ContainsAny(radio.ID, "/ ") {
+			return fmt.Errorf("invalid mesh radio id %q", radio.ID)
+		}
+		if seen[radio.ID] {
+			return fmt.Errorf("duplicate mesh radio id %s", radio.ID)
+		}
+		seen[radio.ID] = true
+
+		if radio.Latitude < -90 || radio.Latitude > 90 || radio.Longitude < -180 || radio.Longitude > 180 {
+			return fmt.Errorf("mesh radio %s has invalid coordinates", radio.ID)
+		}
+	}
+
 	return nil
 }
 
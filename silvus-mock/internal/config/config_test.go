@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"testing"
+
+	"gopkg.in/yaml.v2"
 )
 
 func TestGetDefaultConfig(t *testing.T) {
@@ -253,3 +255,152 @@ func TestFrequencyProfileStructure(t *testing.T) {
 		}
 	}
 }
+
+func TestForRadioAppliesOverrides(t *testing.T) {
+	cfg := getDefaultConfig()
+
+	var overrides map[string]interface{}
+	if err := yaml.Unmarshal([]byte(`
+power:
+  maxDbm: 20
+profiles:
+  frequencyProfiles:
+    - frequencies: ["2412"]
+      bandwidth: "20"
+      antenna_mask: "1"
+`), &overrides); err != nil {
+		t.Fatalf("Failed to parse overrides: %v", err)
+	}
+
+	radioCfg, err := cfg.ForRadio(MeshRadioConfig{ID: "radio-02", Overrides: overrides})
+	if err != nil {
+		t.Fatalf("ForRadio failed: %v", err)
+	}
+
+	if radioCfg.Power.MaxDBm != 20 || radioCfg.Power.MinDBm != 0 {
+		t.Errorf("Expected power 0-20 after override, got %d-%d", radioCfg.Power.MinDBm, radioCfg.Power.MaxDBm)
+	}
+	if len(radioCfg.Profiles.FrequencyProfiles) != 1 || radioCfg.Profiles.FrequencyProfiles[0].Frequencies[0] != "2412" {
+		t.Errorf("Expected profiles to be replaced, got %+v", radioCfg.Profiles.FrequencyProfiles)
+	}
+	if cfg.Power.MaxDBm != 39 || len(cfg.Profiles.FrequencyProfiles) != 3 {
+		t.Error("ForRadio must not modify the base config")
+	}
+
+	if _, err := cfg.ForRadio(MeshRadioConfig{ID: "bad", Overrides: map[string]interface{}{"mode": "broken"}}); err == nil {
+		t.Error("Expected invalid override to fail validation")
+	}
+}
+
+func TestValidateMeshConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		mesh    MeshConfig
+		wantErr bool
+	}{
+		{"valid path", MeshConfig{Enabled: true, Addressing: MeshAddressingPath, Radios: []MeshRadioConfig{{ID: "a"}, {ID: "b"}}}, false},
+		{"valid port", MeshConfig{Enabled: true, Addressing: MeshAddressingPort, BasePort: 9000, Radios: []MeshRadioConfig{{ID: "a"}}}, false},
+		{"no radios", MeshConfig{Enabled: true, Addressing: MeshAddressingPath}, true},
+		{"bad addressing", MeshConfig{Enabled: true, Addressing: "dns", Radios: []MeshRadioConfig{{ID: "a"}}}, true},
+		{"duplicate id", MeshConfig{Enabled: true, Addressing: MeshAddressingPath, Radios: []MeshRadioConfig{{ID: "a"}, {ID: "a"}}}, true},
+		{"slash in id", MeshConfig{Enabled: true, Addressing: MeshAddressingPath, Radios: []MeshRadioConfig{{ID: "a/b"}}}, true},
+		{"bad latitude", MeshConfig{Enabled: true, Addressing: MeshAddressingPath, Radios: []MeshRadioConfig{{ID: "a", Latitude: 91}}}, true},
+		{"port overflow", MeshConfig{Enabled: true, Addressing: MeshAddressingPort, BasePort: 65535, Radios: []MeshRadioConfig{{ID: "a"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := getDefaultConfig()
+			cfg.Mesh = tt.mesh
+			if err := validateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCaptureConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		capture CaptureConfig
+		mesh    bool
+		wantErr bool
+	}{
+		{"disabled", CaptureConfig{}, false, false},
+		{"valid record", CaptureConfig{Mode: CaptureModeRecord, File: "c.jsonl", Upstream: "http://10.0.0.5/streamscape_api", TimeScale: 1}, false, false},
+		{"valid replay", CaptureConfig{Mode: CaptureModeReplay, File: "c.jsonl"}, false, false},
+		{"bad mode", CaptureConfig{Mode: "tap", File: "c.jsonl"}, false, true},
+		{"no file", CaptureConfig{Mode: CaptureModeReplay}, false, true},
+		{"record without upstream", CaptureConfig{Mode: CaptureModeRecord, File: "c.jsonl"}, false, true},
+		{"negative time scale", CaptureConfig{Mode: CaptureModeReplay, File: "c.jsonl", TimeScale: -1}, false, true},
+		{"with mesh", CaptureConfig{Mode: CaptureModeReplay, File: "c.jsonl"}, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := getDefaultConfig()
+			cfg.Capture = tt.capture
+			if tt.mesh {
+				cfg.Mesh = MeshConfig{Enabled: true, Addressing: MeshAddressingPath, Radios: []MeshRadioConfig{{ID: "a"}}}
+			}
+			if err := validateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestForRadioUsesPerRadioStateFile(t *testing.T) {
+	cfg := getDefaultConfig()
+	cfg.Device.StateFile = "/var/lib/silvus-mock/state.json"
+
+	radioCfg, err := cfg.ForRadio(MeshRadioConfig{ID: "radio-02"})
+	if err != nil {
+		t.Fatalf("ForRadio failed: %v", err)
+	}
+	if radioCfg.Device.StateFile != "/var/lib/silvus-mock/state-radio-02.json" {
+		t.Errorf("Expected per-radio state file, got %s", radioCfg.Device.StateFile)
+	}
+}
+
+func TestValidateGPSTrackConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		track   TrackConfig
+		wantErr bool
+	}{
+		{"no track", TrackConfig{Speed: 0}, false},
+		{"valid track", TrackConfig{File: "patrol.gpx", Speed: 10, Loop: true}, false},
+		{"zero speed", TrackConfig{File: "patrol.gpx", Speed: 0}, true},
+		{"excessive speed", TrackConfig{File: "patrol.gpx", Speed: 5000}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := getDefaultConfig()
+			cfg.GPS.Track = tt.track
+			if err := validateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestForRadioAppliesTrackOverride(t *testing.T) {
+	cfg := getDefaultConfig()
+	radioCfg, err := cfg.ForRadio(MeshRadioConfig{
+		ID:        "convoy-1",
+		Overrides: map[string]interface{}{"gps": map[string]interface{}{"track": map[string]interface{}{"file": "convoy-1.nmea", "speed": 4}}},
+	})
+	if err != nil {
+		t.Fatalf("ForRadio failed: %v", err)
+	}
+
+	track := radioCfg.GPS.Track
+	if track.File != "convoy-1.nmea" || track.Speed != 4 || !track.Loop {
+		t.Errorf("Expected per-radio track with default looping, got %+v", track)
+	}
+	if cfg.GPS.Track.File != "" {
+		t.Errorf("Expected base config to be unchanged, got %+v", cfg.GPS.Track)
+	}
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FrequencyResolutionMHz is the finest frequency step the radio accepts (ICD §6.1.1)
+	FrequencyResolutionMHz = 0.1
+
+	// BandwidthAll marks a profile that applies to every supported bandwidth
+	BandwidthAll = "-1"
+
+	// maxAntennaMask covers four antennas (ICD §6.1.2: hex 1..F)
+	maxAntennaMask = 0xF
+)
+
+// SupportedBandwidths are the channel bandwidths in MHz the radio can use
+var SupportedBandwidths = []string{"5", "20"}
+
+// FrequencyRange is a parsed profile entry: "<start>:<step>:<end>" or a single "<freq>"
+type FrequencyRange struct {
+	Start float64
+	Step  float64 // 0 for a single frequency
+	End   float64
+}
+
+// ParseFrequencyRange parses one entry of a profile's frequencies list
+func ParseFrequencyRange(spec string) (FrequencyRange, error) {
+	parts := strings.Split(spec, ":")
+	switch len(parts) {
+	case 1:
+		freq, err := parseMHz(parts[0])
+		if err != nil {
+			return FrequencyRange{}, fmt.Errorf("invalid frequency %q: %v", spec, err)
+		}
+		return FrequencyRange{Start: freq, End: freq}, nil
+	case 3:
+		start, err1 := parseMHz(parts[0])
+		step, err2 := parseMHz(parts[1])
+		end, err3 := parseMHz(parts[2])
+		if err1 != nil || err2 != nil || err3 != nil {
+			return FrequencyRange{}, fmt.Errorf("invalid frequency range %q", spec)
+		}
+		if step <= 0 || end < start {
+			return FrequencyRange{}, fmt.Errorf("invalid frequency range %q: step must be positive and end >= start", spec)
+		}
+		return FrequencyRange{Start: start, Step: step, End: end}, nil
+	default:
+		return FrequencyRange{}, fmt.Errorf("invalid frequency range %q: expected <start>:<step>:<end> or <freq>", spec)
+	}
+}
+
+// Contains reports whether a frequency is one of the range's channels
+func (r FrequencyRange) Contains(freq float64) bool {
+	tolerance := FrequencyResolutionMHz / 2
+	if freq < r.Start-tolerance || freq > r.End+tolerance {
+		return false
+	}
+	if r.Step == 0 {
+		return math.Abs(freq-r.Start) < tolerance
+	}
+	channel := math.Round((freq - r.Start) / r.Step)
+	return math.Abs(r.Start+channel*r.Step-freq) < tolerance
+}
+
+// ParseFrequency parses a requested frequency, rejecting values finer than the radio's resolution
+func ParseFrequency(value string) (float64, error) {
+	freq, err := parseMHz(value)
+	if err != nil {
+		return 0, err
+	}
+	tenths := freq / FrequencyResolutionMHz
+	if math.Abs(tenths-math.Round(tenths)) > 1e-6 {
+		return 0, fmt.Errorf("frequency %s is finer than %.1f MHz resolution", value, FrequencyResolutionMHz)
+	}
+	return freq, nil
+}
+
+// ParseAntennaMask parses an antenna bitmask. Profiles in this repo use
+// decimal ("15"); the ICD examples use hex ("D"), which is accepted too.
+func ParseAntennaMask(value string) (uint64, error) {
+	mask, err := strconv.ParseUint(value, 10, 8)
+	if err != nil {
+		mask, err = strconv.ParseUint(strings.TrimPrefix(strings.ToLower(value), "0x"), 16, 8)
+	}
+	if err != nil || mask == 0 || mask > maxAntennaMask {
+		return 0, fmt.Errorf("invalid antenna mask %q: must select 1-4 antennas (1..F)", value)
+	}
+	return mask, nil
+}
+
+// IsSupportedBandwidth reports whether the radio can operate at a bandwidth
+func IsSupportedBandwidth(bandwidth string) bool {
+	return contains(SupportedBandwidths, bandwidth)
+}
+
+// Validate checks that every frequency entry, the bandwidth and the antenna mask parse
+func (p FrequencyProfile) Validate() error {
+	if len(p.Frequencies) == 0 {
+		return fmt.Errorf("frequency profile has no frequencies")
+	}
+	for _, spec := range p.Frequencies {
+		if _, err := ParseFrequencyRange(spec); err != nil {
+			return err
+		}
+	}
+	if p.Bandwidth != BandwidthAll && !IsSupportedBandwidth(p.Bandwidth) {
+		return fmt.Errorf("invalid profile bandwidth %q, must be %s or one of: %v", p.Bandwidth, BandwidthAll, SupportedBandwidths)
+	}
+	if _, err := ParseAntennaMask(p.AntennaMask); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Allows reports whether the profile permits a frequency at the given
+// bandwidth with the given antennas enabled. The active antennas must be a
+// subset of the profile's mask.
+func (p FrequencyProfile) Allows(freq float64, bandwidth string, antennaMask uint64) bool {
+	if p.Bandwidth != BandwidthAll && p.Bandwidth != bandwidth {
+		return false
+	}
+	profileMask, err := ParseAntennaMask(p.AntennaMask)
+	if err != nil || antennaMask&^profileMask != 0 {
+		return false
+	}
+	return p.AllowsFrequency(freq)
+}
+
+// AllowsFrequency reports whether any of the profile's entries contains the frequency
+func (p FrequencyProfile) AllowsFrequency(freq float64) bool {
+	for _, spec := range p.Frequencies {
+		r, err := ParseFrequencyRange(spec)
+		if err == nil && r.Contains(freq) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMHz parses a finite, positive MHz value
+func parseMHz(value string) (float64, error) {
+	freq, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(freq) || math.IsInf(freq, 0) || freq <= 0 {
+		return 0, fmt.Errorf("not a positive number: %q", value)
+	}
+	return freq, nil
+}
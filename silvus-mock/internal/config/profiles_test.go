@@ -0,0 +1,140 @@
+package config
+
+import "testing"
+
+func TestParseFrequencyRange(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    FrequencyRange
+		wantErr bool
+	}{
+		{"2200:20:2380", FrequencyRange{Start: 2200, Step: 20, End: 2380}, false},
+		{"4700", FrequencyRange{Start: 4700, End: 4700}, false},
+		{" 4420:40:4700 ", FrequencyRange{Start: 4420, Step: 40, End: 4700}, false},
+		{"2200:0:2380", FrequencyRange{}, true},
+		{"2380:20:2200", FrequencyRange{}, true},
+		{"2200:20", FrequencyRange{}, true},
+		{"abc", FrequencyRange{}, true},
+		{"-5", FrequencyRange{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFrequencyRange(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFrequencyRange(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFrequencyRange(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestFrequencyRangeContains(t *testing.T) {
+	r, _ := ParseFrequencyRange("2200:20:2380")
+	for freq, want := range map[float64]bool{
+		2200:   true,
+		2220:   true,
+		2380:   true,
+		2210:   false,
+		2400:   false,
+		2180:   false,
+		2219.9: false,
+	} {
+		if got := r.Contains(freq); got != want {
+			t.Errorf("Contains(%v) = %v, want %v", freq, got, want)
+		}
+	}
+
+	single, _ := ParseFrequencyRange("4700")
+	if !single.Contains(4700.0) || single.Contains(4700.1) {
+		t.Error("Single frequency entry should contain only itself")
+	}
+}
+
+func TestParseFrequency(t *testing.T) {
+	for value, wantErr := range map[string]bool{
+		"4700":    false,
+		"2412.5":  false,
+		"4700.05": true,
+		"0":       true,
+		"NaN":     true,
+		"":        true,
+	} {
+		if _, err := ParseFrequency(value); (err != nil) != wantErr {
+			t.Errorf("ParseFrequency(%q) error = %v, wantErr %v", value, err, wantErr)
+		}
+	}
+}
+
+func TestParseAntennaMask(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    uint64
+		wantErr bool
+	}{
+		{"15", 15, false},
+		{"3", 3, false},
+		{"D", 13, false},
+		{"0xC", 12, false},
+		{"0", 0, true},
+		{"16", 0, true},
+		{"1F", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAntennaMask(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseAntennaMask(%q) = %d, %v; want %d, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFrequencyProfileValidate(t *testing.T) {
+	valid := FrequencyProfile{Frequencies: []string{"2200:20:2380", "4700"}, Bandwidth: "-1", AntennaMask: "15"}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Expected valid profile, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *FrequencyProfile)
+	}{
+		{"no frequencies", func(p *FrequencyProfile) { p.Frequencies = nil }},
+		{"bad range", func(p *FrequencyProfile) { p.Frequencies = []string{"2200:-20:2380"} }},
+		{"unsupported bandwidth", func(p *FrequencyProfile) { p.Bandwidth = "10" }},
+		{"bad antenna mask", func(p *FrequencyProfile) { p.AntennaMask = "0" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := valid
+			tt.modify(&profile)
+			if err := profile.Validate(); err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
+
+func TestFrequencyProfileAllows(t *testing.T) {
+	profile := FrequencyProfile{Frequencies: []string{"4420:40:4700"}, Bandwidth: "20", AntennaMask: "3"}
+
+	tests := []struct {
+		freq        float64
+		bandwidth   string
+		antennaMask uint64
+		want        bool
+	}{
+		{4460, "20", 3, true},
+		{4460, "20", 1, true},
+		{4460, "20", 15, false},
+		{4460, "5", 3, false},
+		{4470, "20", 3, false},
+	}
+	for _, tt := range tests {
+		if got := profile.Allows(tt.freq, tt.bandwidth, tt.antennaMask); got != tt.want {
+			t.Errorf("Allows(%v, %s, %d) = %v, want %v", tt.freq, tt.bandwidth, tt.antennaMask, got, tt.want)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package jsonrpc
 import (
 	"strconv"
 	"strings"
+
+	"github.com/silvus-mock/internal/config"
 )
 
 // MethodHandler handles specific JSON-RPC methods
@@ -17,9 +19,10 @@ func NewMethodHandler(server *Server) *MethodHandler {
 	}
 }
 
-// ValidateFrequency validates a frequency string against supported profiles
+// ValidateFrequency validates a frequency string against supported profiles.
+// Bandwidth and antenna constraints depend on radio state and are enforced by state.RadioState.
 func (mh *MethodHandler) ValidateFrequency(freqStr string) bool {
-	freq, err := strconv.ParseFloat(freqStr, 64)
+	freq, err := config.ParseFrequency(freqStr)
 	if err != nil {
 		return false
 	}
@@ -33,11 +36,9 @@ func (mh *MethodHandler) ValidateFrequency(freqStr string) bool {
 	return false
 }
 
-// frequencyMatchesProfile checks if a frequency matches a profile
-func (mh *MethodHandler) frequencyMatchesProfile(freq float64, profile interface{}) bool {
-	// This is a simplified version - in practice, you'd need to parse the profile structure
-	// For now, we'll implement basic range checking
-	return true // Placeholder - implement proper validation
+// frequencyMatchesProfile checks if a frequency is a channel of any of the profile's ranges
+func (mh *MethodHandler) frequencyMatchesProfile(freq float64, profile config.FrequencyProfile) bool {
+	return profile.AllowsFrequency(freq)
 }
 
 // ValidatePower validates a power value against limits
@@ -52,27 +53,18 @@ func (mh *MethodHandler) ValidatePower(powerStr string) bool {
 
 // ParseFrequencyRange parses a frequency range string
 func (mh *MethodHandler) ParseFrequencyRange(freqRange string) (start, step, end float64, err error) {
-	parts := strings.Split(freqRange, ":")
-	if len(parts) != 3 {
+	if strings.Count(freqRange, ":") != 2 {
 		err = &ParseError{Message: "Invalid frequency range format"}
 		return
 	}
 
-	start, err1 := strconv.ParseFloat(parts[0], 64)
-	step, err2 := strconv.ParseFloat(parts[1], 64)
-	end, err3 := strconv.ParseFloat(parts[2], 64)
-
-	if err1 != nil || err2 != nil || err3 != nil {
-		err = &ParseError{Message: "Invalid frequency values"}
-		return
-	}
-
-	if step <= 0 {
-		err = &ParseError{Message: "Step must be positive"}
+	r, parseErr := config.ParseFrequencyRange(freqRange)
+	if parseErr != nil {
+		err = &ParseError{Message: parseErr.Error()}
 		return
 	}
 
-	return start, step, end, nil
+	return r.Start, r.Step, r.End, nil
 }
 
 // ParseError represents a parsing error
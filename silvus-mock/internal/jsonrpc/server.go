@@ -50,6 +50,24 @@ func NewServer(cfg *config.Config, radioState *state.RadioState) *Server {
 	}
 }
 
+// Commands returns the command registry server so callers can add or inspect commands
+func (s *Server) Commands() *commands.ExtensibleJSONRPCServer {
+	return s.extensibleServer
+}
+
+// GPS returns the handler behind gps_coordinates, which owns the simulated position
+func (s *Server) GPS() (*commands.GPSCommandHandler, bool) {
+	handler, ok := s.extensibleServer.GetCommand("gps_coordinates")
+	if !ok {
+		return nil, false
+	}
+	gps, ok := handler.(*commands.GpsCoordinatesCommandHandler)
+	if !ok {
+		return nil, false
+	}
+	return gps.GPSCommandHandler, true
+}
+
 // HandleRequest handles HTTP POST requests to /streamscape_api
 func (s *Server) HandleRequest(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
@@ -137,6 +137,28 @@ func (s *Server) processMaintenanceRequest(req *Request) *Response {
 		cmdType = "radioReset"
 	case "factory_reset":
 		cmdType = "factoryReset"
+	case "firmware_version":
+		cmdType = "getFirmware"
+	case "network_settings":
+		// No params reads; [ip, netmask, gateway] writes
+		cmdType = "getNetwork"
+		if len(req.Params) > 0 {
+			cmdType = "setNetwork"
+			params = req.Params
+		}
+	case "load_key":
+		cmdType = "loadKey"
+		params = req.Params
+	case "key_status":
+		cmdType = "getKeys"
+	case "config_backup":
+		cmdType = "backupConfig"
+	case "config_restore":
+		cmdType = "restoreConfig"
+		params = req.Params
+	case "log_dump":
+		cmdType = "getLog"
+		params = req.Params
 	default:
 		return &Response{
 			JSONRPC: "2.0",
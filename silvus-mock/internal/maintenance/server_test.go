@@ -434,3 +434,47 @@ func createTestConfig() *config.Config {
 func createTestRadioState(cfg *config.Config) *state.RadioState {
 	return state.NewRadioState(cfg)
 }
+
+func TestProcessExtendedMaintenanceRequests(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Device.FirmwareVersion = "4.0.2.1"
+	radioState := createTestRadioState(cfg)
+	server := NewServer(cfg, radioState)
+	defer radioState.Close()
+
+	call := func(method string, params ...string) *Response {
+		return server.processMaintenanceRequest(&Request{JSONRPC: "2.0", Method: method, Params: params, ID: method})
+	}
+
+	if resp := call("firmware_version"); resp.Error != nil || resp.Result.([]string)[0] != "4.0.2.1" {
+		t.Errorf("Expected firmware version 4.0.2.1, got %+v", resp)
+	}
+
+	if resp := call("network_settings", "10.1.2.3", "255.255.255.0", "10.1.2.1"); resp.Error != nil {
+		t.Errorf("Expected network settings update to succeed, got %v", resp.Error)
+	}
+	if resp := call("network_settings"); resp.Error != nil || resp.Result.([]state.NetworkSettings)[0].IPAddress != "10.1.2.3" {
+		t.Errorf("Expected updated network settings, got %+v", resp)
+	}
+
+	if resp := call("load_key", "tek-1", "000102030405060708090a0b0c0d0e0f"); resp.Error != nil {
+		t.Errorf("Expected key load to succeed, got %v", resp.Error)
+	}
+	if resp := call("key_status"); resp.Error != nil || len(resp.Result.([]string)) != 1 {
+		t.Errorf("Expected one loaded key, got %+v", resp)
+	}
+
+	backup := call("config_backup")
+	if backup.Error != nil {
+		t.Fatalf("Expected config backup to succeed, got %v", backup.Error)
+	}
+	data, _ := json.Marshal(backup.Result.([]state.ConfigBackup)[0])
+	if resp := call("config_restore", string(data)); resp.Error != nil {
+		t.Errorf("Expected config restore to succeed, got %v", resp.Error)
+	}
+
+	// Restore enters the soft-boot blackout, which also blocks log dumps
+	if resp := call("log_dump"); resp.Error != "UNAVAILABLE" {
+		t.Errorf("Expected UNAVAILABLE during restore blackout, got %+v", resp)
+	}
+}
@@ -0,0 +1,141 @@
+package mesh
+
+import (
+	"math"
+	"strconv"
+)
+
+const (
+	// antennaGainDBi is the combined TX+RX antenna gain of a link
+	antennaGainDBi = 10.0
+
+	// noiseFloorDBm is thermal noise over 20 MHz plus a 6 dB receiver noise figure
+	noiseFloorDBm = -95.0
+
+	// channelBandwidthMHz is the occupied bandwidth used for overlap calculations
+	channelBandwidthMHz = 20.0
+
+	// minLinkSNRDb is the lowest SNR at which the simulated modem holds a link
+	minLinkSNRDb = 3.0
+
+	// fullQualitySNRDb maps to 100% link quality
+	fullQualitySNRDb = 30.0
+
+	// earthRadiusM is the mean Earth radius for great-circle distances
+	earthRadiusM = 6371000.0
+)
+
+// Link describes the simulated RF link from one radio to a peer
+type Link struct {
+	Peer          string  `json:"peer"`
+	DistanceM     float64 `json:"distance_m"`
+	FreqOffsetMHz float64 `json:"freq_offset_mhz"`
+	RSSIDBm       float64 `json:"rssi_dBm"`
+	SNRDb         float64 `json:"snr_dB"`
+	Quality       int     `json:"quality"` // 0-100
+	Connected     bool    `json:"connected"`
+}
+
+// nodeSnapshot captures the inputs to the link model for one radio
+type nodeSnapshot struct {
+	id        string
+	latitude  float64
+	longitude float64
+	altitude  float64
+	freqMhz   float64
+	powerDBm  float64
+	online    bool
+}
+
+// snapshot reads a node's position and RF settings.
+// A radio in blackout answers UNAVAILABLE and is reported offline.
+func (n *Node) snapshot() nodeSnapshot {
+	loc := n.location()
+	snap := nodeSnapshot{
+		id:        n.ID,
+		latitude:  loc.Latitude,
+		longitude: loc.Longitude,
+		altitude:  loc.Altitude,
+	}
+
+	freqResp := n.State.ExecuteCommand("getFreq", []string{})
+	powerResp := n.State.ExecuteCommand("getPower", []string{})
+	if freqResp.Error != "" || powerResp.Error != "" {
+		return snap
+	}
+
+	snap.freqMhz = parseFirst(freqResp.Result)
+	snap.powerDBm = parseFirst(powerResp.Result)
+	snap.online = true
+	return snap
+}
+
+// computeLink applies the link budget from one radio to a peer.
+//
+// Received power is the peer's transmit power plus antenna gain minus
+// free-space path loss over the 3D distance between GPS fixes. Frequency
+// mismatch costs the fraction of non-overlapping bandwidth; channels a full
+// bandwidth apart cannot hear each other at all.
+func computeLink(from, to nodeSnapshot) Link {
+	distance := distanceM(from, to)
+	link := Link{
+		Peer:          to.id,
+		DistanceM:     math.Round(distance),
+		FreqOffsetMHz: math.Abs(from.freqMhz - to.freqMhz),
+		RSSIDBm:       noiseFloorDBm,
+	}
+
+	if !from.online || !to.online {
+		return link
+	}
+
+	overlap := 1 - link.FreqOffsetMHz/channelBandwidthMHz
+	if overlap <= 0 {
+		return link
+	}
+
+	rssi := to.powerDBm + antennaGainDBi - freeSpacePathLoss(distance, from.freqMhz) + 10*math.Log10(overlap)
+	snr := rssi - noiseFloorDBm
+
+	link.RSSIDBm = round1(rssi)
+	link.SNRDb = round1(math.Max(0, snr))
+	link.Quality = int(math.Round(math.Max(0, math.Min(1, snr/fullQualitySNRDb)) * 100))
+	link.Connected = snr >= minLinkSNRDb
+	return link
+}
+
+// distanceM returns the great-circle distance combined with the altitude difference
+func distanceM(a, b nodeSnapshot) float64 {
+	lat1, lat2 := toRadians(a.latitude), toRadians(b.latitude)
+	dLat := lat2 - lat1
+	dLon := toRadians(b.longitude - a.longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	ground := 2 * earthRadiusM * math.Asin(math.Min(1, math.Sqrt(h)))
+
+	return math.Hypot(ground, b.altitude-a.altitude)
+}
+
+// freeSpacePathLoss returns the free-space path loss in dB for meters and MHz
+func freeSpacePathLoss(distanceM, freqMhz float64) float64 {
+	distanceKm := math.Max(distanceM, 1) / 1000
+	return 20*math.Log10(distanceKm) + 20*math.Log10(math.Max(freqMhz, 1)) + 32.44
+}
+
+// parseFirst parses the first element of a ["<value>"] command result
+func parseFirst(result interface{}) float64 {
+	values, ok := result.([]string)
+	if !ok || len(values) == 0 {
+		return 0
+	}
+	value, _ := strconv.ParseFloat(values[0], 64)
+	return value
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func round1(value float64) float64 {
+	return math.Round(value*10) / 10
+}
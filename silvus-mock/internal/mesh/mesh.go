@@ -0,0 +1,232 @@
+package mesh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/silvus-mock/internal/commands"
+	"github.com/silvus-mock/internal/config"
+	"github.com/silvus-mock/internal/jsonrpc"
+	"github.com/silvus-mock/internal/state"
+)
+
+const (
+	// defaultLatitude/defaultLongitude anchor radios configured without coordinates
+	defaultLatitude  = 40.7128
+	defaultLongitude = -74.0060
+	defaultAltitude  = 10.0
+
+	// defaultSpacingDeg spaces unplaced radios ~500 m apart along a meridian
+	defaultSpacingDeg = 0.0045
+)
+
+// Node is one simulated radio in the mesh
+type Node struct {
+	ID     string
+	Config *config.Config
+	State  *state.RadioState
+	Server *jsonrpc.Server
+	gps    *commands.GPSCommandHandler
+}
+
+// Mesh hosts several simulated radios that share a link model
+type Mesh struct {
+	nodes      []*Node
+	byID       map[string]*Node
+	middleware func(radioID string, next http.HandlerFunc) http.HandlerFunc
+}
+
+// New creates one radio per configured mesh entry, applying per-radio overrides
+func New(cfg *config.Config) (*Mesh, error) {
+	m := &Mesh{byID: make(map[string]*Node, len(cfg.Mesh.Radios))}
+
+	for i, radioCfg := range cfg.Mesh.Radios {
+		nodeCfg, err := cfg.ForRadio(radioCfg)
+		if err != nil {
+			m.Close()
+			return nil, err
+		}
+
+		radioState := state.NewRadioState(nodeCfg)
+		server := jsonrpc.NewServer(nodeCfg, radioState)
+		node := &Node{
+			ID:     radioCfg.ID,
+			Config: nodeCfg,
+			State:  radioState,
+			Server: server,
+		}
+
+		if gps, ok := server.GPS(); ok {
+			node.gps = gps
+			lat, lon, alt := radioCfg.Latitude, radioCfg.Longitude, radioCfg.Altitude
+			if lat == 0 && lon == 0 {
+				lat, lon, alt = defaultLatitude+float64(i)*defaultSpacingDeg, defaultLongitude, defaultAltitude
+			}
+			node.gps.SetLocation(lat, lon, alt)
+
+			// A track (global or per-radio override) moves the radio from its start point
+			if nodeCfg.GPS.Track.File != "" {
+				if err := node.gps.LoadTrack(nodeCfg.GPS.Track); err != nil {
+					radioState.Close()
+					m.Close()
+					return nil, fmt.Errorf("radio %s: %v", radioCfg.ID, err)
+				}
+			}
+		}
+
+		server.Commands().AddCustomCommand(commands.NewCustomCommandHandler(
+			"mesh_neighbors",
+			"Read simulated mesh neighbors and link quality",
+			true,  // read-only
+			false, // no blackout
+			func(ctx context.Context, params []string) (interface{}, error) {
+				if len(params) > 0 {
+					return nil, &commands.CommandError{Code: commands.ErrInvalidParams, Message: "This command does not accept parameters"}
+				}
+				return m.Neighbors(node.ID), nil
+			},
+		))
+
+		m.nodes = append(m.nodes, node)
+		m.byID[node.ID] = node
+	}
+
+	return m, nil
+}
+
+// Nodes returns the radios in configuration order
+func (m *Mesh) Nodes() []*Node {
+	return m.nodes
+}
+
+// Node returns a radio by ID
+func (m *Mesh) Node(id string) (*Node, bool) {
+	node, ok := m.byID[id]
+	return node, ok
+}
+
+// Links returns the link from a radio to every other radio, connected or not
+func (m *Mesh) Links(id string) []Link {
+	node, ok := m.byID[id]
+	if !ok {
+		return nil
+	}
+
+	from := node.snapshot()
+	links := make([]Link, 0, len(m.nodes)-1)
+	for _, peer := range m.nodes {
+		if peer.ID == id {
+			continue
+		}
+		links = append(links, computeLink(from, peer.snapshot()))
+	}
+	return links
+}
+
+// Neighbors returns only the connected links of a radio
+func (m *Mesh) Neighbors(id string) []Link {
+	neighbors := []Link{}
+	for _, link := range m.Links(id) {
+		if link.Connected {
+			neighbors = append(neighbors, link)
+		}
+	}
+	return neighbors
+}
+
+// Handler serves every radio under /radios/{id}/streamscape_api plus the /mesh topology
+func (m *Mesh) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mesh", m.handleTopology)
+	mux.HandleFunc("/radios/", func(w http.ResponseWriter, r *http.Request) {
+		rest := strings.TrimPrefix(r.URL.Path, "/radios/")
+		id, endpoint, found := strings.Cut(rest, "/")
+		node, ok := m.byID[id]
+		if !found || !ok || endpoint != "streamscape_api" {
+			http.NotFound(w, r)
+			return
+		}
+		m.radioHandler(node)(w, r)
+	})
+	return mux
+}
+
+// RadioHandler serves a single radio at /streamscape_api (port addressing)
+func (m *Mesh) RadioHandler(id string) (http.Handler, error) {
+	node, ok := m.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown mesh radio %s", id)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/streamscape_api", m.radioHandler(node))
+	return mux, nil
+}
+
+// Use wraps every radio's JSON-RPC endpoint, e.g. with fault injection.
+// Must be called before Handler or RadioHandler.
+func (m *Mesh) Use(middleware func(radioID string, next http.HandlerFunc) http.HandlerFunc) {
+	m.middleware = middleware
+}
+
+// radioHandler returns the node's JSON-RPC handler with the middleware applied
+func (m *Mesh) radioHandler(node *Node) http.HandlerFunc {
+	if m.middleware == nil {
+		return node.Server.HandleRequest
+	}
+	return m.middleware(node.ID, node.Server.HandleRequest)
+}
+
+// handleTopology returns every radio's position, settings and links
+func (m *Mesh) handleTopology(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	type radioView struct {
+		ID       string                  `json:"id"`
+		Location commands.GPSCoordinates `json:"location"`
+		Freq     float64                 `json:"freq"`
+		PowerDBm float64                 `json:"power_dBm"`
+		Online   bool                    `json:"online"`
+		Links    []Link                  `json:"links"`
+	}
+
+	radios := make([]radioView, 0, len(m.nodes))
+	for _, node := range m.nodes {
+		snap := node.snapshot()
+		radios = append(radios, radioView{
+			ID:       node.ID,
+			Location: node.location(),
+			Freq:     snap.freqMhz,
+			PowerDBm: snap.powerDBm,
+			Online:   snap.online,
+			Links:    m.Links(node.ID),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"radios": radios})
+}
+
+// Close shuts down every radio's state
+func (m *Mesh) Close() error {
+	var firstErr error
+	for _, node := range m.nodes {
+		if err := node.State.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("radio %s: %v", node.ID, err)
+		}
+	}
+	return firstErr
+}
+
+// location returns the node's GPS fix
+func (n *Node) location() commands.GPSCoordinates {
+	if n.gps == nil {
+		return commands.GPSCoordinates{Latitude: defaultLatitude, Longitude: defaultLongitude, Altitude: defaultAltitude}
+	}
+	return n.gps.Location()
+}
@@ -0,0 +1,229 @@
+package mesh
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/silvus-mock/internal/config"
+)
+
+// newTestMesh builds a mesh from the default config with the given radios.
+func newTestMesh(t *testing.T, radios ...config.MeshRadioConfig) *Mesh {
+	t.Helper()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	cfg.Mesh = config.MeshConfig{Enabled: true, Addressing: config.MeshAddressingPath, Radios: radios}
+
+	m, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create mesh: %v", err)
+	}
+	t.Cleanup(func() { m.Close() })
+	return m
+}
+
+func linkTo(links []Link, peer string) (Link, bool) {
+	for _, link := range links {
+		if link.Peer == peer {
+			return link, true
+		}
+	}
+	return Link{}, false
+}
+
+func TestMeshLinksDegradeWithDistance(t *testing.T) {
+	m := newTestMesh(t,
+		config.MeshRadioConfig{ID: "a", Latitude: 40.0, Longitude: -74.0},
+		config.MeshRadioConfig{ID: "near", Latitude: 40.005, Longitude: -74.0},
+		config.MeshRadioConfig{ID: "far", Latitude: 40.5, Longitude: -74.0},
+	)
+
+	links := m.Links("a")
+	near, _ := linkTo(links, "near")
+	far, _ := linkTo(links, "far")
+
+	if near.DistanceM < 500 || near.DistanceM > 600 {
+		t.Errorf("Expected ~556 m to near radio, got %.0f", near.DistanceM)
+	}
+	if !near.Connected || near.RSSIDBm <= far.RSSIDBm {
+		t.Errorf("Expected near link stronger than far link: near %+v, far %+v", near, far)
+	}
+	if far.Connected {
+		t.Errorf("Expected 55 km link to be out of range, got %+v", far)
+	}
+
+	neighbors := m.Neighbors("a")
+	if len(neighbors) != 1 || neighbors[0].Peer != "near" {
+		t.Errorf("Expected only the near radio as neighbor, got %+v", neighbors)
+	}
+}
+
+func TestMeshLinksDegradeWithFrequencyMismatch(t *testing.T) {
+	// b gets a 10 MHz channel grid on all antennas so it can retune off a's channel
+	fastBoot := map[string]interface{}{
+		"timing": map[string]interface{}{"blackout": map[string]interface{}{"softBootSec": 1}},
+		"profiles": map[string]interface{}{"frequencyProfiles": []interface{}{
+			map[string]interface{}{"frequencies": []string{"4700:10:4800"}, "bandwidth": "-1", "antenna_mask": "15"},
+		}},
+	}
+	m := newTestMesh(t,
+		config.MeshRadioConfig{ID: "a", Latitude: 40.0, Longitude: -74.0},
+		config.MeshRadioConfig{ID: "b", Latitude: 40.005, Longitude: -74.0, Overrides: fastBoot},
+	)
+	b, _ := m.Node("b")
+
+	aligned, _ := linkTo(m.Links("a"), "b")
+
+	retune := func(freq string) Link {
+		if resp := b.State.ExecuteCommand("setFreq", []string{freq}); resp.Error != "" {
+			t.Fatalf("setFreq %s failed: %s", freq, resp.Error)
+		}
+		if during, _ := linkTo(m.Links("a"), "b"); during.Connected {
+			t.Errorf("Expected no link while b is in soft-boot blackout, got %+v", during)
+		}
+		time.Sleep(1100 * time.Millisecond)
+		link, _ := linkTo(m.Links("a"), "b")
+		return link
+	}
+
+	offset := retune("4710")
+	if offset.FreqOffsetMHz != 10 || !offset.Connected || offset.RSSIDBm >= aligned.RSSIDBm {
+		t.Errorf("Expected 10 MHz offset to weaken the link: aligned %+v, offset %+v", aligned, offset)
+	}
+
+	disjoint := retune("4740")
+	if disjoint.Connected {
+		t.Errorf("Expected non-overlapping channels to be disconnected, got %+v", disjoint)
+	}
+}
+
+func TestMeshRadioFollowsTrack(t *testing.T) {
+	// b drives 55 km north; at 100x its 10 s track finishes in 100 ms
+	trackFile := filepath.Join(t.TempDir(), "b.gpx")
+	gpx := `<gpx><trk><trkseg>
+		<trkpt lat="40.005" lon="-74.0"><time>2025-01-01T00:00:00Z</time></trkpt>
+		<trkpt lat="40.5" lon="-74.0"><time>2025-01-01T00:00:10Z</time></trkpt>
+	</trkseg></trk></gpx>`
+	if err := os.WriteFile(trackFile, []byte(gpx), 0644); err != nil {
+		t.Fatalf("Failed to write track: %v", err)
+	}
+
+	m := newTestMesh(t,
+		config.MeshRadioConfig{ID: "a", Latitude: 40.0, Longitude: -74.0},
+		config.MeshRadioConfig{ID: "b", Latitude: 40.005, Longitude: -74.0, Overrides: map[string]interface{}{
+			"gps": map[string]interface{}{"track": map[string]interface{}{"file": trackFile, "speed": 100, "loop": false}},
+		}},
+	)
+
+	time.Sleep(150 * time.Millisecond)
+	link, _ := linkTo(m.Links("a"), "b")
+	if link.DistanceM < 54000 || link.Connected {
+		t.Errorf("Expected b to reach the end of its track out of range, got %+v", link)
+	}
+
+	a, _ := m.Node("a")
+	if location := a.location(); location.Latitude != 40.0 {
+		t.Errorf("Expected radio without a track to stay put, got %+v", location)
+	}
+}
+
+func TestMeshRejectsMissingTrack(t *testing.T) {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	cfg.Mesh = config.MeshConfig{Enabled: true, Addressing: config.MeshAddressingPath, Radios: []config.MeshRadioConfig{{ID: "a"}}}
+	cfg.GPS.Track.File = filepath.Join(t.TempDir(), "missing.gpx")
+
+	if _, err := New(cfg); err == nil {
+		t.Error("Expected error for missing track file")
+	}
+}
+
+func TestMeshHandlerRoutesByRadio(t *testing.T) {
+	m := newTestMesh(t,
+		config.MeshRadioConfig{ID: "a", Overrides: map[string]interface{}{"power": map[string]interface{}{"maxDbm": 20}}},
+		config.MeshRadioConfig{ID: "b"},
+	)
+	handler := m.Handler()
+
+	call := func(path, body string) map[string]interface{} {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		var resp map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return resp
+	}
+
+	// Per-radio override: radio a rejects 30 dBm, radio b accepts it
+	if resp := call("/radios/a/streamscape_api", `{"jsonrpc":"2.0","method":"power_dBm","params":["30"],"id":1}`); resp["error"] == nil {
+		t.Errorf("Expected radio a to reject 30 dBm, got %v", resp)
+	}
+	if resp := call("/radios/b/streamscape_api", `{"jsonrpc":"2.0","method":"power_dBm","params":["30"],"id":1}`); resp["error"] != nil {
+		t.Errorf("Expected radio b to accept 30 dBm, got %v", resp)
+	}
+
+	resp := call("/radios/a/streamscape_api", `{"jsonrpc":"2.0","method":"mesh_neighbors","id":2}`)
+	neighbors, ok := resp["result"].([]interface{})
+	if !ok || len(neighbors) != 1 {
+		t.Errorf("Expected one neighbor for radio a, got %v", resp)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/radios/missing/streamscape_api", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown radio, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/mesh", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	var topology struct {
+		Radios []struct {
+			ID    string `json:"id"`
+			Links []Link `json:"links"`
+		} `json:"radios"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &topology); err != nil || len(topology.Radios) != 2 {
+		t.Errorf("Unexpected topology response: %s", w.Body.String())
+	}
+}
+
+func TestMeshUseWrapsRadioHandlers(t *testing.T) {
+	m := newTestMesh(t,
+		config.MeshRadioConfig{ID: "a"},
+		config.MeshRadioConfig{ID: "b"},
+	)
+
+	var seen []string
+	m.Use(func(radioID string, next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			seen = append(seen, radioID)
+			next(w, r)
+		}
+	})
+
+	body := `{"jsonrpc":"2.0","method":"freq","id":1}`
+	m.Handler().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/radios/b/streamscape_api", bytes.NewBufferString(body)))
+
+	radioHandler, err := m.RadioHandler("a")
+	if err != nil {
+		t.Fatalf("RadioHandler failed: %v", err)
+	}
+	radioHandler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/streamscape_api", bytes.NewBufferString(body)))
+
+	if len(seen) != 2 || seen[0] != "b" || seen[1] != "a" {
+		t.Errorf("Expected middleware to see radios [b a], got %v", seen)
+	}
+}
@@ -0,0 +1,266 @@
+package scenario
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/silvus-mock/internal/state"
+)
+
+// maxScenarioBytes bounds scenarios pushed over the control endpoint
+const maxScenarioBytes = 1 << 20
+
+// Engine runs one scenario at a time against a set of radios
+type Engine struct {
+	mu       sync.Mutex
+	radios   map[string]*state.RadioState // Radio ID ("" in single-radio mode) to state
+	scenario *Scenario
+	started  time.Time
+	counters []int // Matching requests seen per step, for `every`
+	cancel   context.CancelFunc
+	now      func() time.Time
+}
+
+// Status describes the running scenario
+type Status struct {
+	Running     bool      `json:"running"`
+	Name        string    `json:"name,omitempty"`
+	Started     time.Time `json:"started,omitempty"`
+	Elapsed     string    `json:"elapsed,omitempty"`
+	ActiveSteps []int     `json:"activeSteps"`
+	Scenario    *Scenario `json:"scenario,omitempty"`
+}
+
+// NewEngine creates an engine for the given radios
+func NewEngine(radios map[string]*state.RadioState) *Engine {
+	return &Engine{
+		radios: radios,
+		now:    time.Now,
+	}
+}
+
+// Load replaces any running scenario and starts the new one immediately
+func (e *Engine) Load(sc *Scenario) {
+	e.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	e.mu.Lock()
+	e.scenario = sc
+	e.started = e.now()
+	e.counters = make([]int, len(sc.Steps))
+	e.cancel = cancel
+	e.mu.Unlock()
+
+	for i := range sc.Steps {
+		if sc.Steps[i].Fault == FaultReboot {
+			go e.runReboot(ctx, sc.Steps[i], sc.Repeat)
+		}
+	}
+
+	log.Printf("Scenario %q started with %d steps", sc.Name, len(sc.Steps))
+}
+
+// Stop ends the running scenario, if any
+func (e *Engine) Stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.cancel != nil {
+		e.cancel()
+		e.cancel = nil
+		log.Printf("Scenario %q stopped", e.scenario.Name)
+	}
+	e.scenario = nil
+	e.counters = nil
+}
+
+// Status returns the running scenario and its active steps
+func (e *Engine) Status() Status {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	status := Status{ActiveSteps: []int{}}
+	if e.scenario == nil {
+		return status
+	}
+
+	offset := e.offset()
+	status.Running = true
+	status.Name = e.scenario.Name
+	status.Started = e.started
+	status.Elapsed = e.now().Sub(e.started).Round(time.Millisecond).String()
+	status.Scenario = e.scenario
+	for i := range e.scenario.Steps {
+		if e.scenario.Steps[i].activeAt(offset) {
+			status.ActiveSteps = append(status.ActiveSteps, i)
+		}
+	}
+	return status
+}
+
+// Middleware injects the active faults in front of a radio's JSON-RPC handler
+func (e *Engine) Middleware(radioID string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, method := peekRequest(r)
+
+		latency, terminal := e.faultsFor(radioID, method)
+		if latency > 0 {
+			time.Sleep(latency)
+		}
+
+		switch terminal {
+		case FaultDrop:
+			// net/http closes the connection without writing a response
+			panic(http.ErrAbortHandler)
+		case FaultMalformed:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"jsonrpc":"2.0","result":["`))
+		case FaultBusy:
+			writeError(w, "BUSY", id)
+		case FaultBlackout:
+			writeError(w, "UNAVAILABLE", id)
+		default:
+			next(w, r)
+		}
+	}
+}
+
+// faultsFor returns the total injected latency and the first terminal fault for a request
+func (e *Engine) faultsFor(radioID, method string) (time.Duration, string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.scenario == nil {
+		return 0, ""
+	}
+
+	offset := e.offset()
+	var latency time.Duration
+	terminal := ""
+	for i := range e.scenario.Steps {
+		step := &e.scenario.Steps[i]
+		if !step.activeAt(offset) || !step.matches(radioID, method) {
+			continue
+		}
+
+		e.counters[i]++
+		if step.Every > 1 && e.counters[i]%step.Every != 0 {
+			continue
+		}
+
+		if step.Fault == FaultLatency {
+			latency += time.Duration(step.LatencyMs) * time.Millisecond
+		} else if terminal == "" {
+			terminal = step.Fault
+		}
+	}
+	return latency, terminal
+}
+
+// offset returns the position on the (possibly repeating) timeline.
+// Caller must hold e.mu.
+func (e *Engine) offset() time.Duration {
+	elapsed := e.now().Sub(e.started)
+	if e.scenario.Repeat > 0 {
+		elapsed %= e.scenario.Repeat
+	}
+	return elapsed
+}
+
+// runReboot resets the targeted radios at the step offset, once per repeat period
+func (e *Engine) runReboot(ctx context.Context, step Step, repeat time.Duration) {
+	timer := time.NewTimer(step.At)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			for radioID, radioState := range e.radios {
+				if matchesAny(step.Radios, radioID) {
+					log.Printf("Scenario: unexpected reboot of radio %q", radioID)
+					radioState.ExecuteCommand("radioReset", []string{})
+				}
+			}
+			if repeat <= 0 {
+				return
+			}
+			timer.Reset(repeat)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ControlHandler serves GET (status), POST (load YAML/JSON scenario) and DELETE (stop) on /scenario
+func (e *Engine) ControlHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		data, err := io.ReadAll(io.LimitReader(r.Body, maxScenarioBytes))
+		if err != nil {
+			writeControlError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sc, err := Parse(data)
+		if err != nil {
+			writeControlError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		e.Load(sc)
+	case http.MethodDelete:
+		e.Stop()
+	default:
+		writeControlError(w, http.StatusMethodNotAllowed, "Only GET, POST and DELETE are allowed")
+		return
+	}
+
+	json.NewEncoder(w).Encode(e.Status())
+}
+
+// peekRequest reads the JSON-RPC id and method and restores the body for the next handler
+func peekRequest(r *http.Request) (interface{}, string) {
+	if r.Body == nil {
+		return nil, ""
+	}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, ""
+	}
+
+	var req struct {
+		Method string      `json:"method"`
+		ID     interface{} `json:"id"`
+	}
+	json.Unmarshal(body, &req)
+	return req.ID, req.Method
+}
+
+// writeError writes a JSON-RPC error in the same shape as command errors
+func writeError(w http.ResponseWriter, code string, id interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"error": map[string]interface{}{
+			"code":    -32602,
+			"message": code,
+		},
+		"id": id,
+	})
+}
+
+// writeControlError writes a control endpoint error
+func writeControlError(w http.ResponseWriter, status int, message string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,229 @@
+package scenario
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/silvus-mock/internal/config"
+	"github.com/silvus-mock/internal/state"
+)
+
+// okHandler stands in for the JSON-RPC server
+func okHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"jsonrpc":"2.0","result":["2490"],"id":"1"}`))
+}
+
+// newTestServer serves okHandler for radio "" behind the engine's middleware
+func newTestServer(t *testing.T, engine *Engine) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/streamscape_api", engine.Middleware("", okHandler))
+	mux.HandleFunc("/scenario", engine.ControlHandler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func call(t *testing.T, server *httptest.Server, method string) (*http.Response, map[string]interface{}, error) {
+	t.Helper()
+	body := `{"jsonrpc":"2.0","method":"` + method + `","id":"1"}`
+	resp, err := http.Post(server.URL+"/streamscape_api", "application/json", strings.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	var decoded map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp, decoded, err
+}
+
+func errorMessage(body map[string]interface{}) string {
+	rpcErr, _ := body["error"].(map[string]interface{})
+	message, _ := rpcErr["message"].(string)
+	return message
+}
+
+func TestEngineInjectsTerminalFaults(t *testing.T) {
+	tests := []struct {
+		fault string
+		check func(t *testing.T, body map[string]interface{}, err error)
+	}{
+		{FaultBusy, func(t *testing.T, body map[string]interface{}, err error) {
+			if err != nil || errorMessage(body) != "BUSY" || body["id"] != "1" {
+				t.Errorf("Expected BUSY error echoing id, got %v (err %v)", body, err)
+			}
+		}},
+		{FaultBlackout, func(t *testing.T, body map[string]interface{}, err error) {
+			if err != nil || errorMessage(body) != "UNAVAILABLE" {
+				t.Errorf("Expected UNAVAILABLE error, got %v (err %v)", body, err)
+			}
+		}},
+		{FaultMalformed, func(t *testing.T, body map[string]interface{}, err error) {
+			if err == nil {
+				t.Errorf("Expected malformed JSON, decoded %v", body)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fault, func(t *testing.T) {
+			engine := NewEngine(nil)
+			server := newTestServer(t, engine)
+			engine.Load(&Scenario{Name: tt.fault, Steps: []Step{{Fault: tt.fault}}})
+			defer engine.Stop()
+
+			_, body, err := call(t, server, "freq")
+			tt.check(t, body, err)
+		})
+	}
+}
+
+func TestEngineDropClosesConnection(t *testing.T) {
+	engine := NewEngine(nil)
+	server := newTestServer(t, engine)
+	engine.Load(&Scenario{Name: "drop", Steps: []Step{{Fault: FaultDrop}}})
+	defer engine.Stop()
+
+	if _, _, err := call(t, server, "freq"); err == nil {
+		t.Error("Expected dropped connection error")
+	}
+}
+
+func TestEngineLatencyAndMethodFilter(t *testing.T) {
+	engine := NewEngine(nil)
+	server := newTestServer(t, engine)
+	engine.Load(&Scenario{Name: "slow", Steps: []Step{{Fault: FaultLatency, LatencyMs: 200, Methods: []string{"freq"}}}})
+	defer engine.Stop()
+
+	start := time.Now()
+	if _, body, err := call(t, server, "freq"); err != nil || body["result"] == nil {
+		t.Fatalf("Expected delayed success, got %v (err %v)", body, err)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Expected at least 200ms latency, got %v", elapsed)
+	}
+
+	start = time.Now()
+	call(t, server, "power_dBm")
+	if elapsed := time.Since(start); elapsed >= 200*time.Millisecond {
+		t.Errorf("Expected unfiltered method to be fast, took %v", elapsed)
+	}
+}
+
+func TestEngineEveryNthRequest(t *testing.T) {
+	engine := NewEngine(nil)
+	server := newTestServer(t, engine)
+	engine.Load(&Scenario{Name: "storm", Steps: []Step{{Fault: FaultBusy, Every: 3}}})
+	defer engine.Stop()
+
+	var busy int
+	for i := 0; i < 6; i++ {
+		if _, body, _ := call(t, server, "freq"); errorMessage(body) == "BUSY" {
+			busy++
+		}
+	}
+	if busy != 2 {
+		t.Errorf("Expected 2 of 6 requests BUSY, got %d", busy)
+	}
+}
+
+func TestEngineTimelineRepeats(t *testing.T) {
+	engine := NewEngine(nil)
+	now := time.Unix(1000, 0)
+	engine.now = func() time.Time { return now }
+	engine.Load(&Scenario{
+		Name:   "cycle",
+		Repeat: 10 * time.Second,
+		Steps:  []Step{{At: 2 * time.Second, Duration: 3 * time.Second, Fault: FaultBusy}},
+	})
+	defer engine.Stop()
+
+	tests := []struct {
+		elapsed time.Duration
+		busy    bool
+	}{
+		{1 * time.Second, false},
+		{3 * time.Second, true},
+		{6 * time.Second, false},
+		{13 * time.Second, true},
+	}
+
+	for _, tt := range tests {
+		now = time.Unix(1000, 0).Add(tt.elapsed)
+		_, terminal := engine.faultsFor("", "freq")
+		if (terminal == FaultBusy) != tt.busy {
+			t.Errorf("At %v: expected busy=%v, got fault %q", tt.elapsed, tt.busy, terminal)
+		}
+	}
+}
+
+func TestEngineRebootTargetsRadio(t *testing.T) {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	radioA := state.NewRadioState(cfg)
+	radioB := state.NewRadioState(cfg)
+	defer radioA.Close()
+	defer radioB.Close()
+
+	engine := NewEngine(map[string]*state.RadioState{"a": radioA, "b": radioB})
+	engine.Load(&Scenario{Name: "reboot", Steps: []Step{{At: 50 * time.Millisecond, Fault: FaultReboot, Radios: []string{"b"}}}})
+	defer engine.Stop()
+
+	time.Sleep(200 * time.Millisecond)
+
+	if !radioA.IsAvailable() {
+		t.Error("Expected radio a to be unaffected")
+	}
+	if radioB.IsAvailable() {
+		t.Error("Expected radio b to be in reset blackout")
+	}
+}
+
+func TestControlHandler(t *testing.T) {
+	engine := NewEngine(nil)
+	server := newTestServer(t, engine)
+
+	resp, err := http.Post(server.URL+"/scenario", "application/yaml", bytes.NewBufferString("steps:\n  - fault: explode\n"))
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid scenario, got %d", resp.StatusCode)
+	}
+
+	resp, err = http.Post(server.URL+"/scenario", "application/yaml", bytes.NewBufferString("name: busy\nsteps:\n  - fault: busy\n"))
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	var status Status
+	json.NewDecoder(resp.Body).Decode(&status)
+	resp.Body.Close()
+	if !status.Running || status.Name != "busy" || len(status.ActiveSteps) != 1 {
+		t.Errorf("Unexpected status after load: %+v", status)
+	}
+
+	if _, body, _ := call(t, server, "freq"); errorMessage(body) != "BUSY" {
+		t.Errorf("Expected BUSY while scenario runs, got %v", body)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, server.URL+"/scenario", nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("DELETE failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if _, body, _ := call(t, server, "freq"); body["result"] == nil {
+		t.Errorf("Expected success after scenario stopped, got %v", body)
+	}
+}
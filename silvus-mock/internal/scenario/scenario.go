@@ -0,0 +1,123 @@
+package scenario
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Fault types supported by the scenario DSL
+const (
+	FaultLatency   = "latency"   // Delay responses by latencyMs
+	FaultDrop      = "drop"      // Close the connection without a response
+	FaultMalformed = "malformed" // Return truncated, unparseable JSON
+	FaultBusy      = "busy"      // Return BUSY errors
+	FaultReboot    = "reboot"    // Trigger an unexpected radio reset at `at`
+	FaultBlackout  = "blackout"  // Return UNAVAILABLE until the step ends (0 = never)
+)
+
+var validFaults = map[string]bool{
+	FaultLatency:   true,
+	FaultDrop:      true,
+	FaultMalformed: true,
+	FaultBusy:      true,
+	FaultReboot:    true,
+	FaultBlackout:  true,
+}
+
+// Scenario is a timed script of faults
+type Scenario struct {
+	Name   string        `yaml:"name" json:"name"`
+	Repeat time.Duration `yaml:"repeat" json:"repeat"` // Restart the timeline every Repeat (0 = run once)
+	Steps  []Step        `yaml:"steps" json:"steps"`
+}
+
+// Step is one fault window on the scenario timeline
+type Step struct {
+	At        time.Duration `yaml:"at" json:"at"`                                   // Offset from scenario start
+	Duration  time.Duration `yaml:"duration" json:"duration"`                       // Window length (0 = until the scenario stops)
+	Fault     string        `yaml:"fault" json:"fault"`                             // One of the Fault* constants
+	Methods   []string      `yaml:"methods,omitempty" json:"methods,omitempty"`     // JSON-RPC methods affected (empty = all)
+	Radios    []string      `yaml:"radios,omitempty" json:"radios,omitempty"`       // Mesh radio IDs affected (empty = all)
+	LatencyMs int           `yaml:"latencyMs,omitempty" json:"latencyMs,omitempty"` // Latency fault delay
+	Every     int           `yaml:"every,omitempty" json:"every,omitempty"`         // Affect every Nth matching request (0/1 = all)
+}
+
+// Parse parses and validates a YAML (or JSON) scenario
+func Parse(data []byte) (*Scenario, error) {
+	sc := &Scenario{}
+	if err := yaml.UnmarshalStrict(data, sc); err != nil {
+		return nil, fmt.Errorf("invalid scenario: %v", err)
+	}
+	if err := sc.Validate(); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// LoadFile reads a scenario from a YAML file
+func LoadFile(filename string) (*Scenario, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Validate checks the scenario for unknown faults and impossible timings
+func (sc *Scenario) Validate() error {
+	if len(sc.Steps) == 0 {
+		return fmt.Errorf("scenario must contain at least one step")
+	}
+	if sc.Repeat < 0 {
+		return fmt.Errorf("repeat must not be negative")
+	}
+
+	for i, step := range sc.Steps {
+		if !validFaults[step.Fault] {
+			return fmt.Errorf("step %d: unknown fault %q", i, step.Fault)
+		}
+		if step.At < 0 || step.Duration < 0 {
+			return fmt.Errorf("step %d: at and duration must not be negative", i)
+		}
+		if step.Every < 0 {
+			return fmt.Errorf("step %d: every must not be negative", i)
+		}
+		if step.Fault == FaultLatency && step.LatencyMs <= 0 {
+			return fmt.Errorf("step %d: latency fault requires latencyMs > 0", i)
+		}
+		if sc.Repeat > 0 && step.At >= sc.Repeat {
+			return fmt.Errorf("step %d: at %v is beyond the repeat period %v", i, step.At, sc.Repeat)
+		}
+	}
+
+	return nil
+}
+
+// activeAt reports whether a window step covers the given timeline offset
+func (s *Step) activeAt(offset time.Duration) bool {
+	if s.Fault == FaultReboot || offset < s.At {
+		return false
+	}
+	return s.Duration == 0 || offset < s.At+s.Duration
+}
+
+// matches reports whether the step applies to a request
+func (s *Step) matches(radioID, method string) bool {
+	return matchesAny(s.Radios, radioID) && matchesAny(s.Methods, method)
+}
+
+// matchesAny treats an empty filter as matching everything
+func matchesAny(filter []string, value string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, item := range filter {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package scenario
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseScenario(t *testing.T) {
+	sc, err := Parse([]byte(`
+name: busy-storm
+repeat: 1m
+steps:
+  - at: 5s
+    duration: 10s
+    fault: busy
+    methods: [setFreq, setPower]
+    every: 2
+  - at: 20s
+    fault: latency
+    latencyMs: 750
+  - at: 30s
+    fault: reboot
+    radios: [radio-b]
+`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if sc.Name != "busy-storm" || sc.Repeat != time.Minute || len(sc.Steps) != 3 {
+		t.Fatalf("Unexpected scenario: %+v", sc)
+	}
+	if sc.Steps[0].At != 5*time.Second || sc.Steps[0].Duration != 10*time.Second || sc.Steps[0].Every != 2 {
+		t.Errorf("Unexpected first step: %+v", sc.Steps[0])
+	}
+	if sc.Steps[1].LatencyMs != 750 {
+		t.Errorf("Expected latencyMs 750, got %d", sc.Steps[1].LatencyMs)
+	}
+}
+
+func TestParseScenarioJSON(t *testing.T) {
+	sc, err := Parse([]byte(`{"name":"drops","steps":[{"at":"0s","duration":"2s","fault":"drop"}]}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if sc.Steps[0].Fault != FaultDrop || sc.Steps[0].Duration != 2*time.Second {
+		t.Errorf("Unexpected step: %+v", sc.Steps[0])
+	}
+}
+
+func TestParseScenarioRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"no steps", "name: empty\n", "at least one step"},
+		{"unknown fault", "steps:\n  - fault: explode\n", "unknown fault"},
+		{"unknown field", "steps:\n  - fault: busy\n    when: 5s\n", "invalid scenario"},
+		{"latency without delay", "steps:\n  - fault: latency\n", "latencyMs"},
+		{"negative at", "steps:\n  - fault: busy\n    at: -1s\n", "must not be negative"},
+		{"step beyond repeat", "repeat: 10s\nsteps:\n  - fault: busy\n    at: 10s\n", "beyond the repeat period"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStepActiveAt(t *testing.T) {
+	window := Step{At: 5 * time.Second, Duration: 10 * time.Second, Fault: FaultBusy}
+	openEnded := Step{At: 5 * time.Second, Fault: FaultBlackout}
+	reboot := Step{At: 5 * time.Second, Fault: FaultReboot}
+
+	tests := []struct {
+		step   Step
+		offset time.Duration
+		want   bool
+	}{
+		{window, 4 * time.Second, false},
+		{window, 5 * time.Second, true},
+		{window, 14 * time.Second, true},
+		{window, 15 * time.Second, false},
+		{openEnded, time.Hour, true},
+		{reboot, 5 * time.Second, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.step.activeAt(tt.offset); got != tt.want {
+			t.Errorf("%s step at %v: expected active=%v, got %v", tt.step.Fault, tt.offset, tt.want, got)
+		}
+	}
+}
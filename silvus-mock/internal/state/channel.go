@@ -0,0 +1,73 @@
+package state
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/silvus-mock/internal/config"
+)
+
+const (
+	// defaultBandwidth is the factory channel bandwidth in MHz
+	defaultBandwidth = "20"
+
+	// defaultAntennaMask enables all four antennas
+	defaultAntennaMask uint64 = 0xF
+)
+
+// handleGetBandwidth reports the active channel bandwidth
+func (rs *RadioState) handleGetBandwidth(cmd Command) {
+	cmd.Response <- CommandResponse{
+		Result: []string{rs.bandwidth},
+	}
+}
+
+// handleSetBandwidth changes the channel bandwidth: [bw_mhz].
+// The current frequency must remain valid; the radio soft-boots like a retune.
+func (rs *RadioState) handleSetBandwidth(cmd Command) {
+	if len(cmd.Params) != 1 {
+		cmd.Response <- CommandResponse{Error: "INTERNAL"}
+		return
+	}
+
+	bandwidth := cmd.Params[0]
+	if !config.IsSupportedBandwidth(bandwidth) || !rs.isValidChannel(rs.currentFreq, bandwidth, rs.antennaMask) {
+		cmd.Response <- CommandResponse{Error: "INVALID_RANGE"}
+		return
+	}
+
+	rs.bandwidth = bandwidth
+	rs.blackoutUntil = time.Now().Add(rs.softBootDuration)
+	rs.logEvent("Bandwidth set to %s MHz", bandwidth)
+	rs.persist()
+
+	cmd.Response <- CommandResponse{Result: []string{""}}
+}
+
+// handleGetAntennaMask reports the active antennas bitmask
+func (rs *RadioState) handleGetAntennaMask(cmd Command) {
+	cmd.Response <- CommandResponse{
+		Result: []string{strconv.FormatUint(rs.antennaMask, 10)},
+	}
+}
+
+// handleSetAntennaMask selects the active antennas: [mask].
+// The current frequency must remain valid for the new antenna set.
+func (rs *RadioState) handleSetAntennaMask(cmd Command) {
+	if len(cmd.Params) != 1 {
+		cmd.Response <- CommandResponse{Error: "INTERNAL"}
+		return
+	}
+
+	mask, err := config.ParseAntennaMask(cmd.Params[0])
+	if err != nil || !rs.isValidChannel(rs.currentFreq, rs.bandwidth, mask) {
+		cmd.Response <- CommandResponse{Error: "INVALID_RANGE"}
+		return
+	}
+
+	rs.antennaMask = mask
+	rs.logEvent("Antenna mask set to %d", mask)
+	rs.persist()
+
+	cmd.Response <- CommandResponse{Result: []string{""}}
+}
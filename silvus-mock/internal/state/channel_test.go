@@ -0,0 +1,113 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/silvus-mock/internal/config"
+)
+
+// newChannelTestRadioState creates a radio state without soft-boot blackouts so retunes can be chained
+func newChannelTestRadioState(t *testing.T, profiles ...config.FrequencyProfile) *RadioState {
+	t.Helper()
+	cfg := createBlackoutTestConfig()
+	cfg.Profiles.FrequencyProfiles = append(cfg.Profiles.FrequencyProfiles, profiles...)
+	cfg.Timing.Blackout.SoftBootSec = 0
+	rs := NewRadioState(cfg)
+	t.Cleanup(func() { rs.Close() })
+	return rs
+}
+
+func TestSetFreqEnforcesProfileGrid(t *testing.T) {
+	tests := []struct {
+		freq string
+		want string
+	}{
+		{"2200", ""},
+		{"2380.0", ""},
+		{"2210", "INVALID_RANGE"},    // between 20 MHz channels
+		{"2400", "INVALID_RANGE"},    // past the end of the range
+		{"4700.05", "INVALID_RANGE"}, // finer than 0.1 MHz resolution
+		{"5050", "INVALID_RANGE"},    // profile requires antennas 1-2 only
+		{"abc", "INVALID_RANGE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.freq, func(t *testing.T) {
+			rs := newPersistentRadioState(t, "")
+			if resp := rs.ExecuteCommand("setFreq", []string{tt.freq}); resp.Error != tt.want {
+				t.Errorf("setFreq %s: expected %q, got %+v", tt.freq, tt.want, resp)
+			}
+		})
+	}
+}
+
+func TestAntennaMaskSelectsProfiles(t *testing.T) {
+	rs := newChannelTestRadioState(t)
+
+	if mask := mustExecute(t, rs, "getAntennaMask").([]string); mask[0] != "15" {
+		t.Fatalf("Expected default antenna mask 15, got %v", mask)
+	}
+	for _, mask := range []string{"0", "16", "G", ""} {
+		if resp := rs.ExecuteCommand("setAntennaMask", []string{mask}); resp.Error != "INVALID_RANGE" {
+			t.Errorf("setAntennaMask %q: expected INVALID_RANGE, got %+v", mask, resp)
+		}
+	}
+
+	// Hex masks are accepted as in the ICD examples
+	mustExecute(t, rs, "setAntennaMask", "0x3")
+	mustExecute(t, rs, "setFreq", "5050")
+
+	// Re-enabling antennas 3-4 would leave 5050 outside every profile
+	if resp := rs.ExecuteCommand("setAntennaMask", []string{"15"}); resp.Error != "INVALID_RANGE" {
+		t.Errorf("Expected INVALID_RANGE when the current frequency is not allowed, got %+v", resp)
+	}
+}
+
+func TestSetBandwidthValidation(t *testing.T) {
+	rs := newPersistentRadioState(t, "")
+
+	if bw := mustExecute(t, rs, "getBandwidth").([]string); bw[0] != "20" {
+		t.Fatalf("Expected default bandwidth 20, got %v", bw)
+	}
+	if resp := rs.ExecuteCommand("setBandwidth", []string{"10"}); resp.Error != "INVALID_RANGE" {
+		t.Errorf("Expected INVALID_RANGE for unsupported bandwidth, got %+v", resp)
+	}
+	if resp := rs.ExecuteCommand("setBandwidth", nil); resp.Error != "INTERNAL" {
+		t.Errorf("Expected INTERNAL for missing bandwidth, got %+v", resp)
+	}
+
+	mustExecute(t, rs, "setBandwidth", "5")
+	if resp := rs.ExecuteCommand("getBandwidth", nil); resp.Error != "UNAVAILABLE" {
+		t.Errorf("Expected soft-boot blackout after bandwidth change, got %+v", resp)
+	}
+}
+
+func TestBandwidthSpecificProfile(t *testing.T) {
+	rs := newChannelTestRadioState(t,
+		config.FrequencyProfile{Frequencies: []string{"3000:5:3100"}, Bandwidth: "5", AntennaMask: "15"})
+
+	// 3000 is only allowed at 5 MHz
+	if resp := rs.ExecuteCommand("setFreq", []string{"3000"}); resp.Error != "INVALID_RANGE" {
+		t.Errorf("Expected INVALID_RANGE at 20 MHz, got %+v", resp)
+	}
+	mustExecute(t, rs, "setBandwidth", "5")
+	mustExecute(t, rs, "setFreq", "3000")
+	if resp := rs.ExecuteCommand("setBandwidth", []string{"20"}); resp.Error != "INVALID_RANGE" {
+		t.Errorf("Expected INVALID_RANGE switching to 20 MHz on a 5 MHz-only channel, got %+v", resp)
+	}
+}
+
+func TestChannelSettingsPersist(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "radio.json")
+
+	first := openPersistentRadioState(stateFile)
+	mustExecute(t, first, "setAntennaMask", "3")
+	mustExecute(t, first, "setBandwidth", "5")
+	first.Close()
+
+	second := newPersistentRadioState(t, stateFile)
+	if second.bandwidth != "5" || second.antennaMask != 3 {
+		t.Errorf("Expected persisted bandwidth 5 and mask 3, got %s and %d", second.bandwidth, second.antennaMask)
+	}
+}
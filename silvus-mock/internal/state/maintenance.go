@@ -0,0 +1,205 @@
+package state
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/silvus-mock/internal/config"
+)
+
+// maxLogEntries bounds the in-memory event log returned by log dumps
+const maxLogEntries = 256
+
+// ConfigBackup is the settings snapshot produced by backupConfig.
+// Keys are never included in backups.
+type ConfigBackup struct {
+	Version         int             `json:"version"`
+	FirmwareVersion string          `json:"firmware_version"`
+	CreatedAt       time.Time       `json:"created_at"`
+	Freq            string          `json:"freq"`
+	Bandwidth       string          `json:"bandwidth,omitempty"`
+	AntennaMask     uint64          `json:"antenna_mask,omitempty"`
+	PowerDBm        int             `json:"power_dBm"`
+	Network         NetworkSettings `json:"network"`
+}
+
+// handleGetFirmware reports the simulated firmware version
+func (rs *RadioState) handleGetFirmware(cmd Command) {
+	cmd.Response <- CommandResponse{
+		Result: []string{rs.firmware},
+	}
+}
+
+// handleGetNetwork reports the management interface settings
+func (rs *RadioState) handleGetNetwork(cmd Command) {
+	cmd.Response <- CommandResponse{
+		Result: []NetworkSettings{rs.network},
+	}
+}
+
+// handleSetNetwork updates the management interface settings: [ip, netmask, gateway]
+func (rs *RadioState) handleSetNetwork(cmd Command) {
+	if len(cmd.Params) != 3 {
+		cmd.Response <- CommandResponse{Error: "INTERNAL"}
+		return
+	}
+
+	settings := NetworkSettings{
+		IPAddress: cmd.Params[0],
+		Netmask:   cmd.Params[1],
+		Gateway:   cmd.Params[2],
+	}
+	if err := validateNetworkSettings(settings); err != nil {
+		cmd.Response <- CommandResponse{Error: "INVALID_RANGE"}
+		return
+	}
+
+	rs.network = settings
+	rs.logEvent("Network settings changed: ip=%s netmask=%s gateway=%s", settings.IPAddress, settings.Netmask, settings.Gateway)
+	rs.persist()
+
+	cmd.Response <- CommandResponse{Result: []string{""}}
+}
+
+// handleLoadKey stores an encryption key: [key_id, hex_material] (128 or 256 bit)
+func (rs *RadioState) handleLoadKey(cmd Command) {
+	if len(cmd.Params) != 2 || cmd.Params[0] == "" {
+		cmd.Response <- CommandResponse{Error: "INTERNAL"}
+		return
+	}
+
+	material, err := hex.DecodeString(cmd.Params[1])
+	if err != nil || (len(material) != 16 && len(material) != 32) {
+		cmd.Response <- CommandResponse{Error: "INVALID_RANGE"}
+		return
+	}
+
+	rs.keys[cmd.Params[0]] = cmd.Params[1]
+	rs.logEvent("Key %s loaded (%d bit)", cmd.Params[0], len(material)*8)
+	rs.persist()
+
+	cmd.Response <- CommandResponse{Result: []string{""}}
+}
+
+// handleGetKeys lists loaded key IDs; key material is never returned
+func (rs *RadioState) handleGetKeys(cmd Command) {
+	ids := make([]string, 0, len(rs.keys))
+	for id := range rs.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	cmd.Response <- CommandResponse{Result: ids}
+}
+
+// handleBackupConfig returns a snapshot of the current settings
+func (rs *RadioState) handleBackupConfig(cmd Command) {
+	rs.logEvent("Configuration backup created")
+	cmd.Response <- CommandResponse{
+		Result: []ConfigBackup{{
+			Version:         persistedVersion,
+			FirmwareVersion: rs.firmware,
+			CreatedAt:       time.Now().UTC(),
+			Freq:            rs.currentFreq,
+			Bandwidth:       rs.bandwidth,
+			AntennaMask:     rs.antennaMask,
+			PowerDBm:        rs.currentPower,
+			Network:         rs.network,
+		}},
+	}
+}
+
+// handleRestoreConfig applies a backup produced by backupConfig: [backup_json].
+// Restoring retunes the radio, so it enters the soft-boot blackout.
+func (rs *RadioState) handleRestoreConfig(cmd Command) {
+	if len(cmd.Params) != 1 {
+		cmd.Response <- CommandResponse{Error: "INTERNAL"}
+		return
+	}
+
+	var backup ConfigBackup
+	if err := json.Unmarshal([]byte(cmd.Params[0]), &backup); err != nil || backup.Version != persistedVersion {
+		cmd.Response <- CommandResponse{Error: "INVALID_RANGE"}
+		return
+	}
+	if backup.Bandwidth == "" {
+		backup.Bandwidth = rs.bandwidth
+	}
+	if backup.AntennaMask == 0 {
+		backup.AntennaMask = rs.antennaMask
+	}
+	if !config.IsSupportedBandwidth(backup.Bandwidth) ||
+		!rs.isValidChannel(backup.Freq, backup.Bandwidth, backup.AntennaMask) ||
+		backup.PowerDBm < rs.powerLimits.MinDBm || backup.PowerDBm > rs.powerLimits.MaxDBm ||
+		validateNetworkSettings(backup.Network) != nil {
+		cmd.Response <- CommandResponse{Error: "INVALID_RANGE"}
+		return
+	}
+
+	rs.currentFreq = backup.Freq
+	rs.bandwidth = backup.Bandwidth
+	rs.antennaMask = backup.AntennaMask
+	rs.currentPower = backup.PowerDBm
+	rs.network = backup.Network
+	rs.blackoutUntil = time.Now().Add(rs.softBootDuration)
+	rs.logEvent("Configuration restored from backup created %s", backup.CreatedAt.Format(time.RFC3339))
+	rs.persist()
+
+	cmd.Response <- CommandResponse{Result: []string{""}}
+}
+
+// handleGetLog returns the most recent event log entries: [] or [count]
+func (rs *RadioState) handleGetLog(cmd Command) {
+	count := len(rs.eventLog)
+	if len(cmd.Params) == 1 {
+		n, err := strconv.Atoi(cmd.Params[0])
+		if err != nil || n <= 0 {
+			cmd.Response <- CommandResponse{Error: "INVALID_RANGE"}
+			return
+		}
+		if n < count {
+			count = n
+		}
+	} else if len(cmd.Params) > 1 {
+		cmd.Response <- CommandResponse{Error: "INTERNAL"}
+		return
+	}
+
+	entries := make([]string, count)
+	copy(entries, rs.eventLog[len(rs.eventLog)-count:])
+	cmd.Response <- CommandResponse{Result: entries}
+}
+
+// logEvent appends a timestamped entry to the event log.
+// Caller must hold rs.mu.
+func (rs *RadioState) logEvent(format string, args ...interface{}) {
+	entry := time.Now().UTC().Format(time.RFC3339) + " " + fmt.Sprintf(format, args...)
+	rs.eventLog = append(rs.eventLog, entry)
+	if len(rs.eventLog) > maxLogEntries {
+		rs.eventLog = rs.eventLog[len(rs.eventLog)-maxLogEntries:]
+	}
+}
+
+// validateNetworkSettings checks for IPv4 addresses, a contiguous netmask and an on-subnet gateway
+func validateNetworkSettings(settings NetworkSettings) error {
+	ip := net.ParseIP(settings.IPAddress).To4()
+	mask := net.ParseIP(settings.Netmask).To4()
+	gateway := net.ParseIP(settings.Gateway).To4()
+	if ip == nil || mask == nil || gateway == nil {
+		return fmt.Errorf("network settings must be IPv4 addresses")
+	}
+
+	ipMask := net.IPMask(mask)
+	if ones, bits := ipMask.Size(); bits == 0 || ones == 0 {
+		return fmt.Errorf("invalid netmask %s", settings.Netmask)
+	}
+	if !ip.Mask(ipMask).Equal(gateway.Mask(ipMask)) {
+		return fmt.Errorf("gateway %s is not on the %s/%s subnet", settings.Gateway, settings.IPAddress, settings.Netmask)
+	}
+	return nil
+}
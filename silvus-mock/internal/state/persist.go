@@ -0,0 +1,148 @@
+package state
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/silvus-mock/internal/config"
+)
+
+// persistedVersion is bumped when the state file layout changes
+const persistedVersion = 1
+
+// NetworkSettings holds the radio's management interface settings
+type NetworkSettings struct {
+	IPAddress string `json:"ip_address"`
+	Netmask   string `json:"netmask"`
+	Gateway   string `json:"gateway"`
+}
+
+// defaultNetworkSettings are the factory management interface settings
+var defaultNetworkSettings = NetworkSettings{
+	IPAddress: "172.20.0.2",
+	Netmask:   "255.255.0.0",
+	Gateway:   "172.20.0.1",
+}
+
+// persistedState is the on-disk layout of the state file
+type persistedState struct {
+	Version     int               `json:"version"`
+	SavedAt     time.Time         `json:"saved_at"`
+	Freq        string            `json:"freq"`
+	Bandwidth   string            `json:"bandwidth,omitempty"`
+	AntennaMask uint64            `json:"antenna_mask,omitempty"`
+	PowerDBm    int               `json:"power_dBm"`
+	Network     NetworkSettings   `json:"network"`
+	Keys        map[string]string `json:"keys,omitempty"` // Key ID to key material (hex)
+}
+
+// loadPersisted restores settings and keys from the state file, if any.
+// A missing file leaves the defaults; an unreadable one is logged and ignored.
+func (rs *RadioState) loadPersisted() {
+	if rs.stateFile == "" {
+		return
+	}
+
+	data, err := os.ReadFile(rs.stateFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Failed to read radio state file %s: %v", rs.stateFile, err)
+		}
+		return
+	}
+
+	var persisted persistedState
+	if err := json.Unmarshal(data, &persisted); err != nil || persisted.Version != persistedVersion {
+		log.Printf("Ignoring invalid radio state file %s (version %d): %v", rs.stateFile, persisted.Version, err)
+		return
+	}
+
+	// Older files have no bandwidth or antenna mask; keep the defaults for those
+	bandwidth, antennaMask := rs.bandwidth, rs.antennaMask
+	if persisted.Bandwidth != "" {
+		bandwidth = persisted.Bandwidth
+	}
+	if persisted.AntennaMask != 0 {
+		antennaMask = persisted.AntennaMask
+	}
+	if config.IsSupportedBandwidth(bandwidth) && rs.isValidChannel(persisted.Freq, bandwidth, antennaMask) {
+		rs.currentFreq = persisted.Freq
+		rs.bandwidth = bandwidth
+		rs.antennaMask = antennaMask
+	}
+	if persisted.PowerDBm >= rs.powerLimits.MinDBm && persisted.PowerDBm <= rs.powerLimits.MaxDBm {
+		rs.currentPower = persisted.PowerDBm
+	}
+	if validateNetworkSettings(persisted.Network) == nil {
+		rs.network = persisted.Network
+	}
+	for id, key := range persisted.Keys {
+		rs.keys[id] = key
+	}
+
+	rs.logEvent("Restored settings from %s (freq=%s power=%d keys=%d)", rs.stateFile, rs.currentFreq, rs.currentPower, len(rs.keys))
+}
+
+// persist writes settings and keys to the state file.
+// Caller must hold rs.mu.
+func (rs *RadioState) persist() {
+	if rs.stateFile == "" {
+		return
+	}
+
+	data, err := json.MarshalIndent(persistedState{
+		Version:     persistedVersion,
+		SavedAt:     time.Now().UTC(),
+		Freq:        rs.currentFreq,
+		Bandwidth:   rs.bandwidth,
+		AntennaMask: rs.antennaMask,
+		PowerDBm:    rs.currentPower,
+		Network:     rs.network,
+		Keys:        rs.keys,
+	}, "", "  ")
+	if err != nil {
+		log.Printf("Failed to encode radio state: %v", err)
+		return
+	}
+
+	if err := writeFileAtomic(rs.stateFile, data); err != nil {
+		log.Printf("Failed to persist radio state to %s: %v", rs.stateFile, err)
+	}
+}
+
+// wipePersisted removes the state file so nothing survives a zeroize.
+// Caller must hold rs.mu.
+func (rs *RadioState) wipePersisted() {
+	if rs.stateFile == "" {
+		return
+	}
+	if err := os.Remove(rs.stateFile); err != nil && !os.IsNotExist(err) {
+		log.Printf("Failed to wipe radio state file %s: %v", rs.stateFile, err)
+	}
+}
+
+// writeFileAtomic writes via a temporary file so a crash never leaves a partial state file.
+// The file is created 0600 since it holds key material.
+func writeFileAtomic(filename string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
@@ -0,0 +1,222 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newPersistentRadioState creates a radio state backed by the given state file
+func newPersistentRadioState(t *testing.T, stateFile string) *RadioState {
+	t.Helper()
+	rs := openPersistentRadioState(stateFile)
+	t.Cleanup(func() { rs.Close() })
+	return rs
+}
+
+// openPersistentRadioState creates a radio state the caller must close, to simulate restarts
+func openPersistentRadioState(stateFile string) *RadioState {
+	cfg := createBlackoutTestConfig()
+	cfg.Device.FirmwareVersion = "4.0.2.1"
+	cfg.Device.StateFile = stateFile
+	return NewRadioState(cfg)
+}
+
+func mustExecute(t *testing.T, rs *RadioState, cmdType string, params ...string) interface{} {
+	t.Helper()
+	resp := rs.ExecuteCommand(cmdType, params)
+	if resp.Error != "" {
+		t.Fatalf("%s %v failed: %s", cmdType, params, resp.Error)
+	}
+	return resp.Result
+}
+
+func TestStatePersistsAcrossRestarts(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "radio.json")
+
+	first := openPersistentRadioState(stateFile)
+	mustExecute(t, first, "setPower", "20")
+	mustExecute(t, first, "setNetwork", "10.1.2.3", "255.255.255.0", "10.1.2.1")
+	mustExecute(t, first, "loadKey", "tek-1", strings.Repeat("ab", 32))
+	first.Close()
+
+	info, err := os.Stat(stateFile)
+	if err != nil {
+		t.Fatalf("Expected state file to be written: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected state file mode 0600, got %v", info.Mode().Perm())
+	}
+
+	second := newPersistentRadioState(t, stateFile)
+	if power := mustExecute(t, second, "getPower").([]string); power[0] != "20" {
+		t.Errorf("Expected persisted power 20, got %v", power)
+	}
+	if network := mustExecute(t, second, "getNetwork").([]NetworkSettings); network[0].IPAddress != "10.1.2.3" {
+		t.Errorf("Expected persisted network settings, got %+v", network)
+	}
+	if keys := mustExecute(t, second, "getKeys").([]string); len(keys) != 1 || keys[0] != "tek-1" {
+		t.Errorf("Expected persisted key tek-1, got %v", keys)
+	}
+}
+
+func TestZeroizeWipesPersistedState(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "radio.json")
+
+	rs := openPersistentRadioState(stateFile)
+	defer rs.Close()
+	mustExecute(t, rs, "setPower", "12")
+	mustExecute(t, rs, "loadKey", "tek-1", strings.Repeat("01", 16))
+	mustExecute(t, rs, "zeroize")
+
+	if _, err := os.Stat(stateFile); !os.IsNotExist(err) {
+		t.Errorf("Expected state file to be removed, got %v", err)
+	}
+	if keys := mustExecute(t, rs, "getKeys").([]string); len(keys) != 0 {
+		t.Errorf("Expected no keys after zeroize, got %v", keys)
+	}
+	if network := mustExecute(t, rs, "getNetwork").([]NetworkSettings); network[0] != defaultNetworkSettings {
+		t.Errorf("Expected default network after zeroize, got %+v", network[0])
+	}
+	if entries := mustExecute(t, rs, "getLog").([]string); len(entries) != 1 || !strings.Contains(entries[0], "Zeroized") {
+		t.Errorf("Expected log to hold only the zeroize entry, got %v", entries)
+	}
+
+	restarted := newPersistentRadioState(t, stateFile)
+	if power := mustExecute(t, restarted, "getPower").([]string); power[0] != "30" {
+		t.Errorf("Expected default power after zeroize and restart, got %v", power)
+	}
+}
+
+func TestFactoryResetKeepsKeys(t *testing.T) {
+	rs := newPersistentRadioState(t, "")
+	mustExecute(t, rs, "loadKey", "tek-1", strings.Repeat("01", 16))
+	mustExecute(t, rs, "setNetwork", "10.1.2.3", "255.255.255.0", "10.1.2.1")
+	mustExecute(t, rs, "factoryReset")
+
+	if keys := mustExecute(t, rs, "getKeys").([]string); len(keys) != 1 {
+		t.Errorf("Expected keys to survive factory reset, got %v", keys)
+	}
+	if network := mustExecute(t, rs, "getNetwork").([]NetworkSettings); network[0] != defaultNetworkSettings {
+		t.Errorf("Expected default network after factory reset, got %+v", network[0])
+	}
+}
+
+func TestBackupAndRestoreConfig(t *testing.T) {
+	rs := newPersistentRadioState(t, "")
+	mustExecute(t, rs, "setPower", "25")
+	mustExecute(t, rs, "loadKey", "tek-1", strings.Repeat("01", 16))
+
+	backups := mustExecute(t, rs, "backupConfig").([]ConfigBackup)
+	backup := backups[0]
+	if backup.PowerDBm != 25 || backup.FirmwareVersion != "4.0.2.1" {
+		t.Errorf("Unexpected backup: %+v", backup)
+	}
+	data, _ := json.Marshal(backup)
+	if strings.Contains(string(data), "0101") {
+		t.Errorf("Backup must not contain key material: %s", data)
+	}
+
+	backup.Freq = "5050"
+	backup.AntennaMask = 3 // 5050 is only allowed on antennas 1-2
+	backup.PowerDBm = 10
+	backup.Network = NetworkSettings{IPAddress: "10.9.0.5", Netmask: "255.255.0.0", Gateway: "10.9.0.1"}
+	data, _ = json.Marshal(backup)
+	mustExecute(t, rs, "restoreConfig", string(data))
+
+	// Restore retunes the radio, so reads are unavailable until the soft boot ends
+	if resp := rs.ExecuteCommand("getPower", nil); resp.Error != "UNAVAILABLE" {
+		t.Errorf("Expected soft-boot blackout after restore, got %+v", resp)
+	}
+
+	freq, power, _ := rs.GetStatus()
+	if freq != "5050" || power != 10 {
+		t.Errorf("Expected restored freq 5050 and power 10, got %s and %d", freq, power)
+	}
+	if rs.antennaMask != 3 {
+		t.Errorf("Expected restored antenna mask 3, got %d", rs.antennaMask)
+	}
+}
+
+func TestRestoreConfigRejectsInvalidBackups(t *testing.T) {
+	rs := newPersistentRadioState(t, "")
+	valid := ConfigBackup{Version: persistedVersion, Freq: "4700", PowerDBm: 20, Network: defaultNetworkSettings}
+
+	tests := []struct {
+		name   string
+		modify func(b *ConfigBackup)
+	}{
+		{"wrong version", func(b *ConfigBackup) { b.Version = 99 }},
+		{"bad frequency", func(b *ConfigBackup) { b.Freq = "9999" }},
+		{"frequency outside antenna mask", func(b *ConfigBackup) { b.Freq = "5050"; b.AntennaMask = 15 }},
+		{"unsupported bandwidth", func(b *ConfigBackup) { b.Bandwidth = "10" }},
+		{"bad power", func(b *ConfigBackup) { b.PowerDBm = 50 }},
+		{"gateway off subnet", func(b *ConfigBackup) { b.Network.Gateway = "192.168.1.1" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backup := valid
+			tt.modify(&backup)
+			data, _ := json.Marshal(backup)
+			if resp := rs.ExecuteCommand("restoreConfig", []string{string(data)}); resp.Error != "INVALID_RANGE" {
+				t.Errorf("Expected INVALID_RANGE, got %+v", resp)
+			}
+		})
+	}
+
+	if resp := rs.ExecuteCommand("restoreConfig", []string{"{not json"}); resp.Error != "INVALID_RANGE" {
+		t.Errorf("Expected INVALID_RANGE for malformed backup, got %+v", resp)
+	}
+}
+
+func TestMaintenanceValidation(t *testing.T) {
+	rs := newPersistentRadioState(t, "")
+
+	tests := []struct {
+		cmdType string
+		params  []string
+		want    string
+	}{
+		{"setNetwork", []string{"10.0.0.5", "255.0.255.0", "10.0.0.1"}, "INVALID_RANGE"},
+		{"setNetwork", []string{"fe80::1", "255.255.255.0", "10.0.0.1"}, "INVALID_RANGE"},
+		{"setNetwork", []string{"10.0.0.5"}, "INTERNAL"},
+		{"loadKey", []string{"tek-1", "not-hex"}, "INVALID_RANGE"},
+		{"loadKey", []string{"tek-1", "abcd"}, "INVALID_RANGE"},
+		{"getLog", []string{"0"}, "INVALID_RANGE"},
+	}
+
+	for _, tt := range tests {
+		if resp := rs.ExecuteCommand(tt.cmdType, tt.params); resp.Error != tt.want {
+			t.Errorf("%s %v: expected %s, got %+v", tt.cmdType, tt.params, tt.want, resp)
+		}
+	}
+}
+
+func TestGetLogReturnsRecentEntries(t *testing.T) {
+	rs := newPersistentRadioState(t, "")
+	for _, power := range []string{"10", "11", "12"} {
+		mustExecute(t, rs, "setPower", power)
+	}
+
+	entries := mustExecute(t, rs, "getLog", "2").([]string)
+	if len(entries) != 2 || !strings.HasSuffix(entries[0], "Power set to 11 dBm") || !strings.HasSuffix(entries[1], "Power set to 12 dBm") {
+		t.Errorf("Expected the two most recent power changes, got %v", entries)
+	}
+	if firmware := mustExecute(t, rs, "getFirmware").([]string); firmware[0] != "4.0.2.1" {
+		t.Errorf("Expected firmware 4.0.2.1, got %v", firmware)
+	}
+}
+
+func TestInvalidStateFileIsIgnored(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "radio.json")
+	os.WriteFile(stateFile, []byte(`{"version":1,"freq":"9999","power_dBm":80}`), 0600)
+
+	rs := newPersistentRadioState(t, stateFile)
+	freq, power, _ := rs.GetStatus()
+	if freq != "4700.0" || power != 30 {
+		t.Errorf("Expected defaults for out-of-range persisted values, got %s and %d", freq, power)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -23,6 +22,13 @@ type RadioState struct {
 	softBootDuration    time.Duration // Channel change blackout
 	powerChangeDuration time.Duration // Power change blackout
 	radioResetDuration  time.Duration // Radio reset blackout
+	bandwidth           string        // Active channel bandwidth in MHz
+	antennaMask         uint64        // Active antennas bitmask
+	network             NetworkSettings
+	keys                map[string]string // Key ID to key material (hex)
+	firmware            string
+	stateFile           string   // Settings and keys persisted here ("" = in memory only)
+	eventLog            []string // Recent events for log dumps
 	commandQueue        chan Command
 	stopChan            chan struct{}
 	wg                  sync.WaitGroup  // For graceful shutdown
@@ -66,12 +72,21 @@ func NewRadioState(cfg *config.Config) *RadioState {
 		softBootDuration:    time.Duration(cfg.Timing.Blackout.SoftBootSec) * time.Second,    // Channel change blackout
 		powerChangeDuration: time.Duration(cfg.Timing.Blackout.PowerChangeSec) * time.Second, // Power change blackout
 		radioResetDuration:  time.Duration(cfg.Timing.Blackout.RadioResetSec) * time.Second,  // Radio reset blackout
+		bandwidth:           defaultBandwidth,
+		antennaMask:         defaultAntennaMask,
+		network:             defaultNetworkSettings,
+		keys:                make(map[string]string),
+		firmware:            cfg.Device.FirmwareVersion,
+		stateFile:           cfg.Device.StateFile,
 		commandQueue:        make(chan Command, 100),
 		stopChan:            make(chan struct{}),
 		ctx:                 ctx,
 		cancel:              cancel,
 	}
 
+	// Restore settings and keys saved by a previous run
+	rs.loadPersisted()
+
 	// Start the command processing worker with proper lifecycle management
 	rs.wg.Add(1)
 	go rs.commandWorker()
@@ -127,6 +142,30 @@ func (rs *RadioState) processCommand(cmd Command) {
 		rs.handleRadioReset(cmd)
 	case "factoryReset":
 		rs.handleFactoryReset(cmd)
+	case "getBandwidth":
+		rs.handleGetBandwidth(cmd)
+	case "setBandwidth":
+		rs.handleSetBandwidth(cmd)
+	case "getAntennaMask":
+		rs.handleGetAntennaMask(cmd)
+	case "setAntennaMask":
+		rs.handleSetAntennaMask(cmd)
+	case "getFirmware":
+		rs.handleGetFirmware(cmd)
+	case "getNetwork":
+		rs.handleGetNetwork(cmd)
+	case "setNetwork":
+		rs.handleSetNetwork(cmd)
+	case "loadKey":
+		rs.handleLoadKey(cmd)
+	case "getKeys":
+		rs.handleGetKeys(cmd)
+	case "backupConfig":
+		rs.handleBackupConfig(cmd)
+	case "restoreConfig":
+		rs.handleRestoreConfig(cmd)
+	case "getLog":
+		rs.handleGetLog(cmd)
 	default:
 		cmd.Response <- CommandResponse{
 			Error: "INTERNAL",
@@ -154,6 +193,8 @@ func (rs *RadioState) handleSetFreq(cmd Command) {
 	// Set frequency and enter soft-boot blackout
 	rs.currentFreq = freqStr
 	rs.blackoutUntil = time.Now().Add(rs.softBootDuration)
+	rs.logEvent("Frequency set to %s MHz", freqStr)
+	rs.persist()
 
 	cmd.Response <- CommandResponse{
 		Result: []string{""},
@@ -187,6 +228,8 @@ func (rs *RadioState) handleSetPower(cmd Command) {
 	}
 
 	rs.currentPower = power
+	rs.logEvent("Power set to %d dBm", power)
+	rs.persist()
 	cmd.Response <- CommandResponse{
 		Result: []string{""},
 	}
@@ -210,10 +253,15 @@ func (rs *RadioState) handleGetProfiles(cmd Command) {
 
 // handleZeroize handles zeroize operation
 func (rs *RadioState) handleZeroize(cmd Command) {
-	// Reset to defaults
+	// Reset to defaults and erase keys, logs and persisted state
 	rs.currentFreq = "2490.0"
 	rs.currentPower = 30
 	rs.blackoutUntil = time.Time{}
+	rs.network = defaultNetworkSettings
+	rs.keys = make(map[string]string)
+	rs.eventLog = nil
+	rs.wipePersisted()
+	rs.logEvent("Zeroized: settings, keys and logs erased")
 
 	cmd.Response <- CommandResponse{
 		Result: []string{""},
@@ -224,6 +272,7 @@ func (rs *RadioState) handleZeroize(cmd Command) {
 func (rs *RadioState) handleRadioReset(cmd Command) {
 	// Enter radio reset blackout (CB-TIMING v0.3 §6.2: 60s)
 	rs.blackoutUntil = time.Now().Add(rs.radioResetDuration)
+	rs.logEvent("Radio reset")
 
 	cmd.Response <- CommandResponse{
 		Result: []string{""},
@@ -232,9 +281,12 @@ func (rs *RadioState) handleRadioReset(cmd Command) {
 
 // handleFactoryReset handles factory reset operation
 func (rs *RadioState) handleFactoryReset(cmd Command) {
-	// Reset to factory defaults
+	// Reset to factory defaults; keys survive until zeroize
 	rs.currentFreq = "2490.0"
 	rs.currentPower = 30
+	rs.network = defaultNetworkSettings
+	rs.logEvent("Factory reset")
+	rs.persist()
 	// Note: factory reset requires radio_reset to take effect per ICD
 
 	cmd.Response <- CommandResponse{
@@ -242,70 +294,34 @@ func (rs *RadioState) handleFactoryReset(cmd Command) {
 	}
 }
 
-// isValidFrequency checks if a frequency is valid according to profiles
+// isValidFrequency checks a frequency against the profiles usable with the
+// active bandwidth and antennas
 func (rs *RadioState) isValidFrequency(freqStr string) bool {
-	freq, err := strconv.ParseFloat(freqStr, 64)
+	return rs.isValidChannel(freqStr, rs.bandwidth, rs.antennaMask)
+}
+
+// isValidChannel checks a frequency against the profiles usable with the given bandwidth and antennas
+func (rs *RadioState) isValidChannel(freqStr, bandwidth string, antennaMask uint64) bool {
+	freq, err := config.ParseFrequency(freqStr)
 	if err != nil {
 		return false
 	}
 
-	// Check against all frequency profiles
 	for _, profile := range rs.frequencyProfiles {
-		if rs.frequencyMatchesProfile(freq, profile) {
+		if profile.Allows(freq, bandwidth, antennaMask) {
 			return true
 		}
 	}
 	return false
 }
 
-// frequencyMatchesProfile checks if a frequency matches a profile
-func (rs *RadioState) frequencyMatchesProfile(freq float64, profile config.FrequencyProfile) bool {
-	for _, freqRange := range profile.Frequencies {
-		if rs.frequencyInRange(freq, freqRange) {
-			return true
-		}
-	}
-	return false
-}
-
-// frequencyInRange checks if a frequency is within a range
+// frequencyInRange checks if a frequency is a channel of a range ("2200:20:2380") or single entry ("4700")
 func (rs *RadioState) frequencyInRange(freq float64, freqRange string) bool {
-	// Handle single frequency (e.g., "4700")
-	if !strings.Contains(freqRange, ":") {
-		rangeFreq, err := strconv.ParseFloat(freqRange, 64)
-		if err != nil {
-			return false
-		}
-		return freq == rangeFreq
-	}
-
-	// Handle range format (e.g., "2200:20:2380")
-	parts := strings.Split(freqRange, ":")
-	if len(parts) != 3 {
-		return false
-	}
-
-	start, err1 := strconv.ParseFloat(parts[0], 64)
-	step, err2 := strconv.ParseFloat(parts[1], 64)
-	end, err3 := strconv.ParseFloat(parts[2], 64)
-
-	if err1 != nil || err2 != nil || err3 != nil {
-		return false
-	}
-
-	if step <= 0 {
+	r, err := config.ParseFrequencyRange(freqRange)
+	if err != nil {
 		return false
 	}
-
-	// Check if frequency is within range with step tolerance
-	for f := start; f <= end; f += step {
-		if freq == f {
-			return true
-		}
-	}
-
-	// Also check if frequency is within the range bounds (for decimal frequencies)
-	return freq >= start && freq <= end
+	return r.Contains(freq)
 }
 
 // IsAvailable checks if the radio is available (not in blackout)
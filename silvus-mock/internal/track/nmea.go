@@ -0,0 +1,193 @@
+package track
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// nmeaEpoch anchors NMEA times of day; sentences without RMC carry no date
+var nmeaEpoch = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ParseNMEA parses position fixes from NMEA 0183 GGA and RMC sentences from
+// any talker (GP, GN, GL, ...). Sentences without a valid fix are skipped,
+// and GGA/RMC pairs sharing a UTC time are merged into one point. Only the
+// time of day is used, so playback follows the log across midnight.
+func ParseNMEA(r io.Reader) (*Track, error) {
+	var points []Point
+	var day time.Duration
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		sentence := strings.TrimSpace(scanner.Text())
+		if sentence == "" {
+			continue
+		}
+
+		fields, err := splitSentence(sentence)
+		if err != nil {
+			return nil, fmt.Errorf("NMEA line %d: %v", line, err)
+		}
+		if len(fields[0]) < 3 {
+			continue
+		}
+
+		var point Point
+		var valid, hasAltitude bool
+		switch fields[0][len(fields[0])-3:] {
+		case "GGA":
+			point, valid, err = parseGGA(fields)
+			hasAltitude = true
+		case "RMC":
+			point, valid, err = parseRMC(fields)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("NMEA line %d: %v", line, err)
+		}
+		if !valid {
+			continue
+		}
+
+		point.Time = point.Time.Add(day)
+		if n := len(points); n > 0 {
+			last := &points[n-1]
+			// Time of day wrapping backwards by more than half a day is midnight
+			if last.Time.Sub(point.Time) > 12*time.Hour {
+				day += 24 * time.Hour
+				point.Time = point.Time.Add(24 * time.Hour)
+			}
+			if point.Time.Equal(last.Time) {
+				last.Latitude, last.Longitude = point.Latitude, point.Longitude
+				if hasAltitude {
+					last.Altitude = point.Altitude
+				}
+				continue
+			}
+			if !hasAltitude {
+				point.Altitude = last.Altitude
+			}
+		}
+		points = append(points, point)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read NMEA: %v", err)
+	}
+
+	return New(points)
+}
+
+// splitSentence verifies the optional checksum and returns the comma-separated fields
+func splitSentence(sentence string) ([]string, error) {
+	if !strings.HasPrefix(sentence, "$") {
+		return nil, fmt.Errorf("sentence does not start with $")
+	}
+	body := sentence[1:]
+
+	if star := strings.LastIndexByte(body, '*'); star >= 0 {
+		want, err := strconv.ParseUint(body[star+1:], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checksum %q", body[star+1:])
+		}
+		body = body[:star]
+
+		var sum byte
+		for i := 0; i < len(body); i++ {
+			sum ^= body[i]
+		}
+		if uint64(sum) != want {
+			return nil, fmt.Errorf("checksum mismatch: got %02X, want %02X", sum, want)
+		}
+	}
+
+	return strings.Split(body, ","), nil
+}
+
+// parseGGA parses $--GGA,time,lat,N/S,lon,E/W,quality,sats,hdop,alt,M,...
+func parseGGA(fields []string) (Point, bool, error) {
+	if len(fields) < 10 {
+		return Point{}, false, fmt.Errorf("GGA sentence has %d fields", len(fields))
+	}
+	if fields[6] == "" || fields[6] == "0" {
+		return Point{}, false, nil // No fix
+	}
+
+	point, err := parseFix(fields[1], fields[2], fields[3], fields[4], fields[5])
+	if err != nil {
+		return Point{}, false, err
+	}
+	if fields[9] != "" {
+		if point.Altitude, err = strconv.ParseFloat(fields[9], 64); err != nil {
+			return Point{}, false, fmt.Errorf("invalid altitude %q", fields[9])
+		}
+	}
+	return point, true, nil
+}
+
+// parseRMC parses $--RMC,time,status,lat,N/S,lon,E/W,...
+func parseRMC(fields []string) (Point, bool, error) {
+	if len(fields) < 7 {
+		return Point{}, false, fmt.Errorf("RMC sentence has %d fields", len(fields))
+	}
+	if fields[2] != "A" {
+		return Point{}, false, nil // Void fix
+	}
+
+	point, err := parseFix(fields[1], fields[3], fields[4], fields[5], fields[6])
+	return point, err == nil, err
+}
+
+// parseFix parses the UTC time and ddmm.mmmm/dddmm.mmmm coordinates shared by GGA and RMC
+func parseFix(utc, lat, ns, lon, ew string) (Point, error) {
+	if len(utc) < 6 {
+		return Point{}, fmt.Errorf("invalid UTC time %q", utc)
+	}
+	hh, err1 := strconv.Atoi(utc[0:2])
+	mm, err2 := strconv.Atoi(utc[2:4])
+	ss, err3 := strconv.ParseFloat(utc[4:], 64)
+	if err1 != nil || err2 != nil || err3 != nil || hh > 23 || mm > 59 || ss >= 61 {
+		return Point{}, fmt.Errorf("invalid UTC time %q", utc)
+	}
+
+	latitude, err := parseCoordinate(lat, ns, 2, "N", "S")
+	if err != nil {
+		return Point{}, err
+	}
+	longitude, err := parseCoordinate(lon, ew, 3, "E", "W")
+	if err != nil {
+		return Point{}, err
+	}
+
+	offset := time.Duration(hh)*time.Hour + time.Duration(mm)*time.Minute + time.Duration(ss*float64(time.Second))
+	return Point{
+		Time:      nmeaEpoch.Add(offset),
+		Latitude:  latitude,
+		Longitude: longitude,
+	}, nil
+}
+
+// parseCoordinate converts degrees and decimal minutes to signed decimal degrees
+func parseCoordinate(value, hemisphere string, degreeDigits int, positive, negative string) (float64, error) {
+	if len(value) < degreeDigits+2 {
+		return 0, fmt.Errorf("invalid coordinate %q", value)
+	}
+	degrees, err1 := strconv.Atoi(value[:degreeDigits])
+	minutes, err2 := strconv.ParseFloat(value[degreeDigits:], 64)
+	if err1 != nil || err2 != nil || minutes >= 60 {
+		return 0, fmt.Errorf("invalid coordinate %q", value)
+	}
+
+	decimal := float64(degrees) + minutes/60
+	switch hemisphere {
+	case positive:
+		return decimal, nil
+	case negative:
+		return -decimal, nil
+	default:
+		return 0, fmt.Errorf("invalid hemisphere %q", hemisphere)
+	}
+}
@@ -0,0 +1,47 @@
+package track
+
+import (
+	"sync"
+	"time"
+)
+
+// Player replays a track against the wall clock
+type Player struct {
+	mu    sync.Mutex
+	track *Track
+	speed float64 // Playback rate multiplier (2 = twice as fast as recorded)
+	loop  bool
+	start time.Time
+	now   func() time.Time
+}
+
+// NewPlayer starts playing a track now. Speeds <= 0 play at the recorded rate.
+func NewPlayer(t *Track, speed float64, loop bool) *Player {
+	if speed <= 0 {
+		speed = 1
+	}
+	p := &Player{track: t, speed: speed, loop: loop, now: time.Now}
+	p.start = p.now()
+	return p
+}
+
+// Position returns the current fix and whether a non-looping track has ended.
+// Finished tracks hold their last point.
+func (p *Player) Position() (Point, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	elapsed := time.Duration(float64(p.now().Sub(p.start)) * p.speed)
+	duration := p.track.Duration()
+	if p.loop && duration > 0 {
+		elapsed %= duration
+	}
+	return p.track.At(elapsed), !p.loop && elapsed >= duration
+}
+
+// Restart rewinds playback to the first point
+func (p *Player) Restart() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.start = p.now()
+}
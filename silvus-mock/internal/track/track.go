@@ -0,0 +1,178 @@
+package track
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultPointInterval spaces track points that carry no timestamps
+const defaultPointInterval = time.Second
+
+// Point is one recorded position fix
+type Point struct {
+	Time      time.Time
+	Latitude  float64
+	Longitude float64
+	Altitude  float64
+}
+
+// Track is a time-ordered sequence of position fixes
+type Track struct {
+	points  []Point
+	offsets []time.Duration // Since the first point
+}
+
+// LoadFile reads a GPX (.gpx) or NMEA 0183 (.nmea, .log, .txt) track.
+// Other extensions are detected from the file contents.
+func LoadFile(filename string) (*Track, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read track file: %v", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".gpx":
+		return ParseGPX(bytes.NewReader(data))
+	case ".nmea", ".log", ".txt":
+		return ParseNMEA(bytes.NewReader(data))
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		return ParseGPX(bytes.NewReader(data))
+	}
+	return ParseNMEA(bytes.NewReader(data))
+}
+
+// gpxFile holds the parts of a GPX 1.1 document that carry positions
+type gpxFile struct {
+	Tracks []struct {
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+	Routes []struct {
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+	Waypoints []gpxPoint `xml:"wpt"`
+}
+
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Ele  float64 `xml:"ele"`
+	Time string  `xml:"time"`
+}
+
+// ParseGPX parses track points from a GPX document. Routes and then
+// waypoints are used when the document has no tracks.
+func ParseGPX(r io.Reader) (*Track, error) {
+	var doc gpxFile
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid GPX: %v", err)
+	}
+
+	var raw []gpxPoint
+	for _, trk := range doc.Tracks {
+		for _, seg := range trk.Segments {
+			raw = append(raw, seg.Points...)
+		}
+	}
+	if len(raw) == 0 {
+		for _, rte := range doc.Routes {
+			raw = append(raw, rte.Points...)
+		}
+	}
+	if len(raw) == 0 {
+		raw = doc.Waypoints
+	}
+
+	points := make([]Point, 0, len(raw))
+	timed := true
+	for i, p := range raw {
+		point := Point{Latitude: p.Lat, Longitude: p.Lon, Altitude: p.Ele}
+		if p.Time == "" {
+			timed = false
+		} else {
+			t, err := time.Parse(time.RFC3339, strings.TrimSpace(p.Time))
+			if err != nil {
+				return nil, fmt.Errorf("GPX point %d: invalid time %q", i, p.Time)
+			}
+			point.Time = t
+		}
+		points = append(points, point)
+	}
+
+	// Timestamps are all-or-nothing: a partly timed track plays at a fixed rate
+	if !timed {
+		for i := range points {
+			points[i].Time = time.Time{}
+		}
+	}
+	return New(points)
+}
+
+// New builds a track from points in playback order. Points without
+// timestamps are spaced one second apart.
+func New(points []Point) (*Track, error) {
+	if len(points) == 0 {
+		return nil, fmt.Errorf("track has no points")
+	}
+
+	t := &Track{
+		points:  append([]Point(nil), points...),
+		offsets: make([]time.Duration, len(points)),
+	}
+	untimed := points[0].Time.IsZero()
+	for i, p := range t.points {
+		if p.Latitude < -90 || p.Latitude > 90 || p.Longitude < -180 || p.Longitude > 180 {
+			return nil, fmt.Errorf("track point %d has invalid coordinates %.6f,%.6f", i, p.Latitude, p.Longitude)
+		}
+		if untimed {
+			t.offsets[i] = time.Duration(i) * defaultPointInterval
+			continue
+		}
+		t.offsets[i] = p.Time.Sub(points[0].Time)
+		if i > 0 && t.offsets[i] < t.offsets[i-1] {
+			return nil, fmt.Errorf("track point %d is earlier than the point before it", i)
+		}
+	}
+	return t, nil
+}
+
+// Len returns the number of points in the track
+func (t *Track) Len() int {
+	return len(t.points)
+}
+
+// Duration returns the time from the first to the last point
+func (t *Track) Duration() time.Duration {
+	return t.offsets[len(t.offsets)-1]
+}
+
+// At returns the position at an offset from the start of the track,
+// interpolating linearly between points and holding the ends.
+func (t *Track) At(offset time.Duration) Point {
+	if offset <= 0 {
+		return t.points[0]
+	}
+	i := sort.Search(len(t.offsets), func(i int) bool { return t.offsets[i] > offset })
+	if i == len(t.offsets) {
+		return t.points[len(t.points)-1]
+	}
+
+	prev, next := t.points[i-1], t.points[i]
+	frac := float64(offset-t.offsets[i-1]) / float64(t.offsets[i]-t.offsets[i-1])
+	return Point{
+		Time:      prev.Time.Add(offset - t.offsets[i-1]),
+		Latitude:  prev.Latitude + (next.Latitude-prev.Latitude)*frac,
+		Longitude: prev.Longitude + (next.Longitude-prev.Longitude)*frac,
+		Altitude:  prev.Altitude + (next.Altitude-prev.Altitude)*frac,
+	}
+}
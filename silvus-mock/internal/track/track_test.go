@@ -0,0 +1,175 @@
+package track
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk><name>patrol</name><trkseg>
+    <trkpt lat="40.0000" lon="-74.0000"><ele>10</ele><time>2025-01-01T12:00:00Z</time></trkpt>
+    <trkpt lat="40.0010" lon="-74.0000"><ele>20</ele><time>2025-01-01T12:00:10Z</time></trkpt>
+  </trkseg><trkseg>
+    <trkpt lat="40.0010" lon="-74.0020"><ele>20</ele><time>2025-01-01T12:00:30Z</time></trkpt>
+  </trkseg></trk>
+</gpx>`
+
+const testNMEA = `$GPGGA,235958.00,4000.0000,N,07400.0000,W,1,08,0.9,10.0,M,,M,,*57
+$GPRMC,235958.00,A,4000.0000,N,07400.0000,W,0.0,0.0,010125,,,A*4C
+$GPRMC,235959.00,V,4000.0300,N,07400.0000,W,0.0,0.0,010125,,,N*56
+$GNGGA,000008.00,4000.0600,N,07400.0000,W,1,08,0.9,30.0,M,,M,,*45
+$GPGSV,3,1,12,01,40,083,46*44
+`
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestParseGPX(t *testing.T) {
+	tr, err := ParseGPX(strings.NewReader(testGPX))
+	if err != nil {
+		t.Fatalf("ParseGPX failed: %v", err)
+	}
+
+	if tr.Len() != 3 || tr.Duration() != 30*time.Second {
+		t.Fatalf("Expected 3 points over 30s across segments, got %d over %v", tr.Len(), tr.Duration())
+	}
+
+	mid := tr.At(5 * time.Second)
+	if !almostEqual(mid.Latitude, 40.0005) || !almostEqual(mid.Altitude, 15) {
+		t.Errorf("Expected interpolated midpoint 40.0005/15m, got %+v", mid)
+	}
+	if end := tr.At(time.Hour); !almostEqual(end.Longitude, -74.002) {
+		t.Errorf("Expected playback to hold the last point, got %+v", end)
+	}
+}
+
+func TestParseGPXWithoutTimestamps(t *testing.T) {
+	gpx := `<gpx><rte>
+		<rtept lat="1" lon="2"/><rtept lat="3" lon="4"/><rtept lat="5" lon="6"><time>2025-01-01T00:00:00Z</time></rtept>
+	</rte></gpx>`
+
+	tr, err := ParseGPX(strings.NewReader(gpx))
+	if err != nil {
+		t.Fatalf("ParseGPX failed: %v", err)
+	}
+	if tr.Duration() != 2*defaultPointInterval {
+		t.Errorf("Expected untimed route points spaced %v apart, got duration %v", defaultPointInterval, tr.Duration())
+	}
+}
+
+func TestParseGPXErrors(t *testing.T) {
+	tests := map[string]string{
+		"not xml":        "not a gpx file",
+		"no points":      `<gpx><trk><trkseg/></trk></gpx>`,
+		"bad latitude":   `<gpx><wpt lat="95" lon="0"/></gpx>`,
+		"bad time":       `<gpx><wpt lat="1" lon="0"><time>yesterday</time></wpt></gpx>`,
+		"time goes back": `<gpx><wpt lat="1" lon="0"><time>2025-01-01T00:00:10Z</time></wpt><wpt lat="1" lon="0"><time>2025-01-01T00:00:00Z</time></wpt></gpx>`,
+	}
+
+	for name, gpx := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseGPX(strings.NewReader(gpx)); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestParseNMEA(t *testing.T) {
+	tr, err := ParseNMEA(strings.NewReader(testNMEA))
+	if err != nil {
+		t.Fatalf("ParseNMEA failed: %v", err)
+	}
+
+	// GGA+RMC at 23:59:58 merge, the void RMC is skipped, and the fix after midnight is 10s later
+	if tr.Len() != 2 || tr.Duration() != 10*time.Second {
+		t.Fatalf("Expected 2 points 10s apart across midnight, got %d over %v", tr.Len(), tr.Duration())
+	}
+
+	start := tr.At(0)
+	if !almostEqual(start.Latitude, 40) || !almostEqual(start.Longitude, -74) || start.Altitude != 10 {
+		t.Errorf("Unexpected first fix: %+v", start)
+	}
+	if end := tr.At(10 * time.Second); !almostEqual(end.Latitude, 40.001) || end.Altitude != 30 {
+		t.Errorf("Unexpected last fix: %+v", end)
+	}
+}
+
+func TestParseNMEAErrors(t *testing.T) {
+	tests := map[string]string{
+		"bad checksum":   "$GPGGA,120000.00,4000.0000,N,07400.0000,W,1,08,0.9,10.0,M,,M,,*00\n",
+		"missing dollar": "GPGGA,120000.00,4000.0000,N,07400.0000,W,1,08,0.9,10.0,M,,M,,\n",
+		"bad hemisphere": "$GPRMC,120000.00,A,4000.0000,X,07400.0000,W,0.0,0.0,010125,,,A\n",
+		"no fixes":       "$GPGGA,120000.00,,,,,0,00,,,M,,M,,\n",
+	}
+
+	for name, nmea := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseNMEA(strings.NewReader(nmea)); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestLoadFileDetectsFormat(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"patrol.gpx":  testGPX,
+		"patrol.nmea": testNMEA,
+		"patrol.dat":  testGPX,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		if _, err := LoadFile(path); err != nil {
+			t.Errorf("LoadFile(%s) failed: %v", name, err)
+		}
+	}
+
+	if _, err := LoadFile(filepath.Join(dir, "missing.gpx")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestPlayerSpeedAndLoop(t *testing.T) {
+	tr, _ := ParseGPX(strings.NewReader(testGPX))
+	clock := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newPlayer := func(speed float64, loop bool) *Player {
+		p := NewPlayer(tr, speed, loop)
+		p.now = func() time.Time { return clock }
+		p.start = clock
+		return p
+	}
+
+	fast := newPlayer(2, false)
+	looping := newPlayer(1, true)
+
+	clock = clock.Add(5 * time.Second)
+	if point, done := fast.Position(); done || !almostEqual(point.Latitude, 40.001) {
+		t.Errorf("Expected 2x playback to reach the second point after 5s, got %+v (done %t)", point, done)
+	}
+
+	clock = clock.Add(30 * time.Second)
+	if point, done := fast.Position(); !done || !almostEqual(point.Longitude, -74.002) {
+		t.Errorf("Expected non-looping playback to finish on the last point, got %+v (done %t)", point, done)
+	}
+	if point, done := looping.Position(); done || !almostEqual(point.Latitude, 40.0005) {
+		t.Errorf("Expected looping playback to wrap to 5s after 35s, got %+v (done %t)", point, done)
+	}
+
+	looping.Restart()
+	if point, _ := looping.Position(); !almostEqual(point.Latitude, 40) {
+		t.Errorf("Expected restart to rewind to the first point, got %+v", point)
+	}
+}